@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
@@ -17,6 +18,11 @@ import (
 
 const (
 	cacheDir = "eco-gotests"
+	// historyDir is the subdirectory of the cache directory where run records are saved. Unlike trees, run records
+	// cannot be regenerated once the original reports are gone, so they are versioned separately from the source code
+	// sum and are never expired.
+	historyDir     = "history"
+	historyVersion = "v1"
 )
 
 var (
@@ -39,6 +45,7 @@ type CacheKey struct {
 // specification.
 type Cache struct {
 	Trees     map[CacheKey]*SuiteTree
+	Runs      map[RunKey]*RunRecord
 	directory string
 	ctx       context.Context
 }
@@ -50,6 +57,7 @@ func NewCacheContext(ctx context.Context) (*Cache, error) {
 
 	cache := &Cache{
 		Trees: make(map[CacheKey]*SuiteTree),
+		Runs:  make(map[RunKey]*RunRecord),
 		ctx:   ctx,
 	}
 
@@ -61,7 +69,8 @@ func NewCacheContext(ctx context.Context) (*Cache, error) {
 	return cache, nil
 }
 
-// CleanCache cleans the existing cache on disk by removing the entire eco-gotests cache directory.
+// CleanCache cleans the existing cache on disk by removing the entire eco-gotests cache directory. This includes the
+// run history.
 func CleanCache() error {
 	cache := &Cache{ctx: context.TODO()}
 
@@ -91,6 +100,11 @@ func (cache *Cache) Load() error {
 
 	klog.V(100).Infof("Loading cache from %s", cachePath)
 
+	err = cache.loadHistory()
+	if err != nil {
+		return err
+	}
+
 	if sourceCodeSum == "" {
 		klog.V(100).Info("Unable to retrieve source code sum. Cache entries cannot be verified and will not be loaded.")
 
@@ -119,7 +133,9 @@ func (cache *Cache) Load() error {
 			continue
 		}
 
-		tree, err := loadCacheFile(filepath.Join(cachePath, dirEntry.Name()))
+		tree := &SuiteTree{}
+
+		err := loadCacheFile(filepath.Join(cachePath, dirEntry.Name()), tree)
 		if err != nil {
 			return err
 		}
@@ -132,6 +148,11 @@ func (cache *Cache) Load() error {
 
 // Save saves the cache to the cache directory, returning early on any errors.
 func (cache *Cache) Save() error {
+	err := cache.saveHistory()
+	if err != nil {
+		return err
+	}
+
 	if sourceCodeSum == "" {
 		klog.V(100).Info("Unable to retrieve source code sum. Cache entries will not be saved.")
 
@@ -165,6 +186,29 @@ func (cache *Cache) Save() error {
 	return nil
 }
 
+// AddRuns adds the provided run records to the run history for branch. Records with the same name as an existing record
+// on the branch replace it.
+func (cache *Cache) AddRuns(branch string, records []*RunRecord) {
+	klog.V(100).Infof("Adding %d runs to the history of branch %s", len(records), branch)
+
+	for _, record := range records {
+		cache.Runs[RunKey{Branch: branch, Name: record.Name}] = record
+	}
+}
+
+// GetRuns returns all the run records in the run history for branch in no particular order.
+func (cache *Cache) GetRuns(branch string) []*RunRecord {
+	var records []*RunRecord
+
+	for key, record := range cache.Runs {
+		if key.Branch == branch {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
 // GetRemotePatterns fetches the branches from the remote repository that match patterns. For each match, the branch and
 // revision are concatenated and used as the key in the returned map. If the match was present in the cache, then its
 // value is the cached SuiteTree. If the match was not present in the cache, its value is nil. All matches will appear
@@ -387,9 +431,83 @@ func (cache *Cache) deleteExpiredFiles() error {
 	return nil
 }
 
-// saveCacheFile saves the tree at the path provided by cacheFileName, truncating if the file already exists.
-func saveCacheFile(cacheFileName string, tree *SuiteTree) error {
-	klog.V(100).Infof("Saving cached tree to %s", cacheFileName)
+// loadHistory loads all the run records from the history subdirectory of the cache directory. Files that do not match
+// the current history version are ignored.
+func (cache *Cache) loadHistory() error {
+	cachePath, err := cache.getDirectory()
+	if err != nil {
+		return err
+	}
+
+	historyPath := filepath.Join(cachePath, historyDir)
+
+	klog.V(100).Infof("Loading run history from %s", historyPath)
+
+	historyDirEntries, err := os.ReadDir(historyPath)
+	if err != nil {
+		klog.V(100).Info("Unable to access history directory. Run history will not be loaded.")
+
+		return nil
+	}
+
+	for _, dirEntry := range historyDirEntries {
+		if !dirEntry.Type().IsRegular() {
+			continue
+		}
+
+		key, version := parseHistoryFileName(dirEntry.Name())
+		if version != historyVersion {
+			continue
+		}
+
+		record := &RunRecord{}
+
+		err := loadCacheFile(filepath.Join(historyPath, dirEntry.Name()), record)
+		if err != nil {
+			return err
+		}
+
+		cache.Runs[key] = record
+	}
+
+	return nil
+}
+
+// saveHistory saves all the run records to the history subdirectory of the cache directory. Existing files are never
+// deleted, so the history accumulates across runs of this program.
+func (cache *Cache) saveHistory() error {
+	if len(cache.Runs) == 0 {
+		return nil
+	}
+
+	cachePath, err := cache.getDirectory()
+	if err != nil {
+		return err
+	}
+
+	historyPath := filepath.Join(cachePath, historyDir)
+
+	klog.V(100).Infof("Saving run history with %d runs to %s", len(cache.Runs), historyPath)
+
+	err = os.MkdirAll(historyPath, 0755)
+	if err != nil {
+		return err
+	}
+
+	for key, record := range cache.Runs {
+		err := saveCacheFile(filepath.Join(historyPath, generateHistoryFileName(key)), record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveCacheFile saves value as compressed JSON at the path provided by cacheFileName, truncating if the file already
+// exists.
+func saveCacheFile(cacheFileName string, value any) error {
+	klog.V(100).Infof("Saving cache file to %s", cacheFileName)
 
 	file, err := os.Create(cacheFileName)
 	if err != nil {
@@ -405,7 +523,7 @@ func saveCacheFile(cacheFileName string, tree *SuiteTree) error {
 
 	defer compressor.Close()
 
-	err = json.NewEncoder(compressor).Encode(tree)
+	err = json.NewEncoder(compressor).Encode(value)
 	if err != nil {
 		return err
 	}
@@ -413,30 +531,25 @@ func saveCacheFile(cacheFileName string, tree *SuiteTree) error {
 	return nil
 }
 
-// loadCacheFile attempts to load a SuiteTree from cacheFileName.
-func loadCacheFile(cacheFileName string) (*SuiteTree, error) {
-	klog.V(100).Infof("Loading cached tree from %s", cacheFileName)
+// loadCacheFile attempts to decode the compressed JSON in cacheFileName into value, which must be a pointer.
+func loadCacheFile(cacheFileName string, value any) error {
+	klog.V(100).Infof("Loading cache file from %s", cacheFileName)
 
 	file, err := os.Open(cacheFileName)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	defer file.Close()
 
 	decompressor, err := zstd.NewReader(file)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	tree := &SuiteTree{}
-
-	err = json.NewDecoder(decompressor).Decode(tree)
-	if err != nil {
-		return nil, err
-	}
+	defer decompressor.Close()
 
-	return tree, nil
+	return json.NewDecoder(decompressor).Decode(value)
 }
 
 // generateCacheFileName takes the parameters and generates the corresponding cache file name. It guarantees that the
@@ -457,3 +570,33 @@ func parseCacheFileName(cacheFileName string) (key CacheKey, sum string) {
 
 	return CacheKey{Branch: fields[0], Revision: fields[1]}, fields[2]
 }
+
+// generateHistoryFileName takes the key of a run record and generates the corresponding history file name. Similar to
+// generateCacheFileName, it consists of the branch, run name, and history version joined by spaces. Since run names may
+// contain spaces and branch names may contain slashes, both are escaped so the name is always a single path element.
+func generateHistoryFileName(key RunKey) string {
+	return fmt.Sprintf("%s %s %s.json.zstd", url.PathEscape(key.Branch), url.PathEscape(key.Name), historyVersion)
+}
+
+// parseHistoryFileName is the inverse of generateHistoryFileName. For an invalid historyFileName, all returns will be
+// empty.
+func parseHistoryFileName(historyFileName string) (key RunKey, version string) {
+	withoutExtension, _ := strings.CutSuffix(historyFileName, ".json.zstd")
+	fields := strings.Fields(withoutExtension)
+
+	if len(fields) != 3 {
+		return RunKey{}, ""
+	}
+
+	branch, err := url.PathUnescape(fields[0])
+	if err != nil {
+		return RunKey{}, ""
+	}
+
+	name, err := url.PathUnescape(fields[1])
+	if err != nil {
+		return RunKey{}, ""
+	}
+
+	return RunKey{Branch: branch, Name: name}, fields[2]
+}
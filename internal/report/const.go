@@ -3,4 +3,6 @@ package main
 const (
 	// RemoteURL is the URL of the remote repository. It should always point to the upstream eco-gotests repository.
 	RemoteURL = "https://github.com/rh-ecosystem-edge/eco-gotests.git"
+	// BaseBranch is the branch every other branch is compared against when generating the static site.
+	BaseBranch = "main"
 )
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+// SpecChange describes how a single spec differs between two branches. Which fields are set depends on the kind of
+// change: added specs only have the head fields set, removed specs only have the base fields set, and relabeled or
+// moved specs have both.
+type SpecChange struct {
+	BaseKey      ResultKey
+	HeadKey      ResultKey
+	BaseLocation string
+	HeadLocation string
+	// AddedLabels are the labels present on the head branch but not the base branch.
+	AddedLabels []string
+	// RemovedLabels are the labels present on the base branch but not the head branch.
+	RemovedLabels []string
+}
+
+// BranchDiff contains all the differences between the specs on two branches. Specs are identified by the suite
+// description and full text, the same as they are identified across runs.
+type BranchDiff struct {
+	Base    string
+	Head    string
+	Added   []*SpecChange
+	Removed []*SpecChange
+	// Relabeled contains the specs present on both branches whose labels, including those inherited from containers,
+	// differ.
+	Relabeled []*SpecChange
+	// Moved contains the specs which are in a different file on the head branch or whose full text only appears in a
+	// different suite on the head branch. Specs may be both moved and relabeled.
+	Moved []*SpecChange
+}
+
+// NewBranchDiff compares the leaves of baseTree and headTree to find the specs that were added, removed, relabeled, or
+// moved between the base and head branches.
+func NewBranchDiff(base, head string, baseTree, headTree *SuiteTree) *BranchDiff {
+	klog.V(100).Infof("Creating BranchDiff from branch %s to branch %s", base, head)
+
+	diff := &BranchDiff{Base: base, Head: head}
+	baseSpecs := getSpecsByKey(baseTree)
+	headSpecs := getSpecsByKey(headTree)
+
+	// Specs whose full text only exists in a different suite are considered moved rather than added and removed, so
+	// the unmatched base specs are indexed by their full text.
+	unmatchedBase := make(map[string][]ResultKey)
+
+	for _, key := range sortedResultKeys(baseSpecs) {
+		if _, ok := headSpecs[key]; !ok {
+			unmatchedBase[key.Spec] = append(unmatchedBase[key.Spec], key)
+		}
+	}
+
+	for _, key := range sortedResultKeys(headSpecs) {
+		headSpec := headSpecs[key]
+
+		baseSpec, ok := baseSpecs[key]
+		if ok {
+			diff.compareSpecs(key, baseSpec, headSpec)
+
+			continue
+		}
+
+		if movedFrom := unmatchedBase[key.Spec]; len(movedFrom) > 0 {
+			unmatchedBase[key.Spec] = movedFrom[1:]
+
+			change := newSpecChange(movedFrom[0], key, baseSpecs[movedFrom[0]], headSpec)
+			diff.Moved = append(diff.Moved, change)
+
+			if len(change.AddedLabels) > 0 || len(change.RemovedLabels) > 0 {
+				diff.Relabeled = append(diff.Relabeled, change)
+			}
+
+			continue
+		}
+
+		diff.Added = append(diff.Added, &SpecChange{HeadKey: key, HeadLocation: formatLocation(headSpec)})
+	}
+
+	for _, key := range sortedResultKeys(baseSpecs) {
+		if _, ok := headSpecs[key]; ok {
+			continue
+		}
+
+		if slices.Contains(unmatchedBase[key.Spec], key) {
+			diff.Removed = append(diff.Removed, &SpecChange{BaseKey: key, BaseLocation: formatLocation(baseSpecs[key])})
+		}
+	}
+
+	return diff
+}
+
+// IsEmpty returns true if there are no differences between the two branches.
+func (diff *BranchDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Relabeled) == 0 && len(diff.Moved) == 0
+}
+
+// String returns a string representation of the diff. It contains a section for each kind of change with one line per
+// spec. Added specs are prefixed with a plus and removed specs with a minus.
+func (diff *BranchDiff) String() string {
+	builder := &strings.Builder{}
+
+	fmt.Fprintf(builder, "Diff from branch %s to branch %s\n", diff.Base, diff.Head)
+
+	writeSpecChanges(builder, "Added", diff.Added, func(change *SpecChange) string {
+		return "+  " + change.HeadKey.String() + " (" + change.HeadLocation + ")"
+	})
+
+	writeSpecChanges(builder, "Removed", diff.Removed, func(change *SpecChange) string {
+		return "-  " + change.BaseKey.String() + " (" + change.BaseLocation + ")"
+	})
+
+	writeSpecChanges(builder, "Relabeled", diff.Relabeled, func(change *SpecChange) string {
+		return fmt.Sprintf(".  %s (added %v, removed %v)", change.HeadKey, change.AddedLabels, change.RemovedLabels)
+	})
+
+	writeSpecChanges(builder, "Moved", diff.Moved, func(change *SpecChange) string {
+		return fmt.Sprintf(".  %s (%s -> %s)", change.HeadKey, change.BaseLocation, change.HeadLocation)
+	})
+
+	return builder.String()
+}
+
+// compareSpecs adds a SpecChange to the Relabeled and Moved lists as necessary for a spec present on both branches.
+func (diff *BranchDiff) compareSpecs(key ResultKey, baseSpec, headSpec *types.SpecReport) {
+	change := newSpecChange(key, key, baseSpec, headSpec)
+
+	if len(change.AddedLabels) > 0 || len(change.RemovedLabels) > 0 {
+		diff.Relabeled = append(diff.Relabeled, change)
+	}
+
+	// Line numbers change with nearly every commit so only the file is compared.
+	if cleanPath(baseSpec.LeafNodeLocation.FileName) != cleanPath(headSpec.LeafNodeLocation.FileName) {
+		diff.Moved = append(diff.Moved, change)
+	}
+}
+
+// newSpecChange creates a SpecChange with both the base and head fields set, including the labels that differ.
+func newSpecChange(baseKey, headKey ResultKey, baseSpec, headSpec *types.SpecReport) *SpecChange {
+	baseLabels := baseSpec.Labels()
+	headLabels := headSpec.Labels()
+
+	change := &SpecChange{
+		BaseKey:      baseKey,
+		HeadKey:      headKey,
+		BaseLocation: formatLocation(baseSpec),
+		HeadLocation: formatLocation(headSpec),
+	}
+
+	for _, label := range headLabels {
+		if !slices.Contains(baseLabels, label) {
+			change.AddedLabels = append(change.AddedLabels, label)
+		}
+	}
+
+	for _, label := range baseLabels {
+		if !slices.Contains(headLabels, label) {
+			change.RemovedLabels = append(change.RemovedLabels, label)
+		}
+	}
+
+	return change
+}
+
+// getSpecsByKey returns the SpecReport of every leaf in tree keyed by the suite description and full text.
+func getSpecsByKey(tree *SuiteTree) map[ResultKey]*types.SpecReport {
+	specs := make(map[ResultKey]*types.SpecReport)
+
+	for suite, leaf := range tree.Leaves() {
+		specs[ResultKey{Suite: suite.Description, Spec: leaf.SpecReport.FullText()}] = leaf.SpecReport
+	}
+
+	return specs
+}
+
+// sortedResultKeys returns the keys of specs sorted by suite and then spec so the diff is deterministic.
+func sortedResultKeys(specs map[ResultKey]*types.SpecReport) []ResultKey {
+	return slices.SortedFunc(maps.Keys(specs), compareResultKeys)
+}
+
+// formatLocation returns the location of the spec as file:line with the file relative to the repo root.
+func formatLocation(spec *types.SpecReport) string {
+	return fmt.Sprintf("%s:%d", cleanPath(spec.LeafNodeLocation.FileName), spec.LeafNodeLocation.LineNumber)
+}
+
+// writeSpecChanges writes a section to builder with the provided title and one line per change, as returned by format.
+func writeSpecChanges(
+	builder *strings.Builder, title string, changes []*SpecChange, format func(*SpecChange) string) {
+	fmt.Fprintf(builder, "%s: %d\n", title, len(changes))
+
+	for _, change := range changes {
+		builder.WriteString(format(change))
+		builder.WriteByte('\n')
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSpec is a spec in a suite used for building trees in tests.
+type testSpec struct {
+	suite  string
+	text   string
+	file   string
+	labels []string
+}
+
+// newTestTree returns a SuiteTree with every spec inserted into a suite under /eco-gotests/tests named after the suite
+// of the spec, which is also used as the suite description.
+func newTestTree(specs ...testSpec) *SuiteTree {
+	reportsBySuite := make(map[string]*types.Report)
+
+	var reports []*types.Report
+
+	for _, spec := range specs {
+		report, ok := reportsBySuite[spec.suite]
+		if !ok {
+			report = &types.Report{SuitePath: "/eco-gotests/tests/" + spec.suite, SuiteDescription: spec.suite}
+			reportsBySuite[spec.suite] = report
+			reports = append(reports, report)
+		}
+
+		report.SpecReports = append(report.SpecReports, types.SpecReport{
+			LeafNodeType:     types.NodeTypeIt,
+			LeafNodeText:     spec.text,
+			LeafNodeLabels:   spec.labels,
+			LeafNodeLocation: types.CodeLocation{FileName: "/home/ci/eco-gotests/tests/" + spec.file, LineNumber: 10},
+		})
+		report.PreRunStats.TotalSpecs++
+	}
+
+	var values []types.Report
+	for _, report := range reports {
+		values = append(values, *report)
+	}
+
+	return NewFromReports(values)
+}
+
+func TestNewBranchDiff(t *testing.T) {
+	baseTree := newTestTree(
+		testSpec{suite: "ptp", text: "unchanged", file: "ptp/tests/a.go", labels: []string{"ptp"}},
+		testSpec{suite: "ptp", text: "relabeled", file: "ptp/tests/a.go", labels: []string{"ptp", "old"}},
+		testSpec{suite: "ptp", text: "moved file", file: "ptp/tests/a.go"},
+		testSpec{suite: "ptp", text: "moved suite", file: "ptp/tests/a.go", labels: []string{"ptp"}},
+		testSpec{suite: "ptp", text: "removed", file: "ptp/tests/a.go"},
+	)
+	headTree := newTestTree(
+		testSpec{suite: "ptp", text: "unchanged", file: "ptp/tests/a.go", labels: []string{"ptp"}},
+		testSpec{suite: "ptp", text: "relabeled", file: "ptp/tests/a.go", labels: []string{"ptp", "new"}},
+		testSpec{suite: "ptp", text: "moved file", file: "ptp/tests/b.go"},
+		testSpec{suite: "ptp", text: "added", file: "ptp/tests/a.go"},
+		testSpec{suite: "talm", text: "moved suite", file: "talm/tests/a.go", labels: []string{"talm"}},
+	)
+
+	diff := NewBranchDiff("main", "feature", baseTree, headTree)
+
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []*SpecChange{{
+		HeadKey:      ResultKey{Suite: "ptp", Spec: "added"},
+		HeadLocation: "eco-gotests/tests/ptp/tests/a.go:10",
+	}}, diff.Added)
+	assert.Equal(t, []*SpecChange{{
+		BaseKey:      ResultKey{Suite: "ptp", Spec: "removed"},
+		BaseLocation: "eco-gotests/tests/ptp/tests/a.go:10",
+	}}, diff.Removed)
+
+	if assert.Len(t, diff.Moved, 2) {
+		assert.Equal(t, ResultKey{Suite: "ptp", Spec: "moved file"}, diff.Moved[0].BaseKey)
+		assert.Equal(t, ResultKey{Suite: "ptp", Spec: "moved file"}, diff.Moved[0].HeadKey)
+		assert.Equal(t, "eco-gotests/tests/ptp/tests/b.go:10", diff.Moved[0].HeadLocation)
+
+		assert.Equal(t, ResultKey{Suite: "ptp", Spec: "moved suite"}, diff.Moved[1].BaseKey)
+		assert.Equal(t, ResultKey{Suite: "talm", Spec: "moved suite"}, diff.Moved[1].HeadKey)
+	}
+
+	if assert.Len(t, diff.Relabeled, 2) {
+		assert.Equal(t, ResultKey{Suite: "ptp", Spec: "relabeled"}, diff.Relabeled[0].HeadKey)
+		assert.Equal(t, []string{"new"}, diff.Relabeled[0].AddedLabels)
+		assert.Equal(t, []string{"old"}, diff.Relabeled[0].RemovedLabels)
+
+		// Specs moved to another suite are compared by their labels too.
+		assert.Equal(t, ResultKey{Suite: "talm", Spec: "moved suite"}, diff.Relabeled[1].HeadKey)
+		assert.Equal(t, []string{"talm"}, diff.Relabeled[1].AddedLabels)
+		assert.Equal(t, []string{"ptp"}, diff.Relabeled[1].RemovedLabels)
+	}
+}
+
+func TestNewBranchDiffDuplicateText(t *testing.T) {
+	// Only one of the two base specs with the same text can be matched to the single head spec, so the other one is
+	// removed.
+	baseTree := newTestTree(
+		testSpec{suite: "ptp", text: "shared", file: "ptp/tests/a.go"},
+		testSpec{suite: "talm", text: "shared", file: "talm/tests/a.go"},
+	)
+	headTree := newTestTree(testSpec{suite: "ran", text: "shared", file: "ran/tests/a.go"})
+
+	diff := NewBranchDiff("main", "feature", baseTree, headTree)
+
+	assert.Empty(t, diff.Added)
+
+	if assert.Len(t, diff.Moved, 1) && assert.Len(t, diff.Removed, 1) {
+		assert.Equal(t, ResultKey{Suite: "ptp", Spec: "shared"}, diff.Moved[0].BaseKey)
+		assert.Equal(t, ResultKey{Suite: "talm", Spec: "shared"}, diff.Removed[0].BaseKey)
+	}
+
+	assert.True(t, NewBranchDiff("main", "main", baseTree, baseTree).IsEmpty())
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ExportFormatJSON exports the trees as nested JSON objects, one per branch.
+	ExportFormatJSON = "json"
+	// ExportFormatCSV exports the specs of the trees as flat CSV with one row per spec.
+	ExportFormatCSV = "csv"
+)
+
+// csvHeader is the header row of the CSV export. Columns are only ever appended so existing consumers keep working.
+var csvHeader = []string{"branch", "revision", "suite_path", "suite", "spec", "labels", "test_ids", "file", "line"}
+
+// ExportedBranch is the top level object of the JSON export. There is one per branch.
+type ExportedBranch struct {
+	Branch   string        `json:"branch"`
+	Revision string        `json:"revision"`
+	Tree     *ExportedNode `json:"tree"`
+}
+
+// ExportedNode is a single node of a SuiteTree in the JSON export. Unlike SuiteTree, the field names are part of the
+// export format and will not change.
+type ExportedNode struct {
+	Name        string          `json:"name"`
+	Path        string          `json:"path"`
+	Description string          `json:"description,omitempty"`
+	Labels      []string        `json:"labels,omitempty"`
+	Specs       int             `json:"specs"`
+	Spec        *ExportedSpec   `json:"spec,omitempty"`
+	Children    []*ExportedNode `json:"children,omitempty"`
+}
+
+// ExportedSpec contains the information about a single spec in both the JSON and CSV exports.
+type ExportedSpec struct {
+	SuitePath string   `json:"suitePath"`
+	Suite     string   `json:"suite"`
+	Text      string   `json:"text"`
+	Labels    []string `json:"labels"`
+	TestIDs   []string `json:"testIDs"`
+	File      string   `json:"file"`
+	Line      int      `json:"line"`
+}
+
+// IsValidExportFormat returns true if format is one of the supported export formats.
+func IsValidExportFormat(format string) bool {
+	return format == ExportFormatJSON || format == ExportFormatCSV
+}
+
+// Export writes all the trees in treeMap to writer in the provided format. Branches are sorted by name so the output is
+// stable for the same trees.
+func Export(writer io.Writer, treeMap map[CacheKey]*SuiteTree, format string) error {
+	klog.V(100).Infof("Exporting %d trees as %s", len(treeMap), format)
+
+	switch format {
+	case ExportFormatJSON:
+		return ExportJSON(writer, treeMap)
+	case ExportFormatCSV:
+		return ExportCSV(writer, treeMap)
+	default:
+		return fmt.Errorf("invalid export format \"%s\"", format)
+	}
+}
+
+// ExportJSON writes all the trees in treeMap to writer as an indented JSON array of ExportedBranch.
+func ExportJSON(writer io.Writer, treeMap map[CacheKey]*SuiteTree) error {
+	exportedBranches := []ExportedBranch{}
+
+	for _, key := range sortedCacheKeys(treeMap) {
+		exportedBranches = append(exportedBranches, ExportedBranch{
+			Branch:   key.Branch,
+			Revision: key.Revision,
+			Tree:     exportNode(treeMap[key], treeMap[key]),
+		})
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(exportedBranches)
+}
+
+// ExportCSV writes the specs of all the trees in treeMap to writer as CSV. Labels and test case IDs are joined by
+// semicolons. Within a branch, rows are sorted by suite path and then spec text.
+func ExportCSV(writer io.Writer, treeMap map[CacheKey]*SuiteTree) error {
+	csvWriter := csv.NewWriter(writer)
+
+	err := csvWriter.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedCacheKeys(treeMap) {
+		var specs []*ExportedSpec
+
+		for suite, leaf := range treeMap[key].Leaves() {
+			specs = append(specs, exportSpec(suite, leaf))
+		}
+
+		slices.SortFunc(specs, func(specA, specB *ExportedSpec) int {
+			return cmp.Or(strings.Compare(specA.SuitePath, specB.SuitePath), strings.Compare(specA.Text, specB.Text))
+		})
+
+		for _, spec := range specs {
+			err := csvWriter.Write([]string{
+				key.Branch,
+				key.Revision,
+				spec.SuitePath,
+				spec.Suite,
+				spec.Text,
+				strings.Join(spec.Labels, ";"),
+				strings.Join(spec.TestIDs, ";"),
+				spec.File,
+				strconv.Itoa(spec.Line),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	csvWriter.Flush()
+
+	return csvWriter.Error()
+}
+
+// exportNode recursively converts tree to an ExportedNode. The suite is the closest node above tree with a description
+// and is used for exporting the specs of leaf nodes.
+func exportNode(tree, suite *SuiteTree) *ExportedNode {
+	if tree.Description != "" {
+		suite = tree
+	}
+
+	node := &ExportedNode{
+		Name:        tree.Name,
+		Path:        cleanPath(tree.Path),
+		Description: tree.Description,
+		Labels:      tree.Labels,
+		Specs:       tree.Specs,
+	}
+
+	if tree.SpecReport != nil {
+		node.Spec = exportSpec(suite, tree)
+	}
+
+	for _, child := range tree.Children {
+		node.Children = append(node.Children, exportNode(child, suite))
+	}
+
+	return node
+}
+
+// exportSpec converts the leaf into an ExportedSpec. Labels and test case IDs are separated the same way as in the
+// SpecIndex.
+func exportSpec(suite, leaf *SuiteTree) *ExportedSpec {
+	indexedSpec := newIndexedSpec(suite, leaf.SpecReport)
+
+	// Empty slices are used instead of nil so the JSON always contains arrays for the labels and test case IDs.
+	return &ExportedSpec{
+		SuitePath: cleanPath(suite.Path),
+		Suite:     suite.Description,
+		Text:      leaf.SpecReport.FullText(),
+		Labels:    append([]string{}, indexedSpec.Labels...),
+		TestIDs:   append([]string{}, indexedSpec.TestIDs...),
+		File:      cleanPath(leaf.SpecReport.LeafNodeLocation.FileName),
+		Line:      leaf.SpecReport.LeafNodeLocation.LineNumber,
+	}
+}
+
+// sortedCacheKeys returns the keys of treeMap sorted by branch.
+func sortedCacheKeys(treeMap map[CacheKey]*SuiteTree) []CacheKey {
+	return slices.SortedFunc(maps.Keys(treeMap), func(keyA, keyB CacheKey) int {
+		return strings.Compare(keyA.Branch, keyB.Branch)
+	})
+}
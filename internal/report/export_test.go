@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTreeMap returns trees for two branches, with main added after release so the export must sort them.
+func newTestTreeMap() map[CacheKey]*SuiteTree {
+	return map[CacheKey]*SuiteTree{
+		{Branch: "release-4.20", Revision: "def456"}: newTestTree(
+			testSpec{suite: "ptp", text: "events", file: "ptp/tests/a.go", labels: []string{"events", "test_id:1", "1"}},
+		),
+		{Branch: "main", Revision: "abc123"}: newTestTree(
+			testSpec{suite: "talm", text: "upgrade", file: "talm/tests/a.go", labels: []string{"talm;upgrade"}},
+			testSpec{suite: "ptp", text: "no labels", file: "ptp/tests/a.go"},
+			testSpec{suite: "ptp", text: "events", file: "ptp/tests/a.go", labels: []string{"events", "test_id:1", "1"}},
+		),
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var buffer bytes.Buffer
+
+	err := Export(&buffer, newTestTreeMap(), ExportFormatCSV)
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		csvHeader,
+		{"main", "abc123", "eco-gotests/tests/ptp", "ptp", "events", "events", "1", "eco-gotests/tests/ptp/tests/a.go", "10"},
+		{"main", "abc123", "eco-gotests/tests/ptp", "ptp", "no labels", "", "", "eco-gotests/tests/ptp/tests/a.go", "10"},
+		{
+			"main", "abc123", "eco-gotests/tests/talm", "talm", "upgrade", "talm;upgrade", "",
+			"eco-gotests/tests/talm/tests/a.go", "10",
+		},
+		{
+			"release-4.20", "def456", "eco-gotests/tests/ptp", "ptp", "events", "events", "1",
+			"eco-gotests/tests/ptp/tests/a.go", "10",
+		},
+	}, records)
+}
+
+func TestExportJSON(t *testing.T) {
+	var buffer bytes.Buffer
+
+	err := Export(&buffer, newTestTreeMap(), ExportFormatJSON)
+	assert.NoError(t, err)
+
+	var exported []ExportedBranch
+
+	err = json.Unmarshal(buffer.Bytes(), &exported)
+	if !assert.NoError(t, err) || !assert.Len(t, exported, 2) {
+		return
+	}
+
+	assert.Equal(t, "main", exported[0].Branch)
+	assert.Equal(t, "abc123", exported[0].Revision)
+	assert.Equal(t, "release-4.20", exported[1].Branch)
+	assert.Equal(t, 3, exported[0].Tree.Specs)
+
+	node := findExportedNode(exported[0].Tree, "ptp")
+	if !assert.NotNil(t, node, "ptp suite should be exported") {
+		return
+	}
+
+	assert.Equal(t, "ptp", node.Description)
+	assert.Equal(t, "eco-gotests/tests/ptp", node.Path)
+
+	if assert.Len(t, node.Children, 2) {
+		assert.Equal(t, &ExportedSpec{
+			SuitePath: "eco-gotests/tests/ptp",
+			Suite:     "ptp",
+			Text:      "no labels",
+			Labels:    []string{},
+			TestIDs:   []string{},
+			File:      "eco-gotests/tests/ptp/tests/a.go",
+			Line:      10,
+		}, node.Children[0].Spec)
+	}
+
+	// Labels and test case IDs are always arrays, even when empty.
+	assert.Contains(t, buffer.String(), `"labels": []`)
+	assert.Contains(t, buffer.String(), `"testIDs": []`)
+}
+
+func TestExportInvalidFormat(t *testing.T) {
+	assert.False(t, IsValidExportFormat("xml"))
+	assert.True(t, IsValidExportFormat(ExportFormatCSV))
+
+	err := Export(&bytes.Buffer{}, newTestTreeMap(), "xml")
+	assert.ErrorContains(t, err, "invalid export format")
+}
+
+// findExportedNode returns the first node under node with the provided description, or nil if there is none.
+func findExportedNode(node *ExportedNode, description string) *ExportedNode {
+	if node.Description == description {
+		return node
+	}
+
+	for _, child := range node.Children {
+		if found := findExportedNode(child, description); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
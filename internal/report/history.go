@@ -0,0 +1,322 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+// RunKey identifies a single run in the run history. Runs are named after the directory their reports were loaded from
+// and are only compared to other runs on the same branch.
+type RunKey struct {
+	Branch string
+	Name   string
+}
+
+// RunRecord is the outcome of every spec in a single run. Unlike a ResultSet, it is resolved against a SuiteTree so
+// JUnit results are keyed the same way as JSON results and it can be saved in the cache.
+type RunRecord struct {
+	Name    string
+	Date    time.Time
+	Results map[ResultKey]*SpecResult
+}
+
+// NewRunRecord creates a new RunRecord by looking up every spec in tree in resultSet. Specs without results are not
+// included in the record.
+func NewRunRecord(name string, date time.Time, tree *SuiteTree, resultSet *ResultSet) *RunRecord {
+	klog.V(100).Infof("Creating RunRecord for run %s on %s", name, date)
+
+	record := &RunRecord{
+		Name:    name,
+		Date:    date,
+		Results: make(map[ResultKey]*SpecResult),
+	}
+
+	for suite, leaf := range tree.Leaves() {
+		result := resultSet.Lookup(suite.Description, leaf.SpecReport)
+		if result == nil {
+			continue
+		}
+
+		record.Results[ResultKey{Suite: suite.Description, Spec: leaf.SpecReport.FullText()}] = result
+	}
+
+	return record
+}
+
+// LoadRunRecords treats every subdirectory of directory as a separate run and loads its results using LoadResults. The
+// date of each run is the earliest start time in its reports or the modification time of the subdirectory if no
+// reports include a start time.
+func LoadRunRecords(directory string, tree *SuiteTree) ([]*RunRecord, error) {
+	klog.V(100).Infof("Loading run records from directory %s", directory)
+
+	dirEntries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*RunRecord
+
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+
+		resultSet, err := LoadResults(filepath.Join(directory, dirEntry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		date := resultSet.StartTime
+		if date.IsZero() {
+			info, err := dirEntry.Info()
+			if err != nil {
+				return nil, err
+			}
+
+			date = info.ModTime()
+		}
+
+		records = append(records, NewRunRecord(dirEntry.Name(), date, tree, resultSet))
+	}
+
+	return records, nil
+}
+
+// SpecTrend contains the history of a single spec over the runs in a Trend.
+type SpecTrend struct {
+	Key ResultKey
+	// Results has one element per run in the Trend, in the same order. Elements are nil for runs without a result for
+	// this spec.
+	Results []*SpecResult
+	Passed  int
+	Failed  int
+	// FirstFailed is the date of the first run in the Trend where this spec failed. It is the zero value if the spec
+	// never failed.
+	FirstFailed time.Time
+	// AverageRunTime is the average runtime of the spec across all runs before the latest one where it passed or
+	// failed. It is used as the baseline for runtime regressions.
+	AverageRunTime   time.Duration
+	NewlyFailing     bool
+	NewlyFixed       bool
+	RuntimeRegressed bool
+}
+
+// PassRate returns the fraction of runs where the spec passed out of those where it either passed or failed. If the
+// spec never passed or failed, 0 is returned.
+func (specTrend *SpecTrend) PassRate() float64 {
+	if specTrend.Passed+specTrend.Failed == 0 {
+		return 0
+	}
+
+	return float64(specTrend.Passed) / float64(specTrend.Passed+specTrend.Failed)
+}
+
+// Latest returns the result of the spec in the latest run of the Trend. It is nil if the spec has no result in the
+// latest run.
+func (specTrend *SpecTrend) Latest() *SpecResult {
+	if len(specTrend.Results) == 0 {
+		return nil
+	}
+
+	return specTrend.Results[len(specTrend.Results)-1]
+}
+
+// Trend is the history of all specs on a single branch across multiple runs.
+type Trend struct {
+	Branch string
+	// Runs is sorted by ascending date, so the last run is the latest.
+	Runs  []*RunRecord
+	Specs []*SpecTrend
+	// RuntimeThreshold is the fraction by which the latest runtime of a spec must exceed its average runtime for it to
+	// be considered a runtime regression.
+	RuntimeThreshold float64
+}
+
+// NewTrend creates a new Trend from the lastRuns most recent records. Records are sorted by date before selecting the
+// most recent ones. If lastRuns is not positive, all records are used.
+func NewTrend(branch string, records []*RunRecord, lastRuns int, runtimeThreshold float64) *Trend {
+	klog.V(100).Infof("Creating Trend for branch %s from %d runs", branch, len(records))
+
+	records = slices.SortedFunc(slices.Values(records), func(recordA, recordB *RunRecord) int {
+		return recordA.Date.Compare(recordB.Date)
+	})
+
+	if lastRuns > 0 && len(records) > lastRuns {
+		records = records[len(records)-lastRuns:]
+	}
+
+	trend := &Trend{
+		Branch:           branch,
+		Runs:             records,
+		RuntimeThreshold: runtimeThreshold,
+	}
+
+	keys := make(map[ResultKey]struct{})
+
+	for _, record := range records {
+		for key := range record.Results {
+			keys[key] = struct{}{}
+		}
+	}
+
+	for _, key := range slices.SortedFunc(maps.Keys(keys), compareResultKeys) {
+		trend.Specs = append(trend.Specs, trend.newSpecTrend(key))
+	}
+
+	return trend
+}
+
+// NewlyFailing returns all the specs that failed in the latest run but passed the last time they were run before that.
+func (trend *Trend) NewlyFailing() []*SpecTrend {
+	return trend.filterSpecs(func(specTrend *SpecTrend) bool { return specTrend.NewlyFailing })
+}
+
+// NewlyFixed returns all the specs that passed in the latest run but failed the last time they were run before that.
+func (trend *Trend) NewlyFixed() []*SpecTrend {
+	return trend.filterSpecs(func(specTrend *SpecTrend) bool { return specTrend.NewlyFixed })
+}
+
+// RuntimeRegressions returns all the specs whose runtime in the latest run exceeded their average by more than the
+// RuntimeThreshold.
+func (trend *Trend) RuntimeRegressions() []*SpecTrend {
+	return trend.filterSpecs(func(specTrend *SpecTrend) bool { return specTrend.RuntimeRegressed })
+}
+
+// Unstable returns all the specs that failed at least once across the runs in the Trend.
+func (trend *Trend) Unstable() []*SpecTrend {
+	return trend.filterSpecs(func(specTrend *SpecTrend) bool { return specTrend.Failed > 0 })
+}
+
+// String returns a string representation of the trend. It contains a header describing the runs followed by a section
+// for each category of spec with one spec per line.
+func (trend *Trend) String() string {
+	builder := &strings.Builder{}
+
+	fmt.Fprintf(builder, "Trend for branch %s over %d runs", trend.Branch, len(trend.Runs))
+
+	if len(trend.Runs) > 0 {
+		fmt.Fprintf(builder, " from %s to %s",
+			trend.Runs[0].Date.Format(time.DateOnly), trend.Runs[len(trend.Runs)-1].Date.Format(time.DateOnly))
+	}
+
+	builder.WriteByte('\n')
+
+	writeSpecTrends(builder, "Newly failing", trend.NewlyFailing(), noDetails)
+	writeSpecTrends(builder, "Newly fixed", trend.NewlyFixed(), noDetails)
+
+	writeSpecTrends(builder, "Runtime regressions", trend.RuntimeRegressions(), func(specTrend *SpecTrend) string {
+		return fmt.Sprintf(" (average %s, latest %s)",
+			roundDuration(specTrend.AverageRunTime), roundDuration(specTrend.Latest().RunTime))
+	})
+
+	writeSpecTrends(builder, "Unstable", trend.Unstable(), func(specTrend *SpecTrend) string {
+		return fmt.Sprintf(" (pass rate %.0f%%, first failed %s)",
+			100*specTrend.PassRate(), specTrend.FirstFailed.Format(time.DateOnly))
+	})
+
+	return builder.String()
+}
+
+// newSpecTrend creates the SpecTrend for the spec identified by key using the runs of the trend.
+func (trend *Trend) newSpecTrend(key ResultKey) *SpecTrend {
+	specTrend := &SpecTrend{Key: key}
+
+	var (
+		previous      *SpecResult
+		totalRunTime  time.Duration
+		executedCount int
+	)
+
+	for i, record := range trend.Runs {
+		result := record.Results[key]
+		specTrend.Results = append(specTrend.Results, result)
+
+		if result == nil || !isExecuted(result) {
+			continue
+		}
+
+		if result.State.Is(types.SpecStatePassed) {
+			specTrend.Passed++
+		} else {
+			specTrend.Failed++
+
+			if specTrend.FirstFailed.IsZero() {
+				specTrend.FirstFailed = record.Date
+			}
+		}
+
+		// The latest run is compared against the previous ones so it must not be included in the baseline.
+		if i == len(trend.Runs)-1 {
+			continue
+		}
+
+		previous = result
+		totalRunTime += result.RunTime
+		executedCount++
+	}
+
+	if executedCount > 0 {
+		specTrend.AverageRunTime = totalRunTime / time.Duration(executedCount)
+	}
+
+	latest := specTrend.Latest()
+	if latest == nil || !isExecuted(latest) || previous == nil {
+		return specTrend
+	}
+
+	latestPassed := latest.State.Is(types.SpecStatePassed)
+	previousPassed := previous.State.Is(types.SpecStatePassed)
+
+	specTrend.NewlyFailing = !latestPassed && previousPassed
+	specTrend.NewlyFixed = latestPassed && !previousPassed
+	specTrend.RuntimeRegressed = specTrend.AverageRunTime > 0 &&
+		float64(latest.RunTime) > float64(specTrend.AverageRunTime)*(1+trend.RuntimeThreshold)
+
+	return specTrend
+}
+
+// filterSpecs returns the specs of the trend for which keep returns true.
+func (trend *Trend) filterSpecs(keep func(*SpecTrend) bool) []*SpecTrend {
+	var specTrends []*SpecTrend
+
+	for _, specTrend := range trend.Specs {
+		if keep(specTrend) {
+			specTrends = append(specTrends, specTrend)
+		}
+	}
+
+	return specTrends
+}
+
+// isExecuted returns true if the spec either passed or failed. Skipped and pending specs do not count towards the pass
+// rate or runtime of a spec.
+func isExecuted(result *SpecResult) bool {
+	return result.State.Is(types.SpecStatePassed | types.SpecStateFailureStates)
+}
+
+// writeSpecTrends writes a section to builder with the provided title and one line per spec in specTrends. The details
+// function is called for each spec and its return value is appended to the line.
+func writeSpecTrends(
+	builder *strings.Builder, title string, specTrends []*SpecTrend, details func(*SpecTrend) string) {
+	fmt.Fprintf(builder, "%s: %d\n", title, len(specTrends))
+
+	for _, specTrend := range specTrends {
+		builder.WriteString(".  ")
+		builder.WriteString(specTrend.Key.String())
+		builder.WriteString(details(specTrend))
+		builder.WriteByte('\n')
+	}
+}
+
+// noDetails is used with writeSpecTrends for sections that do not include any details about each spec.
+func noDetails(*SpecTrend) string {
+	return ""
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryFileNameRoundTrip(t *testing.T) {
+	testCases := []RunKey{
+		{Branch: "main", Name: "run-1"},
+		{Branch: "release-4.20", Name: "nightly run 2025-10-01"},
+		{Branch: "feature/ptp-replay", Name: "100% passing"},
+	}
+
+	for _, key := range testCases {
+		fileName := generateHistoryFileName(key)
+		assert.NotContains(t, fileName, "/", "history file name for %v should be a single path element", key)
+
+		parsedKey, version := parseHistoryFileName(fileName)
+		assert.Equal(t, key, parsedKey)
+		assert.Equal(t, historyVersion, version)
+	}
+
+	parsedKey, version := parseHistoryFileName("main run-1.json.zstd")
+	assert.Equal(t, RunKey{}, parsedKey)
+	assert.Empty(t, version)
+
+	parsedKey, version = parseHistoryFileName("main %zz v1.json.zstd")
+	assert.Equal(t, RunKey{}, parsedKey)
+	assert.Empty(t, version)
+}
+
+func TestNewSpecTrend(t *testing.T) {
+	key := ResultKey{Suite: "PTP", Spec: "verifies the clock"}
+	start := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	passed := func(runTime time.Duration) *SpecResult {
+		return &SpecResult{State: types.SpecStatePassed, RunTime: runTime, NumAttempts: 1}
+	}
+
+	failed := func(runTime time.Duration) *SpecResult {
+		return &SpecResult{State: types.SpecStateFailed, RunTime: runTime, NumAttempts: 1}
+	}
+
+	skipped := &SpecResult{State: types.SpecStateSkipped}
+
+	testCases := []struct {
+		name         string
+		results      []*SpecResult
+		passed       int
+		failed       int
+		firstFailed  int
+		average      time.Duration
+		newlyFailing bool
+		newlyFixed   bool
+		regressed    bool
+	}{
+		{
+			name:        "always passing",
+			results:     []*SpecResult{passed(time.Minute), passed(3 * time.Minute), passed(2 * time.Minute)},
+			passed:      3,
+			firstFailed: -1,
+			average:     2 * time.Minute,
+		},
+		{
+			name:         "newly failing",
+			results:      []*SpecResult{passed(time.Minute), passed(time.Minute), failed(time.Minute)},
+			passed:       2,
+			failed:       1,
+			firstFailed:  2,
+			average:      time.Minute,
+			newlyFailing: true,
+		},
+		{
+			name:        "newly fixed after skip",
+			results:     []*SpecResult{failed(time.Minute), skipped, passed(time.Minute)},
+			passed:      1,
+			failed:      1,
+			firstFailed: 0,
+			average:     time.Minute,
+			newlyFixed:  true,
+		},
+		{
+			name:        "runtime regression",
+			results:     []*SpecResult{passed(time.Minute), nil, passed(2 * time.Minute)},
+			passed:      2,
+			firstFailed: -1,
+			average:     time.Minute,
+			regressed:   true,
+		},
+		{
+			name:        "within runtime threshold",
+			results:     []*SpecResult{passed(time.Minute), passed(70 * time.Second)},
+			passed:      2,
+			firstFailed: -1,
+			average:     time.Minute,
+		},
+		{
+			name:        "missing from latest run",
+			results:     []*SpecResult{failed(time.Minute), passed(time.Minute), nil},
+			passed:      1,
+			failed:      1,
+			firstFailed: 0,
+			average:     time.Minute,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var records []*RunRecord
+
+			for i, result := range testCase.results {
+				record := &RunRecord{Date: start.AddDate(0, 0, i), Results: map[ResultKey]*SpecResult{}}
+				if result != nil {
+					record.Results[key] = result
+				}
+
+				records = append(records, record)
+			}
+
+			// Records are passed in reverse to make sure they are sorted by date.
+			reversed := make([]*RunRecord, 0, len(records))
+			for i := len(records) - 1; i >= 0; i-- {
+				reversed = append(reversed, records[i])
+			}
+
+			trend := NewTrend("main", reversed, 0, 0.5)
+			if !assert.Len(t, trend.Specs, 1) {
+				return
+			}
+
+			specTrend := trend.Specs[0]
+			assert.Equal(t, testCase.results, specTrend.Results)
+			assert.Equal(t, testCase.passed, specTrend.Passed)
+			assert.Equal(t, testCase.failed, specTrend.Failed)
+			assert.Equal(t, testCase.average, specTrend.AverageRunTime)
+			assert.Equal(t, testCase.newlyFailing, specTrend.NewlyFailing)
+			assert.Equal(t, testCase.newlyFixed, specTrend.NewlyFixed)
+			assert.Equal(t, testCase.regressed, specTrend.RuntimeRegressed)
+
+			if testCase.firstFailed < 0 {
+				assert.True(t, specTrend.FirstFailed.IsZero())
+			} else {
+				assert.Equal(t, start.AddDate(0, 0, testCase.firstFailed), specTrend.FirstFailed)
+			}
+		})
+	}
+}
+
+func TestNewTrendLastRuns(t *testing.T) {
+	key := ResultKey{Suite: "PTP", Spec: "verifies the clock"}
+	start := time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	var records []*RunRecord
+
+	for i := range 5 {
+		records = append(records, &RunRecord{
+			Name:    "run",
+			Date:    start.AddDate(0, 0, i),
+			Results: map[ResultKey]*SpecResult{key: {State: types.SpecStatePassed, NumAttempts: 1}},
+		})
+	}
+
+	trend := NewTrend("main", records, 3, 0.5)
+	assert.Len(t, trend.Runs, 3)
+	assert.Equal(t, start.AddDate(0, 0, 2), trend.Runs[0].Date)
+	assert.Equal(t, 3, trend.Specs[0].Passed)
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// testIDLabelPrefix is the prefix of the label added by reportxml.ID to identify the test case ID of a spec. Since
+	// reportxml.ID also adds the bare ID as a label, those labels are excluded from the label index.
+	testIDLabelPrefix = "test_id:"
+)
+
+// IndexedSpec is a single spec in a SpecIndex along with the information used to index it.
+type IndexedSpec struct {
+	Key      ResultKey
+	Location string
+	// Labels are all the labels of the spec, including those inherited from containers and the suite, except for the
+	// labels added by reportxml.ID.
+	Labels []string
+	// TestIDs are the test case IDs of the spec added by reportxml.ID. Most specs have exactly one.
+	TestIDs []string
+	// allLabels are all the labels of the spec, including the suite labels and those added by reportxml.ID. They are
+	// used for matching label filters since Ginkgo matches against the same set of labels.
+	allLabels []string
+}
+
+// SpecIndex groups the specs of a SuiteTree by their labels and test case IDs.
+type SpecIndex struct {
+	// Specs contains every spec in the tree sorted by suite and spec text.
+	Specs    []*IndexedSpec
+	ByLabel  map[string][]*IndexedSpec
+	ByTestID map[string][]*IndexedSpec
+}
+
+// NewSpecIndex creates a new SpecIndex from the leaves of tree.
+func NewSpecIndex(tree *SuiteTree) *SpecIndex {
+	klog.V(100).Infof("Creating SpecIndex for tree with path %s", tree.Path)
+
+	index := &SpecIndex{
+		ByLabel:  make(map[string][]*IndexedSpec),
+		ByTestID: make(map[string][]*IndexedSpec),
+	}
+
+	for suite, leaf := range tree.Leaves() {
+		index.Specs = append(index.Specs, newIndexedSpec(suite, leaf.SpecReport))
+	}
+
+	slices.SortFunc(index.Specs, func(specA, specB *IndexedSpec) int {
+		return compareResultKeys(specA.Key, specB.Key)
+	})
+
+	for _, spec := range index.Specs {
+		for _, label := range spec.Labels {
+			index.ByLabel[label] = append(index.ByLabel[label], spec)
+		}
+
+		for _, testID := range spec.TestIDs {
+			index.ByTestID[testID] = append(index.ByTestID[testID], spec)
+		}
+	}
+
+	return index
+}
+
+// Labels returns all the labels in the index sorted lexicographically.
+func (index *SpecIndex) Labels() []string {
+	return slices.Sorted(maps.Keys(index.ByLabel))
+}
+
+// TestIDs returns all the test case IDs in the index sorted lexicographically.
+func (index *SpecIndex) TestIDs() []string {
+	return slices.Sorted(maps.Keys(index.ByTestID))
+}
+
+// DuplicateTestIDs returns the test case IDs used by specs in more than one suite, sorted lexicographically. IDs
+// repeated within a single suite are not included since table entries commonly share the ID of their table.
+func (index *SpecIndex) DuplicateTestIDs() []string {
+	var duplicates []string
+
+	for _, testID := range index.TestIDs() {
+		specs := index.ByTestID[testID]
+
+		for _, spec := range specs[1:] {
+			if spec.Key.Suite != specs[0].Key.Suite {
+				duplicates = append(duplicates, testID)
+
+				break
+			}
+		}
+	}
+
+	return duplicates
+}
+
+// MissingTestIDs returns all the specs without a test case ID.
+func (index *SpecIndex) MissingTestIDs() []*IndexedSpec {
+	var missing []*IndexedSpec
+
+	for _, spec := range index.Specs {
+		if len(spec.TestIDs) == 0 {
+			missing = append(missing, spec)
+		}
+	}
+
+	return missing
+}
+
+// Filter returns all the specs selected by the Ginkgo label filter expression, such as the value of ECO_TEST_LABELS.
+// Specs are matched against their own labels as well as the labels of their suite, the same as Ginkgo does.
+func (index *SpecIndex) Filter(expression string) ([]*IndexedSpec, error) {
+	labelFilter, err := types.ParseLabelFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*IndexedSpec
+
+	for _, spec := range index.Specs {
+		if labelFilter(spec.allLabels) {
+			selected = append(selected, spec)
+		}
+	}
+
+	return selected, nil
+}
+
+// LabelsString returns a string representation of the specs grouped by label. Each label is followed by its specs
+// indented with a dot and two spaces.
+func (index *SpecIndex) LabelsString() string {
+	builder := &strings.Builder{}
+
+	for _, label := range index.Labels() {
+		writeIndexedSpecs(builder, label, index.ByLabel[label])
+	}
+
+	return builder.String()
+}
+
+// TestIDsString returns a string representation of the specs grouped by test case ID, followed by the duplicate IDs
+// and the specs missing an ID.
+func (index *SpecIndex) TestIDsString() string {
+	builder := &strings.Builder{}
+
+	for _, testID := range index.TestIDs() {
+		writeIndexedSpecs(builder, testID, index.ByTestID[testID])
+	}
+
+	duplicates := index.DuplicateTestIDs()
+	fmt.Fprintf(builder, "Duplicate IDs across suites: %d\n", len(duplicates))
+
+	for _, testID := range duplicates {
+		builder.WriteString(".  ")
+		builder.WriteString(testID)
+		builder.WriteByte('\n')
+	}
+
+	writeIndexedSpecs(builder, "Missing ID", index.MissingTestIDs())
+
+	return builder.String()
+}
+
+// newIndexedSpec creates an IndexedSpec from the spec and the suite it belongs to.
+func newIndexedSpec(suite *SuiteTree, spec *types.SpecReport) *IndexedSpec {
+	indexedSpec := &IndexedSpec{
+		Key:       ResultKey{Suite: suite.Description, Spec: spec.FullText()},
+		Location:  formatLocation(spec),
+		allLabels: slices.Clone(suite.Labels),
+	}
+
+	for _, label := range spec.Labels() {
+		if !slices.Contains(indexedSpec.allLabels, label) {
+			indexedSpec.allLabels = append(indexedSpec.allLabels, label)
+		}
+	}
+
+	for _, label := range indexedSpec.allLabels {
+		if testID, found := strings.CutPrefix(label, testIDLabelPrefix); found {
+			indexedSpec.TestIDs = append(indexedSpec.TestIDs, testID)
+		}
+	}
+
+	for _, label := range indexedSpec.allLabels {
+		if strings.HasPrefix(label, testIDLabelPrefix) || slices.Contains(indexedSpec.TestIDs, label) {
+			continue
+		}
+
+		indexedSpec.Labels = append(indexedSpec.Labels, label)
+	}
+
+	return indexedSpec
+}
+
+// writeIndexedSpecs writes a section to writer with the provided title and number of specs followed by one line per
+// spec.
+func writeIndexedSpecs(writer io.Writer, title string, specs []*IndexedSpec) {
+	fmt.Fprintf(writer, "%s: %d\n", title, len(specs))
+
+	for _, spec := range specs {
+		fmt.Fprintf(writer, ".  %s (%s)\n", spec.Key, spec.Location)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestIndex returns the SpecIndex of a tree where the ptp suite has the ran suite label.
+func newTestIndex() *SpecIndex {
+	tree := newTestTree(
+		testSpec{suite: "ptp", text: "events", file: "ptp/tests/a.go", labels: []string{"events", "test_id:1", "1"}},
+		testSpec{suite: "ptp", text: "events table", file: "ptp/tests/a.go", labels: []string{"test_id:1", "1"}},
+		testSpec{suite: "ptp", text: "no id", file: "ptp/tests/a.go", labels: []string{"events", "disruptive"}},
+		testSpec{suite: "talm", text: "upgrade", file: "talm/tests/a.go", labels: []string{"test_id:1", "1"}},
+		testSpec{suite: "talm", text: "backup", file: "talm/tests/a.go", labels: []string{"test_id:2", "2", "backup"}},
+	)
+
+	for suite := range tree.Leaves() {
+		if suite.Description == "ptp" {
+			suite.Labels = []string{"ran"}
+		}
+	}
+
+	return NewSpecIndex(tree)
+}
+
+func TestSpecIndex(t *testing.T) {
+	index := newTestIndex()
+
+	assert.Equal(t, []string{"backup", "disruptive", "events", "ran"}, index.Labels())
+	assert.Equal(t, []string{"1", "2"}, index.TestIDs())
+	assert.Len(t, index.ByTestID["1"], 3)
+	assert.Len(t, index.ByLabel["ran"], 3)
+
+	// The suite labels are indexed but the labels added by reportxml.ID are not.
+	assert.Equal(t, []string{"ran", "events"}, index.ByLabel["events"][0].Labels)
+	assert.Equal(t, []string{"1"}, index.ByLabel["events"][0].TestIDs)
+
+	missing := index.MissingTestIDs()
+	if assert.Len(t, missing, 1) {
+		assert.Equal(t, ResultKey{Suite: "ptp", Spec: "no id"}, missing[0].Key)
+	}
+}
+
+func TestDuplicateTestIDs(t *testing.T) {
+	// Test ID 1 is used in both the ptp and talm suites, while sharing an ID within a suite is not a duplicate.
+	assert.Equal(t, []string{"1"}, newTestIndex().DuplicateTestIDs())
+
+	index := NewSpecIndex(newTestTree(
+		testSpec{suite: "ptp", text: "entry 1", file: "ptp/tests/a.go", labels: []string{"test_id:1", "1"}},
+		testSpec{suite: "ptp", text: "entry 2", file: "ptp/tests/a.go", labels: []string{"test_id:1", "1"}},
+	))
+	assert.Empty(t, index.DuplicateTestIDs())
+}
+
+func TestSpecIndexFilter(t *testing.T) {
+	testCases := []struct {
+		expression string
+		expected   []string
+		err        bool
+	}{
+		{expression: "events", expected: []string{"events", "no id"}},
+		{expression: "ran && !disruptive", expected: []string{"events", "events table"}},
+		{expression: "1", expected: []string{"events", "events table", "upgrade"}},
+		{expression: "test_id:2 || backup", expected: []string{"backup"}},
+		{expression: "", expected: []string{"events", "events table", "no id", "backup", "upgrade"}},
+		{expression: "missing", expected: nil},
+		{expression: "ran && (", err: true},
+	}
+
+	index := newTestIndex()
+
+	for _, testCase := range testCases {
+		selected, err := index.Filter(testCase.expression)
+		if testCase.err {
+			assert.Error(t, err, "expression %q should be invalid", testCase.expression)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		var texts []string
+		for _, spec := range selected {
+			texts = append(texts, spec.Key.Spec)
+		}
+
+		assert.Equal(t, testCase.expected, texts, "specs selected by %q", testCase.expression)
+	}
+}
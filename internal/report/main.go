@@ -12,9 +12,17 @@ Usage:
 
 The flags are:
 
+	-f, -filter string
+		Ginkgo label filter expression, such as the value of ECO_TEST_LABELS. The specs it selects are printed instead of
+		the trees and included in the index page
+
 	-h, -help
 		Print this help message
 
+	-i, -ids
+		Print the specs grouped by test case ID, the IDs duplicated across suites, and the specs missing an ID instead
+		of the trees
+
 	-a, -action-url string
 		URL to the action generating this report. Only necessary with -o. Uses "/" if left blank
 
@@ -24,9 +32,34 @@ The flags are:
 	-c, -clean
 		Delete the test suite cache and exit without running
 
+	-d, -diff string
+		Two branches given as base..head whose specs are compared. The differences are printed instead of the trees.
+		Both branches are added to the branch flag
+
+	-x, -export string
+		Format to export the trees in, either json or csv. The export is printed instead of the trees and, if -o is
+		provided, saved for each branch alongside its report
+
 	-o, -output string
 		Directory to output static site to. Will not be generated if left blank
 
+	-l, -labels
+		Print the specs grouped by label instead of the trees
+
+	-n, -runs int
+		Number of most recent runs to include in the trend. Use 0 to include all runs in the history (default 10)
+
+	-r, -results string
+		Directory with Ginkgo JSON and JUnit reports from a real run to overlay on the trees. Leave blank to only report
+		the specs from the dry run
+
+	-s, -slowdown float
+		Fraction by which the latest runtime of a spec must exceed its average to be a runtime regression (default 0.5)
+
+	-t, -trend string
+		Directory with one subdirectory of Ginkgo JSON and JUnit reports per run. Runs are added to the run history of
+		the branch, which is saved in the cache, and a trend is reported. Requires exactly one branch
+
 	-v int
 		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
 */
@@ -53,7 +86,16 @@ var (
 	actionURL string
 	branch    string
 	clean     bool
+	diff      string
+	export    string
+	filter    string
+	ids       bool
+	labels    bool
 	output    string
+	results   string
+	trendDir  string
+	trendRuns int
+	slowdown  float64
 )
 
 //nolint:gochecknoinits // This is a main package so init is fine.
@@ -63,13 +105,31 @@ func init() {
 		actionURLUsage = "URL to the action generating this report. Only necessary with -o. Uses \"/\" if left blank"
 		branchUsage    = "Space-separated list of globs to match branches. Leave blank to use the local directory"
 		cleanUsage     = "Delete the test suite cache and exit without running"
+		diffUsage      = "Two branches given as base..head to compare. The differences are printed instead of the trees"
+		exportUsage    = "Format to export the trees in, either json or csv. The export is printed instead of the trees"
+		filterUsage    = "Ginkgo label filter expression. The specs it selects are printed instead of the trees"
+		idsUsage       = "Print the specs grouped by test case ID instead of the trees"
+		labelsUsage    = "Print the specs grouped by label instead of the trees"
 		outputUsage    = "Directory to output static site to. Will not be generated if left blank"
+		resultsUsage   = "Directory with Ginkgo JSON and JUnit reports from a real run to overlay on the trees"
+		trendUsage     = "Directory with one subdirectory of reports per run to add to the run history and report a trend"
+		runsUsage      = "Number of most recent runs to include in the trend. Use 0 to include all runs in the history"
+		slowdownUsage  = "Fraction by which the latest runtime of a spec must exceed its average to be a regression"
 
 		defaultHelp      = false
 		defaultActionURL = "/"
 		defaultBranch    = ""
 		defaultClean     = false
+		defaultDiff      = ""
+		defaultExport    = ""
+		defaultFilter    = ""
+		defaultIDs       = false
+		defaultLabels    = false
 		defaultOutput    = ""
+		defaultResults   = ""
+		defaultTrend     = ""
+		defaultRuns      = 10
+		defaultSlowdown  = 0.5
 
 		shorthand = " (shorthand)"
 	)
@@ -92,8 +152,35 @@ func init() {
 	flag.BoolVar(&clean, "clean", defaultClean, cleanUsage)
 	flag.BoolVar(&clean, "c", defaultClean, cleanUsage+shorthand)
 
+	flag.StringVar(&diff, "diff", defaultDiff, diffUsage)
+	flag.StringVar(&diff, "d", defaultDiff, diffUsage+shorthand)
+
+	flag.StringVar(&export, "export", defaultExport, exportUsage)
+	flag.StringVar(&export, "x", defaultExport, exportUsage+shorthand)
+
+	flag.StringVar(&filter, "filter", defaultFilter, filterUsage)
+	flag.StringVar(&filter, "f", defaultFilter, filterUsage+shorthand)
+
+	flag.BoolVar(&ids, "ids", defaultIDs, idsUsage)
+	flag.BoolVar(&ids, "i", defaultIDs, idsUsage+shorthand)
+
+	flag.BoolVar(&labels, "labels", defaultLabels, labelsUsage)
+	flag.BoolVar(&labels, "l", defaultLabels, labelsUsage+shorthand)
+
 	flag.StringVar(&output, "output", defaultOutput, outputUsage)
 	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.StringVar(&results, "results", defaultResults, resultsUsage)
+	flag.StringVar(&results, "r", defaultResults, resultsUsage+shorthand)
+
+	flag.StringVar(&trendDir, "trend", defaultTrend, trendUsage)
+	flag.StringVar(&trendDir, "t", defaultTrend, trendUsage+shorthand)
+
+	flag.IntVar(&trendRuns, "runs", defaultRuns, runsUsage)
+	flag.IntVar(&trendRuns, "n", defaultRuns, runsUsage+shorthand)
+
+	flag.Float64Var(&slowdown, "slowdown", defaultSlowdown, slowdownUsage)
+	flag.Float64Var(&slowdown, "s", defaultSlowdown, slowdownUsage+shorthand)
 }
 
 func main() {
@@ -116,58 +203,175 @@ func main() {
 		return
 	}
 
-	treeMap, err := getTrees(branch)
+	err := generateReport()
 	if err != nil {
-		klog.Errorf("Failed to get suite trees when branch=\"%s\": %v", branch, err)
+		klog.Errorf("Failed to generate report: %v", err)
 
 		os.Exit(1)
 	}
+}
 
-	printTreeMap(treeMap)
+// generateReport gets the trees for all branches matching the branch flag, adds any run results and history, then
+// prints the trees and templates them if requested. All steps share a single cache so it is only loaded and saved once.
+func generateReport() error {
+	ctx, cancel := signal.NotifyContext(context.TODO(), os.Interrupt, os.Kill)
+	defer cancel()
 
-	if output != "" {
-		err := templateTreeMap(treeMap, output)
+	branches := branch
+
+	if export != "" && !IsValidExportFormat(export) {
+		return fmt.Errorf("export format must be %s or %s but was \"%s\"", ExportFormatJSON, ExportFormatCSV, export)
+	}
+
+	diffBase, diffHead, err := parseDiff(diff)
+	if err != nil {
+		return err
+	}
+
+	if diff != "" {
+		branches = strings.Join([]string{branch, diffBase, diffHead}, " ")
+	}
+
+	cache, err := NewCacheContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load cache: %w", err)
+	}
+
+	treeMap, err := getTrees(ctx, cache, branches)
+	if err != nil {
+		return fmt.Errorf("failed to get suite trees when branch=\"%s\": %w", branches, err)
+	}
+
+	var trend *Trend
+
+	if trendDir != "" {
+		trend, err = getTrend(cache, treeMap, trendDir)
 		if err != nil {
-			klog.Errorf("Failed to template tree map and save to %s: %v", output, err)
+			return fmt.Errorf("failed to get trend from runs in %s: %w", trendDir, err)
+		}
+	}
 
-			os.Exit(1)
+	err = cache.Save()
+	if err != nil {
+		return fmt.Errorf("failed to save cache: %w", err)
+	}
+
+	if results != "" {
+		resultSet, err := LoadResults(results)
+		if err != nil {
+			return fmt.Errorf("failed to load run results from %s: %w", results, err)
+		}
+
+		// Results must be overlaid before trimming so the suite descriptions are still available for matching specs.
+		for _, tree := range treeMap {
+			tree.OverlayResults(resultSet)
 		}
 	}
-}
 
-func getTrees(branch string) (map[CacheKey]*SuiteTree, error) {
-	ctx, cancel := signal.NotifyContext(context.TODO(), os.Interrupt, os.Kill)
-	defer cancel()
+	for key, tree := range treeMap {
+		treeMap[key] = tree.TrimRoot()
+		treeMap[key].Sort(true)
+	}
 
-	cache, err := NewCacheContext(ctx)
+	err = printTrees(treeMap, trend, diffBase, diffHead)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if output != "" {
+		err := templateTreeMap(treeMap, trend, output)
+		if err != nil {
+			return fmt.Errorf("failed to template tree map and save to %s: %w", output, err)
+		}
 	}
 
-	var treeMap map[CacheKey]*SuiteTree
+	return nil
+}
+
+// parseDiff splits diff into the base and head branches. It returns empty strings if diff is empty and an error if
+// diff is not in the form base..head.
+func parseDiff(diff string) (base, head string, err error) {
+	if diff == "" {
+		return "", "", nil
+	}
+
+	base, head, found := strings.Cut(diff, "..")
+	if !found || base == "" || head == "" {
+		return "", "", fmt.Errorf("diff must be in the form base..head but was \"%s\"", diff)
+	}
 
+	return base, head, nil
+}
+
+func getTrees(ctx context.Context, cache *Cache, branch string) (map[CacheKey]*SuiteTree, error) {
 	if branch != "" {
 		patterns := strings.Fields(branch)
-		treeMap, err = getFromCacheOrClone(ctx, cache, patterns)
-	} else {
-		treeMap, err = getLocalTreeMap(cache, ".")
+
+		return getFromCacheOrClone(ctx, cache, patterns)
 	}
 
-	if err != nil {
-		return nil, err
+	return getLocalTreeMap(cache, ".")
+}
+
+// getTrend adds the runs in directory to the run history of the only tree in treeMap and creates a Trend from the
+// history. Since runs cannot be attributed to a branch otherwise, it returns an error if treeMap does not have exactly
+// one tree.
+func getTrend(cache *Cache, treeMap map[CacheKey]*SuiteTree, directory string) (*Trend, error) {
+	if len(treeMap) != 1 {
+		return nil, fmt.Errorf("trend requires exactly one branch but found %d", len(treeMap))
 	}
 
-	err = cache.Save()
+	var (
+		key  CacheKey
+		tree *SuiteTree
+	)
+
+	for treeKey, treeValue := range treeMap {
+		key, tree = treeKey, treeValue
+	}
+
+	records, err := LoadRunRecords(directory, tree)
 	if err != nil {
 		return nil, err
 	}
 
-	for key, tree := range treeMap {
-		treeMap[key] = tree.TrimRoot()
-		treeMap[key].Sort(true)
+	cache.AddRuns(key.Branch, records)
+
+	return NewTrend(key.Branch, cache.GetRuns(key.Branch), trendRuns, slowdown), nil
+}
+
+// printTrees prints the diff and index views requested by the flags followed by the trend, if there is one. If none
+// are requested, the trees themselves are printed instead. When exporting, only the export is printed so the output
+// can be consumed by other tools.
+func printTrees(treeMap map[CacheKey]*SuiteTree, trend *Trend, diffBase, diffHead string) error {
+	if export != "" {
+		return Export(os.Stdout, treeMap, export)
 	}
 
-	return treeMap, nil
+	if diff == "" && !labels && !ids && filter == "" {
+		printTreeMap(treeMap)
+	}
+
+	if diff != "" {
+		err := printDiff(treeMap, diffBase, diffHead)
+		if err != nil {
+			return err
+		}
+	}
+
+	if labels || ids || filter != "" {
+		err := printIndexes(treeMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	if trend != nil {
+		fmt.Println("---")
+		fmt.Print(trend)
+	}
+
+	return nil
 }
 
 func printTreeMap(treeMap map[CacheKey]*SuiteTree) {
@@ -178,7 +382,92 @@ func printTreeMap(treeMap map[CacheKey]*SuiteTree) {
 	}
 }
 
-func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
+// printDiff prints the BranchDiff between the trees for the base and head branches in treeMap.
+func printDiff(treeMap map[CacheKey]*SuiteTree, base, head string) error {
+	baseTree := findBranch(treeMap, base)
+	if baseTree == nil {
+		return fmt.Errorf("failed to find tree for base branch %s", base)
+	}
+
+	headTree := findBranch(treeMap, head)
+	if headTree == nil {
+		return fmt.Errorf("failed to find tree for head branch %s", head)
+	}
+
+	fmt.Print(NewBranchDiff(base, head, baseTree, headTree))
+
+	return nil
+}
+
+// printIndexes prints the label and test case ID indexes requested by the flags for every tree in treeMap.
+func printIndexes(treeMap map[CacheKey]*SuiteTree) error {
+	for key, tree := range treeMap {
+		index := NewSpecIndex(tree)
+
+		fmt.Println("---")
+		fmt.Printf("Branch %s (%s)\n", key.Branch, key.Revision[:7])
+
+		if labels {
+			fmt.Print(index.LabelsString())
+		}
+
+		if ids {
+			fmt.Print(index.TestIDsString())
+		}
+
+		if filter != "" {
+			selected, err := index.Filter(filter)
+			if err != nil {
+				return fmt.Errorf("failed to parse label filter \"%s\": %w", filter, err)
+			}
+
+			writeIndexedSpecs(os.Stdout, "Selected by "+filter, selected)
+		}
+	}
+
+	return nil
+}
+
+// exportTree saves the export of the tree for a single branch in both formats to the output directory and returns the
+// names of the JSON and CSV files.
+func exportTree(key CacheKey, tree *SuiteTree, output string) (jsonFile, csvFile string, err error) {
+	treeMap := map[CacheKey]*SuiteTree{key: tree}
+	jsonFile = fmt.Sprintf("specs_%s.json", key.Branch)
+	csvFile = fmt.Sprintf("specs_%s.csv", key.Branch)
+
+	for fileName, format := range map[string]string{jsonFile: ExportFormatJSON, csvFile: ExportFormatCSV} {
+		exportFile, err := os.Create(filepath.Join(output, fileName))
+		if err != nil {
+			return "", "", err
+		}
+
+		err = Export(exportFile, treeMap, format)
+		closeErr := exportFile.Close()
+
+		if err != nil {
+			return "", "", err
+		}
+
+		if closeErr != nil {
+			return "", "", closeErr
+		}
+	}
+
+	return jsonFile, csvFile, nil
+}
+
+// findBranch returns the tree in treeMap for the provided branch. If there is no tree for the branch, nil is returned.
+func findBranch(treeMap map[CacheKey]*SuiteTree, branch string) *SuiteTree {
+	for key, tree := range treeMap {
+		if key.Branch == branch {
+			return tree
+		}
+	}
+
+	return nil
+}
+
+func templateTreeMap(treeMap map[CacheKey]*SuiteTree, trend *Trend, output string) error {
 	err := os.MkdirAll(output, 0755)
 	if err != nil {
 		return err
@@ -186,6 +475,8 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 
 	var branchReports []BranchReportConfig
 
+	baseTree := findBranch(treeMap, BaseBranch)
+
 	for key, tree := range treeMap {
 		config := TreeTemplateConfig{
 			Tree:       tree,
@@ -209,6 +500,62 @@ func templateTreeMap(treeMap map[CacheKey]*SuiteTree, output string) error {
 			Revision:      key.Revision,
 			ShortRevision: key.Revision[:7],
 		}
+
+		if baseTree != nil && key.Branch != BaseBranch {
+			diffConfig := DiffTemplateConfig{
+				Diff:       NewBranchDiff(BaseBranch, key.Branch, baseTree, tree),
+				Generated:  time.Now(),
+				ActionURL:  template.URL(actionURL),
+				RepoURL:    RemoteURL,
+				TimeFormat: time.RFC3339,
+			}
+			branchReport.DiffFile = fmt.Sprintf("diff_%s.html", key.Branch)
+
+			err := TemplateDiff(diffConfig, filepath.Join(output, branchReport.DiffFile))
+			if err != nil {
+				return err
+			}
+		}
+
+		indexConfig := IndexTemplateConfig{
+			Index:      NewSpecIndex(tree),
+			Generated:  time.Now(),
+			Branch:     key.Branch,
+			Filter:     filter,
+			ActionURL:  template.URL(actionURL),
+			RepoURL:    RemoteURL,
+			TimeFormat: time.RFC3339,
+		}
+		branchReport.IndexFile = fmt.Sprintf("index_%s.html", key.Branch)
+
+		err = TemplateIndex(indexConfig, filepath.Join(output, branchReport.IndexFile))
+		if err != nil {
+			return err
+		}
+
+		if export != "" {
+			branchReport.JSONFile, branchReport.CSVFile, err = exportTree(key, tree, output)
+			if err != nil {
+				return err
+			}
+		}
+
+		if trend != nil && trend.Branch == key.Branch {
+			trendConfig := TrendTemplateConfig{
+				Trend:      trend,
+				Generated:  time.Now(),
+				ActionURL:  template.URL(actionURL),
+				RepoURL:    RemoteURL,
+				TimeFormat: time.RFC3339,
+			}
+			branchReport.TrendFile = fmt.Sprintf("trend_%s.html", key.Branch)
+
+			err := TemplateTrend(trendConfig, filepath.Join(output, branchReport.TrendFile))
+			if err != nil {
+				return err
+			}
+		}
+
 		branchReports = append(branchReports, branchReport)
 	}
 
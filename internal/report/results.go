@@ -0,0 +1,343 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	junitSuffix = "_junit.xml"
+	jsonSuffix  = ".json"
+	// junitTimestampLayout is the layout Ginkgo uses for the timestamp attribute of JUnit test suites.
+	junitTimestampLayout = "2006-01-02T15:04:05"
+)
+
+// RunResults contains the aggregated outcome of a real run for a node in the SuiteTree. Flaky specs are those that
+// passed after more than one attempt and are counted as both passed and flaky.
+type RunResults struct {
+	Passed   int
+	Failed   int
+	Skipped  int
+	Flaky    int
+	Duration time.Duration
+}
+
+// Total returns the number of specs that have a recorded outcome. Flaky specs are already counted as passed so they are
+// not counted twice.
+func (results *RunResults) Total() int {
+	return results.Passed + results.Failed + results.Skipped
+}
+
+// Add adds the counts and duration from other to the receiver.
+func (results *RunResults) Add(other *RunResults) {
+	if other == nil {
+		return
+	}
+
+	results.Passed += other.Passed
+	results.Failed += other.Failed
+	results.Skipped += other.Skipped
+	results.Flaky += other.Flaky
+	results.Duration += other.Duration
+}
+
+// SpecResult is the outcome of a single spec from a real run. It is only set on leaf nodes of the SuiteTree.
+type SpecResult struct {
+	State          types.SpecState
+	RunTime        time.Duration
+	NumAttempts    int
+	FailureMessage string
+}
+
+// IsFlaky returns true if the spec passed but required more than one attempt.
+func (result *SpecResult) IsFlaky() bool {
+	return result.State.Is(types.SpecStatePassed) && result.NumAttempts > 1
+}
+
+// toRunResults converts a single spec outcome into RunResults so it can be aggregated up the tree.
+func (result *SpecResult) toRunResults() *RunResults {
+	results := &RunResults{Duration: result.RunTime}
+
+	switch {
+	case result.State.Is(types.SpecStatePassed):
+		results.Passed = 1
+
+		if result.IsFlaky() {
+			results.Flaky = 1
+		}
+	case result.State.Is(types.SpecStateFailureStates):
+		results.Failed = 1
+	case result.State.Is(types.SpecStateSkipped | types.SpecStatePending):
+		results.Skipped = 1
+	}
+
+	return results
+}
+
+// junitTestSuites is the subset of the JUnit report format written by Ginkgo that is necessary for loading results.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite is a single suite in a JUnit report. Its name is the description of the Ginkgo suite.
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase is a single spec in a JUnit report. The name is the leaf node type in brackets followed by the full
+// text of the spec and its labels in brackets.
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Status  string        `xml:"status,attr"`
+	Time    float64       `xml:"time,attr"`
+	Error   *junitMessage `xml:"error,omitempty"`
+	Failure *junitMessage `xml:"failure,omitempty"`
+}
+
+// junitMessage is the message attribute of either the error or failure element of a JUnit test case.
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// ResultKey identifies a spec across runs. Suite paths differ between the machine that ran the tests and the one
+// generating the report, so specs are matched using the suite description and the full text of the spec instead.
+type ResultKey struct {
+	Suite string
+	Spec  string
+}
+
+// MarshalText joins the suite and spec with a tab so that ResultKey may be used as the key of a map encoded as JSON.
+func (key ResultKey) MarshalText() ([]byte, error) {
+	return []byte(key.Suite + "\t" + key.Spec), nil
+}
+
+// UnmarshalText is the inverse of MarshalText. It returns an error if text does not contain a tab.
+func (key *ResultKey) UnmarshalText(text []byte) error {
+	suite, spec, found := strings.Cut(string(text), "\t")
+	if !found {
+		return fmt.Errorf("failed to parse result key from `%s`", text)
+	}
+
+	key.Suite = suite
+	key.Spec = spec
+
+	return nil
+}
+
+// String returns the suite description and spec text separated by a colon for displaying the key.
+func (key ResultKey) String() string {
+	return key.Suite + ": " + key.Spec
+}
+
+// compareResultKeys compares keys first by suite and then by spec. It may be used for sorting keys so output is
+// deterministic.
+func compareResultKeys(keyA, keyB ResultKey) int {
+	return cmp.Or(strings.Compare(keyA.Suite, keyB.Suite), strings.Compare(keyA.Spec, keyB.Spec))
+}
+
+// ResultSet holds all the spec outcomes loaded from the reports of a single real run.
+type ResultSet struct {
+	Results map[ResultKey]*SpecResult
+	// StartTime is the earliest start time of any suite in the loaded reports. It is the zero value if no reports
+	// included a start time.
+	StartTime time.Time
+	// junitCases holds the JUnit test cases for each suite description. Since JUnit test case names include the labels
+	// of the spec, they cannot be looked up directly and are instead matched by prefix when the key is not found in
+	// Results.
+	junitCases map[string][]junitTestCase
+}
+
+// NewResultSet returns an empty ResultSet ready to have reports added to it.
+func NewResultSet() *ResultSet {
+	return &ResultSet{
+		Results:    make(map[ResultKey]*SpecResult),
+		junitCases: make(map[string][]junitTestCase),
+	}
+}
+
+// LoadResults walks the provided directory and loads all Ginkgo JSON reports and JUnit XML reports into a ResultSet.
+// Files that end in .json but cannot be parsed as a Ginkgo report are skipped with a log message, since the reports
+// dump directory often contains other JSON files. When a spec is present in both formats, the JSON report takes
+// precedence since it includes the number of attempts.
+func LoadResults(directory string) (*ResultSet, error) {
+	klog.V(100).Infof("Loading run results from directory %s", directory)
+
+	resultSet := NewResultSet()
+
+	err := filepath.WalkDir(directory, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !dirEntry.Type().IsRegular() {
+			return nil
+		}
+
+		switch {
+		case strings.HasSuffix(dirEntry.Name(), junitSuffix):
+			return resultSet.loadJUnitFile(path)
+		case strings.HasSuffix(dirEntry.Name(), jsonSuffix):
+			err := resultSet.loadJSONFile(path)
+			if err != nil {
+				klog.V(100).Infof("Skipping file %s since it is not a Ginkgo JSON report: %v", path, err)
+			}
+
+			return nil
+		default:
+			return nil
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resultSet, nil
+}
+
+// AddReports adds the results of all It specs from the provided reports to the ResultSet.
+func (resultSet *ResultSet) AddReports(reports []types.Report) {
+	for _, report := range reports {
+		resultSet.updateStartTime(report.StartTime)
+
+		for _, spec := range report.SpecReports.WithLeafNodeType(types.NodeTypeIt) {
+			key := ResultKey{Suite: report.SuiteDescription, Spec: spec.FullText()}
+			resultSet.Results[key] = &SpecResult{
+				State:          spec.State,
+				RunTime:        spec.RunTime,
+				NumAttempts:    spec.NumAttempts,
+				FailureMessage: spec.Failure.Message,
+			}
+		}
+	}
+}
+
+// addJUnitSuites adds the test cases from the provided JUnit test suites to the ResultSet. They will only be used for
+// specs that were not found in a JSON report.
+func (resultSet *ResultSet) addJUnitSuites(suites junitTestSuites) {
+	for _, suite := range suites.TestSuites {
+		startTime, err := time.Parse(junitTimestampLayout, suite.Timestamp)
+		if err == nil {
+			resultSet.updateStartTime(startTime)
+		}
+
+		resultSet.junitCases[suite.Name] = append(resultSet.junitCases[suite.Name], suite.TestCases...)
+	}
+}
+
+// Lookup returns the result for the spec in the suite with the provided description. If no result exists for the spec,
+// nil is returned.
+func (resultSet *ResultSet) Lookup(suiteDescription string, spec *types.SpecReport) *SpecResult {
+	key := ResultKey{Suite: suiteDescription, Spec: spec.FullText()}
+	if result, ok := resultSet.Results[key]; ok {
+		return result
+	}
+
+	// JUnit names are "[It] <full text>" optionally followed by the labels in brackets.
+	junitName := "[" + types.NodeTypeIt.String() + "] " + spec.FullText()
+
+	for _, testCase := range resultSet.junitCases[suiteDescription] {
+		if testCase.Name != junitName && !strings.HasPrefix(testCase.Name, junitName+" [") {
+			continue
+		}
+
+		result := &SpecResult{
+			State:       parseSpecState(testCase.Status),
+			RunTime:     time.Duration(testCase.Time * float64(time.Second)),
+			NumAttempts: 1,
+		}
+
+		if testCase.Failure != nil {
+			result.FailureMessage = testCase.Failure.Message
+		} else if testCase.Error != nil {
+			result.FailureMessage = testCase.Error.Message
+		}
+
+		resultSet.Results[key] = result
+
+		return result
+	}
+
+	return nil
+}
+
+// updateStartTime sets the StartTime of the ResultSet to startTime if it is earlier than the current one or if the
+// current one is not set.
+func (resultSet *ResultSet) updateStartTime(startTime time.Time) {
+	if startTime.IsZero() {
+		return
+	}
+
+	if resultSet.StartTime.IsZero() || startTime.Before(resultSet.StartTime) {
+		resultSet.StartTime = startTime
+	}
+}
+
+// loadJSONFile loads a Ginkgo JSON report from path and adds it to the ResultSet.
+func (resultSet *ResultSet) loadJSONFile(path string) error {
+	klog.V(100).Infof("Loading Ginkgo JSON report from %s", path)
+
+	reportsBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	reports := []types.Report{}
+
+	err = json.Unmarshal(reportsBytes, &reports)
+	if err != nil {
+		return err
+	}
+
+	resultSet.AddReports(reports)
+
+	return nil
+}
+
+// loadJUnitFile loads a JUnit XML report from path and adds it to the ResultSet.
+func (resultSet *ResultSet) loadJUnitFile(path string) error {
+	klog.V(100).Infof("Loading JUnit report from %s", path)
+
+	junitBytes, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	suites := junitTestSuites{}
+
+	err = xml.Unmarshal(junitBytes, &suites)
+	if err != nil {
+		return err
+	}
+
+	resultSet.addJUnitSuites(suites)
+
+	return nil
+}
+
+// parseSpecState converts the string representation of a SpecState used in JUnit reports back into a SpecState. Unknown
+// strings result in SpecStateInvalid.
+func parseSpecState(status string) types.SpecState {
+	var state types.SpecState
+
+	err := state.UnmarshalJSON([]byte(strconv.Quote(status)))
+	if err != nil {
+		return types.SpecStateInvalid
+	}
+
+	return state
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultSetLookup(t *testing.T) {
+	resultSet := NewResultSet()
+	resultSet.AddReports([]types.Report{{
+		SuiteDescription: "PTP",
+		SpecReports: types.SpecReports{{
+			LeafNodeType: types.NodeTypeIt,
+			LeafNodeText: "from json",
+			State:        types.SpecStatePassed,
+			NumAttempts:  2,
+		}},
+	}})
+	resultSet.addJUnitSuites(junitTestSuites{TestSuites: []junitTestSuite{{
+		Name:      "PTP",
+		Timestamp: "2025-10-01T12:00:00",
+		TestCases: []junitTestCase{
+			{Name: "[It] from json", Status: "failed"},
+			{Name: "[It] no labels", Status: "passed", Time: 1.5},
+			{Name: "[It] with labels [ptp, 12345]", Status: "failed", Failure: &junitMessage{Message: "timed out"}},
+			{Name: "[It] with error", Status: "panicked", Error: &junitMessage{Message: "nil pointer"}},
+			{Name: "[It] with labels and more text", Status: "passed"},
+			{Name: "[It] unknown status", Status: "exploded"},
+		},
+	}}})
+
+	testCases := []struct {
+		suite    string
+		spec     string
+		expected *SpecResult
+	}{
+		{
+			suite:    "PTP",
+			spec:     "from json",
+			expected: &SpecResult{State: types.SpecStatePassed, NumAttempts: 2},
+		},
+		{
+			suite:    "PTP",
+			spec:     "no labels",
+			expected: &SpecResult{State: types.SpecStatePassed, RunTime: 1500 * time.Millisecond, NumAttempts: 1},
+		},
+		{
+			suite: "PTP",
+			spec:  "with labels",
+			expected: &SpecResult{
+				State: types.SpecStateFailed, NumAttempts: 1, FailureMessage: "timed out",
+			},
+		},
+		{
+			suite: "PTP",
+			spec:  "with error",
+			expected: &SpecResult{
+				State: types.SpecStatePanicked, NumAttempts: 1, FailureMessage: "nil pointer",
+			},
+		},
+		{
+			suite:    "PTP",
+			spec:     "unknown status",
+			expected: &SpecResult{State: types.SpecStateInvalid, NumAttempts: 1},
+		},
+		{suite: "PTP", spec: "with"},
+		{suite: "PTP", spec: "missing"},
+		{suite: "TALM", spec: "no labels"},
+	}
+
+	for _, testCase := range testCases {
+		spec := &types.SpecReport{LeafNodeType: types.NodeTypeIt, LeafNodeText: testCase.spec}
+		result := resultSet.Lookup(testCase.suite, spec)
+		assert.Equal(t, testCase.expected, result, "result for %s: %s", testCase.suite, testCase.spec)
+	}
+
+	assert.Equal(t, time.Date(2025, time.October, 1, 12, 0, 0, 0, time.UTC), resultSet.StartTime)
+}
+
+func TestRunResultsAdd(t *testing.T) {
+	results := &RunResults{}
+
+	for _, result := range []*SpecResult{
+		{State: types.SpecStatePassed, RunTime: time.Second, NumAttempts: 1},
+		{State: types.SpecStatePassed, RunTime: time.Second, NumAttempts: 3},
+		{State: types.SpecStateFailed, RunTime: time.Second, NumAttempts: 1},
+		{State: types.SpecStateSkipped},
+	} {
+		results.Add(result.toRunResults())
+	}
+
+	results.Add(nil)
+
+	assert.Equal(t, &RunResults{Passed: 2, Failed: 1, Skipped: 1, Flaky: 1, Duration: 3 * time.Second}, results)
+	assert.Equal(t, 4, results.Total())
+}
+
+func TestResultKeyText(t *testing.T) {
+	key := ResultKey{Suite: "PTP", Spec: "verifies the clock"}
+
+	text, err := key.MarshalText()
+	assert.NoError(t, err)
+
+	var parsed ResultKey
+
+	err = parsed.UnmarshalText(text)
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	err = parsed.UnmarshalText([]byte("no tab"))
+	assert.Error(t, err)
+}
@@ -2,12 +2,15 @@ package main
 
 import (
 	_ "embed"
+	"fmt"
 	"html/template"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
 )
 
 var (
@@ -16,14 +19,29 @@ var (
 
 	//go:embed report_template.html
 	reportTemplateFile string
+
+	//go:embed trend_template.html
+	trendTemplateFile string
+
+	//go:embed diff_template.html
+	diffTemplateFile string
+
+	//go:embed index_template.html
+	indexTemplateFile string
 )
 
 var (
 	funcMap = template.FuncMap{
-		"cleanPath": cleanPath,
+		"cleanPath":     cleanPath,
+		"resultClass":   resultClass,
+		"roundDuration": roundDuration,
+		"percent":       percent,
 	}
 	treeTemplate   = template.Must(template.New("tree_template.html").Funcs(funcMap).Parse(treeTemplateFile))
 	reportTemplate = template.Must(template.New("report_template.html").Parse(reportTemplateFile))
+	trendTemplate  = template.Must(template.New("trend_template.html").Funcs(funcMap).Parse(trendTemplateFile))
+	diffTemplate   = template.Must(template.New("diff_template.html").Parse(diffTemplateFile))
+	indexTemplate  = template.Must(template.New("index_template.html").Parse(indexTemplateFile))
 )
 
 // TreeTemplateConfig contains the data necessary to template a single SuiteTree into an html report.
@@ -54,6 +72,11 @@ type ReportTemplateConfig struct {
 type BranchReportConfig struct {
 	Name          string
 	ReportFile    string
+	TrendFile     string
+	DiffFile      string
+	IndexFile     string
+	JSONFile      string
+	CSVFile       string
 	Revision      string
 	ShortRevision string
 }
@@ -68,6 +91,51 @@ func TemplateReport(config ReportTemplateConfig, outputFileName string) error {
 	return executeTemplateAndSave(reportTemplate, config, outputFileName)
 }
 
+// TrendTemplateConfig contains the data necessary to template the Trend for a single branch into an html report.
+type TrendTemplateConfig struct {
+	Trend      *Trend
+	Generated  time.Time
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateTrend uses config to generate a Trend report and save it at outputFileName.
+func TemplateTrend(config TrendTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(trendTemplate, config, outputFileName)
+}
+
+// DiffTemplateConfig contains the data necessary to template the BranchDiff between two branches into an html report.
+type DiffTemplateConfig struct {
+	Diff       *BranchDiff
+	Generated  time.Time
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateDiff uses config to generate a BranchDiff report and save it at outputFileName.
+func TemplateDiff(config DiffTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(diffTemplate, config, outputFileName)
+}
+
+// IndexTemplateConfig contains the data necessary to template the SpecIndex for a single branch into an html report.
+// If Filter is not empty, the specs selected by it are included in the report.
+type IndexTemplateConfig struct {
+	Index      *SpecIndex
+	Generated  time.Time
+	Branch     string
+	Filter     string
+	ActionURL  template.URL
+	RepoURL    template.URL
+	TimeFormat string
+}
+
+// TemplateIndex uses config to generate a SpecIndex report and save it at outputFileName.
+func TemplateIndex(config IndexTemplateConfig, outputFileName string) error {
+	return executeTemplateAndSave(indexTemplate, config, outputFileName)
+}
+
 // executeTemplateAndSave creates a file at outputFileName before executing tmpl with data provided by config. If
 // outputFileName already exists, then it is truncated.
 func executeTemplateAndSave(tmpl *template.Template, config any, outputFileName string) error {
@@ -98,3 +166,28 @@ func cleanPath(path string) string {
 
 	return path
 }
+
+// resultClass returns the name of the CSS class used to display the result of a single spec. It is one of passed,
+// flaky, failed, or skipped.
+func resultClass(result *SpecResult) string {
+	switch {
+	case result.IsFlaky():
+		return "flaky"
+	case result.State.Is(types.SpecStatePassed):
+		return "passed"
+	case result.State.Is(types.SpecStateFailureStates):
+		return "failed"
+	default:
+		return "skipped"
+	}
+}
+
+// roundDuration rounds the duration to the nearest tenth of a second so it is more readable in the report.
+func roundDuration(duration time.Duration) time.Duration {
+	return duration.Round(100 * time.Millisecond)
+}
+
+// percent formats a fraction between 0 and 1 as a whole number percentage.
+func percent(fraction float64) string {
+	return fmt.Sprintf("%.0f%%", 100*fraction)
+}
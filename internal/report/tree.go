@@ -3,7 +3,9 @@ package main
 import (
 	"cmp"
 	"encoding/json"
+	"fmt"
 	"io"
+	"iter"
 	"os"
 	"path"
 	"slices"
@@ -22,6 +24,9 @@ type SuiteTree struct {
 	Name string
 	// Description is the description of the test suite. It is taken from the report.
 	Description string
+	// Labels are the labels applied to the entire test suite through RunSpecs. Like Description, they are only set on
+	// the node for the test suite.
+	Labels []string `json:",omitempty"`
 	// Specs is the sum of specs from all child suites, recursively.
 	Specs int
 	// Children is a list of child suites. It can be sorted by [SuiteTree.Sort].
@@ -29,6 +34,12 @@ type SuiteTree struct {
 	// SpecReport should only be set when Children is empty, meaning this is a leaf node representing a single spec.
 	// It should only have It specs.
 	SpecReport *types.SpecReport
+	// Results is the aggregated outcome of all specs under this node from a real run. It is nil unless results have been
+	// overlaid using [SuiteTree.OverlayResults].
+	Results *RunResults `json:",omitempty"`
+	// Result is the outcome of the spec from a real run. Like SpecReport, it is only set on leaf nodes and is nil if the
+	// spec was not found in the overlaid results.
+	Result *SpecResult `json:",omitempty"`
 }
 
 // NewFromReports creates a new SuiteTree from a list of reports. The root of the tree will be `/`.
@@ -42,6 +53,7 @@ func NewFromReports(reports []types.Report) *SuiteTree {
 
 	for _, report := range reports {
 		leaf := root.Insert(report.SuitePath, report.SuiteDescription, report.PreRunStats.TotalSpecs)
+		leaf.Labels = report.SuiteLabels
 		leaf.InsertSpecs(report.SpecReports)
 	}
 
@@ -133,6 +145,68 @@ func (tree *SuiteTree) InsertSpecs(specs types.SpecReports) {
 	}
 }
 
+// OverlayResults matches every spec in the tree against resultSet, setting Result on the leaves that were found and
+// aggregating Results on every node. It returns the Results of the receiver.
+func (tree *SuiteTree) OverlayResults(resultSet *ResultSet) *RunResults {
+	klog.V(100).Infof("Overlaying run results on tree with path %s", tree.Path)
+
+	return tree.overlayResults(resultSet, tree.Description)
+}
+
+// overlayResults is a helper function to recursively overlay results. Since the specs are matched by the description of
+// the suite, suiteDescription tracks the description of the closest suite above the current node.
+func (tree *SuiteTree) overlayResults(resultSet *ResultSet, suiteDescription string) *RunResults {
+	if tree.Description != "" {
+		suiteDescription = tree.Description
+	}
+
+	tree.Results = &RunResults{}
+
+	if tree.SpecReport != nil {
+		tree.Result = resultSet.Lookup(suiteDescription, tree.SpecReport)
+		if tree.Result != nil {
+			tree.Results = tree.Result.toRunResults()
+		}
+
+		return tree.Results
+	}
+
+	for _, child := range tree.Children {
+		tree.Results.Add(child.overlayResults(resultSet, suiteDescription))
+	}
+
+	return tree.Results
+}
+
+// Leaves returns an iterator over all the leaf nodes of the tree, which represent single specs. Each leaf is yielded
+// along with the closest suite above it, the node with a Description, since specs are identified by both across runs.
+// If there is no suite above a leaf, the receiver is yielded as the suite.
+func (tree *SuiteTree) Leaves() iter.Seq2[*SuiteTree, *SuiteTree] {
+	return func(yield func(*SuiteTree, *SuiteTree) bool) {
+		tree.yieldLeaves(yield, tree)
+	}
+}
+
+// yieldLeaves is a helper function to recursively yield the leaves of the tree. It returns false once yield returns
+// false so iteration can stop early.
+func (tree *SuiteTree) yieldLeaves(yield func(*SuiteTree, *SuiteTree) bool, suite *SuiteTree) bool {
+	if tree.Description != "" {
+		suite = tree
+	}
+
+	if tree.SpecReport != nil {
+		return yield(suite, tree)
+	}
+
+	for _, child := range tree.Children {
+		if !child.yieldLeaves(yield, suite) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Sort sorts the children of the tree first by the number of specs and then by name. If descending is true, the
 // children are sorted in descending order by number of specs, but the name is still sorted alphabetically.
 func (tree *SuiteTree) Sort(descending bool) {
@@ -175,7 +249,7 @@ func (tree *SuiteTree) TrimRoot() *SuiteTree {
 }
 
 // String returns a string representation of the tree. It contains one line per node and is indented with a dot and two
-// spaces per level.
+// spaces per level. If results have been overlaid, the counts for each node are appended to its line.
 func (tree *SuiteTree) String() string {
 	builder := &strings.Builder{}
 	tree.stringLevel(builder, 0)
@@ -193,6 +267,12 @@ func (tree *SuiteTree) stringLevel(builder *strings.Builder, level uint) {
 	builder.WriteString(tree.Name)
 	builder.WriteByte(' ')
 	builder.WriteString(strconv.Itoa(tree.Specs))
+
+	if tree.Results != nil {
+		fmt.Fprintf(builder, " (passed %d, failed %d, skipped %d, flaky %d)",
+			tree.Results.Passed, tree.Results.Failed, tree.Results.Skipped, tree.Results.Flaky)
+	}
+
 	builder.WriteByte('\n')
 
 	for _, child := range tree.Children {
@@ -68,6 +68,8 @@ func NewAccelConfig() *AccelConfig {
 		accelConfig.SpokeAPIClient = nil
 	}
 
+	config.RegisterSuiteConfig("accel", &accelConfig)
+
 	return &accelConfig
 }
 
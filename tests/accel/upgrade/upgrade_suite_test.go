@@ -28,6 +28,10 @@ func TestUpgrade(t *testing.T) {
 	RunSpecs(t, "Acceleration upgrade test", Label(upgradeparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = AfterSuite(func() {
 	By("Deleting test namespace")
 
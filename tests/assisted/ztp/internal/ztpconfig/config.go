@@ -16,6 +16,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/assisted/ztp/internal/find"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/assisted/ztp/internal/ztpparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
 	"k8s.io/klog/v2"
 )
@@ -66,6 +67,8 @@ func NewZTPConfig() *ZTPConfig {
 	ztpconfig.HubConfig = new(HubConfig)
 	ztpconfig.SpokeConfig = new(SpokeConfig)
 
+	config.RegisterSuiteConfig("ztp", &ztpconfig)
+
 	if err := ztpconfig.newHubConfig(); err != nil {
 		ztpconfig.HubConfig.HubAPIClient = nil
 
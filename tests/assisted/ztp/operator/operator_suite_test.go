@@ -26,6 +26,10 @@ func TestOperator(t *testing.T) {
 	RunSpecs(t, "Operator Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Check if hub has valid apiClient")
 
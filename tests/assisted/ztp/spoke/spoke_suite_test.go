@@ -25,6 +25,10 @@ func TestSpoke(t *testing.T) {
 	RunSpecs(t, "Spoke Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Check if hub has valid apiClient")
 
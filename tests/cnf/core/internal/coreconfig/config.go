@@ -8,6 +8,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"gopkg.in/yaml.v2"
 )
 
@@ -47,6 +48,8 @@ func NewCoreConfig() *CoreConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("core", &coreConf)
+
 	return &coreConf
 }
 
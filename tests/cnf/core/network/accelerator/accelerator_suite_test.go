@@ -31,6 +31,10 @@ func TestAccelerator(t *testing.T) {
 	RunSpecs(t, "accelerator", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Creating privileged test namespace")
 
@@ -29,6 +29,10 @@ func TestLB(t *testing.T) {
 	RunSpecs(t, "CNI", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Creating test namespace with privileged labels")
 
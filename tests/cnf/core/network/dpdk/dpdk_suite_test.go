@@ -34,6 +34,10 @@ func TestLB(t *testing.T) {
 	RunSpecs(t, "dpdk", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Creating privileged test namespace")
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/core/internal/coreconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"gopkg.in/yaml.v2"
 )
 
@@ -74,6 +75,8 @@ func NewNetConfig() *NetworkConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("network", &netConf)
+
 	return &netConf
 }
 
@@ -39,6 +39,10 @@ func TestLB(t *testing.T) {
 	RunSpecs(t, "MetalLB", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Creating privileged test namespace")
 
@@ -25,6 +25,10 @@ func TestDeployment(t *testing.T) {
 	RunSpecs(t, "RAN Deployment Types Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = JustAfterEach(func() {
 	var (
 		currentDir, currentFilename = path.Split(currentFile)
@@ -7,6 +7,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran-deployment/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran-deployment/internal/version"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"k8s.io/klog/v2"
 )
 
@@ -69,6 +70,8 @@ func NewRANConfig() *RANConfig {
 		klog.V(ranparam.LogLevel).Infof("Skip TLS verification is true")
 	}
 
+	config.RegisterSuiteConfig("ran-deployment", &ranConfig)
+
 	return &ranConfig
 }
 
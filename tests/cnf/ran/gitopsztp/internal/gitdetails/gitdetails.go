@@ -2,9 +2,14 @@ package gitdetails
 
 import (
 	"fmt"
+	"path"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/argocd"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver/fixtures"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"k8s.io/klog/v2"
 )
 
 // GetGitPath retrieves the git path from the provided Argo CD application. It returns an error if it encounters any nil
@@ -25,9 +30,29 @@ func GetGitPath(app *argocd.ApplicationBuilder) (string, error) {
 	return app.Definition.Spec.Source.Path, nil
 }
 
+// DoesGitPathExist checks whether the git path formed by elements exists for the provided Argo CD application. When
+// the path has a fixture served by the git server, it always exists. Otherwise, the repo of the application is checked.
+func DoesGitPathExist(app *argocd.ApplicationBuilder, elements ...string) bool {
+	if usesGitServer(app, elements...) {
+		return true
+	}
+
+	return app.DoesGitPathExist(elements...)
+}
+
 // UpdateAndWaitForSync appends elements to the git path of the provided Argo CD application and waits for the source to
 // be updated. The synced parameter indicates whether to wait for the application to be in a synced state or not.
+//
+// When the path has a fixture served by the git server, the fixture is committed to the git server first and the
+// application is repointed to it. The original source is restored by gitserver.RestoreApps.
 func UpdateAndWaitForSync(app *argocd.ApplicationBuilder, synced bool, elements ...string) error {
+	if usesGitServer(app, elements...) {
+		err := commitFixtureAndRepoint(app, path.Join(elements...))
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err := app.WithGitPathAppended(elements...).Update(true)
 	if err != nil {
 		return fmt.Errorf("failed to update the application: %w", err)
@@ -40,3 +65,47 @@ func UpdateAndWaitForSync(app *argocd.ApplicationBuilder, synced bool, elements
 
 	return nil
 }
+
+// usesGitServer returns whether the git path formed by elements is served from the git server for the provided Argo
+// CD application. This requires the git server to be enabled, a fixture to be checked in for the path, and the source
+// of the application to be a repo the git server can mirror.
+func usesGitServer(app *argocd.ApplicationBuilder, elements ...string) bool {
+	if !RANConfig.ZtpGitFixtures || !fixtures.Has(path.Join(elements...)) {
+		return false
+	}
+
+	if !gitserver.CanMirror(app) {
+		klog.V(tsparams.LogLevel).Infof(
+			"Cannot mirror the source of app %s, using git path %s from the original repo",
+			app.Definition.Name, path.Join(elements...))
+
+		return false
+	}
+
+	return true
+}
+
+// commitFixtureAndRepoint commits the fixture at fixturePath to the git server and repoints the provided Argo CD
+// application to it. The application is not updated on the cluster.
+func commitFixtureAndRepoint(app *argocd.ApplicationBuilder, fixturePath string) error {
+	server, err := gitserver.Pull(HubAPIClient)
+	if err != nil {
+		return fmt.Errorf("failed to get the git server: %w", err)
+	}
+
+	err = server.CommitFixture(app, fixturePath, fixtures.Data{
+		SpokeName:              RANConfig.Spoke1Name,
+		TestNamespace:          tsparams.TestNamespace,
+		SriovOperatorNamespace: RANConfig.SriovOperatorNamespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to commit fixture %s to the git server: %w", fixturePath, err)
+	}
+
+	err = server.Repoint(app)
+	if err != nil {
+		return fmt.Errorf("failed to repoint the application to the git server: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Package fixtures holds the ZTP test fixtures served by the gitserver package, checked in as templates at the same
+// paths as the ZtpTestPath constants in tsparams. It only depends on the standard library so the fixtures can also be
+// rendered offline without the test configuration.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"text/template"
+)
+
+const (
+	// leftDelim and rightDelim are the template delimiters used in the fixtures. The default delimiters cannot be
+	// used since ACM hub templates in the fixtures use {{hub ... hub}}, which must be left as is.
+	leftDelim  = "[["
+	rightDelim = "]]"
+)
+
+//go:embed ztp-test
+var fixtures embed.FS
+
+// Data is the data the fixture templates are rendered with.
+type Data struct {
+	// SpokeName is the name of the spoke cluster the generated policies are bound to.
+	SpokeName string
+	// TestNamespace is the namespace on the hub where the generated policies are created.
+	TestNamespace string
+	// SriovOperatorNamespace is the namespace of the SR-IOV operator on the spoke.
+	SriovOperatorNamespace string
+}
+
+// Has returns whether a fixture is checked in for fixturePath, such as tsparams.ZtpTestPathAcmCrs.
+func Has(fixturePath string) bool {
+	info, err := fs.Stat(fixtures, path.Clean(fixturePath))
+
+	return err == nil && info.IsDir()
+}
+
+// List returns the paths of all the fixtures checked in, sorted. Each fixture is a directory containing a
+// kustomization.yaml.
+func List() ([]string, error) {
+	var fixturePaths []string
+
+	err := fs.WalkDir(fixtures, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() && entry.Name() == "kustomization.yaml" {
+			fixturePaths = append(fixturePaths, path.Dir(filePath))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk fixtures: %w", err)
+	}
+
+	sort.Strings(fixturePaths)
+
+	return fixturePaths, nil
+}
+
+// Render renders every file of the fixture at fixturePath using data. The returned map is keyed by the path of
+// each file relative to the fixture directory.
+func Render(fixturePath string, data Data) (map[string][]byte, error) {
+	if !Has(fixturePath) {
+		return nil, fmt.Errorf("no fixture found for git path %s", fixturePath)
+	}
+
+	fixtureRoot := path.Clean(fixturePath)
+	rendered := make(map[string][]byte)
+
+	err := fs.WalkDir(fixtures, fixtureRoot, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := fs.ReadFile(fixtures, filePath)
+		if err != nil {
+			return err
+		}
+
+		fileTemplate, err := template.New(entry.Name()).Delims(leftDelim, rightDelim).Option("missingkey=error").
+			Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", filePath, err)
+		}
+
+		var buffer bytes.Buffer
+
+		err = fileTemplate.Execute(&buffer, data)
+		if err != nil {
+			return fmt.Errorf("failed to render template %s: %w", filePath, err)
+		}
+
+		rendered[filePath[len(fixtureRoot)+1:]] = buffer.Bytes()
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render fixture %s: %w", fixturePath, err)
+	}
+
+	return rendered, nil
+}
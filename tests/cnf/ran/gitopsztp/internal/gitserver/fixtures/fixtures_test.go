@@ -0,0 +1,66 @@
+package fixtures
+
+import (
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+var testData = Data{
+	SpokeName:              "spoke1",
+	TestNamespace:          tsparams.TestNamespace,
+	SriovOperatorNamespace: "openshift-sriov-network-operator",
+}
+
+func TestRender(t *testing.T) {
+	fixturePaths, err := List()
+	assert.Nil(t, err)
+
+	for _, fixturePath := range []string{
+		tsparams.ZtpTestPathAcmCrs,
+		tsparams.ZtpTestPathCustomInterval,
+		tsparams.ZtpTestPathInvalidInterval,
+		tsparams.ZtpTestPathCustomSourceNewCr,
+		tsparams.ZtpTestPathCustomSourceReplaceExisting,
+		tsparams.ZtpTestPathCustomSourceNoCrFile,
+		tsparams.ZtpTestPathCustomSourceSearchPath,
+		tsparams.ZtpTestPathTemplatingAutoIndent,
+		tsparams.ZtpTestPathTemplatingValid,
+		tsparams.ZtpTestPathTemplatingValid416,
+	} {
+		assert.True(t, Has(fixturePath), "missing fixture %s", fixturePath)
+		assert.Contains(t, fixturePaths, fixturePath)
+	}
+
+	for _, fixturePath := range fixturePaths {
+		t.Run(fixturePath, func(t *testing.T) {
+			files, err := Render(fixturePath, testData)
+			assert.Nil(t, err)
+			assert.Contains(t, files, "kustomization.yaml")
+
+			for fileName, content := range files {
+				var document map[string]any
+
+				assert.Nil(t, yaml.Unmarshal(content, &document), "%s is not valid YAML", fileName)
+				assert.NotContains(t, string(content), leftDelim, "%s was not fully rendered", fileName)
+				assert.NotContains(t, string(content), "<no value>", "%s was not fully rendered", fileName)
+			}
+		})
+	}
+}
+
+func TestRenderHubTemplates(t *testing.T) {
+	files, err := Render(tsparams.ZtpTestPathTemplatingValid416, testData)
+	assert.Nil(t, err)
+
+	policyGenTemplate := string(files["hub-templating-pgt.yaml"])
+	assert.Contains(t, policyGenTemplate, `'{{hub .ManagedClusterName hub}}'`)
+	assert.Contains(t, policyGenTemplate, "name: spoke1")
+
+	_, err = Render("ztp-test/missing", testData)
+	assert.NotNil(t, err)
+	assert.False(t, Has("ztp-test/missing"))
+	assert.False(t, Has(tsparams.ZtpTestPathAcmCrs+"/kustomization.yaml"))
+}
@@ -0,0 +1,329 @@
+// Package gitserver serves the ZTP test fixtures from a git server deployed on the hub, rather than depending on them
+// being present in the repo the Argo CD apps point to.
+//
+// The fixtures are checked in as templates in the fixtures package, at the same paths as the ZtpTestPath constants in
+// tsparams. When a fixture is used, the original source of the Argo CD app is mirrored to the git server, the rendered
+// fixture is committed to the mirror below the git path of the app, and the app is repointed to the mirror. Since the
+// mirror contains everything in the original source, the git paths without a fixture keep working while the app is
+// repointed. The original source is saved in an annotation on the app so it can be restored after the spec.
+package gitserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/service"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// Name is the name of the git server deployment, service, and configmap.
+	Name = "ztp-git-server"
+	// Branch is the branch of the mirrored repos that fixtures are committed to and the Argo CD apps are repointed
+	// to.
+	Branch = "ztp-test"
+
+	containerName = "git-server"
+	port          = 8080
+	projectRoot   = "/srv/git"
+	workRoot      = "/srv/work"
+	scriptDir     = "/opt/ztp-git-server"
+	scriptName    = "server.py"
+	readyTimeout  = 5 * time.Minute
+	// maxArchiveSize is the largest encoded fixture archive that can be passed as an argument to the exec command,
+	// kept below the 128 KiB limit Linux places on a single argument.
+	maxArchiveSize = 100 * 1024
+)
+
+var (
+	// serverLabels are the labels on the git server pods, also used as the selector of the service.
+	serverLabels = map[string]string{"app": Name}
+
+	//go:embed server.py
+	serverScript string
+)
+
+// mirrorScript clones the original source into a work tree and creates the bare repo served by the git server from
+// it, checked out on the branch at the original revision. It does nothing if the repo has already been mirrored.
+// Arguments are the repo name, original URL, original revision, and branch.
+const mirrorScript = `set -eu
+repo="` + projectRoot + `/$1.git"
+work="` + workRoot + `/$1"
+if [ -d "$repo" ]; then exit 0; fi
+rm -rf "$work" "$repo.tmp"
+git clone --quiet --no-checkout "$2" "$work"
+commit=$(git -C "$work" rev-parse --verify --quiet "origin/$3^{commit}" ||
+  git -C "$work" rev-parse --verify "$3^{commit}")
+git -C "$work" checkout --quiet -B "$4" "$commit"
+git -C "$work" remote remove origin
+git clone --quiet --bare "$work" "$repo.tmp"
+mv "$repo.tmp" "$repo"
+git -C "$work" remote add origin "$repo"
+git -C "$work" fetch --quiet origin
+git -C "$work" branch --quiet --set-upstream-to "origin/$4"
+`
+
+// commitScript replaces a directory in the work tree of a mirrored repo with the contents of a base64 encoded,
+// gzipped tar archive, then commits and pushes the change if there is one. Arguments are the repo name, directory
+// relative to the root of the repo, archive, and commit message.
+const commitScript = `set -eu
+work="` + workRoot + `/$1"
+rm -rf "${work:?}/$2"
+echo "$3" | base64 -d | tar -xzf - -C "$work"
+git -C "$work" add -A
+if ! git -C "$work" diff --cached --quiet; then
+  git -C "$work" -c user.name=eco-gotests -c user.email=eco-gotests@localhost commit --quiet -m "$4"
+fi
+git -C "$work" push --quiet origin HEAD
+`
+
+// Server is the git server deployed on the hub.
+type Server struct {
+	client *clients.Settings
+}
+
+// Deploy deploys the git server on the hub using image, which must provide python3 and git, and waits for it to be
+// ready. If the git server is already deployed, it is reused along with any repos already mirrored to it.
+func Deploy(client *clients.Settings, image string) (*Server, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cannot deploy git server with nil client")
+	}
+
+	if image == "" {
+		return nil, fmt.Errorf("cannot deploy git server with empty image")
+	}
+
+	klog.V(tsparams.LogLevel).Infof(
+		"Deploying git server in namespace %s using image %s", tsparams.GitServerNamespace, image)
+
+	_, err := namespace.NewBuilder(client, tsparams.GitServerNamespace).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server namespace %s: %w", tsparams.GitServerNamespace, err)
+	}
+
+	_, err = configmap.NewBuilder(client, Name, tsparams.GitServerNamespace).
+		WithData(map[string]string{scriptName: serverScript}).
+		Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server configmap: %w", err)
+	}
+
+	servicePort, err := service.DefineServicePort(port, port, corev1.ProtocolTCP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to define git server service port: %w", err)
+	}
+
+	_, err = service.NewBuilder(client, Name, tsparams.GitServerNamespace, serverLabels, *servicePort).Create()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create git server service: %w", err)
+	}
+
+	deploymentBuilder, err := deployment.Pull(client, Name, tsparams.GitServerNamespace)
+	if err != nil {
+		deploymentBuilder, err = deployment.NewBuilder(
+			client, Name, tsparams.GitServerNamespace, serverLabels, getContainer(image)).
+			WithVolume(corev1.Volume{
+				Name: "script",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: Name},
+				}},
+			}).
+			WithVolume(corev1.Volume{
+				Name:         "repos",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}).
+			Create()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create git server deployment: %w", err)
+		}
+	}
+
+	if !deploymentBuilder.IsReady(readyTimeout) {
+		return nil, fmt.Errorf("git server deployment did not become ready within %s", readyTimeout)
+	}
+
+	return &Server{client: client}, nil
+}
+
+// Pull returns the git server deployed on the hub, returning an error if it is not deployed and ready.
+func Pull(client *clients.Settings) (*Server, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cannot pull git server with nil client")
+	}
+
+	deploymentBuilder, err := deployment.Pull(client, Name, tsparams.GitServerNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull git server deployment: %w", err)
+	}
+
+	if deploymentBuilder.Object.Status.ReadyReplicas == 0 {
+		return nil, fmt.Errorf(
+			"git server deployment %s in namespace %s is not ready", Name, tsparams.GitServerNamespace)
+	}
+
+	return &Server{client: client}, nil
+}
+
+// RepoURL returns the URL Argo CD uses to reach the repo named repoName on the git server.
+func (server *Server) RepoURL(repoName string) string {
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/%s.git", Name, tsparams.GitServerNamespace, port, repoName)
+}
+
+// mirror mirrors the repo at repoURL to the repo named repoName on the git server, checking out the revision on
+// Branch. If the repo has already been mirrored, it is left as is.
+func (server *Server) mirror(repoName, repoURL, revision string) error {
+	if revision == "" {
+		revision = "HEAD"
+	}
+
+	klog.V(tsparams.LogLevel).Infof("Mirroring revision %s of %s to git server repo %s", revision, repoURL, repoName)
+
+	_, err := server.exec(mirrorScript, repoName, repoURL, revision, Branch)
+	if err != nil {
+		return fmt.Errorf("failed to mirror revision %s to git server repo %s: %w", revision, repoName, err)
+	}
+
+	return nil
+}
+
+// commit replaces directory in the repo named repoName on the git server with files, then commits and pushes the
+// change.
+func (server *Server) commit(repoName, directory string, files map[string][]byte, message string) error {
+	archive, err := archiveFiles(directory, files)
+	if err != nil {
+		return err
+	}
+
+	encodedArchive := base64.StdEncoding.EncodeToString(archive)
+	if len(encodedArchive) > maxArchiveSize {
+		return fmt.Errorf("encoded archive for %s is %d bytes, larger than the maximum of %d",
+			directory, len(encodedArchive), maxArchiveSize)
+	}
+
+	klog.V(tsparams.LogLevel).Infof("Committing %d files to %s in git server repo %s", len(files), directory, repoName)
+
+	_, err = server.exec(commitScript, repoName, directory, encodedArchive, message)
+	if err != nil {
+		return fmt.Errorf("failed to commit %s to git server repo %s: %w", directory, repoName, err)
+	}
+
+	return nil
+}
+
+// exec runs script with args in the git server container, returning the combined output.
+func (server *Server) exec(script string, args ...string) (string, error) {
+	podList, err := pod.List(server.client, tsparams.GitServerNamespace, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(serverLabels).String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list git server pods: %w", err)
+	}
+
+	for _, serverPod := range podList {
+		if serverPod.Object.Status.Phase != corev1.PodRunning || serverPod.Object.DeletionTimestamp != nil {
+			continue
+		}
+
+		command := append([]string{"sh", "-c", script, "sh"}, args...)
+
+		output, err := serverPod.ExecCommand(command, containerName)
+		if err != nil {
+			return output.String(), fmt.Errorf("command failed with output %q: %w", strings.TrimSpace(output.String()), err)
+		}
+
+		return output.String(), nil
+	}
+
+	return "", fmt.Errorf("no running git server pod found in namespace %s", tsparams.GitServerNamespace)
+}
+
+// getContainer returns the definition of the git server container using image.
+func getContainer(image string) corev1.Container {
+	return corev1.Container{
+		Name:    containerName,
+		Image:   image,
+		Command: []string{"python3", scriptDir + "/" + scriptName},
+		Env: []corev1.EnvVar{
+			{Name: "GIT_PROJECT_ROOT", Value: projectRoot},
+			{Name: "GIT_SERVER_PORT", Value: fmt.Sprint(port)},
+			{Name: "HOME", Value: workRoot},
+		},
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: port, Protocol: corev1.ProtocolTCP}},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(port)}},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "script", MountPath: scriptDir, ReadOnly: true},
+			{Name: "repos", MountPath: projectRoot, SubPath: "git"},
+			{Name: "repos", MountPath: workRoot, SubPath: "work"},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			RunAsNonRoot:             ptr.To(true),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		},
+	}
+}
+
+// archiveFiles returns a gzipped tar archive of files with each file placed under directory.
+func archiveFiles(directory string, files map[string][]byte) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+
+	sort.Strings(fileNames)
+
+	for _, fileName := range fileNames {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name: path.Join(directory, fileName),
+			Mode: 0o644,
+			Size: int64(len(files[fileName])),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write tar header for %s: %w", fileName, err)
+		}
+
+		_, err = tarWriter.Write(files[fileName])
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s to tar: %w", fileName, err)
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close tar writer: %w", err)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
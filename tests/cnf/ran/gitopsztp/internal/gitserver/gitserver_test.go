@@ -0,0 +1,47 @@
+package gitserver
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveFiles(t *testing.T) {
+	archive, err := archiveFiles("site-policies/ztp-test", map[string][]byte{
+		"kustomization.yaml":   []byte("generators: []\n"),
+		"source-crs/test.yaml": []byte("kind: Namespace\n"),
+	})
+	assert.Nil(t, err)
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	assert.Nil(t, err)
+
+	tarReader := tar.NewReader(gzipReader)
+	extracted := make(map[string]string)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		assert.Nil(t, err)
+
+		var content strings.Builder
+
+		_, err = io.Copy(&content, tarReader)
+		assert.Nil(t, err)
+
+		extracted[header.Name] = content.String()
+	}
+
+	assert.Equal(t, map[string]string{
+		"site-policies/ztp-test/kustomization.yaml":   "generators: []\n",
+		"site-policies/ztp-test/source-crs/test.yaml": "kind: Namespace\n",
+	}, extracted)
+}
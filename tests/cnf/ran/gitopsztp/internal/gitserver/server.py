@@ -0,0 +1,94 @@
+"""Serves the bare git repositories under GIT_PROJECT_ROOT over the smart HTTP protocol using git http-backend.
+
+Only reads are expected from Argo CD. Fixtures are committed by running git directly in the container.
+"""
+
+import os
+import subprocess
+from http.server import BaseHTTPRequestHandler, ThreadingHTTPServer
+from urllib.parse import urlsplit
+
+PROJECT_ROOT = os.environ.get("GIT_PROJECT_ROOT", "/srv/git")
+PORT = int(os.environ.get("GIT_SERVER_PORT", "8080"))
+
+
+class GitHandler(BaseHTTPRequestHandler):
+    """Passes every request through to git http-backend as a CGI request."""
+
+    protocol_version = "HTTP/1.1"
+
+    def do_GET(self):
+        self.backend()
+
+    def do_POST(self):
+        self.backend()
+
+    def read_body(self):
+        if self.headers.get("Transfer-Encoding", "").lower() != "chunked":
+            return self.rfile.read(int(self.headers.get("Content-Length") or 0))
+
+        body = b""
+
+        while True:
+            size = int(self.rfile.readline().split(b";")[0].strip(), 16)
+            if size == 0:
+                self.rfile.readline()
+
+                return body
+
+            body += self.rfile.read(size)
+            self.rfile.readline()
+
+    def backend(self):
+        url = urlsplit(self.path)
+        body = self.read_body()
+        env = dict(
+            os.environ,
+            GIT_PROJECT_ROOT=PROJECT_ROOT,
+            GIT_HTTP_EXPORT_ALL="1",
+            REQUEST_METHOD=self.command,
+            PATH_INFO=url.path,
+            QUERY_STRING=url.query,
+            CONTENT_TYPE=self.headers.get("Content-Type", ""),
+            CONTENT_LENGTH=str(len(body)),
+            REMOTE_ADDR=self.client_address[0],
+        )
+
+        if self.headers.get("Content-Encoding"):
+            env["HTTP_CONTENT_ENCODING"] = self.headers["Content-Encoding"]
+
+        if self.headers.get("Git-Protocol"):
+            env["GIT_PROTOCOL"] = self.headers["Git-Protocol"]
+
+        result = subprocess.run(["git", "http-backend"], input=body, env=env, capture_output=True, check=False)
+        if result.returncode != 0 and not result.stdout:
+            self.send_error(500, result.stderr.decode(errors="replace").strip())
+
+            return
+
+        separator = b"\r\n\r\n" if b"\r\n\r\n" in result.stdout else b"\n\n"
+        header, _, content = result.stdout.partition(separator)
+
+        status = 200
+        headers = []
+
+        for line in header.decode(errors="replace").splitlines():
+            name, _, value = line.partition(":")
+            if name.lower() == "status":
+                status = int(value.split()[0])
+            elif name:
+                headers.append((name, value.strip()))
+
+        self.send_response(status)
+
+        for name, value in headers:
+            self.send_header(name, value)
+
+        self.send_header("Content-Length", str(len(content)))
+        self.end_headers()
+        self.wfile.write(content)
+
+
+if __name__ == "__main__":
+    os.makedirs(PROJECT_ROOT, exist_ok=True)
+    ThreadingHTTPServer(("", PORT), GitHandler).serve_forever()
@@ -0,0 +1,227 @@
+package gitserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/argocd"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver/fixtures"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// OriginalSourceAnnotation is the annotation on a repointed Argo CD app that holds its original source as JSON.
+	OriginalSourceAnnotation = "ran.openshift.io/ztp-git-server-original-source"
+	// repoSecretSelector selects the Argo CD repository and credential template secrets.
+	repoSecretSelector = "argocd.argoproj.io/secret-type in (repository,repo-creds)"
+)
+
+// originalSource is the source of an Argo CD app before it was repointed to the git server.
+type originalSource struct {
+	RepoURL        string `json:"repoURL"`
+	TargetRevision string `json:"targetRevision"`
+	Path           string `json:"path"`
+}
+
+// CommitFixture renders the fixture at fixturePath using data and commits it to the mirror of the original source of
+// app, below the current git path of app. The original source is mirrored first if it has not been already. The app
+// is not updated; use Repoint for that.
+func (server *Server) CommitFixture(app *argocd.ApplicationBuilder, fixturePath string, data fixtures.Data) error {
+	source, err := getOriginalSource(app)
+	if err != nil {
+		return err
+	}
+
+	files, err := fixtures.Render(fixturePath, data)
+	if err != nil {
+		return err
+	}
+
+	cloneURL, err := server.getCloneURL(source.RepoURL)
+	if err != nil {
+		return err
+	}
+
+	repoName := app.Definition.Name
+
+	err = server.mirror(repoName, cloneURL, source.TargetRevision)
+	if err != nil {
+		return err
+	}
+
+	return server.commit(repoName, path.Join(app.Definition.Spec.Source.Path, fixturePath), files,
+		fmt.Sprintf("Add fixture %s", fixturePath))
+}
+
+// Repoint updates the definition of app to use its mirror on the git server as the source, keeping the current git
+// path. The original source is saved in the OriginalSourceAnnotation so it can be restored later. Like the With
+// methods of the builder, the app is not updated on the cluster.
+func (server *Server) Repoint(app *argocd.ApplicationBuilder) error {
+	source, err := getOriginalSource(app)
+	if err != nil {
+		return err
+	}
+
+	sourceJSON, err := json.Marshal(source)
+	if err != nil {
+		return fmt.Errorf("failed to marshal original source of app %s: %w", app.Definition.Name, err)
+	}
+
+	if app.Definition.Annotations == nil {
+		app.Definition.Annotations = make(map[string]string)
+	}
+
+	app.Definition.Annotations[OriginalSourceAnnotation] = string(sourceJSON)
+	app.Definition.Spec.Source.RepoURL = server.RepoURL(app.Definition.Name)
+	app.Definition.Spec.Source.TargetRevision = Branch
+
+	return nil
+}
+
+// Restore resets the source of app to the original source saved when it was repointed and waits for it to sync. Apps
+// that were never repointed are left unchanged.
+func Restore(app *argocd.ApplicationBuilder) error {
+	sourceJSON, ok := app.Definition.Annotations[OriginalSourceAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var source originalSource
+
+	err := json.Unmarshal([]byte(sourceJSON), &source)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal original source of app %s: %w", app.Definition.Name, err)
+	}
+
+	klog.V(tsparams.LogLevel).Infof("Restoring app %s to original source %+v", app.Definition.Name, source)
+
+	delete(app.Definition.Annotations, OriginalSourceAnnotation)
+	app.Definition.Spec.Source.RepoURL = source.RepoURL
+	app.Definition.Spec.Source.TargetRevision = source.TargetRevision
+	app.Definition.Spec.Source.Path = source.Path
+
+	appName := app.Definition.Name
+
+	app, err = app.Update(true)
+	if err != nil {
+		return fmt.Errorf("failed to restore original source of app %s: %w", appName, err)
+	}
+
+	err = app.WaitForSourceUpdate(true, tsparams.ArgoCdChangeTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to wait for app %s to sync after restoring: %w", appName, err)
+	}
+
+	return nil
+}
+
+// RestoreApps restores the clusters and policies apps on the hub if either was repointed to the git server.
+func RestoreApps(client *clients.Settings) error {
+	for _, appName := range []string{tsparams.ArgoCdClustersAppName, tsparams.ArgoCdPoliciesAppName} {
+		app, err := argocd.PullApplication(client, appName, ranparam.OpenshiftGitOpsNamespace)
+		if err != nil {
+			return fmt.Errorf("failed to pull app %s: %w", appName, err)
+		}
+
+		err = Restore(app)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CanMirror returns whether the original source of app can be mirrored to the git server. Only HTTP(S) repos can be
+// mirrored since the git server has no SSH keys.
+func CanMirror(app *argocd.ApplicationBuilder) bool {
+	source, err := getOriginalSource(app)
+	if err != nil {
+		return false
+	}
+
+	return isHTTPURL(source.RepoURL)
+}
+
+// getOriginalSource returns the source of app before it was repointed, which is the current source if it has not
+// been.
+func getOriginalSource(app *argocd.ApplicationBuilder) (originalSource, error) {
+	if app == nil || app.Definition == nil || app.Definition.Spec.Source == nil {
+		return originalSource{}, fmt.Errorf("cannot get source of nil app or app without source")
+	}
+
+	if sourceJSON, ok := app.Definition.Annotations[OriginalSourceAnnotation]; ok {
+		var source originalSource
+
+		err := json.Unmarshal([]byte(sourceJSON), &source)
+		if err != nil {
+			return originalSource{}, fmt.Errorf(
+				"failed to unmarshal original source of app %s: %w", app.Definition.Name, err)
+		}
+
+		return source, nil
+	}
+
+	return originalSource{
+		RepoURL:        app.Definition.Spec.Source.RepoURL,
+		TargetRevision: app.Definition.Spec.Source.TargetRevision,
+		Path:           app.Definition.Spec.Source.Path,
+	}, nil
+}
+
+// getCloneURL returns repoURL with the credentials Argo CD uses for it, if any, so the git server can clone it.
+func (server *Server) getCloneURL(repoURL string) (string, error) {
+	if !isHTTPURL(repoURL) {
+		return "", fmt.Errorf("cannot mirror repo %s: only http and https repos are supported", repoURL)
+	}
+
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse repo URL %s: %w", repoURL, err)
+	}
+
+	secretList, err := server.client.Secrets(ranparam.OpenshiftGitOpsNamespace).List(
+		context.TODO(), metav1.ListOptions{LabelSelector: repoSecretSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list Argo CD repository secrets: %w", err)
+	}
+
+	longestMatch := ""
+
+	for _, repoSecret := range secretList.Items {
+		secretURL := string(repoSecret.Data["url"])
+		if secretURL == "" || !strings.HasPrefix(repoURL, secretURL) || len(secretURL) <= len(longestMatch) {
+			continue
+		}
+
+		username, password := string(repoSecret.Data["username"]), string(repoSecret.Data["password"])
+		if password == "" {
+			continue
+		}
+
+		if username == "" {
+			// Token authentication for most git providers accepts any non-empty username.
+			username = "git"
+		}
+
+		longestMatch = secretURL
+		parsedURL.User = url.UserPassword(username, password)
+	}
+
+	return parsedURL.String(), nil
+}
+
+// isHTTPURL returns whether rawURL is a valid http or https URL.
+func isHTTPURL(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https")
+}
@@ -119,6 +119,9 @@ const (
 
 	// TestNamespace is the namespace used for ZTP tests.
 	TestNamespace = "ztp-test"
+	// GitServerNamespace is the namespace on the hub where the git server serving the ZTP test fixtures is
+	// deployed.
+	GitServerNamespace = "ztp-git-server"
 	// AcmCrsPolicyName is the name of the policy for ACM CRs.
 	AcmCrsPolicyName = "acm-crs-policy"
 	// HubTemplatingPolicyName is the name used for the hub templating policy.
@@ -28,6 +28,7 @@ var (
 	// ReporterHubNamespacesToDump tells to the reporter which namespaces on the hub to collect pod logs from.
 	ReporterHubNamespacesToDump = map[string]string{
 		TestNamespace:                       "",
+		GitServerNamespace:                  "",
 		ranparam.OpenshiftOperatorNamespace: "",
 		RANConfig.AcmOperatorNamespace:      "",
 	}
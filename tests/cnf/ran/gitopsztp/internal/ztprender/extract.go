@@ -0,0 +1,422 @@
+package ztprender
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// whiteoutPrefix marks a file deleted in a layer, per the OCI image spec.
+	whiteoutPrefix = ".wh."
+	// opaqueWhiteout marks a directory whose contents from lower layers are hidden, per the OCI image spec.
+	opaqueWhiteout = ".wh..wh..opq"
+	// runtimeTimeout is the timeout for each container runtime command, which may include pulling the image.
+	runtimeTimeout = 10 * time.Minute
+)
+
+// pluginPath maps a path in an image to a path in the plugin home.
+type pluginPath struct {
+	imagePath string
+	homePath  string
+}
+
+// pluginPaths are the paths in the images known to contain kustomize plugins. The ztp-site-generate image stores the
+// PolicyGenTemplate and SiteConfig plugins, along with their source CRs and extra manifests, in the kustomize plugin
+// layout. The ACM image stores the PolicyGenerator plugin by itself, so it is moved to where kustomize expects it, as
+// in the Argo CD patch from the ZTP documentation.
+var pluginPaths = []pluginPath{
+	{imagePath: "kustomize/plugin", homePath: ""},
+	{
+		imagePath: "policy-generator/PolicyGenerator-not-fips-compliant",
+		homePath:  "policy.open-cluster-management.io/v1/policygenerator/PolicyGenerator",
+	},
+}
+
+// ExtractPlugins extracts the kustomize plugins from each source into pluginHome. A source is either the path to an
+// image tarball, as created by podman save or docker save in either the docker-archive or oci-archive format, or an
+// image reference, which is exported using the first of podman and docker found locally.
+func ExtractPlugins(sources []string, pluginHome string) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("no sources provided to extract plugins from")
+	}
+
+	for _, source := range sources {
+		var err error
+
+		if _, statErr := os.Stat(source); statErr == nil {
+			klog.V(ranparam.LogLevel).Infof("Extracting plugins from image tarball %s", source)
+
+			err = extractFromImageTarball(source, pluginHome)
+		} else {
+			klog.V(ranparam.LogLevel).Infof("Extracting plugins from image %s", source)
+
+			err = extractFromImage(source, pluginHome)
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to extract plugins from %s: %w", source, err)
+		}
+	}
+
+	return nil
+}
+
+// extractFromImage creates a container from image using the local container runtime and extracts the plugins from
+// the export of its filesystem.
+func extractFromImage(image, pluginHome string) error {
+	runtime, err := findContainerRuntime()
+	if err != nil {
+		return err
+	}
+
+	containerID, err := runContainerRuntime(runtime, nil, "create", image)
+	if err != nil {
+		return fmt.Errorf("failed to create container from image %s: %w", image, err)
+	}
+
+	containerID = strings.TrimSpace(containerID)
+
+	defer func() {
+		_, err := runContainerRuntime(runtime, nil, "rm", containerID)
+		if err != nil {
+			klog.V(ranparam.LogLevel).Infof("Failed to remove container %s: %v", containerID, err)
+		}
+	}()
+
+	var export bytes.Buffer
+
+	_, err = runContainerRuntime(runtime, &export, "export", containerID)
+	if err != nil {
+		return fmt.Errorf("failed to export container %s: %w", containerID, err)
+	}
+
+	_, err = extractFromLayer(&export, pluginHome)
+
+	return err
+}
+
+// findContainerRuntime returns the first of podman and docker found in the PATH.
+func findContainerRuntime() (string, error) {
+	for _, runtime := range []string{"podman", "docker"} {
+		if _, err := exec.LookPath(runtime); err == nil {
+			return runtime, nil
+		}
+	}
+
+	return "", fmt.Errorf("neither podman nor docker was found to pull the image; provide an image tarball instead")
+}
+
+// runContainerRuntime runs the container runtime with args. If stdout is provided, the output is written to it rather
+// than returned.
+func runContainerRuntime(runtime string, stdout io.Writer, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), runtimeTimeout)
+	defer cancel()
+
+	var (
+		output bytes.Buffer
+		stderr bytes.Buffer
+	)
+
+	command := exec.CommandContext(ctx, runtime, args...)
+	command.Stdout = &output
+	command.Stderr = &stderr
+
+	if stdout != nil {
+		command.Stdout = stdout
+	}
+
+	err := command.Run()
+	if err != nil {
+		return "", fmt.Errorf("%s %s failed: %w: %s", runtime, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output.String(), nil
+}
+
+// extractFromImageTarball extracts the plugins from the layers of the image in the tarball at tarballPath, applying
+// the layers in order.
+func extractFromImageTarball(tarballPath, pluginHome string) error {
+	layers, err := getTarballLayers(tarballPath)
+	if err != nil {
+		return err
+	}
+
+	extracted := 0
+
+	for _, layer := range layers {
+		err = readTarballFile(tarballPath, layer, func(reader io.Reader) error {
+			count, err := extractFromLayer(reader, pluginHome)
+			extracted += count
+
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to extract layer %s: %w", layer, err)
+		}
+	}
+
+	if extracted == 0 {
+		return fmt.Errorf("no plugins found in the layers of %s", tarballPath)
+	}
+
+	return nil
+}
+
+// getTarballLayers returns the paths of the layer blobs in the image tarball, from the lowest layer to the highest.
+// Both the docker-archive format, with a manifest.json, and the oci-archive format, with an index.json, are
+// supported. Only the first image in the tarball is used.
+func getTarballLayers(tarballPath string) ([]string, error) {
+	var layers []string
+
+	err := readTarballFile(tarballPath, "manifest.json", func(reader io.Reader) error {
+		var manifest []struct {
+			Layers []string `json:"Layers"`
+		}
+
+		err := json.NewDecoder(reader).Decode(&manifest)
+		if err != nil {
+			return err
+		}
+
+		if len(manifest) == 0 {
+			return fmt.Errorf("manifest.json contains no images")
+		}
+
+		layers = manifest[0].Layers
+
+		return nil
+	})
+	if err == nil {
+		return layers, nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read manifest.json: %w", err)
+	}
+
+	var index struct {
+		Manifests []struct {
+			Digest string `json:"digest"`
+		} `json:"manifests"`
+	}
+
+	err = readTarballJSON(tarballPath, "index.json", &index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest.json or index.json: %w", err)
+	}
+
+	if len(index.Manifests) == 0 {
+		return nil, fmt.Errorf("index.json contains no manifests")
+	}
+
+	var manifest struct {
+		Layers []struct {
+			Digest string `json:"digest"`
+		} `json:"layers"`
+	}
+
+	err = readTarballJSON(tarballPath, digestPath(index.Manifests[0].Digest), &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image manifest: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		layers = append(layers, digestPath(layer.Digest))
+	}
+
+	return layers, nil
+}
+
+// digestPath returns the path of the blob with digest in an OCI layout.
+func digestPath(digest string) string {
+	return path.Join("blobs", strings.Replace(digest, ":", "/", 1))
+}
+
+// readTarballJSON decodes the JSON file at filePath in the tarball into value.
+func readTarballJSON(tarballPath, filePath string, value any) error {
+	return readTarballFile(tarballPath, filePath, func(reader io.Reader) error {
+		return json.NewDecoder(reader).Decode(value)
+	})
+}
+
+// readTarballFile calls read with the contents of the file at filePath in the tarball, decompressing it if it is
+// gzipped. It returns an error wrapping os.ErrNotExist if there is no such file.
+func readTarballFile(tarballPath, filePath string, read func(io.Reader) error) error {
+	tarball, err := os.Open(tarballPath)
+	if err != nil {
+		return err
+	}
+
+	defer tarball.Close()
+
+	tarReader := tar.NewReader(tarball)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s not found in %s: %w", filePath, tarballPath, os.ErrNotExist)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if path.Clean(header.Name) != filePath {
+			continue
+		}
+
+		reader, err := decompress(tarReader)
+		if err != nil {
+			return err
+		}
+
+		return read(reader)
+	}
+}
+
+// decompress returns a reader for the decompressed contents of reader if it is gzipped, otherwise for the contents as
+// they are.
+func decompress(reader io.Reader) (io.Reader, error) {
+	bufferedReader := bufio.NewReader(reader)
+
+	magic, err := bufferedReader.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(bufferedReader)
+	}
+
+	return bufferedReader, nil
+}
+
+// extractFromLayer extracts the files under the pluginPaths from the layer, a tar of a filesystem optionally
+// gzipped, to pluginHome. Whiteouts in the layer remove the files extracted from lower layers. It returns the number
+// of files extracted.
+func extractFromLayer(layer io.Reader, pluginHome string) (int, error) {
+	reader, err := decompress(layer)
+	if err != nil {
+		return 0, err
+	}
+
+	tarReader := tar.NewReader(reader)
+	extracted := 0
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return extracted, nil
+		}
+
+		if err != nil {
+			return extracted, err
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+		dir, base := path.Split(name)
+
+		if base == opaqueWhiteout || strings.HasPrefix(base, whiteoutPrefix) {
+			if base != opaqueWhiteout {
+				dir = path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix))
+			}
+
+			if target, ok := getHomePath(path.Clean(dir), pluginHome); ok {
+				err = os.RemoveAll(target)
+				if err != nil {
+					return extracted, err
+				}
+			}
+
+			continue
+		}
+
+		target, ok := getHomePath(name, pluginHome)
+		if !ok {
+			continue
+		}
+
+		err = extractEntry(header, tarReader, target)
+		if err != nil {
+			return extracted, fmt.Errorf("failed to extract %s: %w", name, err)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			extracted++
+		}
+	}
+}
+
+// getHomePath returns the path in pluginHome for the path in the image, or false if the path is not below any of the
+// pluginPaths.
+func getHomePath(imagePath, pluginHome string) (string, bool) {
+	for _, mapping := range pluginPaths {
+		if imagePath != mapping.imagePath && !strings.HasPrefix(imagePath, mapping.imagePath+"/") {
+			continue
+		}
+
+		relativePath := strings.TrimPrefix(imagePath, mapping.imagePath)
+
+		return filepath.Join(pluginHome, filepath.FromSlash(mapping.homePath), filepath.FromSlash(relativePath)), true
+	}
+
+	return "", false
+}
+
+// extractEntry writes the tar entry described by header to target, replacing anything already there.
+func extractEntry(header *tar.Header, reader io.Reader, target string) error {
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, 0o755)
+	case tar.TypeReg:
+		err := os.MkdirAll(filepath.Dir(target), 0o755)
+		if err != nil {
+			return err
+		}
+
+		_ = os.RemoveAll(target)
+
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm()|0o600)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(file, reader)
+		if err != nil {
+			_ = file.Close()
+
+			return err
+		}
+
+		return file.Close()
+	case tar.TypeSymlink:
+		if filepath.IsAbs(header.Linkname) || strings.HasPrefix(path.Clean(header.Linkname), "..") {
+			klog.V(ranparam.LogLevel).Infof("Skipping symlink %s pointing outside the plugin tree", header.Name)
+
+			return nil
+		}
+
+		err := os.MkdirAll(filepath.Dir(target), 0o755)
+		if err != nil {
+			return err
+		}
+
+		_ = os.RemoveAll(target)
+
+		return os.Symlink(header.Linkname, target)
+	default:
+		return nil
+	}
+}
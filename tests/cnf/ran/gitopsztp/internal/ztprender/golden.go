@@ -0,0 +1,162 @@
+package ztprender
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrorFileName is the name of the golden file recording that rendering is expected to fail. Its contents are the
+// error from when the golden outputs were written and are only informational, since the error messages of the
+// generators are not stable across versions.
+const ErrorFileName = "error.txt"
+
+// WriteGolden replaces the golden outputs in directory with the result of rendering. If renderErr is not nil, only the
+// ErrorFileName is written. Otherwise, each object is written to its own file.
+func WriteGolden(directory string, objects []Object, renderErr error) error {
+	err := os.RemoveAll(directory)
+	if err != nil {
+		return fmt.Errorf("failed to remove golden directory %s: %w", directory, err)
+	}
+
+	err = os.MkdirAll(directory, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create golden directory %s: %w", directory, err)
+	}
+
+	if renderErr != nil {
+		return os.WriteFile(filepath.Join(directory, ErrorFileName), []byte(renderErr.Error()+"\n"), 0o644)
+	}
+
+	for _, object := range objects {
+		content, err := object.Marshal()
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filepath.Join(directory, object.FileName()), content, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to write golden file %s: %w", object.FileName(), err)
+		}
+	}
+
+	return nil
+}
+
+// CompareGolden compares the result of rendering against the golden outputs in directory, returning a description
+// of each difference. No differences are returned when they match.
+func CompareGolden(directory string, objects []Object, renderErr error) ([]string, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read golden directory %s: %w", directory, err)
+	}
+
+	golden := make(map[string]bool)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			golden[entry.Name()] = true
+		}
+	}
+
+	if golden[ErrorFileName] {
+		if renderErr == nil {
+			return []string{"rendering succeeded but was expected to fail"}, nil
+		}
+
+		return nil, nil
+	}
+
+	if renderErr != nil {
+		return []string{fmt.Sprintf("rendering failed but was expected to succeed: %v", renderErr)}, nil
+	}
+
+	var differences []string
+
+	for _, object := range objects {
+		fileName := object.FileName()
+		if !golden[fileName] {
+			differences = append(differences, fmt.Sprintf("%s: rendered but not in golden outputs", fileName))
+
+			continue
+		}
+
+		delete(golden, fileName)
+
+		actual, err := object.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		expected, err := os.ReadFile(filepath.Join(directory, fileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read golden file %s: %w", fileName, err)
+		}
+
+		if difference := describeDifference(expected, actual); difference != "" {
+			differences = append(differences, fmt.Sprintf("%s: %s", fileName, difference))
+		}
+	}
+
+	var missing []string
+	for fileName := range golden {
+		missing = append(missing, fileName)
+	}
+
+	sort.Strings(missing)
+
+	for _, fileName := range missing {
+		differences = append(differences, fmt.Sprintf("%s: in golden outputs but not rendered", fileName))
+	}
+
+	return differences, nil
+}
+
+// IsGoldenDirectory returns whether directory exists and contains golden outputs.
+func IsGoldenDirectory(directory string) bool {
+	entries, err := os.ReadDir(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return false
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// describeDifference returns a description of the first line that differs between expected and actual, or an empty
+// string if they are the same.
+func describeDifference(expected, actual []byte) string {
+	if bytes.Equal(expected, actual) {
+		return ""
+	}
+
+	expectedLines := strings.Split(string(expected), "\n")
+	actualLines := strings.Split(string(actual), "\n")
+
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		var expectedLine, actualLine string
+
+		if i < len(expectedLines) {
+			expectedLine = expectedLines[i]
+		}
+
+		if i < len(actualLines) {
+			actualLine = actualLines[i]
+		}
+
+		if expectedLine != actualLine {
+			return fmt.Sprintf("line %d differs, expected %q but got %q", i+1, expectedLine, actualLine)
+		}
+	}
+
+	return "content differs"
+}
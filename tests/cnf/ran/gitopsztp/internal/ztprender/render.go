@@ -0,0 +1,389 @@
+// Package ztprender renders ZTP inputs offline using the kustomize plugins from the ztp-site-generate image, so
+// regressions in the generators can be caught without a hub.
+//
+// The plugins are extracted from an image tarball or, using a local container runtime, from an image reference into a
+// plugin home laid out the way kustomize expects. Kustomizations are then rendered the way Argo CD would render them:
+// each generator is run as a kustomize exec plugin and the resources are passed through as is, so both
+// PolicyGenTemplate/PolicyGenerator and SiteConfig/ClusterInstance inputs can be rendered. Only the parts of
+// kustomize used by ZTP are supported, which are the generators and resources fields.
+//
+// The rendered objects are compared against golden outputs checked in under testdata/golden, one file per object. This
+// package does not depend on the test configuration so it can be used offline.
+package ztprender
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver/fixtures"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// kustomizationFileName is the name of the file describing a kustomization.
+	kustomizationFileName = "kustomization.yaml"
+	// defaultPluginTimeout is the default timeout for running a single generator plugin.
+	defaultPluginTimeout = 2 * time.Minute
+)
+
+// DefaultFixtureData is the data the fixtures are rendered with offline. It is fixed so the rendered objects can be
+// compared against the golden outputs. The test namespace matches tsparams.TestNamespace, which is not used directly
+// since importing tsparams loads the test configuration.
+var DefaultFixtureData = fixtures.Data{
+	SpokeName:              "spoke1",
+	TestNamespace:          "ztp-test",
+	SriovOperatorNamespace: "openshift-sriov-network-operator",
+}
+
+// kustomization is the subset of a kustomization.yaml used by ZTP.
+type kustomization struct {
+	Generators []string `yaml:"generators"`
+	Resources  []string `yaml:"resources"`
+}
+
+// Object is a rendered Kubernetes object.
+type Object map[string]any
+
+// Kind returns the kind of the object, or an empty string if it has none.
+func (object Object) Kind() string {
+	kind, _ := object["kind"].(string)
+
+	return kind
+}
+
+// APIVersion returns the apiVersion of the object, or an empty string if it has none.
+func (object Object) APIVersion() string {
+	apiVersion, _ := object["apiVersion"].(string)
+
+	return apiVersion
+}
+
+// Name returns the name of the object, or an empty string if it has none.
+func (object Object) Name() string {
+	return object.metadataField("name")
+}
+
+// Namespace returns the namespace of the object, or an empty string if it is cluster scoped.
+func (object Object) Namespace() string {
+	return object.metadataField("namespace")
+}
+
+// FileName returns the name of the file the object is written to, in the form kind_namespace_name.yaml, or
+// kind_name.yaml for cluster scoped objects.
+func (object Object) FileName() string {
+	elements := []string{strings.ToLower(object.Kind())}
+
+	if namespace := object.Namespace(); namespace != "" {
+		elements = append(elements, namespace)
+	}
+
+	elements = append(elements, object.Name())
+
+	return strings.Join(elements, "_") + ".yaml"
+}
+
+// Marshal returns the object as YAML with the keys sorted and two space indentation, so the output is stable across
+// generator versions that only reorder fields.
+func (object Object) Marshal() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(map[string]any(object))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", object.Kind(), object.Name(), err)
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// metadataField returns the string field in the metadata of the object, or an empty string if there is no such field.
+func (object Object) metadataField(field string) string {
+	metadata, _ := object["metadata"].(map[string]any)
+	value, _ := metadata[field].(string)
+
+	return value
+}
+
+// Renderer renders kustomizations using the plugins in a plugin home.
+type Renderer struct {
+	// PluginHome is the directory containing the plugins, laid out as group/version/lowercase kind/Kind.
+	PluginHome string
+	// Timeout is the timeout for running a single generator plugin.
+	Timeout time.Duration
+}
+
+// NewRenderer returns a Renderer using the plugins in pluginHome, which are usually extracted using ExtractPlugins.
+func NewRenderer(pluginHome string) *Renderer {
+	return &Renderer{PluginHome: pluginHome, Timeout: defaultPluginTimeout}
+}
+
+// Render renders the kustomization in directory, returning the objects sorted by their file name. An error is
+// returned if any of the generators fail or if two objects would be written to the same file.
+func (renderer *Renderer) Render(directory string) ([]Object, error) {
+	objects, err := renderer.renderKustomization(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].FileName() < objects[j].FileName()
+	})
+
+	for i := 1; i < len(objects); i++ {
+		if objects[i].FileName() == objects[i-1].FileName() {
+			return nil, fmt.Errorf("rendered multiple objects written to %s", objects[i].FileName())
+		}
+	}
+
+	return objects, nil
+}
+
+// RenderFixture renders the fixture at fixturePath using data, as it would be rendered by Argo CD after being
+// committed to the git server.
+func (renderer *Renderer) RenderFixture(fixturePath string, data fixtures.Data) ([]Object, error) {
+	files, err := fixtures.Render(fixturePath, data)
+	if err != nil {
+		return nil, err
+	}
+
+	directory, err := os.MkdirTemp("", "ztprender-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory for fixture %s: %w", fixturePath, err)
+	}
+
+	defer os.RemoveAll(directory)
+
+	for fileName, content := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(fileName))
+
+		err = os.MkdirAll(filepath.Dir(filePath), 0o755)
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(filePath, content, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write fixture file %s: %w", fileName, err)
+		}
+	}
+
+	objects, err := renderer.Render(directory)
+	if err != nil {
+		// The directory is temporary so remove it from errors to keep them stable between runs.
+		return nil, errors.New(strings.ReplaceAll(err.Error(), directory, fixturePath))
+	}
+
+	return objects, nil
+}
+
+// renderKustomization returns the objects from the generators and resources of the kustomization in directory.
+func (renderer *Renderer) renderKustomization(directory string) ([]Object, error) {
+	kustomizationPath := filepath.Join(directory, kustomizationFileName)
+
+	content, err := os.ReadFile(kustomizationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kustomization: %w", err)
+	}
+
+	var parsed kustomization
+
+	err = yaml.Unmarshal(content, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", kustomizationPath, err)
+	}
+
+	var objects []Object
+
+	for _, resource := range parsed.Resources {
+		resourceObjects, err := renderer.renderResource(directory, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, resourceObjects...)
+	}
+
+	for _, generator := range parsed.Generators {
+		generatorPath := filepath.Join(directory, generator)
+
+		configs, err := readObjects(generatorPath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, config := range configs {
+			generated, err := renderer.runGenerator(directory, config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to run generator %s in %s: %w", config.Kind(), generator, err)
+			}
+
+			objects = append(objects, generated...)
+		}
+	}
+
+	return objects, nil
+}
+
+// renderResource returns the objects in the resource, which is either a file of objects or a directory containing a
+// kustomization.
+func (renderer *Renderer) renderResource(directory, resource string) ([]Object, error) {
+	resourcePath := filepath.Join(directory, resource)
+
+	info, err := os.Stat(resourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat resource %s: %w", resource, err)
+	}
+
+	if info.IsDir() {
+		return renderer.renderKustomization(resourcePath)
+	}
+
+	return readObjects(resourcePath)
+}
+
+// runGenerator runs the exec plugin for the generator config the same way kustomize does, with the config saved to a
+// temporary file passed as the only argument and the kustomization directory as the working directory.
+func (renderer *Renderer) runGenerator(directory string, config Object) ([]Object, error) {
+	pluginPath, err := renderer.getPluginPath(config)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := config.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	configFile, err := os.CreateTemp("", "kust-plugin-config-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plugin config file: %w", err)
+	}
+
+	defer os.Remove(configFile.Name())
+
+	_, err = configFile.Write(content)
+	if err != nil {
+		_ = configFile.Close()
+
+		return nil, fmt.Errorf("failed to write plugin config file: %w", err)
+	}
+
+	err = configFile.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := renderer.Timeout
+	if timeout == 0 {
+		timeout = defaultPluginTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), timeout)
+	defer cancel()
+
+	absoluteDirectory, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, pluginPath, configFile.Name())
+	command.Dir = absoluteDirectory
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	command.Env = append(os.Environ(),
+		"KUSTOMIZE_PLUGIN_CONFIG_STRING="+string(content),
+		"KUSTOMIZE_PLUGIN_CONFIG_ROOT="+absoluteDirectory)
+
+	klog.V(ranparam.LogLevel).Infof("Running plugin %s in %s", pluginPath, absoluteDirectory)
+
+	err = command.Run()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s failed: %w: %s", filepath.Base(pluginPath), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return decodeObjects(&stdout)
+}
+
+// getPluginPath returns the path of the exec plugin for the generator config, returning an error if it is not in the
+// plugin home.
+func (renderer *Renderer) getPluginPath(config Object) (string, error) {
+	group, version, found := strings.Cut(config.APIVersion(), "/")
+	if !found || config.Kind() == "" {
+		return "", fmt.Errorf("generator config %q has no group or kind", config.APIVersion())
+	}
+
+	pluginPath := filepath.Join(renderer.PluginHome, group, version, strings.ToLower(config.Kind()), config.Kind())
+
+	info, err := os.Stat(pluginPath)
+	if err != nil {
+		return "", fmt.Errorf("no plugin found for %s %s: %w", config.APIVersion(), config.Kind(), err)
+	}
+
+	if info.Mode().Perm()&0o111 == 0 {
+		return "", fmt.Errorf("plugin %s is not executable", pluginPath)
+	}
+
+	return pluginPath, nil
+}
+
+// readObjects returns the objects in the multi-document YAML file at filePath.
+func readObjects(filePath string) ([]Object, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+
+	defer file.Close()
+
+	objects, err := decodeObjects(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filePath, err)
+	}
+
+	return objects, nil
+}
+
+// decodeObjects returns the objects in the multi-document YAML from reader, skipping empty documents.
+func decodeObjects(reader io.Reader) ([]Object, error) {
+	decoder := yaml.NewDecoder(reader)
+
+	var objects []Object
+
+	for {
+		// Decode into a plain map rather than an Object so nested maps are decoded as map[string]any too.
+		var object map[string]any
+
+		err := decoder.Decode(&object)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(object) > 0 {
+			objects = append(objects, Object(object))
+		}
+	}
+}
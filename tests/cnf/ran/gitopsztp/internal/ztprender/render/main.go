@@ -0,0 +1,285 @@
+/*
+Render renders ZTP inputs offline using the kustomize plugins from the ztp-site-generate image, writing the rendered
+objects to a directory or comparing them against golden outputs. When no input is provided, every fixture in the
+gitserver fixtures package is rendered instead, each to its own subdirectory.
+
+Upon success the exit code is 0. If any error occurs or the rendered objects differ from the golden outputs, it will be
+logged to stderr and the exit code will be 1.
+
+Usage:
+
+	render [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-s, -source string
+		Comma separated list of images or image tarballs to extract the plugins from. Images are exported using podman
+		or docker. The ACM image providing the PolicyGenerator plugin may be included alongside ztp-site-generate
+
+	-k, -kubeconfig string
+		Path to the kubeconfig of a hub to take the images from the Argo CD init containers of when -source is not
+		provided
+
+	-p, -plugins string
+		Directory to extract the plugins to, or to use the plugins already in if neither -source nor -kubeconfig is
+		provided. A temporary directory is used if left blank
+
+	-i, -input string
+		Directory containing the kustomization.yaml to render. Renders all the gitserver fixtures if left blank
+
+	-o, -output string
+		Directory to write the rendered objects to, one file per object. Existing contents are replaced
+
+	-g, -golden string
+		Directory containing the golden outputs to compare the rendered objects against
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/argocd"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver/fixtures"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/ztprender"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// hubImageSubstrings match the images of the Argo CD init containers that provide kustomize plugins. The first is the
+// ztp-site-generate image, matching both the ztp-site-generator and ztp-site-generate names, and the second is the ACM
+// image providing the PolicyGenerator plugin.
+var hubImageSubstrings = []string{"ztp-site-gen", "multicluster-operators-subscription"}
+
+var (
+	help    bool
+	source  string
+	plugins string
+	input   string
+	output  string
+	golden  string
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage       = "Print this help message"
+		sourceUsage     = "Comma separated list of images or image tarballs to extract the plugins from"
+		kubeconfigUsage = "Path to the kubeconfig of a hub to take the images from when -source is not provided"
+		pluginsUsage    = "Directory to extract the plugins to, or to use the plugins already in. Temporary if left blank"
+		inputUsage      = "Directory containing the kustomization.yaml to render. Renders the fixtures if left blank"
+		outputUsage     = "Directory to write the rendered objects to, one file per object"
+		goldenUsage     = "Directory containing the golden outputs to compare the rendered objects against"
+
+		defaultHelp = false
+		defaultPath = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+	klog.EnableContextualLogging(true)
+	logf.SetLogger(logr.Discard())
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	flag.StringVar(&source, "source", defaultPath, sourceUsage)
+	flag.StringVar(&source, "s", defaultPath, sourceUsage+shorthand)
+
+	// The kubeconfig flag is already registered by controller-runtime when it is imported, so only the shorthand is
+	// registered here and shares its value.
+	flag.Var(flag.Lookup(config.KubeconfigFlagName).Value, "k", kubeconfigUsage+shorthand)
+
+	flag.StringVar(&plugins, "plugins", defaultPath, pluginsUsage)
+	flag.StringVar(&plugins, "p", defaultPath, pluginsUsage+shorthand)
+
+	flag.StringVar(&input, "input", defaultPath, inputUsage)
+	flag.StringVar(&input, "i", defaultPath, inputUsage+shorthand)
+
+	flag.StringVar(&output, "output", defaultPath, outputUsage)
+	flag.StringVar(&output, "o", defaultPath, outputUsage+shorthand)
+
+	flag.StringVar(&golden, "golden", defaultPath, goldenUsage)
+	flag.StringVar(&golden, "g", defaultPath, goldenUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	err := render()
+	if err != nil {
+		klog.Errorf("Failed to render: %v", err)
+
+		os.Exit(1)
+	}
+}
+
+// render extracts the plugins, if needed, then renders the input or the fixtures, writing and comparing the results.
+func render() error {
+	if output == "" && golden == "" {
+		return fmt.Errorf("at least one of -output and -golden must be provided")
+	}
+
+	sources, err := getSources()
+	if err != nil {
+		return err
+	}
+
+	pluginHome := plugins
+
+	if pluginHome == "" {
+		if len(sources) == 0 {
+			return fmt.Errorf("one of -source, -kubeconfig, and -plugins must be provided")
+		}
+
+		pluginHome, err = os.MkdirTemp("", "ztprender-plugins-")
+		if err != nil {
+			return fmt.Errorf("failed to create plugin directory: %w", err)
+		}
+
+		defer os.RemoveAll(pluginHome)
+	}
+
+	if len(sources) > 0 {
+		err = ztprender.ExtractPlugins(sources, pluginHome)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderer := ztprender.NewRenderer(pluginHome)
+
+	if input != "" {
+		objects, renderErr := renderer.Render(input)
+
+		return handleResult(input, "", objects, renderErr)
+	}
+
+	fixturePaths, err := fixtures.List()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+
+	for _, fixturePath := range fixturePaths {
+		objects, renderErr := renderer.RenderFixture(fixturePath, ztprender.DefaultFixtureData)
+
+		err = handleResult(fixturePath, filepath.FromSlash(fixturePath), objects, renderErr)
+		if err != nil {
+			klog.Errorf("Fixture %s: %v", fixturePath, err)
+
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d fixtures failed", failed, len(fixturePaths))
+	}
+
+	return nil
+}
+
+// handleResult writes the result of rendering name to the subdirectory of the output directory and compares it
+// against the same subdirectory of the golden directory, depending on which are provided. Since some fixtures are
+// expected to fail, failing to render is only an error when it differs from the golden outputs.
+func handleResult(name, subdirectory string, objects []ztprender.Object, renderErr error) error {
+	if output != "" {
+		err := ztprender.WriteGolden(filepath.Join(output, subdirectory), objects, renderErr)
+		if err != nil {
+			return err
+		}
+
+		klog.Infof("Wrote %d objects for %s to %s", len(objects), name, filepath.Join(output, subdirectory))
+	}
+
+	if golden == "" {
+		if renderErr != nil {
+			klog.Warningf("Failed to render %s: %v", name, renderErr)
+		}
+
+		return nil
+	}
+
+	differences, err := ztprender.CompareGolden(filepath.Join(golden, subdirectory), objects, renderErr)
+	if err != nil {
+		return err
+	}
+
+	for _, difference := range differences {
+		klog.Errorf("%s: %s", name, difference)
+	}
+
+	if len(differences) > 0 {
+		return fmt.Errorf("rendered objects differ from golden outputs in %d ways", len(differences))
+	}
+
+	klog.Infof("Rendered objects for %s match golden outputs", name)
+
+	return nil
+}
+
+// getSources returns the sources from the -source flag or, if it is not provided, the images of the init containers
+// providing plugins on the hub from the -kubeconfig flag.
+func getSources() ([]string, error) {
+	if source != "" {
+		return strings.Split(source, ","), nil
+	}
+
+	kubeconfig := flag.Lookup(config.KubeconfigFlagName).Value.String()
+	if kubeconfig == "" {
+		return nil, nil
+	}
+
+	client := clients.New(kubeconfig)
+	if client == nil {
+		return nil, fmt.Errorf("failed to create client from kubeconfig %q", kubeconfig)
+	}
+
+	gitops, err := argocd.Pull(client, ranparam.OpenshiftGitOpsNamespace, ranparam.OpenshiftGitOpsNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull Argo CD %s: %w", ranparam.OpenshiftGitOpsNamespace, err)
+	}
+
+	var images []string
+
+	for _, container := range gitops.Definition.Spec.Repo.InitContainers {
+		for _, substring := range hubImageSubstrings {
+			if strings.Contains(container.Image, substring) {
+				images = append(images, container.Image)
+
+				break
+			}
+		}
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("no images providing plugins found in the Argo CD init containers")
+	}
+
+	klog.Infof("Using images %v from the hub", images)
+
+	return images, nil
+}
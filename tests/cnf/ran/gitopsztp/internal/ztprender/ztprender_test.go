@@ -0,0 +1,390 @@
+package ztprender
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver/fixtures"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// renderSourcesEnv is the comma separated list of images or image tarballs to extract the plugins from for the
+	// golden tests.
+	renderSourcesEnv = "ECO_CNF_RAN_ZTP_RENDER_SOURCES"
+	// siteGenerateImageEnv is the ztp-site-generate image used when renderSourcesEnv is not set.
+	siteGenerateImageEnv = "ECO_CNF_RAN_ZTP_SITE_GENERATE_IMAGE"
+
+	fakePluginScript = `#!/bin/sh
+name=$(sed -n 's/^  name: //p' "$1" | head -n 1)
+printf 'apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: %s\n  namespace: ztp-test\ndata:\n  dir: "%s"\n' \
+  "$name" "$(basename "$PWD")"
+`
+	failingPluginScript = `#!/bin/sh
+echo "invalid config in $KUSTOMIZE_PLUGIN_CONFIG_ROOT" >&2
+exit 1
+`
+)
+
+var update = flag.Bool("update", false, "Write the rendered objects to the golden outputs rather than comparing them")
+
+func TestExtractPluginsFromDockerArchive(t *testing.T) {
+	tarballPath := filepath.Join(t.TempDir(), "image.tar")
+	writeTarball(t, tarballPath, map[string][]byte{
+		"manifest.json": mustMarshalJSON(t, []map[string]any{{"Layers": []string{"layer1/layer.tar", "layer2/layer.tar"}}}),
+		"layer1/layer.tar": gzipBytes(t, tarBytes(t, map[string]string{
+			"kustomize/plugin/ran.openshift.io/v1/policygentemplate/PolicyGenTemplate":        "pgt",
+			"kustomize/plugin/ran.openshift.io/v1/policygentemplate/source-crs/removed.yaml":  "removed",
+			"kustomize/plugin/ran.openshift.io/v1/policygentemplate/source-crs/replaced.yaml": "old",
+			"policy-generator/PolicyGenerator-not-fips-compliant":                             "pg",
+			"usr/bin/unrelated": "unrelated",
+		})),
+		"layer2/layer.tar": tarBytes(t, map[string]string{
+			"kustomize/plugin/ran.openshift.io/v1/policygentemplate/source-crs/.wh.removed.yaml": "",
+			"kustomize/plugin/ran.openshift.io/v1/policygentemplate/source-crs/replaced.yaml":    "new",
+		}),
+	})
+
+	pluginHome := t.TempDir()
+	assert.Nil(t, ExtractPlugins([]string{tarballPath}, pluginHome))
+
+	assert.Equal(t, map[string]string{
+		"ran.openshift.io/v1/policygentemplate/PolicyGenTemplate":              "pgt",
+		"ran.openshift.io/v1/policygentemplate/source-crs/replaced.yaml":       "new",
+		"policy.open-cluster-management.io/v1/policygenerator/PolicyGenerator": "pg",
+	}, readTree(t, pluginHome))
+}
+
+func TestExtractPluginsFromOCIArchive(t *testing.T) {
+	tarballPath := filepath.Join(t.TempDir(), "image.tar")
+	writeTarball(t, tarballPath, map[string][]byte{
+		"index.json": mustMarshalJSON(t, map[string]any{"manifests": []map[string]string{{"digest": "sha256:manifest"}}}),
+		"blobs/sha256/manifest": mustMarshalJSON(t, map[string]any{
+			"layers": []map[string]string{{"digest": "sha256:layer"}},
+		}),
+		"blobs/sha256/layer": gzipBytes(t, tarBytes(t, map[string]string{
+			"kustomize/plugin/ran.openshift.io/v1/siteconfig/SiteConfig": "siteconfig",
+			"../escape": "escape",
+		})),
+	})
+
+	pluginHome := t.TempDir()
+	assert.Nil(t, ExtractPlugins([]string{tarballPath}, pluginHome))
+	assert.Equal(t, map[string]string{"ran.openshift.io/v1/siteconfig/SiteConfig": "siteconfig"}, readTree(t, pluginHome))
+
+	emptyTarballPath := filepath.Join(t.TempDir(), "empty.tar")
+	writeTarball(t, emptyTarballPath, map[string][]byte{
+		"manifest.json": mustMarshalJSON(t, []map[string]any{{"Layers": []string{"layer.tar"}}}),
+		"layer.tar":     tarBytes(t, map[string]string{"usr/bin/unrelated": "unrelated"}),
+	})
+
+	assert.NotNil(t, ExtractPlugins([]string{emptyTarballPath}, t.TempDir()))
+	assert.NotNil(t, ExtractPlugins(nil, t.TempDir()))
+}
+
+func TestRender(t *testing.T) {
+	pluginHome := t.TempDir()
+	writeFile(t, filepath.Join(pluginHome, "ran.openshift.io/v1/policygentemplate/PolicyGenTemplate"),
+		fakePluginScript, 0o755)
+	writeFile(t, filepath.Join(pluginHome, "ran.openshift.io/v1/siteconfig/SiteConfig"), failingPluginScript, 0o755)
+
+	inputs := t.TempDir()
+	writeFile(t, filepath.Join(inputs, "kustomization.yaml"),
+		"generators:\n  - pgt.yaml\nresources:\n  - namespace.yaml\n  - nested\n", 0o644)
+	writeFile(t, filepath.Join(inputs, "pgt.yaml"),
+		"apiVersion: ran.openshift.io/v1\nkind: PolicyGenTemplate\nmetadata:\n  name: first\n---\n"+
+			"apiVersion: ran.openshift.io/v1\nkind: PolicyGenTemplate\nmetadata:\n  name: second\n", 0o644)
+	writeFile(t, filepath.Join(inputs, "namespace.yaml"),
+		"apiVersion: v1\nkind: Namespace\nmetadata:\n  name: ztp-test\n", 0o644)
+	writeFile(t, filepath.Join(inputs, "nested/kustomization.yaml"), "resources:\n  - cm.yaml\n", 0o644)
+	writeFile(t, filepath.Join(inputs, "nested/cm.yaml"),
+		"---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: nested\n  namespace: ztp-test\n", 0o644)
+
+	renderer := NewRenderer(pluginHome)
+	objects, err := renderer.Render(inputs)
+	assert.Nil(t, err)
+
+	var fileNames []string
+	for _, object := range objects {
+		fileNames = append(fileNames, object.FileName())
+	}
+
+	assert.Equal(t, []string{
+		"configmap_ztp-test_first.yaml",
+		"configmap_ztp-test_nested.yaml",
+		"configmap_ztp-test_second.yaml",
+		"namespace_ztp-test.yaml",
+	}, fileNames)
+	assert.Equal(t, map[string]any{"dir": filepath.Base(inputs)}, objects[0]["data"])
+
+	writeFile(t, filepath.Join(inputs, "nested/cm.yaml"),
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n  namespace: ztp-test\n", 0o644)
+
+	_, err = renderer.Render(inputs)
+	assert.ErrorContains(t, err, "configmap_ztp-test_first.yaml")
+
+	writeFile(t, filepath.Join(inputs, "pgt.yaml"), "apiVersion: ran.openshift.io/v1\nkind: SiteConfig\n", 0o644)
+
+	_, err = renderer.Render(inputs)
+	assert.ErrorContains(t, err, "invalid config in "+inputs)
+
+	writeFile(t, filepath.Join(inputs, "pgt.yaml"), "apiVersion: ran.openshift.io/v1\nkind: ClusterInstance\n", 0o644)
+
+	_, err = renderer.Render(inputs)
+	assert.ErrorContains(t, err, "no plugin found")
+}
+
+func TestCompareGolden(t *testing.T) {
+	objects := []Object{{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "test", "namespace": "ztp-test"},
+		"data":       map[string]any{"b": "2", "a": "1"},
+	}}
+
+	golden := t.TempDir()
+	assert.Nil(t, WriteGolden(golden, objects, nil))
+	assert.True(t, IsGoldenDirectory(golden))
+	assert.False(t, IsGoldenDirectory(filepath.Join(golden, "missing")))
+
+	content, err := os.ReadFile(filepath.Join(golden, "configmap_ztp-test_test.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, "apiVersion: v1\ndata:\n  a: \"1\"\n  b: \"2\"\nkind: ConfigMap\nmetadata:\n"+
+		"  name: test\n  namespace: ztp-test\n", string(content))
+
+	differences, err := CompareGolden(golden, objects, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, differences)
+
+	objects[0]["data"] = map[string]any{"a": "1", "b": "3"}
+	objects = append(objects, Object{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]any{"name": "new"}})
+
+	differences, err = CompareGolden(golden, objects, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		`configmap_ztp-test_test.yaml: line 4 differs, expected "  b: \"2\"" but got "  b: \"3\""`,
+		"namespace_new.yaml: rendered but not in golden outputs",
+	}, differences)
+
+	differences, err = CompareGolden(golden, nil, assert.AnError)
+	assert.Nil(t, err)
+	assert.Len(t, differences, 1)
+
+	assert.Nil(t, WriteGolden(golden, nil, assert.AnError))
+
+	differences, err = CompareGolden(golden, nil, assert.AnError)
+	assert.Nil(t, err)
+	assert.Empty(t, differences)
+
+	differences, err = CompareGolden(golden, objects, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"rendering succeeded but was expected to fail"}, differences)
+}
+
+// TestGolden renders the fixtures and the inputs under testdata/inputs, comparing them against the golden
+// outputs under testdata/golden. Inputs that use generators are skipped unless renderSourcesEnv or
+// siteGenerateImageEnv provide the images to extract the plugins from. Run with -update to write the golden outputs.
+func TestGolden(t *testing.T) {
+	assert.Equal(t, tsparams.TestNamespace, DefaultFixtureData.TestNamespace)
+
+	renderer := NewRenderer(getPluginHome(t))
+
+	inputs, err := os.ReadDir(filepath.Join("testdata", "inputs"))
+	assert.Nil(t, err)
+
+	for _, input := range inputs {
+		inputPath := filepath.Join("testdata", "inputs", input.Name())
+
+		t.Run("inputs/"+input.Name(), func(t *testing.T) {
+			if renderer.PluginHome == "" && hasGenerators(t, inputPath) {
+				t.Skipf("%s uses generators but neither %s nor %s is set", input.Name(), renderSourcesEnv, siteGenerateImageEnv)
+			}
+
+			objects, err := renderer.Render(inputPath)
+			checkGolden(t, filepath.Join("testdata", "golden", "inputs", input.Name()), objects, err)
+		})
+	}
+
+	fixturePaths, err := fixtures.List()
+	assert.Nil(t, err)
+
+	for _, fixturePath := range fixturePaths {
+		t.Run(fixturePath, func(t *testing.T) {
+			if renderer.PluginHome == "" {
+				t.Skipf("fixtures use generators but neither %s nor %s is set", renderSourcesEnv, siteGenerateImageEnv)
+			}
+
+			objects, err := renderer.RenderFixture(fixturePath, DefaultFixtureData)
+			checkGolden(t, filepath.Join("testdata", "golden", filepath.FromSlash(fixturePath)), objects, err)
+		})
+	}
+}
+
+// getPluginHome extracts the plugins from the sources in the environment to a temporary plugin home and returns it, or
+// returns an empty string if no sources are set.
+func getPluginHome(t *testing.T) string {
+	t.Helper()
+
+	sources := os.Getenv(renderSourcesEnv)
+	if sources == "" {
+		sources = os.Getenv(siteGenerateImageEnv)
+	}
+
+	if sources == "" {
+		return ""
+	}
+
+	pluginHome := t.TempDir()
+
+	err := ExtractPlugins(strings.Split(sources, ","), pluginHome)
+	if err != nil {
+		t.Fatalf("failed to extract plugins: %v", err)
+	}
+
+	return pluginHome
+}
+
+// checkGolden compares the result of rendering against the golden outputs in directory, or writes them when -update
+// is set.
+func checkGolden(t *testing.T, directory string, objects []Object, renderErr error) {
+	t.Helper()
+
+	if *update {
+		assert.Nil(t, WriteGolden(directory, objects, renderErr))
+
+		return
+	}
+
+	if !IsGoldenDirectory(directory) {
+		t.Skipf("no golden outputs in %s, run with -update to write them", directory)
+	}
+
+	differences, err := CompareGolden(directory, objects, renderErr)
+	assert.Nil(t, err)
+	assert.Empty(t, differences, "rendered objects differ from golden outputs in %s", directory)
+}
+
+// hasGenerators returns whether the kustomization in directory uses any generators.
+func hasGenerators(t *testing.T, directory string) bool {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(directory, kustomizationFileName))
+	assert.Nil(t, err)
+
+	var parsed kustomization
+
+	assert.Nil(t, yaml.Unmarshal(content, &parsed))
+
+	return len(parsed.Generators) > 0
+}
+
+func writeFile(t *testing.T, filePath, content string, mode os.FileMode) {
+	t.Helper()
+
+	assert.Nil(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+	assert.Nil(t, os.WriteFile(filePath, []byte(content), mode))
+}
+
+func readTree(t *testing.T, root string) map[string]string {
+	t.Helper()
+
+	tree := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(filePath string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+
+		tree[filepath.ToSlash(relativePath)] = string(content)
+
+		return nil
+	})
+	assert.Nil(t, err)
+
+	return tree
+}
+
+func tarBytes(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	contents := make(map[string][]byte)
+	for name, content := range files {
+		contents[name] = []byte(content)
+	}
+
+	var buffer bytes.Buffer
+
+	writeTar(t, &buffer, contents)
+
+	return buffer.Bytes()
+}
+
+func gzipBytes(t *testing.T, content []byte) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	_, err := gzipWriter.Write(content)
+	assert.Nil(t, err)
+	assert.Nil(t, gzipWriter.Close())
+
+	return buffer.Bytes()
+}
+
+func writeTarball(t *testing.T, tarballPath string, files map[string][]byte) {
+	t.Helper()
+
+	var buffer bytes.Buffer
+
+	writeTar(t, &buffer, files)
+	assert.Nil(t, os.WriteFile(tarballPath, buffer.Bytes(), 0o644))
+}
+
+func writeTar(t *testing.T, buffer *bytes.Buffer, files map[string][]byte) {
+	t.Helper()
+
+	tarWriter := tar.NewWriter(buffer)
+
+	for name, content := range files {
+		assert.Nil(t, tarWriter.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o755,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+
+		_, err := tarWriter.Write(content)
+		assert.Nil(t, err)
+	}
+
+	assert.Nil(t, tarWriter.Close())
+}
+
+func mustMarshalJSON(t *testing.T, value any) []byte {
+	t.Helper()
+
+	content, err := json.Marshal(value)
+	assert.Nil(t, err)
+
+	return content
+}
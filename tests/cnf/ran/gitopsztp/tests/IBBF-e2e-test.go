@@ -44,7 +44,7 @@ var _ = Describe(
 
 			By("checking if the git path exists")
 
-			if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathIBBFe2e) {
+			if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathIBBFe2e) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathIBBFe2e))
 			}
 
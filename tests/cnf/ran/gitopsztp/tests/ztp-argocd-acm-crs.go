@@ -81,7 +81,7 @@ var _ = Describe("ZTP Argo CD ACM CR Tests", Label(tsparams.LabelArgoCdAcmCrsTes
 	It("should use ACM CRs to template a policy, deploy it, and validate it succeeded", reportxml.ID("54236"), func() {
 		By("checking if the ztp test path exists")
 
-		if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathAcmCrs) {
+		if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathAcmCrs) {
 			Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathAcmCrs))
 		}
 
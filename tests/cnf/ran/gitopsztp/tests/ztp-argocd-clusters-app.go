@@ -64,7 +64,7 @@ var _ = Describe("ZTP Argo CD Clusters Tests", Label(tsparams.LabelArgoCdCluster
 	It("should override the KlusterletAddonConfiguration and verify the change", reportxml.ID("54238"), func() {
 		By("checking if the ztp test path exists")
 
-		if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathClustersApp) {
+		if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathClustersApp) {
 			Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathClustersApp))
 		}
 
@@ -86,7 +86,7 @@ var _ = Describe("ZTP Argo CD Clusters Tests", Label(tsparams.LabelArgoCdCluster
 	It("should not have NMStateConfig CR when nodeNetwork section not in siteConfig", reportxml.ID("60619"), func() {
 		By("checking if the ztp test path exists")
 
-		if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathRemoveNmState) {
+		if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathRemoveNmState) {
 			Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathRemoveNmState))
 		}
 
@@ -153,7 +153,7 @@ var _ = Describe("ZTP Argo CD Hub Templating Tests", Label(tsparams.LabelArgoCdH
 func setupHubTemplateTest(app *argocd.ApplicationBuilder, ztpTestPath string) {
 	By("checking if the ztp test path exists")
 
-	if !app.DoesGitPathExist(ztpTestPath) {
+	if !gitdetails.DoesGitPathExist(app, ztpTestPath) {
 		Skip(fmt.Sprintf("git path '%s' could not be found", ztpTestPath))
 	}
 
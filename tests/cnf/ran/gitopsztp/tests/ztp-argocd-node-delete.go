@@ -105,7 +105,7 @@ var _ = Describe("ZTP Argo CD Node Deletion Tests", Label(tsparams.LabelArgoCdNo
 	It("should delete a worker node from the cluster", reportxml.ID("72463"), func() {
 		By("updating the Argo CD git path to apply crAnnotation")
 
-		if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathNodeDeleteAddAnnotation) {
+		if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathNodeDeleteAddAnnotation) {
 			Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathNodeDeleteAddAnnotation))
 		}
 
@@ -126,7 +126,7 @@ var _ = Describe("ZTP Argo CD Node Deletion Tests", Label(tsparams.LabelArgoCdNo
 		// Since UpdateAndWaitForSync appends the git path, we need to reset it first to the original path
 		// before appending the new path.
 		clustersApp.Definition.Spec.Source.Path = originalClustersGitPath
-		exists := clustersApp.DoesGitPathExist(tsparams.ZtpTestPathNodeDeleteAddSuppression)
+		exists := gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathNodeDeleteAddSuppression)
 		Expect(exists).To(BeTrue(), "Already applied node delete crAnnotation but cannot find node delete suppression path")
 
 		err = gitdetails.UpdateAndWaitForSync(clustersApp, false, tsparams.ZtpTestPathNodeDeleteAddSuppression)
@@ -74,7 +74,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 		It("should specify new intervals and verify they were applied", reportxml.ID("54241"), func() {
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathCustomInterval) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathCustomInterval) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathCustomInterval))
 			}
 
@@ -120,7 +120,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 		It("should specify an invalid interval format and verify the app error", reportxml.ID("54242"), func() {
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathInvalidInterval) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathInvalidInterval) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathInvalidInterval))
 			}
 
@@ -180,7 +180,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathImageRegistry) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathImageRegistry) {
 				imageRegistryConfig = nil
 
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathImageRegistry))
@@ -280,7 +280,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathCustomSourceNewCr) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathCustomSourceNewCr) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathCustomSourceNewCr))
 			}
 
@@ -324,7 +324,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathCustomSourceReplaceExisting) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathCustomSourceReplaceExisting) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathCustomSourceReplaceExisting))
 			}
 
@@ -364,7 +364,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathCustomSourceNoCrFile) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathCustomSourceNoCrFile) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathCustomSourceNoCrFile))
 			}
 
@@ -407,7 +407,7 @@ var _ = Describe("ZTP Argo CD Policies Tests", Label(tsparams.LabelArgoCdPolicie
 
 			By("checking if the ztp test path exists")
 
-			if !policiesApp.DoesGitPathExist(tsparams.ZtpTestPathCustomSourceSearchPath) {
+			if !gitdetails.DoesGitPathExist(policiesApp, tsparams.ZtpTestPathCustomSourceSearchPath) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathCustomSourceSearchPath))
 			}
 
@@ -118,7 +118,7 @@ var _ = Describe("ZTP Siteconfig Operator's Cluster Instance Delete Tests",
 			// extra cleanup.
 			By("checking if the ztp test path exists")
 
-			if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathDetachAIMNO) {
+			if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathDetachAIMNO) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathDetachAIMNO))
 			}
 
@@ -218,7 +218,7 @@ var _ = Describe("ZTP Siteconfig Operator's Cluster Instance Delete Tests",
 
 			By("checking if the ztp test path exists")
 
-			if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathDetachAISNO) {
+			if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathDetachAISNO) {
 				Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathDetachAISNO))
 			}
 
@@ -92,7 +92,7 @@ var _ = Describe("ZTP Siteconfig Operator's Day 2 configuration Test",
 			reportxml.ID("75342"), func() {
 				By("checking if ztp test path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathNewClusterLabel) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathNewClusterLabel) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathNewClusterLabel))
 				}
 
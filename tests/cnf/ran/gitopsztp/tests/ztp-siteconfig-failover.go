@@ -66,7 +66,7 @@ var _ = Describe("ZTP Siteconfig Operator's Failover Tests",
 				// in clusterinstance.yaml.
 				By("checking if the non-existent cluster template configmap reference git path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathNoClusterTemplateCm) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathNoClusterTemplateCm) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathNoClusterTemplateCm))
 				}
 
@@ -96,7 +96,7 @@ var _ = Describe("ZTP Siteconfig Operator's Failover Tests",
 				// in clusterinstance.yaml.
 				By("checking if the non-existent extra manifests configmap reference git path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathNoExtraManifestsCm) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathNoExtraManifestsCm) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathNoExtraManifestsCm))
 				}
 
@@ -71,7 +71,7 @@ var _ = Describe("ZTP Siteconfig Operator's Negative Tests",
 				// in kind:ClusterInstance to make ClusterInstance CR invalid.
 				By("checking if the ztp test path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathInvalidTemplateRef) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathInvalidTemplateRef) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathInvalidTemplateRef))
 				}
 
@@ -99,7 +99,7 @@ var _ = Describe("ZTP Siteconfig Operator's Negative Tests",
 				// template configmap in kind:ClusterInstance to make ClusterInstance CR valid.
 				By("checking if the ztp test path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathValidTemplateRef) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathValidTemplateRef) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathValidTemplateRef))
 				}
 
@@ -132,7 +132,7 @@ var _ = Describe("ZTP Siteconfig Operator's Negative Tests",
 				// in kind:ClusterInstance with field clusterName: "clusterA".
 				By("checking if the ztp test path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathUniqueClusterName) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathUniqueClusterName) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathUniqueClusterName))
 				}
 
@@ -161,7 +161,7 @@ var _ = Describe("ZTP Siteconfig Operator's Negative Tests",
 				// in kind:ClusterInstance with field clusterName: "clusterA" (duplicate).
 				By("checking if the ztp test path exists")
 
-				if !clustersApp.DoesGitPathExist(tsparams.ZtpTestPathDuplicateClusterName) {
+				if !gitdetails.DoesGitPathExist(clustersApp, tsparams.ZtpTestPathDuplicateClusterName) {
 					Skip(fmt.Sprintf("git path '%s' could not be found", tsparams.ZtpTestPathDuplicateClusterName))
 				}
 
@@ -9,10 +9,12 @@ import (
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
 	. "github.com/onsi/gomega"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/gitserver"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/gitopsztp/tests"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
@@ -30,12 +32,23 @@ func TestZtp(t *testing.T) {
 	RunSpecs(t, "RAN ZTP Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("checking that the required clusters are present")
 
 	if !rancluster.AreClustersPresent([]*clients.Settings{HubAPIClient, Spoke1APIClient}) {
 		Skip("not all of the required clusters are present")
 	}
+
+	if RANConfig.ZtpGitFixtures {
+		By("deploying the git server for the ZTP test fixtures")
+
+		_, err := gitserver.Deploy(HubAPIClient, RANConfig.ZtpGitServerImage)
+		Expect(err).ToNot(HaveOccurred(), "Failed to deploy the ZTP git server")
+	}
 })
 
 var _ = BeforeEach(func() {
@@ -56,7 +69,28 @@ var _ = BeforeEach(func() {
 	}
 })
 
+var _ = AfterEach(func() {
+	if !RANConfig.ZtpGitFixtures || CurrentSpecReport().State.Is(types.SpecStateSkipped) {
+		return
+	}
+
+	By("restoring the Argo CD apps repointed to the ZTP git server")
+
+	err := gitserver.RestoreApps(HubAPIClient)
+	Expect(err).ToNot(HaveOccurred(), "Failed to restore the Argo CD apps to their original sources")
+})
+
 var _ = AfterSuite(func() {
+	if RANConfig.ZtpGitFixtures && HubAPIClient != nil {
+		By("deleting the ZTP git server")
+
+		err := gitserver.RestoreApps(HubAPIClient)
+		Expect(err).ToNot(HaveOccurred(), "Failed to restore the Argo CD apps to their original sources")
+
+		err = namespace.NewBuilder(HubAPIClient, tsparams.GitServerNamespace).DeleteAndWait(5 * time.Minute)
+		Expect(err).ToNot(HaveOccurred(), "Failed to delete the ZTP git server namespace")
+	}
+
 	By("deleting test namespace")
 
 	for _, client := range []*clients.Settings{HubAPIClient, Spoke1APIClient} {
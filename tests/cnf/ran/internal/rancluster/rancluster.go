@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ocm"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/diskencryption/tsparams"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -191,9 +191,17 @@ func GetManagedClusterID(client *clients.Settings, clusterName string) (string,
 	return clusterID, nil
 }
 
+var (
+	// powerPollInterval is how often PowerOffAndWait and PowerOnAndWait check the power state. It is a variable so
+	// unit tests can shorten it.
+	powerPollInterval = 30 * time.Second
+	// powerPollTimeout is how long PowerOffAndWait and PowerOnAndWait wait for the power state to change.
+	powerPollTimeout = 3 * time.Minute
+)
+
 // PowerOffAndWait will trigger a power off and poll every 30 seconds for up to 3 minutes until the system is off.
-func PowerOffAndWait(bmcClient *bmc.BMC) error {
-	err := bmcClient.SystemPowerOff()
+func PowerOffAndWait(controller powercontrol.Controller) error {
+	err := controller.PowerOff()
 	if err != nil {
 		klog.V(ranparam.LogLevel).Infof("Failed to trigger system power off: %v", err)
 
@@ -201,15 +209,15 @@ func PowerOffAndWait(bmcClient *bmc.BMC) error {
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 30*time.Second, 3*time.Minute, true, func(ctx context.Context) (bool, error) {
-			powerState, err := bmcClient.SystemPowerState()
+		context.TODO(), powerPollInterval, powerPollTimeout, true, func(ctx context.Context) (bool, error) {
+			powerState, err := controller.PowerState()
 			if err != nil {
 				klog.V(ranparam.LogLevel).Infof("Failed to get system power state: %v", err)
 
 				return false, err
 			}
 
-			if powerState != "Off" {
+			if powerState != powercontrol.PowerStateOff {
 				klog.V(ranparam.LogLevel).Infof("System power state is not Off: %s", powerState)
 
 				return false, nil
@@ -221,11 +229,11 @@ func PowerOffAndWait(bmcClient *bmc.BMC) error {
 
 // PowerOffWithRetries will attempt to power off and wait until the system is powered off, trying up to retries times if
 // the system does not power off.
-func PowerOffWithRetries(bmcClient *bmc.BMC, retries uint) error {
+func PowerOffWithRetries(controller powercontrol.Controller, retries uint) error {
 	var err error
 
 	for retry := range retries {
-		err = PowerOffAndWait(bmcClient)
+		err = PowerOffAndWait(controller)
 		if err == nil {
 			return nil
 		}
@@ -237,24 +245,24 @@ func PowerOffWithRetries(bmcClient *bmc.BMC, retries uint) error {
 }
 
 // PowerOnAndWait will trigger a power on and poll every 30 seconds for up to 3 minutes until the system is on.
-func PowerOnAndWait(bmcClient *bmc.BMC) error {
-	err := bmcClient.SystemPowerOn()
+func PowerOnAndWait(controller powercontrol.Controller) error {
+	err := controller.PowerOn()
 	if err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to trigger system power off: %v", err)
+		klog.V(ranparam.LogLevel).Infof("Failed to trigger system power on: %v", err)
 
 		return err
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 30*time.Second, 3*time.Minute, true, func(ctx context.Context) (bool, error) {
-			powerState, err := bmcClient.SystemPowerState()
+		context.TODO(), powerPollInterval, powerPollTimeout, true, func(ctx context.Context) (bool, error) {
+			powerState, err := controller.PowerState()
 			if err != nil {
 				klog.V(ranparam.LogLevel).Infof("Failed to get system power state: %v", err)
 
 				return false, err
 			}
 
-			if powerState != "On" {
+			if powerState != powercontrol.PowerStateOn {
 				klog.V(ranparam.LogLevel).Infof("System power state is not On: %s", powerState)
 
 				return false, nil
@@ -266,11 +274,11 @@ func PowerOnAndWait(bmcClient *bmc.BMC) error {
 
 // PowerOnWithRetries will attempt to power on and wait until the system is powered on, trying up to retries times if
 // the system does not power on.
-func PowerOnWithRetries(bmcClient *bmc.BMC, retries uint) error {
+func PowerOnWithRetries(controller powercontrol.Controller, retries uint) error {
 	var err error
 
 	for retry := range retries {
-		err = PowerOnAndWait(bmcClient)
+		err = PowerOnAndWait(controller)
 		if err == nil {
 			return nil
 		}
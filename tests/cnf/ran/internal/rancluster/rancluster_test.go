@@ -0,0 +1,98 @@
+package rancluster
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/redfishmock"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerOnWithRetries(t *testing.T) {
+	setShortPowerPolling(t)
+
+	testCases := []struct {
+		resetFailures   int
+		transitionReads int
+		supportsOn      bool
+		expectError     bool
+		expectedResets  int
+	}{
+		{supportsOn: true, expectedResets: 1},
+		{resetFailures: 2, supportsOn: true, expectedResets: 1},
+		{transitionReads: 3, supportsOn: true, expectedResets: 1},
+		{resetFailures: 3, supportsOn: true, expectError: true},
+		{transitionReads: 100, supportsOn: true, expectError: true, expectedResets: 3},
+		{supportsOn: false, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		server := redfishmock.NewServer("admin", "password")
+		server.SetPowerState(redfish.OffPowerState)
+		server.SetTransitionReads(testCase.transitionReads)
+		server.FailNext(redfishmock.RouteReset, http.StatusInternalServerError, testCase.resetFailures)
+
+		if !testCase.supportsOn {
+			server.SetSupportedResetTypes(redfish.ForceOffResetType)
+		}
+
+		err := PowerOnWithRetries(newTestController(server), 3)
+
+		assert.Equal(t, testCase.expectError, err != nil)
+		assert.Len(t, server.Resets(), testCase.expectedResets)
+
+		if !testCase.expectError {
+			assert.Equal(t, redfish.OnPowerState, server.PowerState())
+		}
+
+		server.Close()
+	}
+}
+
+func TestPowerOffWithRetries(t *testing.T) {
+	setShortPowerPolling(t)
+
+	server := redfishmock.NewServer("admin", "password")
+	defer server.Close()
+
+	server.SetTransitionReads(2)
+	server.FailNext(redfishmock.RouteReset, http.StatusInternalServerError, 1)
+
+	assert.Nil(t, PowerOffWithRetries(newTestController(server), 2))
+	assert.Equal(t, []redfish.ResetType{redfish.ForceOffResetType}, server.Resets())
+	assert.Equal(t, redfish.OffPowerState, server.PowerState())
+}
+
+func TestPowerOnAndWaitStateError(t *testing.T) {
+	setShortPowerPolling(t)
+
+	fake := powercontrol.NewFake(powercontrol.PowerStateOff).
+		FailNext(powercontrol.OperationPowerState, errors.New("bmc unreachable"))
+
+	assert.NotNil(t, PowerOnAndWait(fake))
+	assert.Equal(t, []powercontrol.Operation{powercontrol.OperationPowerOn, powercontrol.OperationPowerState},
+		fake.Calls())
+}
+
+func newTestController(server *redfishmock.Server) powercontrol.Controller {
+	return powercontrol.NewRedfish(powercontrol.Config{
+		Address:  server.Address(),
+		Username: "admin",
+		Password: "password",
+	}).WithTimeout(5 * time.Second)
+}
+
+func setShortPowerPolling(t *testing.T) {
+	t.Helper()
+
+	originalInterval, originalTimeout := powerPollInterval, powerPollTimeout
+	powerPollInterval, powerPollTimeout = 10*time.Millisecond, 200*time.Millisecond
+
+	t.Cleanup(func() {
+		powerPollInterval, powerPollTimeout = originalInterval, originalTimeout
+	})
+}
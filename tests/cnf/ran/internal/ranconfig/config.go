@@ -13,7 +13,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/internal/cnfconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
 	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 )
@@ -36,7 +38,11 @@ type RANConfig struct {
 	PtpStabilityDuration   time.Duration `yaml:"ptpStabilityDuration" envconfig:"ECO_CNF_RAN_PTP_STABILITY_DURATION"`
 	// PtpStabilityThreshold is the absolute offset threshold for PTP stability analysis. It is measured in
 	// nanoseconds.
-	PtpStabilityThreshold int64    `yaml:"ptpStabilityThreshold" envconfig:"ECO_CNF_RAN_PTP_STABILITY_THRESHOLD"`
+	PtpStabilityThreshold int64 `yaml:"ptpStabilityThreshold" envconfig:"ECO_CNF_RAN_PTP_STABILITY_THRESHOLD"`
+	// PtpStabilityProfile is the threshold profile for PTP stability analysis. It is either the name of a built-in
+	// profile, such as g.8273.2-class-c, or the path to a YAML profile. If empty, the default profile is used with
+	// PtpStabilityThreshold.
+	PtpStabilityProfile   string   `yaml:"ptpStabilityProfile" envconfig:"ECO_CNF_RAN_PTP_STABILITY_PROFILE"`
 	StressngTestImage     string   `yaml:"stressngTestImage" envconfig:"ECO_CNF_RAN_STRESSNG_TEST_IMAGE"`
 	CnfTestImage          string   `yaml:"cnfTestImage" envconfig:"ECO_CNF_RAN_TEST_IMAGE"`
 	OcpUpgradeUpstreamURL string   `yaml:"ocpUpgradeUpstreamUrl" envconfig:"ECO_CNF_RAN_OCP_UPGRADE_UPSTREAM_URL"`
@@ -55,6 +61,18 @@ type RANConfig struct {
 	// so that digests may be specified if needed.
 	PtpEventConsumerV2Tag string `yaml:"ptpEventConsumerV2Tag" envconfig:"ECO_CNF_RAN_PTP_EVENT_CONSUMER_V2_TAG"`
 
+	// PtpConfigLint controls whether the PtpConfigs on spoke 1 are linted before the PTP suite runs. Lint errors
+	// fail the suite before any spec runs, so disable it to run against intentionally unusual configurations.
+	PtpConfigLint bool `yaml:"ptpConfigLint" envconfig:"ECO_CNF_RAN_PTP_CONFIG_LINT"`
+
+	// ZtpGitFixtures controls whether the gitopsztp suite commits the fixtures checked in to this repo to a git
+	// server on the hub and repoints the Argo CD apps to it. When disabled, the fixtures must already exist in the
+	// repos the Argo CD apps point to.
+	ZtpGitFixtures bool `yaml:"ztpGitFixtures" envconfig:"ECO_CNF_RAN_ZTP_GIT_FIXTURES"`
+	// ZtpGitServerImage is the image used for the git server serving the ZTP fixtures. It must provide python3 and
+	// git.
+	ZtpGitServerImage string `yaml:"ztpGitServerImage" envconfig:"ECO_CNF_RAN_ZTP_GIT_SERVER_IMAGE"`
+
 	// PtpMustGatherImage is the image to use for PTP must-gather. If the value is set, this will be used for the
 	// must-gather. Otherwise, it will fallback to the CSV annotation, followed by the image from registry.redhat.io
 	// corresponding to the current Spoke 1 OCP version.
@@ -112,6 +130,9 @@ func (hubConfig *HubConfig) GetAppsURL(subdomain string) string {
 type Spoke1Config struct {
 	Spoke1BMC       *bmc.BMC
 	Spoke1APIClient *clients.Settings
+	// Spoke1PowerController controls the power of spoke 1 through the BMC of type BMCType. It is nil when the BMC
+	// configs are not provided.
+	Spoke1PowerController powercontrol.Controller
 
 	Spoke1OCPVersion       string
 	Spoke1OperatorVersions map[ranparam.SpokeOperatorName]string
@@ -128,6 +149,8 @@ type Spoke1Config struct {
 	BMCPassword string        `envconfig:"ECO_CNF_RAN_BMC_PASSWORD"`
 	BMCHosts    []string      `envconfig:"ECO_CNF_RAN_BMC_HOSTS"`
 	BMCTimeout  time.Duration `yaml:"bmcTimeout" envconfig:"ECO_CNF_RAN_BMC_TIMEOUT"`
+	// BMCType is the type of BMC used for power control, either redfish or ipmi. Defaults to redfish.
+	BMCType powercontrol.Type `yaml:"bmcType" envconfig:"ECO_CNF_RAN_BMC_TYPE"`
 }
 
 // Spoke2Config contains the configuration for the spoke 2 cluster, if present.
@@ -161,6 +184,8 @@ func NewRANConfig() *RANConfig {
 	ranConfig.newSpoke1Config(configFile)
 	ranConfig.newSpoke2Config(configFile)
 
+	config.RegisterSuiteConfig("ran", &ranConfig)
+
 	return &ranConfig
 }
 
@@ -283,6 +308,23 @@ func (ranconfig *RANConfig) newSpoke1Config(configFile string) {
 		ranconfig.Spoke1Config.Spoke1BMC = bmc.New(bmcHost).
 			WithRedfishUser(ranconfig.Spoke1Config.BMCUsername, ranconfig.Spoke1Config.BMCPassword).
 			WithRedfishTimeout(ranconfig.Spoke1Config.BMCTimeout)
+
+		powerControlConfig := powercontrol.Config{
+			Type:     ranconfig.Spoke1Config.BMCType,
+			Address:  bmcHost,
+			Username: ranconfig.Spoke1Config.BMCUsername,
+			Password: ranconfig.Spoke1Config.BMCPassword,
+		}
+
+		if powerControlConfig.Type == "" || powerControlConfig.Type == powercontrol.TypeRedfish {
+			ranconfig.Spoke1Config.Spoke1PowerController = powercontrol.NewRedfish(powerControlConfig).
+				WithTimeout(ranconfig.Spoke1Config.BMCTimeout)
+		} else {
+			ranconfig.Spoke1Config.Spoke1PowerController, err = powercontrol.New(powerControlConfig)
+			if err != nil {
+				klog.V(ranparam.LogLevel).Infof("Failed to create power controller for spoke 1: %v", err)
+			}
+		}
 	}
 }
 
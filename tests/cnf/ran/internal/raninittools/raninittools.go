@@ -6,6 +6,9 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement/gather"
 	"k8s.io/klog/v2"
 )
 
@@ -20,6 +23,8 @@ var (
 	RANConfig *ranconfig.RANConfig
 	// BMCClient provides access to the BMC. Nil when BMC configs are not provided.
 	BMCClient *bmc.BMC
+	// PowerController controls the power of spoke 1 through its BMC. Nil when BMC configs are not provided.
+	PowerController powercontrol.Controller
 )
 
 func init() {
@@ -33,4 +38,11 @@ func init() {
 	HubAPIClient = RANConfig.HubAPIClient
 	Spoke2APIClient = RANConfig.Spoke2APIClient
 	BMCClient = RANConfig.Spoke1BMC
+	PowerController = RANConfig.Spoke1PowerController
+
+	requirement.SetSource(gather.NewLiveSource().
+		WithOptions(gather.ConfigOptions(inittools.GeneralConfig)).
+		WithCluster(requirement.Hub, HubAPIClient).
+		WithCluster(requirement.Spoke1, Spoke1APIClient).
+		WithCluster(requirement.Spoke2, Spoke2APIClient))
 }
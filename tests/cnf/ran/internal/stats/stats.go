@@ -56,3 +56,24 @@ func Median(input []float64) (float64, error) {
 
 	return (inputCopy[numElements/2] + inputCopy[numElements/2-1]) / 2, nil
 }
+
+// Percentile computes the pth percentile of the input array using the nearest-rank method, so the result is always an
+// element of the input. The percentile p must be in the range (0, 100].
+func Percentile(input []float64, p float64) (float64, error) {
+	if len(input) < 1 {
+		return math.NaN(), fmt.Errorf("input array must have at least 1 element")
+	}
+
+	if p <= 0 || p > 100 {
+		return math.NaN(), fmt.Errorf("percentile must be in the range (0, 100]")
+	}
+
+	inputCopy := make([]float64, len(input))
+	copy(inputCopy, input)
+
+	slices.Sort(inputCopy)
+
+	rank := int(math.Ceil(p / 100 * float64(len(inputCopy))))
+
+	return inputCopy[rank-1], nil
+}
@@ -93,3 +93,58 @@ func TestMedian(t *testing.T) {
 		}
 	}
 }
+
+func TestPercentile(t *testing.T) {
+	testCases := []struct {
+		input          []float64
+		percentile     float64
+		expectedOutput float64
+		expectedError  error
+	}{
+		{
+			input:          []float64{5, 1, 4, 2, 3},
+			percentile:     50,
+			expectedOutput: 3,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{5, 1, 4, 2, 3},
+			percentile:     100,
+			expectedOutput: 5,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{5, 1, 4, 2, 3},
+			percentile:     1,
+			expectedOutput: 1,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			percentile:     99.9,
+			expectedOutput: 10,
+			expectedError:  nil,
+		},
+		{
+			input:          []float64{},
+			percentile:     50,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("input array must have at least 1 element"),
+		},
+		{
+			input:          []float64{1},
+			percentile:     0,
+			expectedOutput: math.NaN(),
+			expectedError:  fmt.Errorf("percentile must be in the range (0, 100]"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		output, err := Percentile(testCase.input, testCase.percentile)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.InDelta(t, testCase.expectedOutput, output, epsilon)
+		}
+	}
+}
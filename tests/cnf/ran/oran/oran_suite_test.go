@@ -28,6 +28,10 @@ func TestORAN(t *testing.T) {
 	RunSpecs(t, "RAN O-RAN Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("checking that the hub cluster is present")
 
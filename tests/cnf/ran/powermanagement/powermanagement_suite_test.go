@@ -26,6 +26,10 @@ func TestPowerSave(t *testing.T) {
 	RunSpecs(t, "Power Management Test Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	// Cleanup and create test namespace
 	testNamespace := namespace.NewBuilder(Spoke1APIClient, tsparams.TestingNamespace).
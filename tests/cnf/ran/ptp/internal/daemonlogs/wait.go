@@ -162,25 +162,39 @@ func WaitForPodLog(client *clients.Settings, nodeName string, options ...WaitFor
 			// duplicates, but this will not affect the result.
 			lastFetchTime = localFetchTime
 
-			for line := range strings.SplitSeq(string(logs), "\n") {
-				if logOptions.matcher(line) {
-					klog.V(tsparams.LogLevel).Infof("Found matching log line in PTP daemon pod on node %s: %q", nodeName, line)
+			if line, found := FindMatch(string(logs), logOptions.matcher); found {
+				klog.V(tsparams.LogLevel).Infof("Found matching log line in PTP daemon pod on node %s: %q", nodeName, line)
 
-					return true, nil
-				}
+				return true, nil
 			}
 
 			return false, nil
 		})
 }
 
+// FindMatch returns the first line of logs that matcher matches, along with whether one was found.
+func FindMatch(logs string, matcher LogMatcher) (string, bool) {
+	for line := range strings.SplitSeq(logs, "\n") {
+		if matcher(line) {
+			return line, true
+		}
+	}
+
+	return "", false
+}
+
 // profileLoadMessage is the message that appears in the linuxptp-daemon-container logs when the profiles are loaded.
 const profileLoadMessage = "load profiles"
 
+// ProfileLoadMatcher returns a LogMatcher that matches the log line of the PTP daemon loading the profiles.
+func ProfileLoadMatcher() LogMatcher {
+	return ContainsMatcher(profileLoadMessage)
+}
+
 // WaitForProfileLoad waits for the profile load message to appear in the PTP daemon pod logs on the specified node. It
 // matches for the profile load message and uses the provided options for the WaitForPodLog function.
 func WaitForProfileLoad(client *clients.Settings, nodeName string, options ...WaitForPodLogOption) error {
-	options = append(options, WithMatcher(ContainsMatcher(profileLoadMessage)))
+	options = append(options, WithMatcher(ProfileLoadMatcher()))
 
 	err := WaitForPodLog(client, nodeName, options...)
 	if err != nil {
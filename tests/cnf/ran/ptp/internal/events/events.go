@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strconv"
 	"time"
@@ -28,6 +29,15 @@ var (
 	extractEventRegexp = regexp.MustCompile(`\{.*\}`)
 )
 
+// PodLogGetter is the part of a pod.Builder used to retrieve the logs events are extracted from. It allows for
+// extracting events from logs that do not come from a live pod, such as captured logs being replayed.
+type PodLogGetter interface {
+	GetLogsWithOptions(options *corev1.PodLogOptions) ([]byte, error)
+}
+
+// Assert at compile time that pod.Builder implements PodLogGetter.
+var _ PodLogGetter = (*pod.Builder)(nil)
+
 // waitForEventOptions is a struct that holds options for the WaitForEvent function. Options will update this struct and
 // the final result is used to configure the WaitForEvent function.
 type waitForEventOptions struct {
@@ -61,7 +71,7 @@ func WithoutCurrentState(ignoreCurrentState bool) WaitForEventOption {
 // The startTime is the beginning of the time window to check for events and does not count towards the timeout. All
 // logs between startTime and the current time plus the timeout are checked for events.
 func WaitForEvent(
-	eventPod *pod.Builder,
+	eventPod PodLogGetter,
 	startTime time.Time,
 	timeout time.Duration,
 	filter EventFilter,
@@ -99,6 +109,25 @@ func WaitForEvent(
 		})
 }
 
+// ListEvents returns all the events received by the cloud event consumer in eventPod since startTime, in the order
+// they were logged. It accepts the same options as [WaitForEvent].
+func ListEvents(eventPod PodLogGetter, startTime time.Time, options ...WaitForEventOption) ([]event.Event, error) {
+	combinedOptions := waitForEventOptions{}
+	for _, option := range options {
+		option(&combinedOptions)
+	}
+
+	logs, err := eventPod.GetLogsWithOptions(&corev1.PodLogOptions{
+		SinceTime: &metav1.Time{Time: startTime},
+		Container: combinedOptions.container,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs starting at %s for pod: %w", startTime, err)
+	}
+
+	return extractEventsFromLogs(logs, combinedOptions.ignoreCurrentState), nil
+}
+
 // extractEventsFromLogs extracts events from the logs of either the cloud event consumer or the cloud event proxy
 // containers. Rather than return errors, this function logs them and ignores the line. All lines that were able to be
 // parsed into events are returned.
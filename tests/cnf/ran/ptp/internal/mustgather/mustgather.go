@@ -1,12 +1,12 @@
 package mustgather
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/onsi/ginkgo/v2/types"
@@ -47,61 +47,53 @@ const (
 	mustGatherPodTimeout = 10 * time.Minute
 	// mustGatherPodName is the name of the must-gather pod.
 	mustGatherPodName = "ptp-must-gather"
+	// CollectorTimeout is the timeout to register the Collector with. It allows for the must-gather pod to start and
+	// complete, with time left over for downloading the output and cleaning up.
+	CollectorTimeout = 3 * mustGatherPodTimeout
 )
 
 var majorMinorVersionRegex = regexp.MustCompile(`(\d+)\.(\d+)`)
 
-// MustGatherIfFailed runs the PTP must-gather if the test has failed, saving the output to the report directory.
-func MustGatherIfFailed(
-	report types.SpecReport,
-	testSuite string,
-	client *clients.Settings,
-) {
-	if !report.State.Is(types.SpecStateFailureStates) {
-		return
-	}
-
-	if client == nil {
-		klog.V(ranparam.LogLevel).Info("Client is nil, skipping PTP must-gather")
+// Collector is a reporter.Collector that runs the PTP must-gather when a spec fails.
+type Collector struct {
+	client *clients.Settings
+}
 
-		return
-	}
+// NewCollector creates a Collector that runs the PTP must-gather on the cluster for client. It is meant to be
+// registered using reporter.RegisterCollector with a timeout long enough for the must-gather pod to complete.
+func NewCollector(client *clients.Settings) *Collector {
+	return &Collector{client: client}
+}
 
-	dumpDir := RANConfig.GetDumpFailedTestReportLocation(testSuite)
-	if dumpDir == "" {
-		klog.V(ranparam.LogLevel).Info("No dump directory configured, skipping PTP must-gather")
+// Name returns the name of the Collector.
+func (collector *Collector) Name() string {
+	return "ptp-must-gather"
+}
 
-		return
+// Collect runs the PTP must-gather and saves its output as a tarball in outputDir. It stops waiting on the must-gather
+// pod once ctx is done, although the must-gather resources are still deleted before it returns.
+func (collector *Collector) Collect(ctx context.Context, _ types.SpecReport, outputDir string) error {
+	if collector.client == nil {
+		return fmt.Errorf("cannot run PTP must-gather when client is nil")
 	}
 
-	image, err := getMustGatherImage(client)
+	image, err := getMustGatherImage(collector.client)
 	if err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to get must-gather image: %v, skipping PTP must-gather", err)
-
-		return
+		return fmt.Errorf("failed to get must-gather image: %w", err)
 	}
 
 	klog.V(ranparam.LogLevel).Infof("Running PTP must-gather with image: %s", image)
 
-	// Create the test case specific directory for the must-gather output
-	tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
-	mustGatherDir := filepath.Join(dumpDir, tcReportFolderName)
+	tarballPath := filepath.Join(outputDir, "ptp-must-gather.tar")
 
-	if err := os.MkdirAll(mustGatherDir, 0755); err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to create must-gather directory: %v", err)
-
-		return
-	}
-
-	tarballPath := filepath.Join(mustGatherDir, "ptp-must-gather.tar")
-
-	if err := runMustGather(client, image, tarballPath); err != nil {
-		klog.V(ranparam.LogLevel).Infof("Failed to run PTP must-gather: %v", err)
-
-		return
+	err = runMustGather(ctx, collector.client, image, tarballPath)
+	if err != nil {
+		return fmt.Errorf("failed to run PTP must-gather: %w", err)
 	}
 
 	klog.V(ranparam.LogLevel).Infof("PTP must-gather completed successfully, output saved to: %s", tarballPath)
+
+	return nil
 }
 
 // getMustGatherImage retrieves the must-gather image, trying in order:
@@ -164,8 +156,9 @@ func getPtpMustGatherImageForVersion(ocpVersion string) (string, error) {
 	return fmt.Sprintf("registry.redhat.io/openshift4/ptp-must-gather-rhel8:v%s.%s", major, minor), nil
 }
 
-// runMustGather creates the necessary resources, runs the must-gather pod, and downloads the output.
-func runMustGather(client *clients.Settings, image, tarballPath string) error {
+// runMustGather creates the necessary resources, runs the must-gather pod, and downloads the output. Waiting on the pod
+// and downloading the output are both cut short when ctx is done.
+func runMustGather(ctx context.Context, client *clients.Settings, image, tarballPath string) error {
 	runID := time.Now().UnixNano()
 	nsName := fmt.Sprintf("%s%d", mustGatherNamespacePrefix, runID)
 	crbName := fmt.Sprintf("%s%d", mustGatherCRBPrefix, runID)
@@ -177,7 +170,8 @@ func runMustGather(client *clients.Settings, image, tarballPath string) error {
 
 	// Ensure cleanup happens regardless of success or failure.
 	defer func() {
-		cleanupErr := cleanupMustGatherResources(client, nsName, crbName)
+		// Only wait for the namespace to be deleted if there is time left to do so.
+		cleanupErr := cleanupMustGatherResources(client, nsName, crbName, ctx.Err() == nil)
 		if cleanupErr != nil {
 			klog.V(ranparam.LogLevel).Infof("Failed to cleanup must-gather resources: %v", cleanupErr)
 		}
@@ -201,17 +195,21 @@ func runMustGather(client *clients.Settings, image, tarballPath string) error {
 		return fmt.Errorf("failed to create cluster role binding: %w", err)
 	}
 
+	if ctx.Err() != nil {
+		return fmt.Errorf("context done before creating must-gather pod: %w", ctx.Err())
+	}
+
 	podBuilder, err := createMustGatherPod(client, nsName, image)
 	if err != nil {
 		return fmt.Errorf("failed to create must-gather pod: %w", err)
 	}
 
-	err = waitForGatherComplete(podBuilder)
+	err = waitForGatherComplete(ctx, podBuilder)
 	if err != nil {
 		return fmt.Errorf("failed waiting for must-gather to complete: %w", err)
 	}
 
-	err = downloadMustGatherOutput(podBuilder, tarballPath)
+	err = downloadMustGatherOutput(ctx, podBuilder, tarballPath)
 	if err != nil {
 		return fmt.Errorf("failed to download must-gather output: %w", err)
 	}
@@ -293,11 +291,20 @@ func createMustGatherPod(client *clients.Settings, nsName, image string) (*pod.B
 	return podBuilder, nil
 }
 
-// waitForGatherComplete waits for the gather container to complete.
-func waitForGatherComplete(podBuilder *pod.Builder) error {
+// waitForGatherComplete waits for the pod to start running and then for the gather container to complete, returning an
+// error if ctx is done first.
+func waitForGatherComplete(ctx context.Context, podBuilder *pod.Builder) error {
 	klog.V(ranparam.LogLevel).Info("Waiting for must-gather to complete...")
 
-	err := podBuilder.WaitUntilRunning(mustGatherPodTimeout)
+	err := wait.PollUntilContextTimeout(
+		ctx, time.Second, mustGatherPodTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			if !podBuilder.Exists() {
+				return false, nil
+			}
+
+			return podBuilder.Object.Status.Phase == corev1.PodRunning, nil
+		})
 	if err != nil {
 		return fmt.Errorf("pod failed to start running: %w", err)
 	}
@@ -305,7 +312,7 @@ func waitForGatherComplete(podBuilder *pod.Builder) error {
 	// Poll until the gather container has terminated. We cannot use the pod condition since the copy container will
 	// stay running.
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 10*time.Second, mustGatherPodTimeout, true,
+		ctx, 10*time.Second, mustGatherPodTimeout, true,
 		func(ctx context.Context) (bool, error) {
 			if !podBuilder.Exists() {
 				return false, fmt.Errorf("pod no longer exists")
@@ -331,16 +338,38 @@ func waitForGatherComplete(podBuilder *pod.Builder) error {
 		})
 }
 
-// downloadMustGatherOutput copies the must-gather output from the pod to the local filesystem.
-func downloadMustGatherOutput(podBuilder *pod.Builder, tarballPath string) error {
+// downloadMustGatherOutput copies the must-gather output from the pod to the local filesystem. Since the pod copy does
+// not accept a context, it runs in the background and nothing is written if ctx is done before it finishes. Deleting
+// the must-gather namespace afterwards ends any copy still in progress.
+func downloadMustGatherOutput(ctx context.Context, podBuilder *pod.Builder, tarballPath string) error {
 	klog.V(ranparam.LogLevel).Infof("Downloading must-gather output to %s", tarballPath)
 
-	buffer, err := podBuilder.Copy(mustGatherOutputPath, copyContainerName, true)
-	if err != nil {
-		return fmt.Errorf("failed to copy from pod: %w", err)
+	type copyResult struct {
+		buffer bytes.Buffer
+		err    error
+	}
+
+	// Buffered so the goroutine can exit even if we stop waiting on it.
+	resultChannel := make(chan copyResult, 1)
+
+	go func() {
+		buffer, err := podBuilder.Copy(mustGatherOutputPath, copyContainerName, true)
+		resultChannel <- copyResult{buffer: buffer, err: err}
+	}()
+
+	var result copyResult
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("context done before copy from pod finished: %w", ctx.Err())
+	case result = <-resultChannel:
 	}
 
-	err = os.WriteFile(tarballPath, buffer.Bytes(), 0644)
+	if result.err != nil {
+		return fmt.Errorf("failed to copy from pod: %w", result.err)
+	}
+
+	err := os.WriteFile(tarballPath, result.buffer.Bytes(), 0644)
 	if err != nil {
 		return fmt.Errorf("failed to write tarball: %w", err)
 	}
@@ -348,8 +377,9 @@ func downloadMustGatherOutput(podBuilder *pod.Builder, tarballPath string) error
 	return nil
 }
 
-// cleanupMustGatherResources deletes the must-gather namespace and cluster role binding.
-func cleanupMustGatherResources(client *clients.Settings, nsName, crbName string) error {
+// cleanupMustGatherResources deletes the must-gather namespace and cluster role binding. When waitForNamespace is
+// false, the namespace deletion is started but not waited on.
+func cleanupMustGatherResources(client *clients.Settings, nsName, crbName string, waitForNamespace bool) error {
 	klog.V(ranparam.LogLevel).Infof("Cleaning up must-gather resources in namespace %s", nsName)
 
 	crbBuilder, err := rbac.PullClusterRoleBinding(client, crbName)
@@ -362,7 +392,12 @@ func cleanupMustGatherResources(client *clients.Settings, nsName, crbName string
 
 	nsBuilder, err := namespace.Pull(client, nsName)
 	if err == nil {
-		err = nsBuilder.DeleteAndWait(2 * time.Minute)
+		if waitForNamespace {
+			err = nsBuilder.DeleteAndWait(2 * time.Minute)
+		} else {
+			err = nsBuilder.Delete()
+		}
+
 		if err != nil {
 			return fmt.Errorf("failed to delete namespace: %w", err)
 		}
@@ -0,0 +1,540 @@
+package profiles
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LintSeverity is how likely a problem found while linting PtpConfigs is to cause test failures.
+type LintSeverity string
+
+const (
+	// LintSeverityError is used for problems that prevent the PTP daemon from synchronizing as intended, so tests
+	// are expected to fail for configuration reasons.
+	LintSeverityError LintSeverity = "error"
+	// LintSeverityWarning is used for problems that are likely mistakes but may be intended, such as recommends that
+	// do not match any node.
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// LintRule identifies the check that found a problem.
+type LintRule string
+
+const (
+	// LintRuleParse is used for profiles that cannot be parsed into a ProfileInfo.
+	LintRuleParse LintRule = "parse"
+	// LintRuleDuplicateProfile is used for profile names that appear more than once across PtpConfigs. The PTP
+	// operator assumes profile names are unique.
+	LintRuleDuplicateProfile LintRule = "duplicate-profile"
+	// LintRuleInterfaceConflict is used for interfaces claimed by more than one profile recommended to a node.
+	LintRuleInterfaceConflict LintRule = "interface-conflict"
+	// LintRuleClockRole is used for clientOnly and serverOnly settings that contradict each other or the profile
+	// type.
+	LintRuleClockRole LintRule = "clock-role"
+	// LintRuleDomainNumber is used for domain numbers that differ between ptp4l and phc2sys or across the profiles
+	// of a node.
+	LintRuleDomainNumber LintRule = "domain-number"
+	// LintRuleNICDriver is used for ts2phc and plugin settings that are not supported by the driver of the NIC.
+	LintRuleNICDriver LintRule = "nic-driver"
+	// LintRuleHAProfiles is used for HA profiles referencing profiles that do not exist or are not recommended to the
+	// same node.
+	LintRuleHAProfiles LintRule = "ha-profiles"
+	// LintRuleRecommend is used for recommends that are ignored by the operator or match no node.
+	LintRuleRecommend LintRule = "recommend"
+)
+
+// LintFinding is a single problem found while linting PtpConfigs.
+type LintFinding struct {
+	Severity LintSeverity
+	Rule     LintRule
+	// Config is the PtpConfig the problem was found in. It is empty for problems spanning multiple PtpConfigs.
+	Config runtimeclient.ObjectKey
+	// Profile is the name of the profile the problem was found in, if any.
+	Profile string
+	// Node is the name of the node the problem applies to, if it only applies to one node.
+	Node    string
+	Message string
+}
+
+// String returns the finding on a single line, starting with its severity and rule.
+func (finding LintFinding) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%s [%s]", finding.Severity, finding.Rule)
+
+	if finding.Config.Name != "" {
+		fmt.Fprintf(&builder, " config %s", finding.Config)
+	}
+
+	if finding.Profile != "" {
+		fmt.Fprintf(&builder, " profile %s", finding.Profile)
+	}
+
+	if finding.Node != "" {
+		fmt.Fprintf(&builder, " node %s", finding.Node)
+	}
+
+	fmt.Fprintf(&builder, ": %s", finding.Message)
+
+	return builder.String()
+}
+
+// LintErrors returns only the findings with LintSeverityError.
+func LintErrors(findings []LintFinding) []LintFinding {
+	return slices.DeleteFunc(slices.Clone(findings), func(finding LintFinding) bool {
+		return finding.Severity != LintSeverityError
+	})
+}
+
+// FormatLintFindings returns the findings one per line.
+func FormatLintFindings(findings []LintFinding) string {
+	lines := make([]string, 0, len(findings))
+
+	for _, finding := range findings {
+		lines = append(lines, finding.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// NICDriverGetter returns the driver of the interface ifName on the node nodeName, such as ice for Intel E810 NICs.
+type NICDriverGetter func(nodeName string, ifName iface.Name) (string, error)
+
+// LintCluster lints the PtpConfigs on the cluster of client against its nodes. NIC drivers are retrieved from the
+// nodes using iface.GetNICDriver. An error is only returned if the PtpConfigs or nodes cannot be listed.
+func LintCluster(client *clients.Settings) ([]LintFinding, error) {
+	ptpConfigList, err := ptp.ListPtpConfigs(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PtpConfigs for linting: %w", err)
+	}
+
+	nodeList, err := nodes.List(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes for linting: %w", err)
+	}
+
+	nodeDefinitions := make([]*corev1.Node, 0, len(nodeList))
+	for _, nodeBuilder := range nodeList {
+		nodeDefinitions = append(nodeDefinitions, nodeBuilder.Definition)
+	}
+
+	return LintPtpConfigs(ptpConfigList, nodeDefinitions, func(nodeName string, ifName iface.Name) (string, error) {
+		return iface.GetNICDriver(client, nodeName, ifName)
+	}), nil
+}
+
+// lintedProfile is a profile being linted along with the parsed configuration it is checked against.
+type lintedProfile struct {
+	reference      ProfileReference
+	profile        ptpv1.PtpProfile
+	info           *ProfileInfo
+	ptp4lSections  configSections
+	ts2phcSections configSections
+}
+
+// finding returns a finding for this profile.
+func (lintProfile *lintedProfile) finding(
+	severity LintSeverity, rule LintRule, nodeName, format string, args ...any) LintFinding {
+	return LintFinding{
+		Severity: severity,
+		Rule:     rule,
+		Config:   lintProfile.reference.ConfigReference,
+		Profile:  lintProfile.reference.ProfileName,
+		Node:     nodeName,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// LintPtpConfigs checks ptpConfigs for configuration problems that would cause PTP tests to fail, using the same
+// parsing and recommend matching as GetNodeInfoMap. The nodeList is used to determine which profiles apply to which
+// nodes. If getNICDriver is nil, the checks requiring NIC drivers are skipped. Findings are sorted by severity, rule,
+// config, profile, then node.
+func LintPtpConfigs(
+	ptpConfigs []*ptp.PtpConfigBuilder, nodeList []*corev1.Node, getNICDriver NICDriverGetter) []LintFinding {
+	lintProfiles, findings := parseLintProfiles(ptpConfigs)
+
+	findings = append(findings, lintRecommends(ptpConfigs, nodeList)...)
+
+	for _, lintProfile := range lintProfiles {
+		findings = append(findings, lintClockRoles(lintProfile)...)
+		findings = append(findings, lintPhc2sysDomain(lintProfile)...)
+	}
+
+	allRecommends := getAllRecommends(ptpConfigs)
+	driverCache := make(map[string]string)
+
+	for _, node := range nodeList {
+		var nodeProfiles []*lintedProfile
+
+		for reference := range getRecommendsForNode(node, allRecommends) {
+			if lintProfile, ok := lintProfiles[reference]; ok {
+				nodeProfiles = append(nodeProfiles, lintProfile)
+			}
+		}
+
+		if len(nodeProfiles) == 0 {
+			continue
+		}
+
+		slices.SortFunc(nodeProfiles, func(first, second *lintedProfile) int {
+			return cmp.Compare(first.reference.ProfileName, second.reference.ProfileName)
+		})
+
+		findings = append(findings, lintInterfaceConflicts(node.Name, nodeProfiles)...)
+		findings = append(findings, lintNodeDomains(node.Name, nodeProfiles)...)
+		findings = append(findings, lintHAProfiles(node.Name, nodeProfiles, lintProfiles)...)
+
+		if getNICDriver != nil {
+			findings = append(findings, lintNICDrivers(node.Name, nodeProfiles, getNICDriver, driverCache)...)
+		}
+	}
+
+	slices.SortStableFunc(findings, func(first, second LintFinding) int {
+		return cmp.Or(
+			cmp.Compare(first.Severity, second.Severity),
+			cmp.Compare(first.Rule, second.Rule),
+			cmp.Compare(first.Config.String(), second.Config.String()),
+			cmp.Compare(first.Profile, second.Profile),
+			cmp.Compare(first.Node, second.Node))
+	})
+
+	return findings
+}
+
+// parseLintProfiles parses every profile in ptpConfigs, returning the profiles that could be parsed keyed by their
+// reference, along with the findings for the ones that could not and for duplicate profile names.
+func parseLintProfiles(ptpConfigs []*ptp.PtpConfigBuilder) (map[ProfileReference]*lintedProfile, []LintFinding) {
+	lintProfiles := make(map[ProfileReference]*lintedProfile)
+	profileConfigs := make(map[string][]string)
+
+	var findings []LintFinding
+
+	for _, ptpConfig := range ptpConfigs {
+		configReference := runtimeclient.ObjectKeyFromObject(ptpConfig.Definition)
+
+		for profileIndex, profile := range ptpConfig.Definition.Spec.Profile {
+			if profile.Name == nil || *profile.Name == "" {
+				findings = append(findings, LintFinding{
+					Severity: LintSeverityError,
+					Rule:     LintRuleParse,
+					Config:   configReference,
+					Message:  fmt.Sprintf("profile at index %d has no name", profileIndex),
+				})
+
+				continue
+			}
+
+			profileConfigs[*profile.Name] = append(profileConfigs[*profile.Name], configReference.String())
+
+			lintProfile := &lintedProfile{
+				reference: ProfileReference{
+					ConfigReference: configReference,
+					ProfileIndex:    profileIndex,
+					ProfileName:     *profile.Name,
+				},
+				profile: profile,
+			}
+
+			profileInfo, err := parsePtpProfile(profile, lintProfile.reference)
+			if err != nil {
+				findings = append(findings, lintProfile.finding(LintSeverityError, LintRuleParse, "", "%v", err))
+
+				continue
+			}
+
+			lintProfile.info = profileInfo
+			lintProfile.ptp4lSections, _ = getSectionsFromPtp4lConf(ptr.Deref(profile.Ptp4lConf, ""))
+			lintProfile.ts2phcSections, _ = getSectionsFromPtp4lConf(ptr.Deref(profile.Ts2PhcConf, ""))
+			lintProfiles[lintProfile.reference] = lintProfile
+		}
+	}
+
+	for _, profileName := range slices.Sorted(maps.Keys(profileConfigs)) {
+		if configs := profileConfigs[profileName]; len(configs) > 1 {
+			findings = append(findings, LintFinding{
+				Severity: LintSeverityError,
+				Rule:     LintRuleDuplicateProfile,
+				Profile:  profileName,
+				Message:  fmt.Sprintf("profile name is used %d times, in %s", len(configs), strings.Join(configs, ", ")),
+			})
+		}
+	}
+
+	return lintProfiles, findings
+}
+
+// lintRecommends checks for recommends that the operator ignores and for recommends that match no node.
+func lintRecommends(ptpConfigs []*ptp.PtpConfigBuilder, nodeList []*corev1.Node) []LintFinding {
+	var findings []LintFinding
+
+	for _, ptpConfig := range ptpConfigs {
+		configReference := runtimeclient.ObjectKeyFromObject(ptpConfig.Definition)
+
+		for recommendIndex, recommend := range ptpConfig.Definition.Spec.Recommend {
+			finding := LintFinding{Rule: LintRuleRecommend, Config: configReference, Severity: LintSeverityError}
+			if recommend.Profile != nil {
+				finding.Profile = *recommend.Profile
+			}
+
+			switch {
+			case recommend.Profile == nil || recommend.Priority == nil:
+				finding.Message = fmt.Sprintf("recommend at index %d is missing its profile or priority", recommendIndex)
+			case len(recommend.Match) == 0:
+				finding.Severity = LintSeverityWarning
+				finding.Message = fmt.Sprintf("recommend at index %d has no match rules so it is ignored", recommendIndex)
+			case !slices.ContainsFunc(ptpConfig.Definition.Spec.Profile, func(profile ptpv1.PtpProfile) bool {
+				return profile.Name != nil && *profile.Name == *recommend.Profile
+			}):
+				finding.Message = fmt.Sprintf("recommend at index %d references a profile not in the PtpConfig",
+					recommendIndex)
+			case !slices.ContainsFunc(nodeList, func(node *corev1.Node) bool { return nodeMatches(node, recommend) }):
+				finding.Severity = LintSeverityWarning
+				finding.Message = fmt.Sprintf("recommend at index %d matches no node", recommendIndex)
+			default:
+				continue
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// lintClockRoles checks that the clientOnly and serverOnly settings of the interfaces of lintProfile agree with each
+// other and with the profile type.
+func lintClockRoles(lintProfile *lintedProfile) []LintFinding {
+	var findings []LintFinding
+
+	globalClientOnly := false
+	if globalSection, ok := lintProfile.ptp4lSections["global"]; ok {
+		globalClientOnly = globalSection["clientOnly"] == "1" || globalSection["slaveOnly"] == "1"
+	}
+
+	clientOnly := globalClientOnly || hasClientFlag(lintProfile.profile.Ptp4lOpts)
+
+	for _, sectionName := range slices.Sorted(maps.Keys(lintProfile.ptp4lSections)) {
+		if sectionName == "global" || sectionName == "unicast_master_table" {
+			continue
+		}
+
+		section := lintProfile.ptp4lSections[sectionName]
+		serverOnly := section["serverOnly"] == "1" || section["masterOnly"] == "1"
+
+		if clientOnly && serverOnly {
+			findings = append(findings, lintProfile.finding(LintSeverityError, LintRuleClockRole, "",
+				"interface %s is serverOnly but the profile is clientOnly", sectionName))
+		}
+
+		isGM := lintProfile.info.ProfileType == ProfileTypeGM || lintProfile.info.ProfileType == ProfileTypeMultiNICGM
+		if isGM && !serverOnly {
+			findings = append(findings, lintProfile.finding(LintSeverityError, LintRuleClockRole, "",
+				"interface %s of a grandmaster profile is not serverOnly (masterOnly)", sectionName))
+		}
+	}
+
+	return findings
+}
+
+// lintPhc2sysDomain checks that the domain phc2sys is configured to use matches the domain of ptp4l in the same
+// profile.
+func lintPhc2sysDomain(lintProfile *lintedProfile) []LintFinding {
+	if lintProfile.profile.Phc2sysOpts == nil || len(lintProfile.ptp4lSections) == 0 {
+		return nil
+	}
+
+	fields := strings.Fields(*lintProfile.profile.Phc2sysOpts)
+
+	index := slices.Index(fields, "-n")
+	if index == -1 || index+1 >= len(fields) {
+		return nil
+	}
+
+	ptp4lDomain := getDomainNumber(lintProfile.ptp4lSections)
+	if fields[index+1] == ptp4lDomain {
+		return nil
+	}
+
+	return []LintFinding{lintProfile.finding(LintSeverityError, LintRuleDomainNumber, "",
+		"phc2sys uses domain %s but ptp4l uses domain %s", fields[index+1], ptp4lDomain)}
+}
+
+// lintInterfaceConflicts checks that no interface is used by more than one of the profiles recommended to a node.
+func lintInterfaceConflicts(nodeName string, nodeProfiles []*lintedProfile) []LintFinding {
+	interfaceProfiles := make(map[iface.Name][]string)
+
+	for _, lintProfile := range nodeProfiles {
+		for ifName := range lintProfile.info.Interfaces {
+			interfaceProfiles[ifName] = append(interfaceProfiles[ifName], lintProfile.reference.ProfileName)
+		}
+	}
+
+	var findings []LintFinding
+
+	for _, ifName := range slices.Sorted(maps.Keys(interfaceProfiles)) {
+		if profileNames := interfaceProfiles[ifName]; len(profileNames) > 1 {
+			findings = append(findings, LintFinding{
+				Severity: LintSeverityError,
+				Rule:     LintRuleInterfaceConflict,
+				Node:     nodeName,
+				Message: fmt.Sprintf("interface %s is used by profiles %s",
+					ifName, strings.Join(profileNames, ", ")),
+			})
+		}
+	}
+
+	return findings
+}
+
+// lintNodeDomains checks that the ptp4l instances of all the profiles recommended to a node use the same domain.
+func lintNodeDomains(nodeName string, nodeProfiles []*lintedProfile) []LintFinding {
+	domainProfiles := make(map[string][]string)
+
+	for _, lintProfile := range nodeProfiles {
+		if len(lintProfile.ptp4lSections) == 0 {
+			continue
+		}
+
+		domain := getDomainNumber(lintProfile.ptp4lSections)
+		domainProfiles[domain] = append(domainProfiles[domain], lintProfile.reference.ProfileName)
+	}
+
+	if len(domainProfiles) < 2 {
+		return nil
+	}
+
+	var domains []string
+	for _, domain := range slices.Sorted(maps.Keys(domainProfiles)) {
+		domains = append(domains, fmt.Sprintf("%s (%s)", domain, strings.Join(domainProfiles[domain], ", ")))
+	}
+
+	return []LintFinding{{
+		Severity: LintSeverityWarning,
+		Rule:     LintRuleDomainNumber,
+		Node:     nodeName,
+		Message:  fmt.Sprintf("profiles use different domains: %s", strings.Join(domains, "; ")),
+	}}
+}
+
+// lintHAProfiles checks that the profiles referenced by the HA profiles recommended to a node exist and are also
+// recommended to the node.
+func lintHAProfiles(
+	nodeName string, nodeProfiles []*lintedProfile, allProfiles map[ProfileReference]*lintedProfile) []LintFinding {
+	var findings []LintFinding
+
+	for _, lintProfile := range nodeProfiles {
+		if lintProfile.info.ProfileType != ProfileTypeHA {
+			continue
+		}
+
+		for haProfileName := range strings.SplitSeq(lintProfile.profile.PtpSettings["haProfiles"], ",") {
+			haProfileName = strings.TrimSpace(haProfileName)
+			if haProfileName == "" {
+				continue
+			}
+
+			isNamed := func(profile *lintedProfile) bool { return profile.reference.ProfileName == haProfileName }
+
+			switch {
+			case slices.ContainsFunc(nodeProfiles, isNamed):
+				continue
+			case slices.ContainsFunc(slices.Collect(maps.Values(allProfiles)), isNamed):
+				findings = append(findings, lintProfile.finding(LintSeverityError, LintRuleHAProfiles, nodeName,
+					"HA profile references profile %s, which is not recommended to the node", haProfileName))
+			default:
+				findings = append(findings, lintProfile.finding(LintSeverityError, LintRuleHAProfiles, nodeName,
+					"HA profile references profile %s, which does not exist", haProfileName))
+			}
+		}
+	}
+
+	return findings
+}
+
+// iceDriverPlugins are the PTP daemon plugins that only support NICs using the ice driver.
+var iceDriverPlugins = []string{"e810", "e825"}
+
+// lintNICDrivers checks that the interfaces ts2phc uses in the profiles recommended to a node are on NICs whose driver
+// supports the GNSS and plugin settings of the profile. Drivers are cached in driverCache by node and interface.
+func lintNICDrivers(
+	nodeName string,
+	nodeProfiles []*lintedProfile,
+	getNICDriver NICDriverGetter,
+	driverCache map[string]string) []LintFinding {
+	var findings []LintFinding
+
+	for _, lintProfile := range nodeProfiles {
+		if len(lintProfile.ts2phcSections) == 0 {
+			continue
+		}
+
+		var reasons []string
+
+		if lintProfile.ts2phcSections["global"]["ts2phc.nmea_serialport"] != "" {
+			reasons = append(reasons, "a GNSS time source")
+		}
+
+		for _, plugin := range iceDriverPlugins {
+			if _, ok := lintProfile.profile.Plugins[plugin]; ok {
+				reasons = append(reasons, "the "+plugin+" plugin")
+			}
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+
+		for _, sectionName := range slices.Sorted(maps.Keys(lintProfile.ts2phcSections)) {
+			if sectionName == "global" || sectionName == "nmea" {
+				continue
+			}
+
+			cacheKey := nodeName + "/" + sectionName
+
+			driver, ok := driverCache[cacheKey]
+			if !ok {
+				var err error
+
+				driver, err = getNICDriver(nodeName, iface.Name(sectionName))
+				if err != nil {
+					findings = append(findings, lintProfile.finding(LintSeverityWarning, LintRuleNICDriver, nodeName,
+						"failed to get driver of ts2phc interface %s: %v", sectionName, err))
+
+					continue
+				}
+
+				driverCache[cacheKey] = driver
+			}
+
+			if driver != "ice" {
+				findings = append(findings, lintProfile.finding(LintSeverityError, LintRuleNICDriver, nodeName,
+					"ts2phc interface %s uses driver %q but %s requires an ice NIC",
+					sectionName, driver, strings.Join(reasons, " and ")))
+			}
+		}
+	}
+
+	return findings
+}
+
+// getDomainNumber returns the domainNumber of the global section of sections, or the ptp4l default of 0 if it is not
+// set.
+func getDomainNumber(sections configSections) string {
+	if domain := sections["global"]["domainNumber"]; domain != "" {
+		return domain
+	}
+
+	return "0"
+}
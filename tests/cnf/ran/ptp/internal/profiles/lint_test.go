@@ -0,0 +1,181 @@
+package profiles
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ptp"
+	ptpv1 "github.com/rh-ecosystem-edge/eco-goinfra/pkg/schemes/ptp/v1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testBCConf = `[ens7f0]
+masterOnly 0
+[ens7f1]
+masterOnly 1
+[global]
+domainNumber 24
+`
+	testOCConf = `[ens5f0]
+masterOnly 0
+[global]
+domainNumber 24
+`
+	testGMConf = `[ens2f0]
+masterOnly 1
+[ens2f1]
+masterOnly 0
+[global]
+domainNumber 24
+`
+	testTs2phcConf = `[nmea]
+ts2phc.master 1
+[global]
+ts2phc.nmea_serialport /dev/gnss0
+[ens2f0]
+ts2phc.extts_polarity rising
+`
+)
+
+// buildTestPtpConfig returns a PtpConfig builder named name with the provided profiles and recommends.
+func buildTestPtpConfig(
+	name string, profiles []ptpv1.PtpProfile, recommends []ptpv1.PtpRecommend) *ptp.PtpConfigBuilder {
+	return &ptp.PtpConfigBuilder{Definition: &ptpv1.PtpConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-ptp"},
+		Spec:       ptpv1.PtpConfigSpec{Profile: profiles, Recommend: recommends},
+	}}
+}
+
+// buildTestRecommend returns a recommend for profile matching nodeName.
+func buildTestRecommend(profile, nodeName string) ptpv1.PtpRecommend {
+	return ptpv1.PtpRecommend{
+		Profile:  ptr.To(profile),
+		Priority: ptr.To[int64](4),
+		Match:    []ptpv1.MatchRule{{NodeName: ptr.To(nodeName)}},
+	}
+}
+
+func TestLintPtpConfigs(t *testing.T) {
+	nodeList := []*corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}}
+
+	testCases := []struct {
+		name          string
+		ptpConfigs    []*ptp.PtpConfigBuilder
+		driver        string
+		expectedRules []LintRule
+	}{
+		{
+			name: "valid boundary clock",
+			ptpConfigs: []*ptp.PtpConfigBuilder{buildTestPtpConfig("bc", []ptpv1.PtpProfile{{
+				Name:        ptr.To("bc"),
+				Ptp4lConf:   ptr.To(testBCConf),
+				Phc2sysOpts: ptr.To("-a -r -n 24"),
+			}}, []ptpv1.PtpRecommend{buildTestRecommend("bc", "node-1")})},
+		},
+		{
+			name: "interface conflict and domain mismatch",
+			ptpConfigs: []*ptp.PtpConfigBuilder{
+				buildTestPtpConfig("bc", []ptpv1.PtpProfile{{Name: ptr.To("bc"), Ptp4lConf: ptr.To(testBCConf)}},
+					[]ptpv1.PtpRecommend{buildTestRecommend("bc", "node-1")}),
+				buildTestPtpConfig("oc", []ptpv1.PtpProfile{{
+					Name:        ptr.To("oc"),
+					Interface:   ptr.To("ens7f0"),
+					Ptp4lOpts:   ptr.To("-2 -s"),
+					Ptp4lConf:   ptr.To("[global]\ndomainNumber 0\n"),
+					Phc2sysOpts: ptr.To("-a -r -n 24"),
+				}}, []ptpv1.PtpRecommend{buildTestRecommend("oc", "node-1")}),
+			},
+			expectedRules: []LintRule{LintRuleDomainNumber, LintRuleInterfaceConflict, LintRuleDomainNumber},
+		},
+		{
+			name: "client only with server interface",
+			ptpConfigs: []*ptp.PtpConfigBuilder{buildTestPtpConfig("oc", []ptpv1.PtpProfile{{
+				Name:      ptr.To("oc"),
+				Ptp4lOpts: ptr.To("-2 -s"),
+				Ptp4lConf: ptr.To(testBCConf),
+			}}, []ptpv1.PtpRecommend{buildTestRecommend("oc", "node-1")})},
+			expectedRules: []LintRule{LintRuleClockRole},
+		},
+		{
+			name: "grandmaster on wrong driver with client interface",
+			ptpConfigs: []*ptp.PtpConfigBuilder{buildTestPtpConfig("gm", []ptpv1.PtpProfile{{
+				Name:       ptr.To("gm"),
+				Ptp4lConf:  ptr.To(testGMConf),
+				Ts2PhcConf: ptr.To(testTs2phcConf),
+				Plugins:    map[string]*apiextensions.JSON{"e810": {Raw: []byte("{}")}},
+			}}, []ptpv1.PtpRecommend{buildTestRecommend("gm", "node-1")})},
+			driver:        "mlx5_core",
+			expectedRules: []LintRule{LintRuleClockRole, LintRuleNICDriver},
+		},
+		{
+			name: "HA profile references",
+			ptpConfigs: []*ptp.PtpConfigBuilder{buildTestPtpConfig("ha", []ptpv1.PtpProfile{
+				{Name: ptr.To("bc"), Ptp4lConf: ptr.To(testBCConf)},
+				{Name: ptr.To("oc"), Ptp4lConf: ptr.To(testOCConf)},
+				{Name: ptr.To("ha"), PtpSettings: map[string]string{"haProfiles": "bc, oc,missing"}},
+			}, []ptpv1.PtpRecommend{buildTestRecommend("bc", "node-1"), buildTestRecommend("ha", "node-1")})},
+			expectedRules: []LintRule{LintRuleHAProfiles, LintRuleHAProfiles},
+		},
+		{
+			name: "recommends and duplicate profiles",
+			ptpConfigs: []*ptp.PtpConfigBuilder{
+				buildTestPtpConfig("first", []ptpv1.PtpProfile{{Name: ptr.To("oc"), Ptp4lConf: ptr.To(testOCConf)}},
+					[]ptpv1.PtpRecommend{buildTestRecommend("oc", "node-2"), buildTestRecommend("absent", "node-1")}),
+				buildTestPtpConfig("second", []ptpv1.PtpProfile{{Name: ptr.To("oc"), Ptp4lConf: ptr.To(testOCConf)}},
+					nil),
+			},
+			expectedRules: []LintRule{LintRuleDuplicateProfile, LintRuleRecommend, LintRuleRecommend},
+		},
+		{
+			name: "unparseable profile",
+			ptpConfigs: []*ptp.PtpConfigBuilder{buildTestPtpConfig("empty", []ptpv1.PtpProfile{{Name: ptr.To("empty")}},
+				nil)},
+			expectedRules: []LintRule{LintRuleParse},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			findings := LintPtpConfigs(testCase.ptpConfigs, nodeList, func(string, iface.Name) (string, error) {
+				return testCase.driver, nil
+			})
+
+			var rules []LintRule
+			for _, finding := range findings {
+				rules = append(rules, finding.Rule)
+			}
+
+			assert.Equal(t, testCase.expectedRules, rules, FormatLintFindings(findings))
+		})
+	}
+}
+
+func TestLintFinding(t *testing.T) {
+	ptpConfigs := []*ptp.PtpConfigBuilder{buildTestPtpConfig("gm", []ptpv1.PtpProfile{{
+		Name:       ptr.To("gm"),
+		Ptp4lConf:  ptr.To(testGMConf),
+		Ts2PhcConf: ptr.To(testTs2phcConf),
+	}}, []ptpv1.PtpRecommend{buildTestRecommend("gm", "node-1")})}
+	nodeList := []*corev1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}}
+
+	findings := LintPtpConfigs(ptpConfigs, nodeList, func(string, iface.Name) (string, error) {
+		return "", errors.New("ethtool failed")
+	})
+
+	assert.Len(t, findings, 2)
+	assert.Len(t, LintErrors(findings), 1)
+	assert.Equal(t,
+		"error [clock-role] config openshift-ptp/gm profile gm: "+
+			"interface ens2f1 of a grandmaster profile is not serverOnly (masterOnly)",
+		findings[0].String())
+	assert.Equal(t, LintSeverityWarning, findings[1].Severity)
+	assert.Contains(t, findings[1].Message, "ethtool failed")
+
+	assert.Empty(t, LintPtpConfigs(ptpConfigs, nodeList, nil)[1:])
+}
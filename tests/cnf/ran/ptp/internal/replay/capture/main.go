@@ -0,0 +1,263 @@
+/*
+Capture records the logs of the PTP daemon, cloud event proxy, and cloud event consumer on a node as a fixture for the
+replay package. The logs are retrieved with timestamps so they can be replayed in time order, and the data of the leap
+configmap for the node is saved alongside them. Expectations are not captured and should be added to the fixture.yaml
+of the fixture by hand before checking it in.
+
+Upon successful capture the exit code is 0. If any error occurs it will be logged to stderr and the exit code will be 1.
+
+Usage:
+
+	capture [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-k, -kubeconfig string
+		Path to the kubeconfig of the cluster to capture from. Uses the KUBECONFIG environment variable if left blank
+
+	-n, -node string
+		Name of the node to capture the logs of. Required
+
+	-o, -output string
+		Directory to write the fixture to. It is created if it does not exist. Required
+
+	-d, -description string
+		Description of what happened while the logs were captured, saved in the fixture.yaml
+
+	-s, -since duration
+		How far back from now to capture logs (default 10m0s)
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/configmap"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpdaemon"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/replay"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// leapConfigMapFileName is the name of the file in the fixture holding the data of the leap configmap for the node.
+const leapConfigMapFileName = "leap-configmap.txt"
+
+var (
+	help        bool
+	nodeName    string
+	output      string
+	description string
+	since       time.Duration
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage        = "Print this help message"
+		kubeconfigUsage  = "Path to the kubeconfig of the cluster. Uses the KUBECONFIG environment variable if left blank"
+		nodeUsage        = "Name of the node to capture the logs of. Required"
+		outputUsage      = "Directory to write the fixture to. It is created if it does not exist. Required"
+		descriptionUsage = "Description of what happened while the logs were captured, saved in the fixture.yaml"
+		sinceUsage       = "How far back from now to capture logs"
+
+		defaultHelp        = false
+		defaultNode        = ""
+		defaultOutput      = ""
+		defaultDescription = ""
+		defaultSince       = 10 * time.Minute
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+	klog.EnableContextualLogging(true)
+	logf.SetLogger(logr.Discard())
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	// The kubeconfig flag is already registered by controller-runtime when it is imported, so only the shorthand is
+	// registered here and shares its value.
+	flag.Var(flag.Lookup(config.KubeconfigFlagName).Value, "k", kubeconfigUsage+shorthand)
+
+	flag.StringVar(&nodeName, "node", defaultNode, nodeUsage)
+	flag.StringVar(&nodeName, "n", defaultNode, nodeUsage+shorthand)
+
+	flag.StringVar(&output, "output", defaultOutput, outputUsage)
+	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+
+	flag.StringVar(&description, "description", defaultDescription, descriptionUsage)
+	flag.StringVar(&description, "d", defaultDescription, descriptionUsage+shorthand)
+
+	flag.DurationVar(&since, "since", defaultSince, sinceUsage)
+	flag.DurationVar(&since, "s", defaultSince, sinceUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	err := capture()
+	if err != nil {
+		klog.Errorf("Failed to capture fixture: %v", err)
+
+		os.Exit(1)
+	}
+}
+
+// capture writes the logs of the containers on the node to the output directory, followed by the fixture metadata.
+func capture() error {
+	if nodeName == "" || output == "" {
+		return fmt.Errorf("both -node and -output must be provided")
+	}
+
+	kubeconfig := flag.Lookup(config.KubeconfigFlagName).Value.String()
+
+	client := clients.New(kubeconfig)
+	if client == nil {
+		return fmt.Errorf("failed to create client from kubeconfig %q", kubeconfig)
+	}
+
+	err := os.MkdirAll(output, 0o755)
+	if err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", output, err)
+	}
+
+	metadata := replay.Metadata{
+		Description: description,
+		Node:        nodeName,
+		CapturedAt:  time.Now().UTC().Truncate(time.Second),
+		Files:       map[string]string{},
+	}
+
+	daemonPod, err := ptpdaemon.GetPtpDaemonPodOnNode(client, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get PTP daemon pod on node %s: %w", nodeName, err)
+	}
+
+	for _, container := range []string{ranparam.PtpContainerName, ranparam.CloudEventProxyContainerName} {
+		if !hasContainer(daemonPod, container) {
+			klog.Warningf("PTP daemon pod %s has no %s container, skipping it", daemonPod.Definition.Name, container)
+
+			continue
+		}
+
+		err = captureLogs(daemonPod, container)
+		if err != nil {
+			return err
+		}
+	}
+
+	consumerPod, err := getConsumerPod(client)
+	if err != nil {
+		klog.Warningf("Skipping consumer logs: %v", err)
+	} else {
+		err = captureLogs(consumerPod, replay.ConsumerContainerName)
+		if err != nil {
+			return err
+		}
+	}
+
+	leapConfigMap, err := configmap.Pull(client, tsparams.LeapConfigmapName, ranparam.PtpOperatorNamespace)
+	if err != nil {
+		klog.Warningf("Skipping leap configmap: %v", err)
+	} else if leapData, ok := leapConfigMap.Definition.Data[nodeName]; ok {
+		err = writeFile(leapConfigMapFileName, []byte(leapData))
+		if err != nil {
+			return err
+		}
+
+		metadata.Files[replay.LeapConfigMapFile] = leapConfigMapFileName
+	}
+
+	metadataContent, err := yaml.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture metadata: %w", err)
+	}
+
+	return writeFile(replay.MetadataFileName, metadataContent)
+}
+
+// captureLogs writes the logs of container in capturedPod since the start of the capture window to the output
+// directory.
+func captureLogs(capturedPod *pod.Builder, container string) error {
+	klog.V(tsparams.LogLevel).Infof("Capturing logs of container %s in pod %s", container, capturedPod.Definition.Name)
+
+	logs, err := capturedPod.GetLogsWithOptions(&corev1.PodLogOptions{
+		Container:  container,
+		SinceTime:  &metav1.Time{Time: time.Now().Add(-since)},
+		Timestamps: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get logs of container %s in pod %s: %w", container, capturedPod.Definition.Name, err)
+	}
+
+	return writeFile(container+replay.LogFileSuffix, logs)
+}
+
+// getConsumerPod returns the pod running the cloud event consumer on the node.
+func getConsumerPod(client *clients.Settings) (*pod.Builder, error) {
+	podList, err := pod.List(client, tsparams.CloudEventsNamespace, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"spec.nodeName": nodeName}).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", tsparams.CloudEventsNamespace, err)
+	}
+
+	for _, consumerPod := range podList {
+		if hasContainer(consumerPod, replay.ConsumerContainerName) {
+			return consumerPod, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no pod with a %s container found on node %s", replay.ConsumerContainerName, nodeName)
+}
+
+// hasContainer returns whether the definition of builder has a container named container.
+func hasContainer(builder *pod.Builder, container string) bool {
+	return slices.ContainsFunc(builder.Definition.Spec.Containers, func(podContainer corev1.Container) bool {
+		return podContainer.Name == container
+	})
+}
+
+// writeFile writes content to the file named fileName in the output directory.
+func writeFile(fileName string, content []byte) error {
+	filePath := filepath.Join(output, fileName)
+
+	err := os.WriteFile(filePath, content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+
+	klog.Infof("Wrote %s", filePath)
+
+	return nil
+}
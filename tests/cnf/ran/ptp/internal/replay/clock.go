@@ -0,0 +1,52 @@
+package replay
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// FakeClock is a clock whose time only changes when it is stepped. Replayed logs are only visible up to the time of
+// the clock, so stepping it plays the logs back as if they were being written.
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// Assert at compile time that FakeClock implements clock.PassiveClock.
+var _ clock.PassiveClock = (*FakeClock)(nil)
+
+// NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (fakeClock *FakeClock) Now() time.Time {
+	fakeClock.mutex.Lock()
+	defer fakeClock.mutex.Unlock()
+
+	return fakeClock.now
+}
+
+// Since returns the time elapsed between since and the current time of the clock.
+func (fakeClock *FakeClock) Since(since time.Time) time.Duration {
+	return fakeClock.Now().Sub(since)
+}
+
+// Step moves the clock forward by duration.
+func (fakeClock *FakeClock) Step(duration time.Duration) {
+	fakeClock.mutex.Lock()
+	defer fakeClock.mutex.Unlock()
+
+	fakeClock.now = fakeClock.now.Add(duration)
+}
+
+// SetTime sets the clock to now, which may be before its current time.
+func (fakeClock *FakeClock) SetTime(now time.Time) {
+	fakeClock.mutex.Lock()
+	defer fakeClock.mutex.Unlock()
+
+	fakeClock.now = now
+}
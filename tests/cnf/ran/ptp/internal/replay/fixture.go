@@ -0,0 +1,308 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	eventptp "github.com/redhat-cne/sdk-go/pkg/event/ptp"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/iface"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// MetadataFileName is the name of the file in each fixture directory describing the fixture and what replaying
+	// it is expected to find.
+	MetadataFileName = "fixture.yaml"
+	// LogFileSuffix is the suffix of the files in a fixture directory holding container logs. The rest of the file
+	// name is the name of the container.
+	LogFileSuffix = ".log"
+	// ConsumerContainerName is the container name the logs of the cloud event consumer are stored under. The consumer
+	// runs in a separate pod, so it does not share a pod with the other containers of a fixture.
+	ConsumerContainerName = "cloud-event-consumer"
+	// LeapConfigMapFile is the key in Metadata.Files of the file holding the data of the leap configmap for the node.
+	LeapConfigMapFile = "leapConfigMap"
+)
+
+// Line is a single line of captured logs.
+type Line struct {
+	// Time is when the line was logged, as reported by the kubelet.
+	Time time.Time
+	// Text is the line as logged by the container, without the timestamp or trailing newline.
+	Text string
+}
+
+// Metadata describes a fixture. It is stored as MetadataFileName in the fixture directory.
+type Metadata struct {
+	// Description explains what happened while the logs were captured, such as the failure being reproduced.
+	Description string `yaml:"description"`
+	// Node is the name of the node the logs were captured from.
+	Node string `yaml:"node"`
+	// CapturedAt is when the logs were captured.
+	CapturedAt time.Time `yaml:"capturedAt,omitempty"`
+	// Versions are the versions of the components the logs were captured with, such as the PTP operator or
+	// linuxptp, keyed by component.
+	Versions map[string]string `yaml:"versions,omitempty"`
+	// Files are the extra files in the fixture directory keyed by what they hold, such as leapConfigMap for the data
+	// of the leap configmap.
+	Files map[string]string `yaml:"files,omitempty"`
+	// Expect is what replaying the fixture must find.
+	Expect Expectations `yaml:"expect,omitempty"`
+}
+
+// Expectations are the results the parsers must produce when replaying a fixture.
+type Expectations struct {
+	Events           []EventExpectation    `yaml:"events,omitempty"`
+	Logs             []LogExpectation      `yaml:"logs,omitempty"`
+	Stability        *StabilityExpectation `yaml:"stability,omitempty"`
+	LeapAnnouncement string                `yaml:"leapAnnouncement,omitempty"`
+}
+
+// EventExpectation is an event that must be extracted from the logs of a container. All the fields that are set must
+// match the same event.
+type EventExpectation struct {
+	// Container is the container whose logs hold the event, usually the consumer or the cloud event proxy.
+	Container string `yaml:"container"`
+	// Type is the type of the event, such as event.sync.ptp-status.ptp-state-change.
+	Type string `yaml:"type,omitempty"`
+	// SyncState is the sync state of one of the values of the event, such as FREERUN.
+	SyncState string `yaml:"syncState,omitempty"`
+	// Metric is the metric of one of the values of the event, such as the offset or clock class.
+	Metric *int64 `yaml:"metric,omitempty"`
+	// Interface is the interface or NIC name the values of the event must be for.
+	Interface string `yaml:"interface,omitempty"`
+	// Within is how long after the start of the fixture the event must have been logged by. It defaults to the
+	// whole fixture.
+	Within time.Duration `yaml:"within,omitempty"`
+	// Absent inverts the expectation so no event may match.
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+// LogExpectation is a line that must be found in the logs of a container. Exactly one of Contains, Regexp, or
+// ProfileLoad should be set.
+type LogExpectation struct {
+	// Container is the container whose logs hold the line.
+	Container string `yaml:"container"`
+	// Contains is a string the line must contain.
+	Contains string `yaml:"contains,omitempty"`
+	// Regexp is a regular expression the line must match.
+	Regexp string `yaml:"regexp,omitempty"`
+	// ProfileLoad is true when the line must be the one the PTP daemon logs when loading profiles.
+	ProfileLoad bool `yaml:"profileLoad,omitempty"`
+	// Within is how long after the start of the fixture the line must have been logged by. It defaults to the
+	// whole fixture.
+	Within time.Duration `yaml:"within,omitempty"`
+}
+
+// StabilityExpectation is the result of analyzing the stability of the PTP daemon logs.
+type StabilityExpectation struct {
+	// Container is the container whose logs are analyzed. It defaults to the PTP daemon container.
+	Container string `yaml:"container,omitempty"`
+	// ThresholdNanoseconds is the threshold the logs are analyzed with.
+	ThresholdNanoseconds int64 `yaml:"thresholdNanoseconds"`
+	// Passed is whether the analysis must pass.
+	Passed bool `yaml:"passed"`
+	// MinSamples is the minimum number of offset samples that must be parsed for each process. A parser that no
+	// longer recognizes the offset lines shows up as missing samples.
+	MinSamples map[string]int `yaml:"minSamples,omitempty"`
+	// FaultyLines is the number of lines containing FAULTY.
+	FaultyLines int `yaml:"faultyLines"`
+	// PTP4LStarts is the number of times ptp4l must have been started.
+	PTP4LStarts uint `yaml:"ptp4lStarts"`
+	// Details are strings that must each be contained in one of the failure details of the analysis.
+	Details []string `yaml:"details,omitempty"`
+}
+
+// Filter returns the events.EventFilter matching the event described by expectation. The sync state and metric are
+// usually reported in separate values of an event, so each must be in a value for the interface rather than both
+// being in the same value.
+func (expectation EventExpectation) Filter() events.EventFilter {
+	var filters []events.EventFilter
+
+	if expectation.Type != "" {
+		filters = append(filters, events.IsType(eventptp.EventType(expectation.Type)))
+	}
+
+	var valueFilters []events.ValueFilter
+
+	if expectation.SyncState != "" {
+		valueFilters = append(valueFilters, events.WithSyncState(eventptp.SyncState(expectation.SyncState)))
+	}
+
+	if expectation.Metric != nil {
+		valueFilters = append(valueFilters, events.WithMetric(*expectation.Metric))
+	}
+
+	for _, valueFilter := range valueFilters {
+		if expectation.Interface != "" {
+			filters = append(filters,
+				events.HasValue(valueFilter, events.OnInterface(iface.NICName(expectation.Interface))))
+		} else {
+			filters = append(filters, events.HasValue(valueFilter))
+		}
+	}
+
+	if len(valueFilters) == 0 && expectation.Interface != "" {
+		filters = append(filters, events.HasValue(events.OnInterface(iface.NICName(expectation.Interface))))
+	}
+
+	return events.All(filters...)
+}
+
+// Fixture is a set of logs captured from a single node, along with the extra files and metadata stored with them.
+type Fixture struct {
+	Metadata
+
+	// Name is the name of the fixture directory.
+	Name string
+	// Dir is the path of the fixture directory.
+	Dir string
+	// Logs are the captured lines of each container, keyed by container name and sorted by time.
+	Logs map[string][]Line
+}
+
+// LoadFixture loads the fixture stored in dir. The directory must contain MetadataFileName and may contain one log file
+// per container, named after the container with the LogFileSuffix. The logs must have been retrieved with timestamps.
+func LoadFixture(dir string) (*Fixture, error) {
+	metadataPath := filepath.Join(dir, MetadataFileName)
+
+	metadataContent, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture metadata %s: %w", metadataPath, err)
+	}
+
+	fixture := &Fixture{Name: filepath.Base(dir), Dir: dir, Logs: make(map[string][]Line)}
+
+	err = yaml.UnmarshalStrict(metadataContent, &fixture.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal fixture metadata %s: %w", metadataPath, err)
+	}
+
+	logPaths, err := filepath.Glob(filepath.Join(dir, "*"+LogFileSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list log files in %s: %w", dir, err)
+	}
+
+	for _, logPath := range logPaths {
+		logContent, err := os.ReadFile(logPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read log file %s: %w", logPath, err)
+		}
+
+		lines, err := ParseLogs(logContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log file %s: %w", logPath, err)
+		}
+
+		fixture.Logs[strings.TrimSuffix(filepath.Base(logPath), LogFileSuffix)] = lines
+	}
+
+	if len(fixture.Logs) == 0 {
+		return nil, fmt.Errorf("fixture %s has no %s files", dir, LogFileSuffix)
+	}
+
+	return fixture, nil
+}
+
+// LoadFixtures loads every fixture in the subdirectories of dir, sorted by name.
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures in %s: %w", dir, err)
+	}
+
+	var fixtures []*Fixture
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		fixture, err := LoadFixture(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		fixtures = append(fixtures, fixture)
+	}
+
+	return fixtures, nil
+}
+
+// ParseLogs parses logs retrieved with timestamps into lines sorted by time. Empty lines are skipped and every other
+// line must start with an RFC 3339 timestamp.
+func ParseLogs(logs []byte) ([]Line, error) {
+	var lines []Line
+
+	lineNumber := 0
+
+	for rawLine := range bytes.Lines(logs) {
+		lineNumber++
+
+		text := strings.TrimRight(string(rawLine), "\r\n")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		timestamp, message, _ := strings.Cut(text, " ")
+
+		lineTime, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("line %d does not start with a timestamp: %w", lineNumber, err)
+		}
+
+		lines = append(lines, Line{Time: lineTime, Text: message})
+	}
+
+	slices.SortStableFunc(lines, func(first, second Line) int {
+		return first.Time.Compare(second.Time)
+	})
+
+	return lines, nil
+}
+
+// Start returns the time of the earliest line in the fixture.
+func (fixture *Fixture) Start() time.Time {
+	var start time.Time
+
+	for _, lines := range fixture.Logs {
+		if len(lines) > 0 && (start.IsZero() || lines[0].Time.Before(start)) {
+			start = lines[0].Time
+		}
+	}
+
+	return start
+}
+
+// End returns the time of the latest line in the fixture.
+func (fixture *Fixture) End() time.Time {
+	var end time.Time
+
+	for _, lines := range fixture.Logs {
+		if len(lines) > 0 && lines[len(lines)-1].Time.After(end) {
+			end = lines[len(lines)-1].Time
+		}
+	}
+
+	return end
+}
+
+// ReadFile returns the content of the extra file stored under key in the metadata.
+func (fixture *Fixture) ReadFile(key string) (string, error) {
+	fileName, ok := fixture.Files[key]
+	if !ok {
+		return "", fmt.Errorf("fixture %s has no %s file", fixture.Name, key)
+	}
+
+	content, err := os.ReadFile(filepath.Join(fixture.Dir, fileName))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s file of fixture %s: %w", key, fixture.Name, err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,89 @@
+package replay
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/clock"
+)
+
+// Pod serves the logs of a fixture the way the kubelet serves the logs of a running pod, except only the lines logged
+// up to the current time of its clock are visible.
+type Pod struct {
+	fixture          *Fixture
+	clock            clock.PassiveClock
+	defaultContainer string
+}
+
+// Assert at compile time that Pod implements events.PodLogGetter.
+var _ events.PodLogGetter = (*Pod)(nil)
+
+// NewPod returns a Pod serving the logs of fixture as of the current time of passiveClock. The defaultContainer is used
+// when the log options do not specify a container.
+func NewPod(fixture *Fixture, passiveClock clock.PassiveClock, defaultContainer string) *Pod {
+	return &Pod{fixture: fixture, clock: passiveClock, defaultContainer: defaultContainer}
+}
+
+// GetLogsWithOptions returns the logs of the container in options. The SinceTime, SinceSeconds, TailLines, and
+// Timestamps options are honored, while the others are ignored.
+func (pod *Pod) GetLogsWithOptions(options *corev1.PodLogOptions) ([]byte, error) {
+	if options == nil {
+		options = &corev1.PodLogOptions{}
+	}
+
+	container := options.Container
+	if container == "" {
+		container = pod.defaultContainer
+	}
+
+	lines, ok := pod.fixture.Logs[container]
+	if !ok {
+		return nil, fmt.Errorf("fixture %s has no logs for container %q", pod.fixture.Name, container)
+	}
+
+	now := pod.clock.Now()
+
+	var since time.Time
+
+	switch {
+	case options.SinceTime != nil:
+		since = options.SinceTime.Time
+	case options.SinceSeconds != nil:
+		since = now.Add(-time.Duration(*options.SinceSeconds) * time.Second)
+	}
+
+	var visible []Line
+
+	for _, line := range lines {
+		if line.Time.After(now) {
+			break
+		}
+
+		if line.Time.Before(since) {
+			continue
+		}
+
+		visible = append(visible, line)
+	}
+
+	if options.TailLines != nil && int64(len(visible)) > *options.TailLines {
+		visible = visible[int64(len(visible))-*options.TailLines:]
+	}
+
+	var logs bytes.Buffer
+
+	for _, line := range visible {
+		if options.Timestamps {
+			logs.WriteString(line.Time.UTC().Format(time.RFC3339Nano))
+			logs.WriteByte(' ')
+		}
+
+		logs.WriteString(line.Text)
+		logs.WriteByte('\n')
+	}
+
+	return logs.Bytes(), nil
+}
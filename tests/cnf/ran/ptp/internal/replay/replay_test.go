@@ -0,0 +1,234 @@
+package replay
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/daemonlogs"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpleap"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/stability"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// pollInterval is how far the clock is stepped between checks while replaying, matching the interval the waits use on
+// a cluster.
+const pollInterval = 5 * time.Second
+
+func TestFixtures(t *testing.T) {
+	fixtures, err := LoadFixtures("testdata")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, fixtures)
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.Name, func(t *testing.T) {
+			for _, expectation := range fixture.Expect.Events {
+				replayEvent(t, fixture, expectation)
+			}
+
+			for _, expectation := range fixture.Expect.Logs {
+				replayLog(t, fixture, expectation)
+			}
+
+			if fixture.Expect.Stability != nil {
+				replayStability(t, fixture, *fixture.Expect.Stability)
+			}
+
+			if fixture.Expect.LeapAnnouncement != "" {
+				leapData, err := fixture.ReadFile(LeapConfigMapFile)
+				assert.Nil(t, err)
+
+				announcement, err := ptpleap.GetLastAnnouncement(leapData)
+				assert.Nil(t, err)
+				assert.Equal(t, fixture.Expect.LeapAnnouncement, announcement)
+			}
+		})
+	}
+}
+
+// replayEvent replays the logs of the container in expectation until the expected event is extracted or the time it
+// must be found within has been replayed.
+func replayEvent(t *testing.T, fixture *Fixture, expectation EventExpectation) {
+	t.Helper()
+
+	replayer := NewReplayer(fixture)
+	eventPod := replayer.Pod(expectation.Container)
+	filter := expectation.Filter()
+
+	within := withinOrEnd(fixture, expectation.Within)
+	if expectation.Absent {
+		replayer.Clock.SetTime(fixture.End())
+
+		receivedEvents, err := events.ListEvents(eventPod, fixture.Start())
+		assert.Nil(t, err)
+		assert.False(t, slices.ContainsFunc(receivedEvents, filter.Filter),
+			"unexpected event %+v in %s", expectation, expectation.Container)
+
+		return
+	}
+
+	elapsed, err := replayer.Poll(pollInterval, within, func() (bool, error) {
+		receivedEvents, err := events.ListEvents(eventPod, fixture.Start())
+
+		return slices.ContainsFunc(receivedEvents, filter.Filter), err
+	})
+	assert.Nil(t, err, "event %+v not found in %s", expectation, expectation.Container)
+
+	// Once found, WaitForEvent should also find the event on its first check.
+	err = events.WaitForEvent(eventPod, fixture.Start(), time.Second, filter)
+	assert.Nil(t, err, "WaitForEvent did not find event %+v after %s", expectation, elapsed)
+}
+
+// replayLog replays the logs of the container in expectation until a line matches or the time it must be found within
+// has been replayed.
+func replayLog(t *testing.T, fixture *Fixture, expectation LogExpectation) {
+	t.Helper()
+
+	var matcher daemonlogs.LogMatcher
+
+	switch {
+	case expectation.ProfileLoad:
+		matcher = daemonlogs.ProfileLoadMatcher()
+	case expectation.Regexp != "":
+		matcher = daemonlogs.RegexpMatcher(regexp.MustCompile(expectation.Regexp))
+	default:
+		matcher = daemonlogs.ContainsMatcher(expectation.Contains)
+	}
+
+	replayer := NewReplayer(fixture)
+	daemonPod := replayer.Pod(expectation.Container)
+
+	_, err := replayer.Poll(pollInterval, withinOrEnd(fixture, expectation.Within), func() (bool, error) {
+		logs, err := daemonPod.GetLogsWithOptions(&corev1.PodLogOptions{
+			SinceTime: &metav1.Time{Time: fixture.Start()},
+		})
+		if err != nil {
+			return false, err
+		}
+
+		_, found := daemonlogs.FindMatch(string(logs), matcher)
+
+		return found, nil
+	})
+	assert.Nil(t, err, "log line %+v not found in %s", expectation, expectation.Container)
+}
+
+// replayStability analyzes the stability of the entire logs of the container in expectation.
+func replayStability(t *testing.T, fixture *Fixture, expectation StabilityExpectation) {
+	t.Helper()
+
+	replayer := NewReplayer(fixture)
+	replayer.Clock.SetTime(fixture.End())
+
+	logPath := filepath.Join(t.TempDir(), "daemon.log")
+	err := replayer.WriteLogs(expectation.Container, logPath)
+	assert.Nil(t, err)
+
+	result, err := stability.AnalyzeFromFile(logPath, expectation.ThresholdNanoseconds)
+	assert.Nil(t, err)
+
+	assert.Equal(t, expectation.Passed, result.Passed, "stability details: %v", result.Details)
+	assert.Equal(t, expectation.FaultyLines, result.FaultyLineCount)
+	assert.Equal(t, expectation.PTP4LStarts, result.PTP4LStartCount)
+	assert.Empty(t, result.ParseWarnings)
+
+	for _, detail := range expectation.Details {
+		assert.True(t, slices.ContainsFunc(result.Details, func(resultDetail string) bool {
+			return strings.Contains(resultDetail, detail)
+		}), "stability details %v do not contain %q", result.Details, detail)
+	}
+
+	samples := map[string]int{
+		"ptp4l":   result.PTP4L.Stats.SampleCount,
+		"phc2sys": result.PHC2SYS.Stats.SampleCount,
+		"ts2phc":  result.TS2PHC.Stats.SampleCount,
+	}
+
+	for process, minSamples := range expectation.MinSamples {
+		assert.GreaterOrEqual(t, samples[process], minSamples, "too few %s samples parsed", process)
+	}
+}
+
+// withinOrEnd returns within, or the duration of the entire fixture if within is zero.
+func withinOrEnd(fixture *Fixture, within time.Duration) time.Duration {
+	if within > 0 {
+		return within
+	}
+
+	return fixture.End().Sub(fixture.Start())
+}
+
+func TestPodLogs(t *testing.T) {
+	fixture := &Fixture{
+		Name: "test",
+		Logs: map[string][]Line{
+			ranparam.PtpContainerName: {
+				{Time: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), Text: "first"},
+				{Time: time.Date(2025, 3, 1, 10, 0, 10, 0, time.UTC), Text: "second"},
+				{Time: time.Date(2025, 3, 1, 10, 0, 20, 0, time.UTC), Text: "third"},
+			},
+		},
+	}
+
+	replayer := NewReplayer(fixture)
+	pod := replayer.Pod(ranparam.PtpContainerName)
+
+	logs, err := pod.GetLogsWithOptions(&corev1.PodLogOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "first\n", string(logs))
+
+	replayer.Clock.Step(15 * time.Second)
+
+	logs, err = pod.GetLogsWithOptions(&corev1.PodLogOptions{Timestamps: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "2025-03-01T10:00:00Z first\n2025-03-01T10:00:10Z second\n", string(logs))
+
+	replayer.Clock.SetTime(fixture.End())
+
+	logs, err = pod.GetLogsWithOptions(&corev1.PodLogOptions{SinceSeconds: ptr.To[int64](10)})
+	assert.Nil(t, err)
+	assert.Equal(t, "second\nthird\n", string(logs))
+
+	logs, err = pod.GetLogsWithOptions(&corev1.PodLogOptions{TailLines: ptr.To[int64](1)})
+	assert.Nil(t, err)
+	assert.Equal(t, "third\n", string(logs))
+
+	_, err = pod.GetLogsWithOptions(&corev1.PodLogOptions{Container: "missing"})
+	assert.NotNil(t, err)
+}
+
+func TestPoll(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	replayer := NewReplayer(&Fixture{Logs: map[string][]Line{"test": {{Time: start}}}})
+
+	elapsed, err := replayer.Poll(pollInterval, time.Minute, func() (bool, error) {
+		return !replayer.Clock.Now().Before(start.Add(12 * time.Second)), nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Second, elapsed)
+	assert.Equal(t, 15*time.Second, replayer.Elapsed())
+
+	elapsed, err = replayer.Poll(pollInterval, 20*time.Second, func() (bool, error) { return false, nil })
+	assert.NotNil(t, err)
+	assert.Equal(t, 20*time.Second, elapsed)
+}
+
+func TestParseLogs(t *testing.T) {
+	lines, err := ParseLogs([]byte("2025-03-01T10:00:01.5Z second line\n\n2025-03-01T10:00:00Z first line\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []Line{
+		{Time: time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), Text: "first line"},
+		{Time: time.Date(2025, 3, 1, 10, 0, 1, 500000000, time.UTC), Text: "second line"},
+	}, lines)
+
+	_, err = ParseLogs([]byte("ptp4l[4312.100]: no timestamp\n"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,91 @@
+// Package replay feeds logs captured from the linuxptp daemon, cloud event proxy, and cloud event consumer back through
+// the parsers of the PTP suite without a cluster. Captured fixtures are checked in under testdata and replayed in unit
+// tests, so changes to the log formats, such as from a linuxptp upgrade, are caught before a lab run.
+//
+// Time during a replay is controlled by a [FakeClock]: the [Pod] returned by [Replayer.Pod] only serves the lines
+// logged up to the current time of the clock, and [Replayer.Poll] steps the clock rather than sleeping. Fixtures are
+// recorded from a cluster using the command in the capture directory.
+package replay
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Replayer plays the logs of a fixture back in time order.
+type Replayer struct {
+	// Fixture is the fixture being replayed.
+	Fixture *Fixture
+	// Clock controls which lines of the fixture are visible. It starts at the earliest line of the fixture.
+	Clock *FakeClock
+}
+
+// NewReplayer returns a Replayer for fixture with its clock at the start of the fixture.
+func NewReplayer(fixture *Fixture) *Replayer {
+	return &Replayer{Fixture: fixture, Clock: NewFakeClock(fixture.Start())}
+}
+
+// Pod returns a Pod serving the logs of the fixture as of the current time of the replayer clock. The defaultContainer
+// is used when the log options do not specify a container.
+func (replayer *Replayer) Pod(defaultContainer string) *Pod {
+	return NewPod(replayer.Fixture, replayer.Clock, defaultContainer)
+}
+
+// Elapsed returns how much time has been replayed since the start of the fixture.
+func (replayer *Replayer) Elapsed() time.Duration {
+	return replayer.Clock.Since(replayer.Fixture.Start())
+}
+
+// Poll runs condition immediately and then after stepping the clock by interval, until it returns true or an error,
+// or more than timeout has been replayed since Poll was called. It returns the replayed time when the condition was
+// met. Unlike polling on a cluster, no real time passes, so a timeout of several minutes replays instantly.
+func (replayer *Replayer) Poll(
+	interval, timeout time.Duration, condition func() (bool, error)) (time.Duration, error) {
+	if interval <= 0 {
+		return 0, fmt.Errorf("cannot poll with non-positive interval %s", interval)
+	}
+
+	start := replayer.Clock.Now()
+
+	for {
+		done, err := condition()
+		if err != nil {
+			return replayer.Clock.Since(start), err
+		}
+
+		if done {
+			return replayer.Clock.Since(start), nil
+		}
+
+		if replayer.Clock.Since(start)+interval > timeout {
+			return replayer.Clock.Since(start), fmt.Errorf("condition not met after replaying %s", timeout)
+		}
+
+		replayer.Clock.Step(interval)
+	}
+}
+
+// WriteLogs writes the lines of container logged up to the current time of the replayer clock to the file at path,
+// without timestamps. This is the same format daemonlogs.CollectDaemonLogs writes, so the file can be analyzed by the
+// stability package. It defaults to the PTP daemon container if container is empty.
+func (replayer *Replayer) WriteLogs(container, path string) error {
+	if container == "" {
+		container = ranparam.PtpContainerName
+	}
+
+	logs, err := replayer.Pod(container).GetLogsWithOptions(&corev1.PodLogOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, logs, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write logs of container %s to %s: %w", container, path, err)
+	}
+
+	return nil
+}
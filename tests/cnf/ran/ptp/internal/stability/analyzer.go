@@ -3,10 +3,15 @@ package stability
 import (
 	"bufio"
 	"fmt"
+	"maps"
+	"math"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/stats"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/processes"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
 	"k8s.io/klog/v2"
@@ -16,6 +21,9 @@ import (
 // intentionally positive, since it is meant for comparison with absolute offsets.
 const DefaultOffsetThresholdAbsoluteNanoseconds int64 = 100
 
+// lockedState is the servo state when the clock is locked.
+const lockedState = "s2"
+
 // OffsetStatistics captures descriptive statistics about absolute offsets over a set of samples. All offsets are in
 // nanoseconds.
 type OffsetStatistics struct {
@@ -25,14 +33,25 @@ type OffsetStatistics struct {
 	MinAbs int64
 	// AvgAbs is the average absolute offset in nanoseconds.
 	AvgAbs float64
+	// P50Abs is the median absolute offset in nanoseconds.
+	P50Abs int64
+	// P99Abs is the 99th percentile of the absolute offsets in nanoseconds.
+	P99Abs int64
+	// P999Abs is the 99.9th percentile of the absolute offsets in nanoseconds.
+	P999Abs int64
+	// LockedMean is the mean of the signed offsets in nanoseconds while the servo is locked. It approximates the
+	// constant time error of the clock.
+	LockedMean float64
 	// SampleCount is the number of samples used to compute the statistics.
 	SampleCount int
 
-	totalAbs float64
+	totalAbs     float64
+	absOffsets   []float64
+	lockedOffset []float64
 }
 
 // observe adds a new offset sample to the statistics.
-func (s *OffsetStatistics) observe(offset int64) {
+func (s *OffsetStatistics) observe(offset int64, state string) {
 	absoluteValue := abs(offset)
 
 	if s.SampleCount == 0 {
@@ -51,6 +70,31 @@ func (s *OffsetStatistics) observe(offset int64) {
 	s.totalAbs += float64(absoluteValue)
 	s.SampleCount++
 	s.AvgAbs = s.totalAbs / float64(s.SampleCount)
+	s.absOffsets = append(s.absOffsets, float64(absoluteValue))
+
+	if state == lockedState {
+		s.lockedOffset = append(s.lockedOffset, float64(offset))
+	}
+}
+
+// finalize computes the statistics that need all of the samples. The percentiles are always elements of the samples,
+// so they are exact integers.
+func (s *OffsetStatistics) finalize() {
+	if s.SampleCount == 0 {
+		return
+	}
+
+	p50, _ := stats.Percentile(s.absOffsets, 50)
+	p99, _ := stats.Percentile(s.absOffsets, 99)
+	p999, _ := stats.Percentile(s.absOffsets, 99.9)
+
+	s.P50Abs = int64(p50)
+	s.P99Abs = int64(p99)
+	s.P999Abs = int64(p999)
+
+	if len(s.lockedOffset) > 0 {
+		s.LockedMean, _ = stats.Mean(s.lockedOffset)
+	}
 }
 
 // StateTransition describes a servo state change between adjacent parsed entries.
@@ -65,6 +109,19 @@ type StateTransition struct {
 	Raw string
 }
 
+// ViolationBurst describes a run of consecutive locked samples whose absolute offset exceeds the threshold.
+type ViolationBurst struct {
+	// SampleCount is the number of samples in the burst.
+	SampleCount int
+	// Duration is the time from the first sample in the burst to the first sample after it, or to the last sample
+	// in the burst if the logs end during the burst.
+	Duration time.Duration
+	// Raw is the full raw log line of the first sample in the burst.
+	Raw string
+
+	start time.Duration
+}
+
 // ProcessResult groups per-process output fields from stability analysis.
 type ProcessResult struct {
 	// Stats is the descriptive statistics for the process's offsets.
@@ -73,15 +130,49 @@ type ProcessResult struct {
 	ThresholdViolationCount int
 	// StateTransitions is the servo state transitions between adjacent entries.
 	StateTransitions []StateTransition
-
-	name      string
-	pattern   *regexp.Regexp
-	threshold int64
+	// TimeInState is the time spent in each servo state, based on the timestamps of adjacent entries.
+	TimeInState map[string]time.Duration
+	// LongestViolationBurst is the longest run of consecutive threshold violations, by duration then by number of
+	// samples. It is zero-valued if there were no violations.
+	LongestViolationBurst ViolationBurst
+	// SamplingInterval is the median interval between locked samples. It is zero if the log lines have no
+	// timestamps, in which case StabilityPoints is empty.
+	SamplingInterval time.Duration
+	// StabilityPoints are the time-based stability metrics of the locked offsets for each observation interval
+	// with enough samples.
+	StabilityPoints []StabilityPoint
+
+	name    string
+	pattern *regexp.Regexp
+	limits  ProcessLimits
 
 	candidateLines int
 	droppedLines   int
 
-	prevState string
+	prevState        string
+	prevTimestamp    time.Duration
+	currentBurst     *ViolationBurst
+	lockedTimestamps []time.Duration
+}
+
+// newProcessResult creates a ProcessResult for the named process with the provided limits. The built-in patterns are
+// used for ptp4l, phc2sys, and ts2phc, while other processes are assumed to use the same format as ts2phc.
+func newProcessResult(name string, limits ProcessLimits) ProcessResult {
+	pattern, ok := map[string]*regexp.Regexp{
+		string(processes.Ptp4l):   ptp4lPattern,
+		string(processes.Phc2sys): phc2sysPattern,
+		string(processes.Ts2phc):  ts2phcPattern,
+	}[name]
+	if !ok {
+		pattern = newProcessPattern(name)
+	}
+
+	return ProcessResult{
+		TimeInState: make(map[string]time.Duration),
+		name:        name,
+		pattern:     pattern,
+		limits:      limits,
+	}
 }
 
 // processEntry tries to parse a log line and updates per-process accumulators if it matches.
@@ -101,21 +192,96 @@ func (p *ProcessResult) processEntry(line string) {
 		return
 	}
 
-	p.Stats.observe(result.Entry.Offset)
+	entry := result.Entry
 
-	if result.Entry.State == "s2" && abs(result.Entry.Offset) > p.threshold {
-		p.ThresholdViolationCount++
-	}
+	p.Stats.observe(entry.Offset, entry.State)
 
-	if p.prevState != "" && p.prevState != result.Entry.State {
+	if p.prevState != "" && p.prevState != entry.State {
 		p.StateTransitions = append(p.StateTransitions, StateTransition{
 			From: p.prevState,
-			To:   result.Entry.State,
-			Raw:  result.Entry.Raw,
+			To:   entry.State,
+			Raw:  entry.Raw,
 		})
 	}
 
-	p.prevState = result.Entry.State
+	if p.prevState != "" && entry.Timestamp > p.prevTimestamp {
+		p.TimeInState[p.prevState] += entry.Timestamp - p.prevTimestamp
+	}
+
+	if entry.State == lockedState {
+		p.lockedTimestamps = append(p.lockedTimestamps, entry.Timestamp)
+	}
+
+	p.trackViolations(entry)
+
+	p.prevState = entry.State
+	p.prevTimestamp = entry.Timestamp
+}
+
+// trackViolations counts entry as a violation if it is locked and over the threshold, tracking the burst of violations
+// it belongs to. Any other entry ends the current burst.
+func (p *ProcessResult) trackViolations(entry LogEntry) {
+	if entry.State != lockedState || abs(entry.Offset) <= p.limits.threshold() {
+		p.endBurst(entry.Timestamp)
+
+		return
+	}
+
+	p.ThresholdViolationCount++
+
+	if p.currentBurst == nil {
+		p.currentBurst = &ViolationBurst{Raw: entry.Raw, start: entry.Timestamp}
+	}
+
+	p.currentBurst.SampleCount++
+	p.currentBurst.Duration = max(0, entry.Timestamp-p.currentBurst.start)
+}
+
+// endBurst ends the current burst at endTimestamp, keeping it if it is the longest so far.
+func (p *ProcessResult) endBurst(endTimestamp time.Duration) {
+	if p.currentBurst == nil {
+		return
+	}
+
+	burst := *p.currentBurst
+	p.currentBurst = nil
+
+	if endTimestamp > burst.start {
+		burst.Duration = endTimestamp - burst.start
+	}
+
+	longest := p.LongestViolationBurst
+	if burst.Duration > longest.Duration ||
+		(burst.Duration == longest.Duration && burst.SampleCount > longest.SampleCount) {
+		p.LongestViolationBurst = burst
+	}
+}
+
+// finalize computes the statistics that need all of the samples. Bursts still in progress end at the last sample.
+func (p *ProcessResult) finalize(observationIntervals []time.Duration) {
+	if p.currentBurst != nil {
+		p.endBurst(p.currentBurst.start + p.currentBurst.Duration)
+	}
+
+	p.Stats.finalize()
+
+	p.SamplingInterval = estimateSamplingInterval(p.lockedTimestamps)
+	p.StabilityPoints = computeStabilityPoints(p.Stats.lockedOffset, p.SamplingInterval, observationIntervals)
+}
+
+// lockedRatio returns the fraction of the time spent in s2, or NaN if the logs have no timestamps.
+func (p *ProcessResult) lockedRatio() float64 {
+	var total time.Duration
+
+	for _, duration := range p.TimeInState {
+		total += duration
+	}
+
+	if total == 0 {
+		return math.NaN()
+	}
+
+	return float64(p.TimeInState[lockedState]) / float64(total)
 }
 
 // parseWarning returns a human-readable warning if any lines were dropped during parsing, or an empty string otherwise.
@@ -128,17 +294,86 @@ func (p *ProcessResult) parseWarning() string {
 		p.name, p.droppedLines, p.candidateLines)
 }
 
+// failureDetails returns one detail string per limit of the process that was exceeded.
+func (p *ProcessResult) failureDetails() []string {
+	var details []string
+
+	limits := p.limits
+
+	if p.Stats.SampleCount == 0 {
+		if limits.Required {
+			details = append(details, fmt.Sprintf("no %s delay logs parsed", p.name))
+		}
+
+		return details
+	}
+
+	if limits.MaxViolations != nil && p.ThresholdViolationCount > *limits.MaxViolations {
+		details = append(details, fmt.Sprintf(
+			"found %d %s s2 offset violations over threshold", p.ThresholdViolationCount, p.name))
+	}
+
+	if limits.MaxStateTransitions != nil && len(p.StateTransitions) > *limits.MaxStateTransitions {
+		details = append(details, fmt.Sprintf("found %d %s state transitions", len(p.StateTransitions), p.name))
+	}
+
+	if limits.MaxMeanOffset > 0 && math.Abs(p.Stats.LockedMean) > limits.MaxMeanOffset {
+		details = append(details, fmt.Sprintf("%s locked mean offset %.3f ns exceeds %.3f ns",
+			p.name, p.Stats.LockedMean, limits.MaxMeanOffset))
+	}
+
+	if limits.MaxP99AbsOffset > 0 && p.Stats.P99Abs > limits.MaxP99AbsOffset {
+		details = append(details, fmt.Sprintf("%s p99 absolute offset %d ns exceeds %d ns",
+			p.name, p.Stats.P99Abs, limits.MaxP99AbsOffset))
+	}
+
+	if limits.MaxP999AbsOffset > 0 && p.Stats.P999Abs > limits.MaxP999AbsOffset {
+		details = append(details, fmt.Sprintf("%s p99.9 absolute offset %d ns exceeds %d ns",
+			p.name, p.Stats.P999Abs, limits.MaxP999AbsOffset))
+	}
+
+	if limits.MaxViolationBurst > 0 && p.LongestViolationBurst.Duration > limits.MaxViolationBurst {
+		details = append(details, fmt.Sprintf("%s longest burst of offset violations lasted %s, exceeding %s",
+			p.name, p.LongestViolationBurst.Duration, limits.MaxViolationBurst))
+	}
+
+	if lockedRatio := p.lockedRatio(); limits.MinLockedRatio > 0 && lockedRatio < limits.MinLockedRatio {
+		details = append(details, fmt.Sprintf("%s spent %.2f%% of the time in s2, below %.2f%%",
+			p.name, 100*lockedRatio, 100*limits.MinLockedRatio))
+	}
+
+	for _, point := range p.StabilityPoints {
+		if limit := limitAt(limits.MTIEMask, point.ObservationInterval); limit > 0 && point.MTIE > limit {
+			details = append(details, fmt.Sprintf("%s MTIE %.3f ns at %s exceeds mask limit %.3f ns",
+				p.name, point.MTIE, point.ObservationInterval, limit))
+		}
+
+		if limit := limitAt(limits.TDEVMask, point.ObservationInterval); limit > 0 && point.TDEV > limit {
+			details = append(details, fmt.Sprintf("%s TDEV %.3f ns at %s exceeds mask limit %.3f ns",
+				p.name, point.TDEV, point.ObservationInterval, limit))
+		}
+	}
+
+	return details
+}
+
 // AnalysisResult is the pass/fail decision output of stability analysis.
 type AnalysisResult struct {
 	// Passed is true if the analysis passed, false otherwise. It counts as passed if there are no failure details.
 	Passed bool
 	// Details is a list of failure detail messages. It is empty if the analysis passed.
 	Details []string
+	// Profile is the name of the threshold profile the verdict is based on.
+	Profile string
 
 	// PTP4L is the per-process result for ptp4l.
 	PTP4L ProcessResult
 	// PHC2SYS is the per-process result for phc2sys.
 	PHC2SYS ProcessResult
+	// TS2PHC is the per-process result for ts2phc.
+	TS2PHC ProcessResult
+	// Others is the per-process result for the other processes in the threshold profile, keyed by process name.
+	Others map[string]*ProcessResult
 
 	// PTP4LStartCount is the number of times the ptp4l process was started.
 	PTP4LStartCount uint
@@ -150,32 +385,47 @@ type AnalysisResult struct {
 	// ParseWarnings is a list of warnings that occurred during parsing. These are warnings where the log lines
 	// could not be parsed into log entries.
 	ParseWarnings []string
+
+	profile ThresholdProfile
 }
 
-// AnalyzeFromFile performs a single-pass streaming analysis of the daemon log file at filePath. It reads the file line
-// by line to keep memory bounded regardless of file size.
+// AnalyzeFromFile performs a single-pass streaming analysis of the daemon log file at filePath using the default
+// threshold profile with the provided threshold.
 func AnalyzeFromFile(filePath string, thresholdAbsoluteNanoseconds int64) (AnalysisResult, error) {
+	return AnalyzeFromFileWithProfile(filePath, DefaultThresholdProfile(thresholdAbsoluteNanoseconds))
+}
+
+// AnalyzeFromFileWithProfile performs a single-pass streaming analysis of the daemon log file at filePath, deciding
+// whether it passes using profile. It reads the file line by line, so memory only grows with the number of offset
+// samples rather than the size of the file.
+func AnalyzeFromFileWithProfile(filePath string, profile ThresholdProfile) (AnalysisResult, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return AnalysisResult{}, fmt.Errorf("failed to open log file %s: %w", filePath, err)
 	}
 	defer file.Close()
 
-	if thresholdAbsoluteNanoseconds <= 0 {
-		thresholdAbsoluteNanoseconds = DefaultOffsetThresholdAbsoluteNanoseconds
+	err = profile.validate()
+	if err != nil {
+		return AnalysisResult{}, fmt.Errorf("invalid threshold profile %s: %w", profile.Name, err)
 	}
 
 	result := AnalysisResult{
-		PTP4L: ProcessResult{
-			name:      string(processes.Ptp4l),
-			pattern:   ptp4lPattern,
-			threshold: thresholdAbsoluteNanoseconds,
-		},
-		PHC2SYS: ProcessResult{
-			name:      string(processes.Phc2sys),
-			pattern:   phc2sysPattern,
-			threshold: thresholdAbsoluteNanoseconds,
-		},
+		Profile: profile.Name,
+		PTP4L:   newProcessResult(string(processes.Ptp4l), profile.Processes[string(processes.Ptp4l)]),
+		PHC2SYS: newProcessResult(string(processes.Phc2sys), profile.Processes[string(processes.Phc2sys)]),
+		TS2PHC:  newProcessResult(string(processes.Ts2phc), profile.Processes[string(processes.Ts2phc)]),
+		Others:  make(map[string]*ProcessResult),
+		profile: profile,
+	}
+
+	for name, limits := range profile.Processes {
+		if name == result.PTP4L.name || name == result.PHC2SYS.name || name == result.TS2PHC.name {
+			continue
+		}
+
+		processResult := newProcessResult(name, limits)
+		result.Others[name] = &processResult
 	}
 
 	scanner := bufio.NewScanner(file)
@@ -194,6 +444,18 @@ func AnalyzeFromFile(filePath string, thresholdAbsoluteNanoseconds int64) (Analy
 	return result, nil
 }
 
+// processResults returns pointers to the result of every analyzed process, starting with ptp4l, phc2sys, and ts2phc,
+// followed by the other processes sorted by name.
+func (a *AnalysisResult) processResults() []*ProcessResult {
+	results := []*ProcessResult{&a.PTP4L, &a.PHC2SYS, &a.TS2PHC}
+
+	for _, name := range slices.Sorted(maps.Keys(a.Others)) {
+		results = append(results, a.Others[name])
+	}
+
+	return results
+}
+
 // processLine parses and accumulates a single log line.
 func (a *AnalysisResult) processLine(line string) {
 	if containsFaulty(line) {
@@ -208,14 +470,24 @@ func (a *AnalysisResult) processLine(line string) {
 		a.PTP4LStartCount++
 	}
 
-	a.PTP4L.processEntry(line)
-	a.PHC2SYS.processEntry(line)
+	for _, processResult := range a.processResults() {
+		processResult.processEntry(line)
+	}
 }
 
 // finalize processes the accumulated data and determines the pass/fail decision.
 func (a *AnalysisResult) finalize() {
+	observationIntervals := a.profile.ObservationIntervals
+	if len(observationIntervals) == 0 {
+		observationIntervals = DefaultObservationIntervals
+	}
+
+	for _, processResult := range a.processResults() {
+		processResult.finalize(observationIntervals)
+	}
+
 	a.ParseWarnings = a.buildParseWarnings()
-	a.Details = buildFailureDetails(*a)
+	a.Details = buildFailureDetails(a)
 	a.Passed = len(a.Details) == 0
 }
 
@@ -223,12 +495,10 @@ func (a *AnalysisResult) finalize() {
 func (a *AnalysisResult) buildParseWarnings() []string {
 	var warnings []string
 
-	if w := a.PTP4L.parseWarning(); w != "" {
-		warnings = append(warnings, w)
-	}
-
-	if w := a.PHC2SYS.parseWarning(); w != "" {
-		warnings = append(warnings, w)
+	for _, processResult := range a.processResults() {
+		if w := processResult.parseWarning(); w != "" {
+			warnings = append(warnings, w)
+		}
 	}
 
 	return warnings
@@ -258,50 +528,41 @@ func (a AnalysisResult) DiagnosticMessage() string {
 		}
 	}
 
-	builder.WriteByte('\n')
-	builder.WriteString(formatStatsLine("ptp4l", a.PTP4L.Stats))
-	builder.WriteByte('\n')
-	builder.WriteString(formatStatsLine("phc2sys", a.PHC2SYS.Stats))
+	fmt.Fprintf(&builder, "\nthreshold_profile=%s", a.Profile)
+
+	for _, processResult := range a.processResults() {
+		// Processes that are not running, such as ts2phc on a boundary clock, are left out to reduce noise.
+		if processResult.Stats.SampleCount == 0 && !processResult.limits.Required {
+			continue
+		}
+
+		builder.WriteByte('\n')
+		builder.WriteString(formatStatsLine(processResult.name, processResult.Stats))
+		builder.WriteString(formatTimingLines(processResult))
+	}
+
 	fmt.Fprintf(&builder, "\nptp4l_start_count=%d", a.PTP4LStartCount)
 
 	return builder.String()
 }
 
 // buildFailureDetails collects one detail string per stability check that failed.
-func buildFailureDetails(result AnalysisResult) []string {
+func buildFailureDetails(result *AnalysisResult) []string {
 	var details []string
 
-	if result.PTP4L.Stats.SampleCount == 0 {
-		details = append(details, "no ptp4l delay logs parsed")
-	}
-
-	if result.PHC2SYS.Stats.SampleCount == 0 {
-		details = append(details, "no phc2sys delay logs parsed")
+	for _, processResult := range result.processResults() {
+		details = append(details, processResult.failureDetails()...)
 	}
 
-	if result.FaultyLineCount > 0 {
+	if result.FaultyLineCount > 0 && !result.profile.AllowFaultyLines {
 		details = append(details, fmt.Sprintf("found %d lines containing FAULTY", result.FaultyLineCount))
 	}
 
-	if result.TimeoutLineCount > 0 {
+	if result.TimeoutLineCount > 0 && !result.profile.AllowTimeoutLines {
 		details = append(details, fmt.Sprintf("found %d lines containing timeout", result.TimeoutLineCount))
 	}
 
-	if result.PTP4L.ThresholdViolationCount > 0 {
-		details = append(details,
-			fmt.Sprintf("found %d ptp4l s2 offset violations over threshold", result.PTP4L.ThresholdViolationCount))
-	}
-
-	if result.PHC2SYS.ThresholdViolationCount > 0 {
-		details = append(details, fmt.Sprintf(
-			"found %d phc2sys s2 offset violations over threshold", result.PHC2SYS.ThresholdViolationCount))
-	}
-
-	if len(result.PTP4L.StateTransitions) > 0 {
-		details = append(details, fmt.Sprintf("found %d ptp4l state transitions", len(result.PTP4L.StateTransitions)))
-	}
-
-	if result.PTP4LStartCount > 1 {
+	if result.PTP4LStartCount > result.profile.MaxPTP4LRestarts+1 {
 		details = append(details, fmt.Sprintf("found %d ptp4l restarts", result.PTP4LStartCount-1))
 	}
 
@@ -310,8 +571,36 @@ func buildFailureDetails(result AnalysisResult) []string {
 
 // formatStatsLine renders an OffsetStatistics value as a single key=value diagnostic line.
 func formatStatsLine(process string, stats OffsetStatistics) string {
-	return fmt.Sprintf("%s_offsets_max_abs=%d min_abs=%d avg_abs=%.3f samples=%d",
-		process, stats.MaxAbs, stats.MinAbs, stats.AvgAbs, stats.SampleCount)
+	return fmt.Sprintf(
+		"%s_offsets_max_abs=%d min_abs=%d avg_abs=%.3f p50_abs=%d p99_abs=%d p99.9_abs=%d locked_mean=%.3f samples=%d",
+		process, stats.MaxAbs, stats.MinAbs, stats.AvgAbs, stats.P50Abs, stats.P99Abs, stats.P999Abs,
+		stats.LockedMean, stats.SampleCount)
+}
+
+// formatTimingLines renders the time-based results of a process as key=value diagnostic lines, each starting with a
+// newline. Results that are not available, such as when the logs have no timestamps, are left out.
+func formatTimingLines(processResult *ProcessResult) string {
+	var builder strings.Builder
+
+	if len(processResult.TimeInState) > 0 {
+		fmt.Fprintf(&builder, "\n%s_time_in_state", processResult.name)
+
+		for _, state := range slices.Sorted(maps.Keys(processResult.TimeInState)) {
+			fmt.Fprintf(&builder, " %s=%s", state, processResult.TimeInState[state])
+		}
+	}
+
+	if burst := processResult.LongestViolationBurst; burst.SampleCount > 0 {
+		fmt.Fprintf(&builder, "\n%s_longest_violation_burst duration=%s samples=%d",
+			processResult.name, burst.Duration, burst.SampleCount)
+	}
+
+	for _, point := range processResult.StabilityPoints {
+		fmt.Fprintf(&builder, "\n%s_stability tau=%s adev=%.3e mtie=%.3f tdev=%.3f",
+			processResult.name, point.ObservationInterval, point.ADEV, point.MTIE, point.TDEV)
+	}
+
+	return builder.String()
 }
 
 // abs returns the absolute value of an int64. It ignores the possibility of overflow since it is not applicable to the
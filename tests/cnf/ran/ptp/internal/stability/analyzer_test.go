@@ -0,0 +1,230 @@
+package stability
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+// ptp4lTestLine returns a ptp4l delay log line at timestamp seconds with the provided offset and servo state.
+func ptp4lTestLine(timestamp float64, offset int, state string) string {
+	return fmt.Sprintf("ptp4l[%.3f]: [ptp4l.0.config:6] master offset %10d %s freq  -94379 path delay       161",
+		timestamp, offset, state)
+}
+
+// phc2sysTestLine returns a phc2sys delay log line at timestamp seconds with the provided offset and servo state.
+func phc2sysTestLine(timestamp float64, offset int, state string) string {
+	return fmt.Sprintf(
+		"phc2sys[%.3f]: [ptp4l.0.config:6] CLOCK_REALTIME phc offset %9d %s freq  -19334 delay    470",
+		timestamp, offset, state)
+}
+
+// ts2phcTestLine returns a ts2phc offset log line at timestamp seconds with the provided offset and servo state.
+func ts2phcTestLine(timestamp float64, offset int, state string) string {
+	return fmt.Sprintf("ts2phc[%.3f]: [ts2phc.0.config:6] ens7f0 master offset %10d %s freq      +3",
+		timestamp, offset, state)
+}
+
+// writeTestLog writes lines to a log file in a temporary directory and returns its path.
+func writeTestLog(t *testing.T, lines []string) string {
+	t.Helper()
+
+	logPath := filepath.Join(t.TempDir(), "daemon.log")
+	err := os.WriteFile(logPath, []byte(strings.Join(lines, "\n")+"\n"), 0644)
+	assert.Nil(t, err)
+
+	return logPath
+}
+
+func TestAnalyzeFromFile(t *testing.T) {
+	testCases := []struct {
+		name            string
+		lines           []string
+		expectedPassed  bool
+		expectedDetails []string
+	}{
+		{
+			name: "stable",
+			lines: []string{
+				"Starting ptp4l",
+				ptp4lTestLine(1, 5, "s2"),
+				phc2sysTestLine(1, -3, "s2"),
+				ptp4lTestLine(2, -4, "s2"),
+				phc2sysTestLine(2, 2, "s2"),
+			},
+			expectedPassed: true,
+		},
+		{
+			name:            "missing phc2sys",
+			lines:           []string{ptp4lTestLine(1, 5, "s2")},
+			expectedPassed:  false,
+			expectedDetails: []string{"no phc2sys delay logs parsed"},
+		},
+		{
+			name: "violations and transitions",
+			lines: []string{
+				ptp4lTestLine(1, 5, "s1"),
+				ptp4lTestLine(2, 500, "s2"),
+				phc2sysTestLine(2, 2, "s2"),
+				ts2phcTestLine(2, 200, "s2"),
+			},
+			expectedPassed: false,
+			expectedDetails: []string{
+				"found 1 ptp4l s2 offset violations over threshold",
+				"found 1 ptp4l state transitions",
+				"found 1 ts2phc s2 offset violations over threshold",
+			},
+		},
+		{
+			name: "faulty, timeout, and restarts",
+			lines: []string{
+				"Starting ptp4l",
+				ptp4lTestLine(1, 5, "s2"),
+				phc2sysTestLine(1, 2, "s2"),
+				"ptp4l[1.5]: [ptp4l.0.config:6] port 1: FAULTY",
+				"ptp4l[1.6]: [ptp4l.0.config:6] timed out while polling for tx timestamp",
+				"ptp4l[1.7]: [ptp4l.0.config:6] increasing tx_timestamp_timeout may correct this issue",
+				"Starting ptp4l",
+			},
+			expectedPassed: false,
+			expectedDetails: []string{
+				"found 1 lines containing FAULTY",
+				"found 1 lines containing timeout",
+				"found 1 ptp4l restarts",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := AnalyzeFromFile(writeTestLog(t, testCase.lines), 100)
+			assert.Nil(t, err)
+
+			assert.Equal(t, testCase.expectedPassed, result.Passed, result.DiagnosticMessage())
+			assert.Equal(t, testCase.expectedDetails, result.Details)
+			assert.Equal(t, "default", result.Profile)
+		})
+	}
+
+	_, err := AnalyzeFromFile(filepath.Join(t.TempDir(), "missing.log"), 100)
+	assert.NotNil(t, err)
+}
+
+func TestAnalyzeFromFileWithProfileTiming(t *testing.T) {
+	lines := []string{
+		ptp4lTestLine(0, 5, "s1"),
+		ptp4lTestLine(2, 5, "s2"),
+		ptp4lTestLine(3, 150, "s2"),
+		ptp4lTestLine(4, -160, "s2"),
+		ptp4lTestLine(5, 10, "s2"),
+		ptp4lTestLine(6, 120, "s2"),
+		ptp4lTestLine(7, 10, "s2"),
+		ptp4lTestLine(8, 10, "s2"),
+	}
+
+	profile := ThresholdProfile{
+		Name: "timing",
+		Processes: map[string]ProcessLimits{
+			"ptp4l": {
+				Required:          true,
+				MaxViolations:     ptr.To(3),
+				MaxViolationBurst: time.Second,
+				MinLockedRatio:    0.9,
+			},
+		},
+	}
+
+	result, err := AnalyzeFromFileWithProfile(writeTestLog(t, lines), profile)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 3, result.PTP4L.ThresholdViolationCount)
+	assert.Equal(t, map[string]time.Duration{"s1": 2 * time.Second, "s2": 6 * time.Second}, result.PTP4L.TimeInState)
+	assert.Equal(t, 2, result.PTP4L.LongestViolationBurst.SampleCount)
+	assert.Equal(t, 2*time.Second, result.PTP4L.LongestViolationBurst.Duration)
+	assert.Equal(t, time.Second, result.PTP4L.SamplingInterval)
+	assert.Equal(t, int64(10), result.PTP4L.Stats.P50Abs)
+	assert.Equal(t, int64(160), result.PTP4L.Stats.P99Abs)
+
+	assert.False(t, result.Passed)
+	assert.Equal(t, []string{
+		"ptp4l longest burst of offset violations lasted 2s, exceeding 1s",
+		"ptp4l spent 75.00% of the time in s2, below 90.00%",
+	}, result.Details)
+
+	assert.Contains(t, result.DiagnosticMessage(), "threshold_profile=timing")
+	assert.Contains(t, result.DiagnosticMessage(), "ptp4l_time_in_state s1=2s s2=6s")
+	assert.NotContains(t, result.DiagnosticMessage(), "phc2sys_offsets")
+}
+
+func TestGetThresholdProfile(t *testing.T) {
+	profile, err := GetThresholdProfile("", 50)
+	assert.Nil(t, err)
+	assert.Equal(t, "default", profile.Name)
+	assert.Equal(t, int64(50), profile.Processes["ptp4l"].MaxAbsOffset)
+
+	profile, err = GetThresholdProfile("g.8273.2-class-c", 50)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(30), profile.Processes["ptp4l"].MaxAbsOffset)
+	assert.Equal(t, []MaskPoint{{ObservationInterval: time.Second, Limit: 10}}, profile.Processes["ptp4l"].MTIEMask)
+
+	profile, err = GetThresholdProfile("g.8272-prtc-b", 50)
+	assert.Nil(t, err)
+	assert.True(t, profile.Processes["ts2phc"].Required)
+	assert.False(t, profile.Processes["ptp4l"].Required)
+
+	profilePath := filepath.Join(t.TempDir(), "custom.yaml")
+	err = os.WriteFile(profilePath, []byte(`processes:
+  ptp4l:
+    required: true
+    maxAbsOffset: 20
+    tdevMask:
+    - observationInterval: 10s
+      limit: 5
+    - observationInterval: 1s
+      limit: 8
+`), 0644)
+	assert.Nil(t, err)
+
+	profile, err = GetThresholdProfile(profilePath, 50)
+	assert.Nil(t, err)
+	assert.Equal(t, "custom", profile.Name)
+	assert.Equal(t, []MaskPoint{
+		{ObservationInterval: time.Second, Limit: 8},
+		{ObservationInterval: 10 * time.Second, Limit: 5},
+	}, profile.Processes["ptp4l"].TDEVMask)
+
+	_, err = GetThresholdProfile("g.8273.2-class-z", 50)
+	assert.ErrorContains(t, err, "neither a file nor one of the built-in profiles")
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.yaml")
+	err = os.WriteFile(invalidPath, []byte("processes:\n  ptp4l:\n    unknownLimit: 1\n"), 0644)
+	assert.Nil(t, err)
+
+	_, err = GetThresholdProfile(invalidPath, 50)
+	assert.ErrorContains(t, err, "failed to parse threshold profile")
+
+	emptyPath := filepath.Join(t.TempDir(), "empty.yaml")
+	err = os.WriteFile(emptyPath, []byte("name: empty\n"), 0644)
+	assert.Nil(t, err)
+
+	_, err = GetThresholdProfile(emptyPath, 50)
+	assert.ErrorContains(t, err, "at least one process must be provided")
+}
+
+func TestLimitAt(t *testing.T) {
+	mask := []MaskPoint{
+		{ObservationInterval: time.Second, Limit: 40},
+		{ObservationInterval: 100 * time.Second, Limit: 0},
+	}
+
+	assert.Equal(t, 0.0, limitAt(mask, 500*time.Millisecond))
+	assert.Equal(t, 40.0, limitAt(mask, time.Second))
+	assert.Equal(t, 40.0, limitAt(mask, 10*time.Second))
+	assert.Equal(t, 0.0, limitAt(mask, 100*time.Second))
+}
@@ -1,13 +1,15 @@
 package stability
 
 import (
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// LogEntry is a parsed delay log line from a synchronization daemon (ptp4l or phc2sys). Each process contains a clock
-// offset and a servo state, both of which are extracted from the log line.
+// LogEntry is a parsed delay log line from a synchronization daemon (ptp4l, phc2sys, or ts2phc). Each process contains
+// a clock offset and a servo state, both of which are extracted from the log line.
 type LogEntry struct {
 	// Raw is the full raw log line that was matched.
 	Raw string
@@ -16,17 +18,33 @@ type LogEntry struct {
 	// State is the servo state of the log line. It is the letter s followed by a number. For example, "s1" means
 	// the servo is in state 1.
 	State string
+	// Timestamp is the monotonic timestamp at the start of the log line, which is the time since the node booted.
+	// It is zero if the line has no timestamp.
+	Timestamp time.Duration
 }
 
 var (
 	// ptp4lPattern is a regular expression that matches the ptp4l log lines. For example:
 	//  ptp4l[401304.873]: [ptp4l.1.config:6] master offset         -3 s2 freq  -94379 path delay       161
-	ptp4lPattern = regexp.MustCompile(`^ptp4l\[.*?\boffset\s+(?P<offset>-?\d+)\s+(?P<state>s\d+).*delay`)
+	ptp4lPattern = regexp.MustCompile(
+		`^ptp4l\[(?P<timestamp>[\d.]*)\].*?\boffset\s+(?P<offset>-?\d+)\s+(?P<state>s\d+).*delay`)
 	// phc2sysPattern is a regular expression that matches the phc2sys log lines. For example:
 	//  phc2sys[401304.879]: [ptp4l.1.config:6] CLOCK_REALTIME phc offset        -5 s2 freq  -19334 delay    470
-	phc2sysPattern = regexp.MustCompile(`^phc2sys\[.*?\boffset\s+(?P<offset>-?\d+)\s+(?P<state>s\d+).*delay`)
+	phc2sysPattern = regexp.MustCompile(
+		`^phc2sys\[(?P<timestamp>[\d.]*)\].*?\boffset\s+(?P<offset>-?\d+)\s+(?P<state>s\d+).*delay`)
+	// ts2phcPattern is a regular expression that matches the ts2phc log lines. Unlike the other processes, ts2phc
+	// does not log a delay. For example:
+	//  ts2phc[401304.871]: [ts2phc.0.config:6] ens7f0 master offset          1 s2 freq      +3
+	ts2phcPattern = newProcessPattern("ts2phc")
 )
 
+// newProcessPattern returns a regular expression that matches the offset log lines of the named process, for
+// processes that log in the same format as ts2phc.
+func newProcessPattern(process string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(
+		`^%s\[(?P<timestamp>[\d.]*)\].*?\boffset\s+(?P<offset>-?\d+)\s+(?P<state>s\d+)`, regexp.QuoteMeta(process)))
+}
+
 // ParseResult holds the outcome of attempting to parse a single log line.
 type ParseResult struct {
 	// Entry is the parsed log entry. It is zero-valued when Matched is false or Dropped is true.
@@ -54,14 +72,30 @@ func tryParseEntry(line string, pattern *regexp.Regexp) ParseResult {
 
 	return ParseResult{
 		Entry: LogEntry{
-			Raw:    line,
-			Offset: offset,
-			State:  match[stateIndex],
+			Raw:       line,
+			Offset:    offset,
+			State:     match[stateIndex],
+			Timestamp: parseTimestamp(match, pattern.SubexpIndex("timestamp")),
 		},
 		Matched: true,
 	}
 }
 
+// parseTimestamp returns the timestamp in seconds at timestampIndex of match as a duration. It returns zero if there is
+// no timestamp or it cannot be parsed, since the timestamp is only needed for the time-based statistics.
+func parseTimestamp(match []string, timestampIndex int) time.Duration {
+	if timestampIndex < 0 || match[timestampIndex] == "" {
+		return 0
+	}
+
+	seconds, err := strconv.ParseFloat(match[timestampIndex], 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
 // isPTP4LStart returns true if the line indicates a ptp4l process start.
 func isPTP4LStart(line string) bool {
 	return strings.Contains(line, "Starting ptp4l")
@@ -0,0 +1,257 @@
+package stability
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/processes"
+	"gopkg.in/yaml.v2"
+	"k8s.io/utils/ptr"
+)
+
+// ThresholdProfile declares the limits that the stability analysis checks to decide whether the logs pass. Profiles
+// can be loaded from YAML files so the acceptance criteria can be changed without changing the tests. For example:
+//
+//	name: custom
+//	observationIntervals: [1s, 10s, 100s]
+//	processes:
+//	  ptp4l:
+//	    required: true
+//	    maxAbsOffset: 50
+//	    maxViolations: 0
+//	    maxP99AbsOffset: 30
+//	    mtieMask:
+//	    - observationInterval: 1s
+//	      limit: 40
+type ThresholdProfile struct {
+	// Name identifies the profile in diagnostic messages.
+	Name string `yaml:"name"`
+	// Processes maps the name of each process to the limits for its offsets. Processes other than ptp4l, phc2sys,
+	// and ts2phc are parsed assuming the same log format as ts2phc.
+	Processes map[string]ProcessLimits `yaml:"processes"`
+	// ObservationIntervals are the intervals to compute the time-based stability metrics over. If empty,
+	// DefaultObservationIntervals is used.
+	ObservationIntervals []time.Duration `yaml:"observationIntervals"`
+	// MaxPTP4LRestarts is the number of times ptp4l may restart during the logs.
+	MaxPTP4LRestarts uint `yaml:"maxPtp4lRestarts"`
+	// AllowFaultyLines allows lines containing FAULTY in the logs.
+	AllowFaultyLines bool `yaml:"allowFaultyLines"`
+	// AllowTimeoutLines allows lines containing timeout in the logs.
+	AllowTimeoutLines bool `yaml:"allowTimeoutLines"`
+}
+
+// ProcessLimits are the limits for the offsets of a single process. Offsets are in nanoseconds and limits that are
+// zero or nil are not checked.
+type ProcessLimits struct {
+	// Required fails the analysis if no offsets were parsed for the process.
+	Required bool `yaml:"required"`
+	// MaxAbsOffset is the threshold for the absolute offset of locked samples. Samples over it are violations and
+	// consecutive violations form a burst. If zero, DefaultOffsetThresholdAbsoluteNanoseconds is used.
+	MaxAbsOffset int64 `yaml:"maxAbsOffset"`
+	// MaxViolations is the number of locked samples that may exceed MaxAbsOffset.
+	MaxViolations *int `yaml:"maxViolations"`
+	// MaxStateTransitions is the number of servo state transitions that may occur.
+	MaxStateTransitions *int `yaml:"maxStateTransitions"`
+	// MaxMeanOffset is the limit for the absolute value of the mean offset of locked samples, which approximates
+	// the constant time error.
+	MaxMeanOffset float64 `yaml:"maxMeanOffset"`
+	// MaxP99AbsOffset is the limit for the 99th percentile of the absolute offsets.
+	MaxP99AbsOffset int64 `yaml:"maxP99AbsOffset"`
+	// MaxP999AbsOffset is the limit for the 99.9th percentile of the absolute offsets.
+	MaxP999AbsOffset int64 `yaml:"maxP999AbsOffset"`
+	// MaxViolationBurst is the limit for the duration of the longest burst of consecutive violations.
+	MaxViolationBurst time.Duration `yaml:"maxViolationBurst"`
+	// MinLockedRatio is the minimum fraction of the time, between 0 and 1, that the servo must spend in s2.
+	MinLockedRatio float64 `yaml:"minLockedRatio"`
+	// MTIEMask is the limit for the MTIE in nanoseconds as a function of the observation interval.
+	MTIEMask []MaskPoint `yaml:"mtieMask"`
+	// TDEVMask is the limit for the TDEV in nanoseconds as a function of the observation interval.
+	TDEVMask []MaskPoint `yaml:"tdevMask"`
+}
+
+// MaskPoint is a single step of a mask for a time-based stability metric. The limit applies from the observation
+// interval of the point up to the observation interval of the next point. A limit of zero stops checking the metric
+// from that observation interval on.
+type MaskPoint struct {
+	ObservationInterval time.Duration `yaml:"observationInterval"`
+	Limit               float64       `yaml:"limit"`
+}
+
+// limitAt returns the limit of the mask at observationInterval, or zero if the mask does not apply to it. The mask
+// points must be sorted by observation interval.
+func limitAt(mask []MaskPoint, observationInterval time.Duration) float64 {
+	limit := 0.0
+
+	for _, point := range mask {
+		if point.ObservationInterval > observationInterval {
+			break
+		}
+
+		limit = point.Limit
+	}
+
+	return limit
+}
+
+// threshold returns the absolute offset threshold for the process, using the default if it is not set.
+func (limits ProcessLimits) threshold() int64 {
+	if limits.MaxAbsOffset <= 0 {
+		return DefaultOffsetThresholdAbsoluteNanoseconds
+	}
+
+	return limits.MaxAbsOffset
+}
+
+// g82732Profile returns a profile for a boundary or slave clock of the provided G.8273.2 class. The limits are the
+// max|TE|, cTE, and dTE_L MTIE limits for the class, applied to the ptp4l offsets. Since the offsets are measured by
+// the servo itself rather than against an external reference, this approximates compliance rather than proving it.
+func g82732Profile(class string, maxAbsOffset int64, maxMeanOffset, mtieLimit float64) ThresholdProfile {
+	profile := DefaultThresholdProfile(DefaultOffsetThresholdAbsoluteNanoseconds)
+	profile.Name = "g.8273.2-class-" + class
+
+	ptp4lLimits := profile.Processes[string(processes.Ptp4l)]
+	ptp4lLimits.MaxAbsOffset = maxAbsOffset
+	ptp4lLimits.MaxMeanOffset = maxMeanOffset
+	ptp4lLimits.MTIEMask = []MaskPoint{{ObservationInterval: time.Second, Limit: mtieLimit}}
+	profile.Processes[string(processes.Ptp4l)] = ptp4lLimits
+
+	return profile
+}
+
+// g8272Profile returns a profile for a grandmaster clock of the provided G.8272 PRTC class. The max|TE| limit for the
+// class is applied to the ts2phc offsets, which are measured against the GNSS receiver. Since ptp4l only has master
+// ports on a grandmaster, it does not log offsets and is not required.
+func g8272Profile(class string, maxAbsOffset int64) ThresholdProfile {
+	profile := DefaultThresholdProfile(DefaultOffsetThresholdAbsoluteNanoseconds)
+	profile.Name = "g.8272-prtc-" + class
+	profile.Processes[string(processes.Ptp4l)] = ProcessLimits{}
+	profile.Processes[string(processes.Ts2phc)] = ProcessLimits{
+		Required:      true,
+		MaxAbsOffset:  maxAbsOffset,
+		MaxViolations: ptr.To(0),
+	}
+
+	return profile
+}
+
+// builtinProfiles returns the threshold profiles that can be loaded by name.
+func builtinProfiles() map[string]ThresholdProfile {
+	builtins := []ThresholdProfile{
+		DefaultThresholdProfile(DefaultOffsetThresholdAbsoluteNanoseconds),
+		g82732Profile("a", 100, 50, 40),
+		g82732Profile("b", 70, 20, 40),
+		g82732Profile("c", 30, 10, 10),
+		g8272Profile("a", 100),
+		g8272Profile("b", 40),
+	}
+
+	profiles := make(map[string]ThresholdProfile)
+	for _, profile := range builtins {
+		profiles[profile.Name] = profile
+	}
+
+	return profiles
+}
+
+// DefaultThresholdProfile returns the profile used when no other profile is provided. It requires ptp4l and phc2sys
+// offsets with no locked samples over thresholdAbsoluteNanoseconds, no ptp4l state transitions, no ptp4l restarts, and
+// no lines containing FAULTY or timeout. The ts2phc offsets are checked against the same threshold if present.
+func DefaultThresholdProfile(thresholdAbsoluteNanoseconds int64) ThresholdProfile {
+	if thresholdAbsoluteNanoseconds <= 0 {
+		thresholdAbsoluteNanoseconds = DefaultOffsetThresholdAbsoluteNanoseconds
+	}
+
+	return ThresholdProfile{
+		Name: "default",
+		Processes: map[string]ProcessLimits{
+			string(processes.Ptp4l): {
+				Required:            true,
+				MaxAbsOffset:        thresholdAbsoluteNanoseconds,
+				MaxViolations:       ptr.To(0),
+				MaxStateTransitions: ptr.To(0),
+			},
+			string(processes.Phc2sys): {
+				Required:      true,
+				MaxAbsOffset:  thresholdAbsoluteNanoseconds,
+				MaxViolations: ptr.To(0),
+			},
+			string(processes.Ts2phc): {
+				MaxAbsOffset:  thresholdAbsoluteNanoseconds,
+				MaxViolations: ptr.To(0),
+			},
+		},
+	}
+}
+
+// GetThresholdProfile returns the threshold profile for nameOrPath. If nameOrPath is empty, the default profile with
+// the provided threshold is returned. Otherwise, it is either the name of a built-in profile, such as
+// g.8273.2-class-c, or the path to a YAML file containing a ThresholdProfile.
+func GetThresholdProfile(nameOrPath string, thresholdAbsoluteNanoseconds int64) (ThresholdProfile, error) {
+	if nameOrPath == "" {
+		return DefaultThresholdProfile(thresholdAbsoluteNanoseconds), nil
+	}
+
+	builtins := builtinProfiles()
+	if profile, ok := builtins[nameOrPath]; ok {
+		return profile, nil
+	}
+
+	content, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return ThresholdProfile{}, fmt.Errorf(
+			"threshold profile %s is neither a file nor one of the built-in profiles %s: %w",
+			nameOrPath, strings.Join(slices.Sorted(maps.Keys(builtins)), ", "), err)
+	}
+
+	var profile ThresholdProfile
+
+	err = yaml.UnmarshalStrict(content, &profile)
+	if err != nil {
+		return ThresholdProfile{}, fmt.Errorf("failed to parse threshold profile %s: %w", nameOrPath, err)
+	}
+
+	if profile.Name == "" {
+		profile.Name = strings.TrimSuffix(filepath.Base(nameOrPath), filepath.Ext(nameOrPath))
+	}
+
+	err = profile.validate()
+	if err != nil {
+		return ThresholdProfile{}, fmt.Errorf("invalid threshold profile %s: %w", nameOrPath, err)
+	}
+
+	return profile, nil
+}
+
+// validate checks that the profile has at least one process and that its masks and observation intervals are usable.
+// Mask points are sorted by observation interval so they can be used by limitAt.
+func (profile ThresholdProfile) validate() error {
+	if len(profile.Processes) == 0 {
+		return fmt.Errorf("at least one process must be provided")
+	}
+
+	for _, observationInterval := range profile.ObservationIntervals {
+		if observationInterval <= 0 {
+			return fmt.Errorf("observation intervals must be positive but found %s", observationInterval)
+		}
+	}
+
+	for name, limits := range profile.Processes {
+		if limits.MinLockedRatio < 0 || limits.MinLockedRatio > 1 {
+			return fmt.Errorf("minLockedRatio for %s must be between 0 and 1", name)
+		}
+
+		for _, mask := range [][]MaskPoint{limits.MTIEMask, limits.TDEVMask} {
+			slices.SortFunc(mask, func(first, second MaskPoint) int {
+				return cmp.Compare(first.ObservationInterval, second.ObservationInterval)
+			})
+		}
+	}
+
+	return nil
+}
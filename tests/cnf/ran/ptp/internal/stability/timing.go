@@ -0,0 +1,152 @@
+package stability
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// DefaultObservationIntervals are the observation intervals used for the time-based stability metrics when the
+// threshold profile does not specify any.
+var DefaultObservationIntervals = []time.Duration{time.Second, 10 * time.Second, 100 * time.Second, 1000 * time.Second}
+
+// StabilityPoint holds the time-based stability metrics for a single observation interval. The metrics are computed
+// from the offsets while the servo is locked, treating the offset as the time error of the clock.
+type StabilityPoint struct {
+	// ObservationInterval is the observation interval, often written as tau, for the metrics.
+	ObservationInterval time.Duration
+	// ADEV is the overlapping Allan deviation, which is dimensionless.
+	ADEV float64
+	// MTIE is the maximum time interval error in nanoseconds.
+	MTIE float64
+	// TDEV is the time deviation in nanoseconds.
+	TDEV float64
+}
+
+// computeStabilityPoints computes the stability metrics for each observation interval that is a multiple of
+// samplingInterval. Observation intervals without enough samples for all three metrics are skipped. The offsets are
+// assumed to be evenly spaced by samplingInterval.
+func computeStabilityPoints(
+	offsets []float64, samplingInterval time.Duration, observationIntervals []time.Duration) []StabilityPoint {
+	if samplingInterval <= 0 {
+		return nil
+	}
+
+	var points []StabilityPoint
+
+	for _, observationInterval := range observationIntervals {
+		multiple := int(math.Round(float64(observationInterval) / float64(samplingInterval)))
+
+		// TDEV needs the most samples of the three metrics, with 3n+1 samples needed for a single estimate.
+		if multiple < 1 || len(offsets) < 3*multiple+1 {
+			continue
+		}
+
+		points = append(points, StabilityPoint{
+			ObservationInterval: observationInterval,
+			ADEV:                allanDeviation(offsets, multiple, observationInterval),
+			MTIE:                maxTimeIntervalError(offsets, multiple),
+			TDEV:                timeDeviation(offsets, multiple),
+		})
+	}
+
+	return points
+}
+
+// allanDeviation computes the overlapping Allan deviation from the time error samples in nanoseconds, where
+// observationInterval is multiple times the sampling interval.
+func allanDeviation(offsets []float64, multiple int, observationInterval time.Duration) float64 {
+	count := len(offsets) - 2*multiple
+	sum := 0.0
+
+	for index := range count {
+		secondDifference := offsets[index+2*multiple] - 2*offsets[index+multiple] + offsets[index]
+		sum += secondDifference * secondDifference
+	}
+
+	tau := observationInterval.Seconds()
+
+	// The offsets are in nanoseconds while tau is in seconds, so the result must be scaled to be dimensionless.
+	return math.Sqrt(sum/(2*tau*tau*float64(count))) * 1e-9
+}
+
+// maxTimeIntervalError computes the MTIE from the time error samples in nanoseconds over windows spanning multiple
+// sampling intervals. It uses monotonic queues so it runs in linear time regardless of the window size.
+func maxTimeIntervalError(offsets []float64, multiple int) float64 {
+	var (
+		maxQueue, minQueue []int
+		mtie               float64
+	)
+
+	for index, offset := range offsets {
+		for len(maxQueue) > 0 && offsets[maxQueue[len(maxQueue)-1]] <= offset {
+			maxQueue = maxQueue[:len(maxQueue)-1]
+		}
+
+		for len(minQueue) > 0 && offsets[minQueue[len(minQueue)-1]] >= offset {
+			minQueue = minQueue[:len(minQueue)-1]
+		}
+
+		maxQueue = append(maxQueue, index)
+		minQueue = append(minQueue, index)
+
+		// Each window includes multiple+1 samples, so it spans multiple sampling intervals.
+		windowStart := index - multiple
+		if maxQueue[0] < windowStart {
+			maxQueue = maxQueue[1:]
+		}
+
+		if minQueue[0] < windowStart {
+			minQueue = minQueue[1:]
+		}
+
+		if windowStart >= 0 {
+			mtie = max(mtie, offsets[maxQueue[0]]-offsets[minQueue[0]])
+		}
+	}
+
+	return mtie
+}
+
+// timeDeviation computes the TDEV from the time error samples in nanoseconds, where the observation interval is
+// multiple times the sampling interval. Prefix sums keep it linear in the number of samples.
+func timeDeviation(offsets []float64, multiple int) float64 {
+	prefixSums := make([]float64, len(offsets)+1)
+	for index, offset := range offsets {
+		prefixSums[index+1] = prefixSums[index] + offset
+	}
+
+	windowSum := func(start int) float64 {
+		return prefixSums[start+multiple] - prefixSums[start]
+	}
+
+	count := len(offsets) - 3*multiple + 1
+	sum := 0.0
+
+	for index := range count {
+		innerSum := windowSum(index+2*multiple) - 2*windowSum(index+multiple) + windowSum(index)
+		sum += innerSum * innerSum
+	}
+
+	return math.Sqrt(sum / (6 * float64(multiple) * float64(multiple) * float64(count)))
+}
+
+// estimateSamplingInterval returns the median of the positive intervals between consecutive timestamps. It returns zero
+// if there are no positive intervals, such as when the log lines have no timestamps.
+func estimateSamplingInterval(timestamps []time.Duration) time.Duration {
+	var intervals []time.Duration
+
+	for index := 1; index < len(timestamps); index++ {
+		if interval := timestamps[index] - timestamps[index-1]; interval > 0 {
+			intervals = append(intervals, interval)
+		}
+	}
+
+	if len(intervals) == 0 {
+		return 0
+	}
+
+	slices.Sort(intervals)
+
+	return intervals[len(intervals)/2]
+}
@@ -0,0 +1,117 @@
+package stability
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxTimeIntervalError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		offsets  []float64
+		multiple int
+		expected float64
+	}{
+		{
+			name:     "constant",
+			offsets:  []float64{5, 5, 5, 5},
+			multiple: 1,
+			expected: 0,
+		},
+		{
+			name:     "single step",
+			offsets:  []float64{0, 1, 4, 2, 3},
+			multiple: 1,
+			expected: 3,
+		},
+		{
+			name:     "wider window",
+			offsets:  []float64{0, 1, 4, 2, -3, 3},
+			multiple: 2,
+			expected: 7,
+		},
+		{
+			name:     "window covers all samples",
+			offsets:  []float64{-10, 0, 10},
+			multiple: 2,
+			expected: 20,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, maxTimeIntervalError(testCase.offsets, testCase.multiple))
+		})
+	}
+}
+
+func TestTimeDeviation(t *testing.T) {
+	// A linear time error, such as from a constant frequency offset, has no second difference so its TDEV is zero.
+	linear := []float64{0, 1, 2, 3, 4, 5, 6}
+	assert.InDelta(t, 0, timeDeviation(linear, 1), 1e-12)
+	assert.InDelta(t, 0, timeDeviation(linear, 2), 1e-12)
+
+	// For alternating offsets of ±1, each second difference is ±4 when the window is a single sample.
+	alternating := []float64{1, -1, 1, -1, 1}
+	assert.InDelta(t, math.Sqrt(16.0/6), timeDeviation(alternating, 1), 1e-12)
+}
+
+func TestAllanDeviation(t *testing.T) {
+	linear := []float64{0, 1, 2, 3, 4}
+	assert.InDelta(t, 0, allanDeviation(linear, 1, time.Second), 1e-24)
+
+	// The second differences of the alternating offsets are ±4 ns, so the ADEV is sqrt(16/2) ns over 1 s.
+	alternating := []float64{1, -1, 1, -1, 1}
+	assert.InDelta(t, math.Sqrt(8)*1e-9, allanDeviation(alternating, 1, time.Second), 1e-18)
+}
+
+func TestComputeStabilityPoints(t *testing.T) {
+	offsets := make([]float64, 31)
+	for index := range offsets {
+		offsets[index] = float64(index % 2)
+	}
+
+	points := computeStabilityPoints(offsets, time.Second, DefaultObservationIntervals)
+	if assert.Len(t, points, 2) {
+		assert.Equal(t, time.Second, points[0].ObservationInterval)
+		assert.Equal(t, 1.0, points[0].MTIE)
+		assert.Equal(t, 10*time.Second, points[1].ObservationInterval)
+		assert.Equal(t, 1.0, points[1].MTIE)
+	}
+
+	assert.Empty(t, computeStabilityPoints(offsets, 0, DefaultObservationIntervals))
+}
+
+func TestEstimateSamplingInterval(t *testing.T) {
+	testCases := []struct {
+		name       string
+		timestamps []time.Duration
+		expected   time.Duration
+	}{
+		{
+			name:     "no timestamps",
+			expected: 0,
+		},
+		{
+			name:       "all zero",
+			timestamps: []time.Duration{0, 0, 0},
+			expected:   0,
+		},
+		{
+			name: "median ignores gaps",
+			timestamps: []time.Duration{
+				time.Second, 2 * time.Second, 3 * time.Second, 10 * time.Second, 11 * time.Second,
+			},
+			expected: time.Second,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, estimateSamplingInterval(testCase.timestamps))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package timeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/querier"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/consumer"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/events"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/metrics"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpdaemon"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"golang.org/x/exp/constraints"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// CollectorTimeout is the timeout to register the Collector with. It allows for retrieving the logs of every node
+	// and running the range queries.
+	CollectorTimeout = 5 * time.Minute
+	// JSONFileName is the name of the file the Collector writes the timeline to as JSON.
+	JSONFileName = "timeline.json"
+	// TableFileName is the name of the file the Collector writes the timeline to as a table.
+	TableFileName = "timeline.txt"
+	// windowLead is how long before the start of a spec the timeline starts, so the state the spec started from is
+	// included.
+	windowLead = time.Minute
+	// defaultStep is the step of the range queries, which matches the default scrape interval of the PTP metrics.
+	defaultStep = 30 * time.Second
+)
+
+// Recorder captures the timeline of a cluster. Each node running the PTP daemon is recorded separately and their
+// entries are merged onto a single timeline.
+type Recorder struct {
+	client        *clients.Settings
+	prometheusAPI prometheusv1.API
+	step          time.Duration
+}
+
+// NewRecorder creates a Recorder for the cluster of client. If prometheusAPI is nil, one is created for the cluster
+// when the first timeline is recorded, so creating a Recorder does not require access to Prometheus.
+func NewRecorder(client *clients.Settings, prometheusAPI prometheusv1.API) *Recorder {
+	return &Recorder{client: client, prometheusAPI: prometheusAPI, step: defaultStep}
+}
+
+// WithStep sets the step of the range queries used to capture the metrics. Values less than or equal to zero are
+// ignored.
+func (recorder *Recorder) WithStep(step time.Duration) *Recorder {
+	if step > 0 {
+		recorder.step = step
+	}
+
+	return recorder
+}
+
+// Record returns the timeline of the cluster between start and end. Failures to capture a single source are recorded
+// in the Errors of the timeline instead of being returned, so a timeline is returned as long as the PTP daemon nodes
+// can be listed.
+func (recorder *Recorder) Record(ctx context.Context, start, end time.Time) (*Timeline, error) {
+	if recorder.client == nil {
+		return nil, fmt.Errorf("cannot record timeline with nil client")
+	}
+
+	nodeList, err := ptpdaemon.ListPtpDaemonNodes(recorder.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PTP daemon nodes: %w", err)
+	}
+
+	timeline := New(start, end)
+
+	eventsEnabled, err := consumer.AreEventsEnabled(recorder.client)
+	if err != nil {
+		timeline.AddError(fmt.Errorf("failed to check if events are enabled: %w", err))
+	}
+
+	for _, node := range nodeList {
+		nodeName := node.Definition.Name
+
+		klog.V(tsparams.LogLevel).Infof("Recording PTP timeline of node %s from %s to %s", nodeName, start, end)
+
+		logEntries, err := recorder.recordDaemonLogs(nodeName, start)
+		if err != nil {
+			timeline.AddError(err)
+		}
+
+		timeline.Add(logEntries...)
+
+		if !eventsEnabled {
+			continue
+		}
+
+		eventEntries, err := recorder.recordEvents(nodeName, start)
+		if err != nil {
+			timeline.AddError(err)
+		}
+
+		timeline.Add(eventEntries...)
+	}
+
+	for _, err := range recorder.recordMetrics(ctx, timeline) {
+		timeline.AddError(err)
+	}
+
+	timeline.Sort()
+
+	return timeline, nil
+}
+
+// recordDaemonLogs returns the entries from the logs of the PTP daemon on nodeName since start.
+func (recorder *Recorder) recordDaemonLogs(nodeName string, start time.Time) ([]Entry, error) {
+	daemonPod, err := ptpdaemon.GetPtpDaemonPodOnNode(recorder.client, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PTP daemon pod on node %s: %w", nodeName, err)
+	}
+
+	logs, err := daemonPod.GetLogsWithOptions(&corev1.PodLogOptions{
+		SinceTime:  &metav1.Time{Time: start},
+		Container:  ranparam.PtpContainerName,
+		Timestamps: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PTP daemon logs on node %s: %w", nodeName, err)
+	}
+
+	return LogEntries(nodeName, logs), nil
+}
+
+// recordEvents returns the entries from the events received by the consumer on nodeName since start.
+func (recorder *Recorder) recordEvents(nodeName string, start time.Time) ([]Entry, error) {
+	eventPod, err := consumer.GetConsumerPodforNode(recorder.client, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consumer pod on node %s: %w", nodeName, err)
+	}
+
+	receivedEvents, err := events.ListEvents(eventPod, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events on node %s: %w", nodeName, err)
+	}
+
+	return EventEntries(nodeName, receivedEvents), nil
+}
+
+// recordMetrics adds the changes of the PTP state metrics during the window of timeline. The errors of the queries that
+// failed are returned.
+func (recorder *Recorder) recordMetrics(ctx context.Context, timeline *Timeline) []error {
+	if recorder.prometheusAPI == nil {
+		prometheusAPI, err := querier.CreatePrometheusAPIForCluster(recorder.client)
+		if err != nil {
+			return []error{fmt.Errorf("failed to create Prometheus API client: %w", err)}
+		}
+
+		recorder.prometheusAPI = prometheusAPI
+	}
+
+	var errs []error
+
+	addEntries := func(entries []Entry, err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		timeline.Add(entries...)
+	}
+
+	addEntries(queryRange(ctx, recorder, timeline, metrics.ClockStateQuery{}))
+	addEntries(queryRange(ctx, recorder, timeline, metrics.ClockClassQuery{}))
+	addEntries(queryRange(ctx, recorder, timeline, metrics.InterfaceRoleQuery{}))
+	addEntries(queryRange(ctx, recorder, timeline, metrics.ProcessStatusQuery{}))
+
+	return errs
+}
+
+// queryRange returns the entries for the changes of query during the window of timeline.
+func queryRange[V constraints.Integer](
+	ctx context.Context, recorder *Recorder, timeline *Timeline, query metrics.Query[V]) ([]Entry, error) {
+	metricQuery := query.ToMetricQuery()
+	metricQuery.Start = timeline.Start
+	metricQuery.End = timeline.End
+	metricQuery.Step = recorder.step
+
+	matrix, err := metrics.ExecuteQueryRange(ctx, recorder.prometheusAPI, metricQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", metricQuery.Metric, err)
+	}
+
+	return MetricEntries(matrix), nil
+}
+
+// Collector is a reporter.Collector that records the timeline of the window of failed specs.
+type Collector struct {
+	recorder *Recorder
+}
+
+// NewCollector creates a Collector that records timelines using recorder. It is meant to be registered using
+// reporter.RegisterCollector with CollectorTimeout.
+func NewCollector(recorder *Recorder) *Collector {
+	return &Collector{recorder: recorder}
+}
+
+// Name returns the name of the Collector.
+func (collector *Collector) Name() string {
+	return "ptp-timeline"
+}
+
+// Collect records the timeline from shortly before the failed spec started until now and writes it to outputDir as
+// both JSON and a table.
+func (collector *Collector) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	if collector.recorder == nil {
+		return fmt.Errorf("cannot collect PTP timeline with nil recorder")
+	}
+
+	start := report.StartTime.Add(-windowLead)
+	if report.StartTime.IsZero() {
+		start = time.Now().Add(-windowLead)
+	}
+
+	timeline, err := collector.recorder.Record(ctx, start, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to record PTP timeline: %w", err)
+	}
+
+	return WriteFiles(timeline, outputDir)
+}
+
+// WriteFiles writes timeline to JSONFileName and TableFileName in outputDir.
+func WriteFiles(timeline *Timeline, outputDir string) error {
+	for fileName, write := range map[string]func(*os.File) error{
+		JSONFileName:  func(file *os.File) error { return timeline.WriteJSON(file) },
+		TableFileName: func(file *os.File) error { return timeline.WriteTable(file) },
+	} {
+		filePath := filepath.Join(outputDir, fileName)
+
+		file, err := os.Create(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", filePath, err)
+		}
+
+		err = write(file)
+		closeErr := file.Close()
+
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+
+		if closeErr != nil {
+			return fmt.Errorf("failed to close %s: %w", filePath, closeErr)
+		}
+	}
+
+	return nil
+}
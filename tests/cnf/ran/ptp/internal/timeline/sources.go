@@ -0,0 +1,253 @@
+package timeline
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/redhat-cne/sdk-go/pkg/event"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/metrics"
+)
+
+var (
+	// portStateRegexp matches ptp4l port state transitions. The interface is only logged by recent versions of
+	// linuxptp. For example:
+	//  ptp4l[4312.211]: [ptp4l.0.config:5] port 1 (ens7f1): SLAVE to FAULTY on FAULT_DETECTED (FT_UNSPECIFIED)
+	portStateRegexp = regexp.MustCompile(
+		`^(\w+)\[[\d.]+\]: \[([^\]:]+)(?::\d+)?\] port \d+(?: \(([^)]+)\))?: (\S+) to (\S+) on (\S+)`)
+	// clockClassRegexp matches clock class changes logged by the daemon. For example:
+	//  ptp4l[4312.211]: [ptp4l.0.config] CLOCK_CLASS_CHANGE 248
+	clockClassRegexp = regexp.MustCompile(`^(\w+)\[[\d.]+\]: \[([^\]:]+)(?::\d+)?\] CLOCK_CLASS_CHANGE (\d+)`)
+	// servoStateRegexp matches log lines ending in or containing a servo state, such as offset lines and the status
+	// lines of the T-GM processes. For example:
+	//  phc2sys[4312.211]: [ptp4l.0.config:6] CLOCK_REALTIME phc offset -5 s2 freq -19334 delay 470
+	//  GM[4312.211]: [ts2phc.0.config] ens7f0 T-GM-STATUS s0
+	servoStateRegexp = regexp.MustCompile(`^(\w+)\[[\d.]+\]: \[([^\]:]+)(?::\d+)?\] (.*?)\b(s\d)\b`)
+)
+
+// nonInterfaceTokens are the first words of daemon log messages that do not start with an interface or clock name.
+var nonInterfaceTokens = map[string]bool{"master": true, "offset": true, "phc": true, "port": true, "selected": true}
+
+// servoStateNames are the names of the linuxptp servo states, as logged with the letter s followed by their number.
+var servoStateNames = map[string]string{
+	"s0": "unlocked",
+	"s1": "jump",
+	"s2": "locked",
+	"s3": "locked stable",
+}
+
+// EventEntries returns an entry for each value of events received by the consumer on nodeName. The node and interface
+// are taken from the resource address of the values when it has the /cluster/node/<node>/<interface> form.
+func EventEntries(nodeName string, events []event.Event) []Entry {
+	var entries []Entry
+
+	for _, receivedEvent := range events {
+		if receivedEvent.Data == nil {
+			continue
+		}
+
+		var eventTime time.Time
+		if receivedEvent.Time != nil {
+			eventTime = receivedEvent.Time.Time
+		}
+
+		for _, value := range receivedEvent.Data.Values {
+			node, iface := parseResourceAddress(value.Resource)
+			if node == "" {
+				node = nodeName
+			}
+
+			entries = append(entries, Entry{
+				Time:      eventTime,
+				Node:      node,
+				Interface: iface,
+				Source:    SourceEvent,
+				Kind:      strings.TrimPrefix(receivedEvent.Type, "event.sync."),
+				Value:     fmt.Sprint(value.Value),
+				Detail:    fmt.Sprintf("%s %s", value.DataType, value.Resource),
+			})
+		}
+	}
+
+	return entries
+}
+
+// MetricEntries returns an entry for the first sample of each series in matrix and for every sample after that where
+// the value changed, so only the state changes are on the timeline rather than every scrape.
+func MetricEntries(matrix model.Matrix) []Entry {
+	var entries []Entry
+
+	for _, series := range matrix {
+		metricName := string(series.Metric[model.MetricNameLabel])
+		kind := strings.TrimPrefix(metricName, "openshift_ptp_")
+
+		if process := series.Metric[model.LabelName(metrics.KeyProcess)]; process != "" {
+			kind = fmt.Sprintf("%s (%s)", kind, process)
+		}
+
+		for index, sample := range series.Values {
+			if index > 0 && series.Values[index-1].Value == sample.Value {
+				continue
+			}
+
+			entries = append(entries, Entry{
+				Time:      sample.Timestamp.Time(),
+				Node:      string(series.Metric[model.LabelName(metrics.KeyNode)]),
+				Interface: string(series.Metric[model.LabelName(metrics.KeyInterface)]),
+				Source:    SourceMetric,
+				Kind:      kind,
+				Value:     describeMetricValue(metrics.PtpMetric(metricName), sample.Value),
+				Detail:    series.Metric.String(),
+			})
+		}
+	}
+
+	return entries
+}
+
+// LogEntries returns the state changes in the linuxptp daemon logs of nodeName. The logs are expected to have been
+// retrieved with timestamps, which are used as the entry times. Port state transitions and clock class changes are
+// always included, while servo states are only included when they differ from the previous one logged by the same
+// process for the same interface.
+func LogEntries(nodeName string, logs []byte) []Entry {
+	var entries []Entry
+
+	lastServoStates := map[string]string{}
+
+	for line := range bytes.Lines(logs) {
+		logTime, message := splitLogTimestamp(strings.TrimSpace(string(line)))
+
+		if match := portStateRegexp.FindStringSubmatch(message); match != nil {
+			entries = append(entries, Entry{
+				Time:      logTime,
+				Node:      nodeName,
+				Interface: match[3],
+				Source:    SourceLog,
+				Kind:      match[1] + " port state",
+				Value:     match[5],
+				Detail:    fmt.Sprintf("%s to %s on %s [%s]", match[4], match[5], match[6], match[2]),
+			})
+
+			continue
+		}
+
+		if match := clockClassRegexp.FindStringSubmatch(message); match != nil {
+			entries = append(entries, Entry{
+				Time:   logTime,
+				Node:   nodeName,
+				Source: SourceLog,
+				Kind:   match[1] + " clock class",
+				Value:  match[3],
+				Detail: fmt.Sprintf("[%s]", match[2]),
+			})
+
+			continue
+		}
+
+		match := servoStateRegexp.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+
+		iface := ""
+		if fields := strings.Fields(match[3]); len(fields) > 0 && !nonInterfaceTokens[fields[0]] {
+			iface = fields[0]
+		}
+
+		servoKey := strings.Join([]string{match[1], match[2], iface}, "/")
+		if lastServoStates[servoKey] == match[4] {
+			continue
+		}
+
+		previousState, seen := lastServoStates[servoKey]
+		lastServoStates[servoKey] = match[4]
+
+		// The message follows the configuration file, which is the first bracketed field followed by a space.
+		_, servoMessage, _ := strings.Cut(message, "] ")
+
+		detail := fmt.Sprintf("[%s] %s", match[2], servoMessage)
+		if seen {
+			detail = fmt.Sprintf("from %s: %s", previousState, detail)
+		}
+
+		entries = append(entries, Entry{
+			Time:      logTime,
+			Node:      nodeName,
+			Interface: iface,
+			Source:    SourceLog,
+			Kind:      match[1] + " servo",
+			Value:     describeServoState(match[4]),
+			Detail:    detail,
+		})
+	}
+
+	return entries
+}
+
+// parseResourceAddress returns the node and interface from a resource address in the
+// /cluster/node/<node>/<interface>/... form. Both are empty if the address has another form and the interface is empty
+// if the address ends with the node.
+func parseResourceAddress(resource string) (string, string) {
+	fields := strings.Split(resource, "/")
+	if len(fields) < 4 || fields[1] != "cluster" || fields[2] != "node" {
+		return "", ""
+	}
+
+	if len(fields) < 5 {
+		return fields[3], ""
+	}
+
+	return fields[3], fields[4]
+}
+
+// splitLogTimestamp splits the RFC 3339 timestamp added by the kubelet when retrieving logs with timestamps from the
+// rest of line. The returned time is zero if line does not start with a timestamp.
+func splitLogTimestamp(line string) (time.Time, string) {
+	timestamp, message, found := strings.Cut(line, " ")
+	if !found {
+		return time.Time{}, line
+	}
+
+	logTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return time.Time{}, line
+	}
+
+	return logTime, message
+}
+
+// describeServoState returns the servo state along with its name, such as "s2 (locked)".
+func describeServoState(state string) string {
+	name, ok := servoStateNames[state]
+	if !ok {
+		return state
+	}
+
+	return fmt.Sprintf("%s (%s)", state, name)
+}
+
+// describeMetricValue returns the value of a sample of metric, using the name of the state for the metrics that are
+// enums.
+func describeMetricValue(metric metrics.PtpMetric, value model.SampleValue) string {
+	var names []string
+
+	switch metric {
+	case metrics.MetricClockState:
+		names = []string{"FREERUN", "LOCKED", "HOLDOVER"}
+	case metrics.MetricProcessStatus:
+		names = []string{"DOWN", "UP"}
+	case metrics.MetricInterfaceRole:
+		names = []string{"PASSIVE", "FOLLOWER", "LEADER", "FAULTY", "UNKNOWN", "LISTENING"}
+	default:
+	}
+
+	index := int(value)
+	if float64(index) == float64(value) && index >= 0 && index < len(names) {
+		return fmt.Sprintf("%s (%d)", names[index], index)
+	}
+
+	return value.String()
+}
@@ -0,0 +1,191 @@
+// Package timeline correlates the cloud events, Prometheus metrics and linuxptp daemon logs captured during a spec
+// into a single timeline per node and interface. It is meant for failure reports: when an assertion on an event or
+// metric times out, the timeline shows what was seen from every source around the time of the expected transition.
+//
+// The [Collector] records the timeline for the window of each failed spec and writes it to the failure dump as both
+// JSON and a readable table. The parsing functions, [EventEntries], [MetricEntries] and [LogEntries], do not depend on
+// a cluster and may be used on their own.
+package timeline
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Source is where a timeline entry was captured from.
+type Source string
+
+const (
+	// SourceEvent is used for entries from cloud events received by the consumer.
+	SourceEvent Source = "event"
+	// SourceMetric is used for entries from changes in the value of Prometheus series.
+	SourceMetric Source = "metric"
+	// SourceLog is used for entries from state changes logged by the linuxptp daemon.
+	SourceLog Source = "log"
+)
+
+// Entry is a single state change on the timeline.
+type Entry struct {
+	// Time is when the change happened, as reported by its source.
+	Time time.Time `json:"time"`
+	// Node is the name of the node the change happened on.
+	Node string `json:"node"`
+	// Interface is the interface or clock the change applies to. It is empty for changes that apply to the whole
+	// node, such as a ptp4l servo state.
+	Interface string `json:"interface,omitempty"`
+	// Source is where the change was captured from.
+	Source Source `json:"source"`
+	// Kind is what changed, such as the event type, metric name or process.
+	Kind string `json:"kind"`
+	// Value is the new state, such as LOCKED or FREERUN.
+	Value string `json:"value"`
+	// Detail holds additional context, such as the event resource, metric labels or log line.
+	Detail string `json:"detail,omitempty"`
+}
+
+// Timeline is the merged list of entries captured between Start and End.
+type Timeline struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Entries []Entry   `json:"entries"`
+	// Errors are the problems encountered while capturing entries. Sources that failed are missing from the
+	// timeline but do not prevent the other sources from being recorded.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Key identifies the node and interface a group of entries belongs to.
+type Key struct {
+	Node      string
+	Interface string
+}
+
+// String returns the key as node/interface, or only the node when there is no interface.
+func (key Key) String() string {
+	if key.Interface == "" {
+		return key.Node
+	}
+
+	return key.Node + "/" + key.Interface
+}
+
+// New returns an empty timeline for the window between start and end.
+func New(start, end time.Time) *Timeline {
+	return &Timeline{Start: start, End: end, Entries: []Entry{}}
+}
+
+// Add adds entries to the timeline, dropping the ones outside of its window. Entries without a time are kept since
+// they cannot be placed but may still be useful.
+func (timeline *Timeline) Add(entries ...Entry) {
+	for _, entry := range entries {
+		if !entry.Time.IsZero() && (entry.Time.Before(timeline.Start) || entry.Time.After(timeline.End)) {
+			continue
+		}
+
+		timeline.Entries = append(timeline.Entries, entry)
+	}
+}
+
+// AddError records a problem encountered while capturing entries.
+func (timeline *Timeline) AddError(err error) {
+	timeline.Errors = append(timeline.Errors, err.Error())
+}
+
+// Sort orders the entries by time. Entries at the same time are ordered by node, interface, then source so the order
+// is deterministic.
+func (timeline *Timeline) Sort() {
+	slices.SortStableFunc(timeline.Entries, func(first, second Entry) int {
+		return cmp.Or(
+			first.Time.Compare(second.Time),
+			cmp.Compare(first.Node, second.Node),
+			cmp.Compare(first.Interface, second.Interface),
+			cmp.Compare(first.Source, second.Source))
+	})
+}
+
+// Groups returns the sorted entries of the timeline grouped by node and interface, along with the sorted keys of the
+// groups.
+func (timeline *Timeline) Groups() ([]Key, map[Key][]Entry) {
+	timeline.Sort()
+
+	groups := map[Key][]Entry{}
+
+	for _, entry := range timeline.Entries {
+		key := Key{Node: entry.Node, Interface: entry.Interface}
+		groups[key] = append(groups[key], entry)
+	}
+
+	keys := make([]Key, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(first, second Key) int {
+		return cmp.Or(cmp.Compare(first.Node, second.Node), cmp.Compare(first.Interface, second.Interface))
+	})
+
+	return keys, groups
+}
+
+// WriteJSON writes the sorted timeline to writer as indented JSON.
+func (timeline *Timeline) WriteJSON(writer io.Writer) error {
+	timeline.Sort()
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(timeline)
+}
+
+// WriteTable writes the timeline to writer as a table for each node and interface, so the changes seen by every
+// source for the same clock can be read one after another.
+func (timeline *Timeline) WriteTable(writer io.Writer) error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "PTP timeline from %s to %s\n",
+		timeline.Start.UTC().Format(time.RFC3339), timeline.End.UTC().Format(time.RFC3339))
+
+	for _, err := range timeline.Errors {
+		fmt.Fprintf(&builder, "WARNING: %s\n", err)
+	}
+
+	keys, groups := timeline.Groups()
+	if len(keys) == 0 {
+		builder.WriteString("\nNo entries were captured.\n")
+	}
+
+	for _, key := range keys {
+		fmt.Fprintf(&builder, "\n== %s ==\n", key)
+
+		tableWriter := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tableWriter, "TIME\tSOURCE\tKIND\tVALUE\tDETAIL")
+
+		for _, entry := range groups[key] {
+			fmt.Fprintf(tableWriter, "%s\t%s\t%s\t%s\t%s\n",
+				formatTime(entry.Time), entry.Source, entry.Kind, entry.Value, entry.Detail)
+		}
+
+		err := tableWriter.Flush()
+		if err != nil {
+			return fmt.Errorf("failed to format timeline table for %s: %w", key, err)
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+
+	return err
+}
+
+// formatTime returns the time in UTC with millisecond precision, or a dash for entries without a time.
+func formatTime(timestamp time.Time) string {
+	if timestamp.IsZero() {
+		return "-"
+	}
+
+	return timestamp.UTC().Format("2006-01-02T15:04:05.000Z")
+}
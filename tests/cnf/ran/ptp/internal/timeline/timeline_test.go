@@ -0,0 +1,180 @@
+package timeline
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/redhat-cne/sdk-go/pkg/event"
+	"github.com/redhat-cne/sdk-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// daemonLogs are linuxptp daemon logs retrieved with timestamps, kept as logged.
+//
+//nolint:lll
+const daemonLogs = `2025-03-01T10:00:00.100000000Z ptp4l[4312.100]: [ptp4l.0.config:6] master offset -3 s2 freq -94379 path delay 161
+2025-03-01T10:00:01.100000000Z ptp4l[4313.100]: [ptp4l.0.config:6] master offset 2 s2 freq -94374 path delay 161
+2025-03-01T10:00:01.200000000Z phc2sys[4313.200]: [ptp4l.0.config:6] CLOCK_REALTIME phc offset -5 s2 freq -19334 delay 470
+2025-03-01T10:00:02.000000000Z ptp4l[4314.000]: [ptp4l.0.config:5] port 1 (ens7f1): SLAVE to FAULTY on FAULT_DETECTED (FT_UNSPECIFIED)
+2025-03-01T10:00:02.100000000Z ptp4l[4314.100]: [ptp4l.0.config:6] master offset 1021 s0 freq -94374 path delay 161
+2025-03-01T10:00:02.200000000Z ptp4l[4314.200]: [ptp4l.0.config] CLOCK_CLASS_CHANGE 248
+2025-03-01T10:00:03.100000000Z I0301 10:00:03.100000 1 daemon.go:123] unrelated daemon message
+2025-03-01T10:00:04.100000000Z GM[4316.100]: [ts2phc.0.config] ens7f0 T-GM-STATUS s0
+2025-03-01T10:00:05.100000000Z ptp4l[4317.100]: [ptp4l.0.config:6] master offset 1021 s0 freq -94374 path delay 161
+`
+
+func TestLogEntries(t *testing.T) {
+	entries := LogEntries("node-1", []byte(daemonLogs))
+
+	assert.Len(t, entries, 6)
+
+	assert.Equal(t, Entry{
+		Time:   time.Date(2025, 3, 1, 10, 0, 0, 100000000, time.UTC),
+		Node:   "node-1",
+		Source: SourceLog,
+		Kind:   "ptp4l servo",
+		Value:  "s2 (locked)",
+		Detail: "[ptp4l.0.config] master offset -3 s2 freq -94379 path delay 161",
+	}, entries[0])
+
+	assert.Equal(t, "CLOCK_REALTIME", entries[1].Interface)
+	assert.Equal(t, "phc2sys servo", entries[1].Kind)
+
+	assert.Equal(t, "ens7f1", entries[2].Interface)
+	assert.Equal(t, "ptp4l port state", entries[2].Kind)
+	assert.Equal(t, "FAULTY", entries[2].Value)
+	assert.Equal(t, "SLAVE to FAULTY on FAULT_DETECTED [ptp4l.0.config]", entries[2].Detail)
+
+	assert.Equal(t, "s0 (unlocked)", entries[3].Value)
+	assert.True(t, strings.HasPrefix(entries[3].Detail, "from s2: "))
+
+	assert.Equal(t, "ptp4l clock class", entries[4].Kind)
+	assert.Equal(t, "248", entries[4].Value)
+
+	assert.Equal(t, "ens7f0", entries[5].Interface)
+	assert.Equal(t, "GM servo", entries[5].Kind)
+}
+
+func TestEventEntries(t *testing.T) {
+	eventTime := time.Date(2025, 3, 1, 10, 0, 2, 500000000, time.UTC)
+	receivedEvents := []event.Event{
+		{
+			Type: "event.sync.ptp-status.ptp-state-change",
+			Time: &types.Timestamp{Time: eventTime},
+			Data: &event.Data{Values: []event.DataValue{
+				{
+					Resource:  "/cluster/node/node-1/ens7fx/master",
+					DataType:  event.NOTIFICATION,
+					ValueType: event.ENUMERATION,
+					Value:     "FREERUN",
+				},
+				{
+					Resource:  "/cluster/node/node-1/ens7fx/master",
+					DataType:  event.METRIC,
+					ValueType: event.DECIMAL,
+					Value:     1021.0,
+				},
+			}},
+		},
+		{Type: "event.sync.ptp-status.ptp-clock-class-change"},
+		{
+			Type: "event.sync.sync-status.os-clock-sync-state-change",
+			Data: &event.Data{Values: []event.DataValue{{Resource: "/sync/sync-status/sync-state", Value: "LOCKED"}}},
+		},
+	}
+
+	entries := EventEntries("node-2", receivedEvents)
+
+	assert.Len(t, entries, 3)
+	assert.Equal(t, Entry{
+		Time:      eventTime,
+		Node:      "node-1",
+		Interface: "ens7fx",
+		Source:    SourceEvent,
+		Kind:      "ptp-status.ptp-state-change",
+		Value:     "FREERUN",
+		Detail:    "notification /cluster/node/node-1/ens7fx/master",
+	}, entries[0])
+	assert.Equal(t, "1021", entries[1].Value)
+	assert.Equal(t, "node-2", entries[2].Node)
+	assert.Empty(t, entries[2].Interface)
+	assert.True(t, entries[2].Time.IsZero())
+}
+
+func TestMetricEntries(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	matrix := model.Matrix{
+		{
+			Metric: model.Metric{
+				model.MetricNameLabel: "openshift_ptp_clock_state",
+				"node":                "node-1",
+				"iface":               "ens7fx",
+				"process":             "ptp4l",
+			},
+			Values: []model.SamplePair{
+				{Timestamp: model.TimeFromUnixNano(start.UnixNano()), Value: 1},
+				{Timestamp: model.TimeFromUnixNano(start.Add(30 * time.Second).UnixNano()), Value: 1},
+				{Timestamp: model.TimeFromUnixNano(start.Add(60 * time.Second).UnixNano()), Value: 0},
+			},
+		},
+		{
+			Metric: model.Metric{model.MetricNameLabel: "openshift_ptp_clock_class", "node": "node-1"},
+			Values: []model.SamplePair{{Timestamp: model.TimeFromUnixNano(start.UnixNano()), Value: 248}},
+		},
+	}
+
+	entries := MetricEntries(matrix)
+
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "clock_state (ptp4l)", entries[0].Kind)
+	assert.Equal(t, "LOCKED (1)", entries[0].Value)
+	assert.Equal(t, "ens7fx", entries[0].Interface)
+	assert.Equal(t, "FREERUN (0)", entries[1].Value)
+	assert.True(t, entries[1].Time.Equal(start.Add(time.Minute)))
+	assert.Equal(t, "clock_class", entries[2].Kind)
+	assert.Equal(t, "248", entries[2].Value)
+}
+
+func TestTimeline(t *testing.T) {
+	start := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	timeline := New(start, start.Add(time.Minute))
+
+	timeline.Add(
+		Entry{Time: start.Add(20 * time.Second), Node: "node-1", Interface: "ens7fx", Source: SourceMetric,
+			Kind: "clock_state (ptp4l)", Value: "FREERUN (0)"},
+		Entry{Time: start.Add(2 * time.Minute), Node: "node-1", Source: SourceLog, Kind: "ptp4l servo"},
+		Entry{Time: start.Add(10 * time.Second), Node: "node-1", Interface: "ens7fx", Source: SourceEvent,
+			Kind: "ptp-status.ptp-state-change", Value: "FREERUN"},
+		Entry{Time: start.Add(5 * time.Second), Node: "node-1", Source: SourceLog, Kind: "ptp4l servo",
+			Value: "s0 (unlocked)"},
+	)
+	timeline.AddError(os.ErrDeadlineExceeded)
+
+	assert.Len(t, timeline.Entries, 3)
+
+	keys, groups := timeline.Groups()
+	assert.Equal(t, []Key{{Node: "node-1"}, {Node: "node-1", Interface: "ens7fx"}}, keys)
+	assert.Equal(t, SourceEvent, groups[keys[1]][0].Source)
+	assert.Equal(t, "node-1/ens7fx", keys[1].String())
+
+	var table bytes.Buffer
+
+	assert.Nil(t, timeline.WriteTable(&table))
+	assert.Contains(t, table.String(), "PTP timeline from 2025-03-01T10:00:00Z to 2025-03-01T10:01:00Z")
+	assert.Contains(t, table.String(), "WARNING: i/o timeout")
+	assert.Contains(t, table.String(), "== node-1/ens7fx ==")
+	assert.Regexp(t, `2025-03-01T10:00:10.000Z\s+event\s+ptp-status.ptp-state-change\s+FREERUN`, table.String())
+
+	outputDir := t.TempDir()
+	assert.Nil(t, WriteFiles(timeline, outputDir))
+	assert.FileExists(t, filepath.Join(outputDir, TableFileName))
+
+	jsonContent, err := os.ReadFile(filepath.Join(outputDir, JSONFileName))
+	assert.Nil(t, err)
+	assert.Contains(t, string(jsonContent), `"kind": "ptp-status.ptp-state-change"`)
+}
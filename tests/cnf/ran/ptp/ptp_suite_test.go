@@ -12,11 +12,15 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/querier"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/consumer"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/mustgather"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/timeline"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/tests"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
+	"k8s.io/klog/v2"
 )
 
 var _, currentFile, _, _ = runtime.Caller(0)
@@ -29,16 +33,45 @@ func TestPTP(t *testing.T) {
 	RunSpecs(t, "RAN PTP Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("checking that the spoke 1 cluster is present")
 
 	isSpoke1Present := rancluster.AreClustersPresent([]*clients.Settings{Spoke1APIClient})
 	Expect(isSpoke1Present).To(BeTrue(), "Spoke 1 cluster must be present for PTP tests")
 
+	if RANConfig.PtpConfigLint {
+		By("linting PtpConfigs")
+
+		findings, err := profiles.LintCluster(RANConfig.Spoke1APIClient)
+		Expect(err).ToNot(HaveOccurred(), "Failed to lint PtpConfigs on spoke 1")
+
+		if len(findings) > 0 {
+			klog.V(tsparams.LogLevel).Infof("PtpConfig lint findings:\n%s", profiles.FormatLintFindings(findings))
+			AddReportEntry("ptp-config-lint", profiles.FormatLintFindings(findings))
+		}
+
+		lintErrors := profiles.LintErrors(findings)
+		Expect(lintErrors).To(BeEmpty(),
+			"PtpConfigs on spoke 1 are misconfigured:\n%s", profiles.FormatLintFindings(lintErrors))
+	}
+
 	By("deploying consumers")
 
 	err := consumer.DeployConsumersOnNodes(RANConfig.Spoke1APIClient)
 	Expect(err).ToNot(HaveOccurred(), "Failed to deploy consumers on nodes with PTP daemons")
+
+	By("registering failure artifact collectors")
+
+	// The timeline is collected first so it ends as close as possible to the failure.
+	reporter.RegisterCollector(
+		timeline.NewCollector(timeline.NewRecorder(RANConfig.Spoke1APIClient, nil)), timeline.CollectorTimeout)
+	reporter.RegisterCollector(mustgather.NewCollector(RANConfig.Spoke1APIClient), mustgather.CollectorTimeout)
+	reporter.RegisterCollector(
+		reporter.NewEventsCollector(RANConfig.Spoke1APIClient, ranparam.PtpOperatorNamespace), 0)
 })
 
 var _ = AfterSuite(func() {
@@ -56,7 +89,6 @@ var _ = AfterSuite(func() {
 var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, tsparams.ReporterSpokeNamespacesToDump, tsparams.ReporterSpokeCRsToDump)
-	mustgather.MustGatherIfFailed(CurrentSpecReport(), currentFile, RANConfig.Spoke1APIClient)
 })
 
 var _ = ReportAfterSuite("", func(report Report) {
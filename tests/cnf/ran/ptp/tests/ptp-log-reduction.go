@@ -17,12 +17,13 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/querier"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/daemonlogs"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/metrics"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/profiles"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/ptpdaemon"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/ptp/internal/tsparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement/skip"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
@@ -48,9 +49,12 @@ var (
 	// log pattern when offset is any value.
 	// used in test 83586.
 	fullLogPatternAny = regexp.MustCompile(`master\soffset\s+([+-]?\d+)`)
+
+	// log reduction is only supported for PTP version 4.20 and higher.
+	logReductionRequirement = requirement.OperatorVersionAtLeast(requirement.Spoke1, string(ranparam.PTP), "4.20")
 )
 
-var _ = Describe("PTP Log Reduction", Label(tsparams.LabelLogReduction), func() {
+var _ = Describe("PTP Log Reduction", Label(tsparams.LabelLogReduction), skip.Unless(logReductionRequirement), func() {
 	var (
 		prometheusAPI   prometheusv1.API
 		savedPtpConfigs []*ptp.PtpConfigBuilder
@@ -59,15 +63,6 @@ var _ = Describe("PTP Log Reduction", Label(tsparams.LabelLogReduction), func()
 	BeforeEach(func() {
 		var err error
 
-		By("skipping if the PTP version is not supported")
-
-		inRange, err := version.IsVersionStringInRange(RANConfig.Spoke1OperatorVersions[ranparam.PTP], "4.20", "")
-		Expect(err).ToNot(HaveOccurred(), "Failed to check PTP version range")
-
-		if !inRange {
-			Skip("log reduction is only supported for PTP version 4.20 and higher")
-		}
-
 		By("creating a Prometheus API client")
 
 		prometheusAPI, err = querier.CreatePrometheusAPIForCluster(RANConfig.Spoke1APIClient)
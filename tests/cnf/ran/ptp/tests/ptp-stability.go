@@ -53,6 +53,10 @@ var _ = Describe("PTP Stability", Label(tsparams.LabelStability), func() {
 		nodeInfoMap, err := profiles.GetNodeInfoMap(RANConfig.Spoke1APIClient)
 		Expect(err).ToNot(HaveOccurred(), "Failed to get node info map")
 
+		thresholdProfile, err := stability.GetThresholdProfile(
+			RANConfig.PtpStabilityProfile, RANConfig.PtpStabilityThreshold)
+		Expect(err).ToNot(HaveOccurred(), "Failed to get PTP stability threshold profile")
+
 		// Since the collection is sequential, test time scales linearly with the number of nodes. Node counts
 		// are expected to be small, so the test is kept sequential to avoid complexity of parallelization.
 		for _, nodeInfo := range nodeInfoMap {
@@ -97,8 +101,7 @@ var _ = Describe("PTP Stability", Label(tsparams.LabelStability), func() {
 
 			By("analyzing collected daemon logs for node " + nodeInfo.Name)
 
-			analysisResult, err := stability.AnalyzeFromFile(
-				collectionResult.TempFilePath, RANConfig.PtpStabilityThreshold)
+			analysisResult, err := stability.AnalyzeFromFileWithProfile(collectionResult.TempFilePath, thresholdProfile)
 			Expect(err).ToNot(HaveOccurred(), "Failed to analyze daemon logs for node %s", nodeInfo.Name)
 
 			AddReportEntry("ptp_stability_analysis_"+nodeInfo.Name, analysisResult.DiagnosticMessage())
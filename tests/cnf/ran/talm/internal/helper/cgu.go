@@ -21,15 +21,18 @@ import (
 
 // WaitForCguBlocked waits up to the timeout until the provided cguBuilder matches the condition for being blocked.
 func WaitForCguBlocked(cguBuilder *cgu.CguBuilder, message string) error {
-	blockedCondition := metav1.Condition{
+	_, err := cguBuilder.WaitForCondition(CguBlockedCondition(message), 6*time.Minute)
+
+	return err
+}
+
+// CguBlockedCondition returns the condition for a CGU being blocked with the provided message.
+func CguBlockedCondition(message string) metav1.Condition {
+	return metav1.Condition{
 		Type:    tsparams.ProgressingType,
 		Status:  metav1.ConditionFalse,
 		Message: message,
 	}
-
-	_, err := cguBuilder.WaitForCondition(blockedCondition, 6*time.Minute)
-
-	return err
 }
 
 // SetupCguWithNamespace creates the policy with a namespace and its components for a cguBuilder then creates the
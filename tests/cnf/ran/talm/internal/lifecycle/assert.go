@@ -0,0 +1,243 @@
+package lifecycle
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+)
+
+// Matcher matches events in a History. Empty fields match any value, so the zero Matcher matches every event.
+type Matcher struct {
+	Kind    Kind
+	Subject string
+	Value   string
+	Reason  string
+	// Message matches any event whose message contains it, rather than requiring an exact match.
+	Message string
+}
+
+// Matches returns whether the event is matched by the matcher.
+func (matcher Matcher) Matches(event Event) bool {
+	return (matcher.Kind == "" || matcher.Kind == event.Kind) &&
+		(matcher.Subject == "" || matcher.Subject == event.Subject) &&
+		(matcher.Value == "" || matcher.Value == event.Value) &&
+		(matcher.Reason == "" || matcher.Reason == event.Reason) &&
+		(matcher.Message == "" || strings.Contains(event.Message, matcher.Message))
+}
+
+// String returns a description of the events the matcher matches.
+func (matcher Matcher) String() string {
+	return Event{
+		Kind:    cmp.Or(matcher.Kind, "*"),
+		Subject: matcher.Subject,
+		Value:   cmp.Or(matcher.Value, "*"),
+		Reason:  matcher.Reason,
+		Message: matcher.Message,
+	}.String()
+}
+
+// CguStarted matches the CGU starting.
+func CguStarted() Matcher {
+	return Matcher{Kind: KindCgu, Value: ValueStarted}
+}
+
+// CguFinished matches the CGU finishing, whether it succeeded or timed out.
+func CguFinished() Matcher {
+	return Matcher{Kind: KindCgu, Value: ValueFinished}
+}
+
+// Condition matches the CGU condition changing to expected. Like cgu.CguBuilder.WaitForCondition, empty fields of
+// expected are ignored and the message only needs to be contained in the actual message, so the condition variables in
+// tsparams can be used directly.
+func Condition(expected metav1.Condition) Matcher {
+	return Matcher{
+		Kind:    KindCondition,
+		Subject: expected.Type,
+		Value:   string(expected.Status),
+		Reason:  expected.Reason,
+		Message: expected.Message,
+	}
+}
+
+// BatchStarted matches the batch starting. Batches are numbered from 1.
+func BatchStarted(batch int) Matcher {
+	return Matcher{Kind: KindBatch, Subject: strconv.Itoa(batch), Value: ValueStarted}
+}
+
+// BatchEnded matches the batch ending, either because the next batch started or because the CGU finished.
+func BatchEnded(batch int) Matcher {
+	return Matcher{Kind: KindBatch, Subject: strconv.Itoa(batch), Value: ValueEnded}
+}
+
+// Remediation matches the remediation of cluster in the current batch changing to state, which is one of
+// v1alpha1.NotStarted, v1alpha1.InProgress, or v1alpha1.Completed. An empty state matches any change.
+func Remediation(cluster, state string) Matcher {
+	return Matcher{Kind: KindRemediation, Subject: cluster, Value: state}
+}
+
+// ClusterState matches the state of cluster changing to state once its batch ends, such as complete or timedout.
+func ClusterState(cluster, state string) Matcher {
+	return Matcher{Kind: KindCluster, Subject: cluster, Value: state}
+}
+
+// Precache matches the precaching state of cluster changing to state, such as Starting, Succeeded, or
+// UnrecoverableError.
+func Precache(cluster, state string) Matcher {
+	return Matcher{Kind: KindPrecache, Subject: cluster, Value: state}
+}
+
+// Backup matches the backup state of cluster changing to state, such as Starting, Succeeded, or UnrecoverableError.
+func Backup(cluster, state string) Matcher {
+	return Matcher{Kind: KindBackup, Subject: cluster, Value: state}
+}
+
+// PolicyCompliance matches the compliance of the managed policy on cluster changing to state.
+func PolicyCompliance(policy, cluster string, state policiesv1.ComplianceState) Matcher {
+	return Matcher{Kind: KindPolicy, Subject: policySubject(policy, cluster), Value: string(state)}
+}
+
+// Assertion is a check on the events in a History.
+type Assertion interface {
+	// Check returns an error describing why the history does not satisfy the assertion, or nil if it does.
+	Check(history *History) error
+}
+
+// Occurrence asserts that an event happened and, optionally, when it happened relative to other events. Only the
+// first matching event of each matcher is considered.
+type Occurrence struct {
+	matcher   Matcher
+	after     []Matcher
+	before    []Matcher
+	within    time.Duration
+	notBefore time.Time
+}
+
+// Happened returns an assertion that an event matched by matcher happened.
+func Happened(matcher Matcher) *Occurrence {
+	return &Occurrence{matcher: matcher}
+}
+
+// After additionally asserts that the event happened after an event matched by other, which must also have happened.
+func (occurrence *Occurrence) After(other Matcher) *Occurrence {
+	occurrence.after = append(occurrence.after, other)
+
+	return occurrence
+}
+
+// Before additionally asserts that the event happened before an event matched by other, which must also have
+// happened. Use Never to assert that other did not happen instead.
+func (occurrence *Occurrence) Before(other Matcher) *Occurrence {
+	occurrence.before = append(occurrence.before, other)
+
+	return occurrence
+}
+
+// Within additionally asserts that the event happened within timeout of the history starting.
+func (occurrence *Occurrence) Within(timeout time.Duration) *Occurrence {
+	occurrence.within = timeout
+
+	return occurrence
+}
+
+// NotBefore additionally asserts that the event did not happen before timestamp. Since the CGU status only has second
+// precision, use this with times from the status, such as an event found in the history of another CGU.
+func (occurrence *Occurrence) NotBefore(timestamp time.Time) *Occurrence {
+	occurrence.notBefore = timestamp
+
+	return occurrence
+}
+
+// Check implements the Assertion interface.
+func (occurrence *Occurrence) Check(history *History) error {
+	index := history.First(occurrence.matcher)
+	if index < 0 {
+		return fmt.Errorf("expected %s to happen but it did not", occurrence.matcher)
+	}
+
+	event := history.Events[index]
+
+	for _, other := range occurrence.after {
+		otherIndex := history.First(other)
+		if otherIndex < 0 {
+			return fmt.Errorf("expected %s to happen after %s but %s did not happen", occurrence.matcher, other, other)
+		}
+
+		if otherIndex > index {
+			return fmt.Errorf("expected %s to happen after %s but it happened %s before",
+				occurrence.matcher, other, history.Events[otherIndex].Time.Sub(event.Time))
+		}
+	}
+
+	for _, other := range occurrence.before {
+		otherIndex := history.First(other)
+		if otherIndex < 0 {
+			return fmt.Errorf("expected %s to happen before %s but %s did not happen", occurrence.matcher, other, other)
+		}
+
+		if otherIndex < index {
+			return fmt.Errorf("expected %s to happen before %s but it happened %s after",
+				occurrence.matcher, other, event.Time.Sub(history.Events[otherIndex].Time))
+		}
+	}
+
+	if event.Time.Before(occurrence.notBefore) {
+		return fmt.Errorf("expected %s to happen at or after %s but it happened at %s",
+			occurrence.matcher, formatTime(occurrence.notBefore), formatTime(event.Time))
+	}
+
+	if occurrence.within > 0 {
+		elapsed := event.Time.Sub(history.Start)
+		if elapsed > occurrence.within {
+			return fmt.Errorf("expected %s to happen within %s but it happened after %s",
+				occurrence.matcher, occurrence.within, elapsed.Truncate(time.Second))
+		}
+	}
+
+	return nil
+}
+
+// never asserts that no event matched by matcher happened.
+type never struct {
+	matcher Matcher
+}
+
+// Never returns an assertion that no event matched by matcher happened.
+func Never(matcher Matcher) Assertion {
+	return never{matcher: matcher}
+}
+
+// Check implements the Assertion interface.
+func (assertion never) Check(history *History) error {
+	index := history.First(assertion.matcher)
+	if index < 0 {
+		return nil
+	}
+
+	return fmt.Errorf("expected %s to never happen but it happened at %s",
+		assertion.matcher, formatTime(history.Events[index].Time))
+}
+
+// Verify checks every assertion against the history, returning an error describing each failed assertion followed by
+// the history itself. Nil is returned if all the assertions pass.
+func (history *History) Verify(assertions ...Assertion) error {
+	var errs []error
+
+	for _, assertion := range assertions {
+		err := assertion.Check(history)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w\n\n%s", errors.Join(errs...), history)
+}
@@ -0,0 +1,255 @@
+package lifecycle
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// snapshot is the state of a CGU and its managed policies at a single point in time.
+type snapshot struct {
+	// cgu is nil when the CGU does not exist.
+	cgu *v1alpha1.ClusterGroupUpgrade
+	// compliance maps the subject of policy events, policy/cluster, to the compliance state.
+	compliance map[string]string
+}
+
+// diffSnapshots returns the events for the changes between previous and current, in the order they happened. The
+// previous snapshot is empty for the first poll, so the events describe the initial state of the CGU.
+func diffSnapshots(previous, current snapshot, observedAt time.Time) []Event {
+	newEvent := func(timestamp metav1.Time, kind Kind, subject, value string) Event {
+		eventTime := observedAt
+		if !timestamp.IsZero() {
+			eventTime = timestamp.Time
+		}
+
+		return Event{Time: eventTime, ObservedAt: observedAt, Kind: kind, Subject: subject, Value: value}
+	}
+
+	var (
+		events         []Event
+		previousStatus v1alpha1.ClusterGroupUpgradeStatus
+		currentStatus  v1alpha1.ClusterGroupUpgradeStatus
+	)
+
+	if previous.cgu != nil {
+		previousStatus = previous.cgu.Status
+	}
+
+	if current.cgu != nil {
+		currentStatus = current.cgu.Status
+	}
+
+	if previousStatus.Status.StartedAt.IsZero() && !currentStatus.Status.StartedAt.IsZero() {
+		events = append(events, newEvent(currentStatus.Status.StartedAt, KindCgu, "", ValueStarted))
+	}
+
+	events = append(events, diffConditions(previousStatus.Conditions, currentStatus.Conditions, observedAt)...)
+
+	previousBatch := previousStatus.Status.CurrentBatch
+	currentBatch := currentStatus.Status.CurrentBatch
+	previousFinished := isFinished(previousStatus)
+	currentFinished := isFinished(currentStatus)
+
+	// The batch number is kept once the CGU finishes, so a batch ends either when the next one starts or when the CGU
+	// finishes. Ending at the same time as the next batch starts keeps them in order once the history is sorted.
+	if previousBatch != currentBatch && !previousFinished {
+		if previousBatch > 0 {
+			events = append(events, newEvent(currentStatus.Status.CurrentBatchStartedAt,
+				KindBatch, strconv.Itoa(previousBatch), ValueEnded))
+		}
+
+		if currentBatch > 0 {
+			events = append(events,
+				newEvent(currentStatus.Status.CurrentBatchStartedAt, KindBatch, strconv.Itoa(currentBatch), ValueStarted))
+		}
+	}
+
+	events = append(events, diffRemediation(previousStatus.Status.CurrentBatchRemediationProgress,
+		currentStatus.Status.CurrentBatchRemediationProgress, observedAt)...)
+	events = append(events, diffMaps(KindCluster, clusterStates(previousStatus.Clusters),
+		clusterStates(currentStatus.Clusters), observedAt)...)
+	events = append(events, diffMaps(KindPrecache, precacheStates(previousStatus.Precaching),
+		precacheStates(currentStatus.Precaching), observedAt)...)
+	events = append(events, diffMaps(KindBackup, backupStates(previousStatus.Backup),
+		backupStates(currentStatus.Backup), observedAt)...)
+	events = append(events, diffMaps(KindPolicy, previous.compliance, current.compliance, observedAt)...)
+
+	if currentFinished && !previousFinished {
+		finishedAt := getFinishedAt(currentStatus)
+
+		if currentBatch > 0 {
+			events = append(events, newEvent(finishedAt, KindBatch, strconv.Itoa(currentBatch), ValueEnded))
+		}
+
+		events = append(events, newEvent(finishedAt, KindCgu, "", ValueFinished))
+	}
+
+	return events
+}
+
+// succeededType is the type of the CGU condition set once the CGU finishes. It matches tsparams.SucceededType, which
+// is not used directly so this package does not load the test configuration.
+const succeededType = "Succeeded"
+
+// isFinished returns whether the CGU has finished, either by completing or by having a Succeeded condition with any
+// status, which is the case for timeouts.
+func isFinished(status v1alpha1.ClusterGroupUpgradeStatus) bool {
+	if !status.Status.CompletedAt.IsZero() {
+		return true
+	}
+
+	for _, condition := range status.Conditions {
+		if condition.Type == succeededType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getFinishedAt returns when the CGU finished, which is when it completed or, if it did not complete, the last
+// transition of the Succeeded condition. The zero time is returned if neither is available.
+func getFinishedAt(status v1alpha1.ClusterGroupUpgradeStatus) metav1.Time {
+	if !status.Status.CompletedAt.IsZero() {
+		return status.Status.CompletedAt
+	}
+
+	for _, condition := range status.Conditions {
+		if condition.Type == succeededType {
+			return condition.LastTransitionTime
+		}
+	}
+
+	return metav1.Time{}
+}
+
+// diffConditions returns an event for each condition that was added or whose status, reason, or message changed. The
+// last transition time is only used when the status changed since it is not updated otherwise.
+func diffConditions(previous, current []metav1.Condition, observedAt time.Time) []Event {
+	var events []Event
+
+	for _, condition := range current {
+		index := slices.IndexFunc(previous, func(old metav1.Condition) bool { return old.Type == condition.Type })
+
+		eventTime := observedAt
+
+		if index >= 0 {
+			old := previous[index]
+			if old.Status == condition.Status && old.Reason == condition.Reason && old.Message == condition.Message {
+				continue
+			}
+
+			if old.Status != condition.Status && !condition.LastTransitionTime.IsZero() {
+				eventTime = condition.LastTransitionTime.Time
+			}
+		} else if !condition.LastTransitionTime.IsZero() {
+			eventTime = condition.LastTransitionTime.Time
+		}
+
+		events = append(events, Event{
+			Time:       eventTime,
+			ObservedAt: observedAt,
+			Kind:       KindCondition,
+			Subject:    condition.Type,
+			Value:      string(condition.Status),
+			Reason:     condition.Reason,
+			Message:    condition.Message,
+		})
+	}
+
+	return events
+}
+
+// diffRemediation returns an event for each cluster whose remediation state changed in the current batch. Clusters
+// leaving the map are not reported since they are reported by their cluster state instead.
+func diffRemediation(
+	previous, current map[string]*v1alpha1.ClusterRemediationProgress, observedAt time.Time) []Event {
+	previousStates := make(map[string]string)
+	currentStates := make(map[string]string)
+
+	for cluster, progress := range previous {
+		if progress != nil {
+			previousStates[cluster] = progress.State
+		}
+	}
+
+	for cluster, progress := range current {
+		if progress != nil {
+			currentStates[cluster] = progress.State
+		}
+	}
+
+	events := diffMaps(KindRemediation, previousStates, currentStates, observedAt)
+
+	for index, event := range events {
+		if event.Value != v1alpha1.Completed {
+			continue
+		}
+
+		if progress := current[event.Subject]; progress != nil && !progress.FirstCompliantAt.IsZero() {
+			events[index].Time = progress.FirstCompliantAt.Time
+		}
+	}
+
+	return events
+}
+
+// diffMaps returns an event for each key whose value was added or changed between previous and current, sorted by
+// key so events from the same poll are in a stable order.
+func diffMaps(kind Kind, previous, current map[string]string, observedAt time.Time) []Event {
+	var events []Event
+
+	for key, value := range current {
+		if oldValue, ok := previous[key]; ok && oldValue == value {
+			continue
+		}
+
+		events = append(events, Event{Time: observedAt, ObservedAt: observedAt, Kind: kind, Subject: key, Value: value})
+	}
+
+	slices.SortFunc(events, func(first, second Event) int {
+		return strings.Compare(first.Subject, second.Subject)
+	})
+
+	return events
+}
+
+// clusterStates returns a map of cluster name to state.
+func clusterStates(clusters []v1alpha1.ClusterState) map[string]string {
+	states := make(map[string]string)
+
+	for _, cluster := range clusters {
+		states[cluster.Name] = cluster.State
+	}
+
+	return states
+}
+
+// precacheStates returns the precaching status, which may be nil.
+func precacheStates(precaching *v1alpha1.PrecachingStatus) map[string]string {
+	if precaching == nil {
+		return nil
+	}
+
+	return precaching.Status
+}
+
+// backupStates returns the backup status, which may be nil.
+func backupStates(backup *v1alpha1.BackupStatus) map[string]string {
+	if backup == nil {
+		return nil
+	}
+
+	return backup.Status
+}
+
+// policySubject returns the subject used for policy events.
+func policySubject(policy, cluster string) string {
+	return fmt.Sprintf("%s/%s", policy, cluster)
+}
@@ -0,0 +1,187 @@
+// Package lifecycle records how a ClusterGroupUpgrade progresses during a spec and provides assertions on the order of
+// what happened. Rather than waiting for a single condition at a time, a spec starts a [Recorder] once the CGU is
+// defined, waits for the CGU to finish, then verifies the [History]:
+//
+//	recorder := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace).Start()
+//	...
+//	history := recorder.Stop()
+//	Expect(history.Verify(
+//		lifecycle.Happened(lifecycle.BatchStarted(2)).After(lifecycle.BatchEnded(1)),
+//		lifecycle.Never(lifecycle.Remediation(RANConfig.Spoke1Name, v1alpha1.InProgress)),
+//	)).To(Succeed())
+//
+// When an assertion fails, the error includes the full history so the failure can be understood without rerunning the
+// spec.
+package lifecycle
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Kind is the part of the CGU or its policies an event is about.
+type Kind string
+
+const (
+	// KindCgu is used for the CGU starting and finishing. The subject is empty.
+	KindCgu Kind = "CGU"
+	// KindCondition is used for changes to the conditions of the CGU. The subject is the condition type and the value
+	// is the condition status.
+	KindCondition Kind = "Condition"
+	// KindBatch is used for batches starting and ending. The subject is the batch number, starting from 1.
+	KindBatch Kind = "Batch"
+	// KindRemediation is used for changes to the remediation progress of clusters in the current batch. The subject is
+	// the cluster and the value is one of NotStarted, InProgress, or Completed.
+	KindRemediation Kind = "Remediation"
+	// KindCluster is used for changes to the state of clusters once their batch has ended, such as complete or
+	// timedout. The subject is the cluster.
+	KindCluster Kind = "Cluster"
+	// KindPrecache is used for changes to the precaching state of clusters. The subject is the cluster.
+	KindPrecache Kind = "Precache"
+	// KindBackup is used for changes to the backup state of clusters. The subject is the cluster.
+	KindBackup Kind = "Backup"
+	// KindPolicy is used for changes to the compliance of the managed policies on clusters. The subject is the policy
+	// and cluster, separated by a slash.
+	KindPolicy Kind = "Policy"
+)
+
+const (
+	// ValueStarted is the value of events for the CGU or a batch starting.
+	ValueStarted = "Started"
+	// ValueEnded is the value of events for a batch ending, either because the next batch started or because the CGU
+	// finished.
+	ValueEnded = "Ended"
+	// ValueFinished is the value of the event for the CGU finishing, whether it succeeded or not.
+	ValueFinished = "Finished"
+)
+
+// Event is a single change recorded for the CGU or its managed policies.
+type Event struct {
+	// Time is when the change happened. It is taken from the status of the CGU when available, such as the start of
+	// a batch or the last transition of a condition, and is otherwise the same as ObservedAt.
+	Time time.Time `json:"time"`
+	// ObservedAt is when the Recorder saw the change.
+	ObservedAt time.Time `json:"observedAt"`
+	Kind       Kind      `json:"kind"`
+	Subject    string    `json:"subject,omitempty"`
+	Value      string    `json:"value"`
+	Reason     string    `json:"reason,omitempty"`
+	Message    string    `json:"message,omitempty"`
+}
+
+// String returns the event on a single line, without the times.
+func (event Event) String() string {
+	var builder strings.Builder
+
+	builder.WriteString(string(event.Kind))
+
+	if event.Subject != "" {
+		fmt.Fprintf(&builder, " %s", event.Subject)
+	}
+
+	fmt.Fprintf(&builder, " %s", event.Value)
+
+	if event.Reason != "" {
+		fmt.Fprintf(&builder, " (%s)", event.Reason)
+	}
+
+	if event.Message != "" {
+		fmt.Fprintf(&builder, ": %s", event.Message)
+	}
+
+	return builder.String()
+}
+
+// History is every event recorded for a CGU between Start and End, in the order they happened.
+type History struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Events    []Event   `json:"events"`
+}
+
+// First returns the index of the first event matching matcher, or -1 if no event matches.
+func (history *History) First(matcher Matcher) int {
+	for index, event := range history.Events {
+		if matcher.Matches(event) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Find returns the first event matching matcher and whether one was found. It is useful for comparing events across
+// the histories of different CGUs, such as with Occurrence.NotBefore.
+func (history *History) Find(matcher Matcher) (Event, bool) {
+	index := history.First(matcher)
+	if index < 0 {
+		return Event{}, false
+	}
+
+	return history.Events[index], true
+}
+
+// All returns every event matching matcher.
+func (history *History) All(matcher Matcher) []Event {
+	var events []Event
+
+	for _, event := range history.Events {
+		if matcher.Matches(event) {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// sort orders the events by time. Events at the same time keep the order they were recorded in, which is the order
+// they happened in for events seen in the same poll, such as a batch ending before the next one starts.
+func (history *History) sort() {
+	slices.SortStableFunc(history.Events, func(first, second Event) int {
+		return cmp.Compare(first.Time.UnixNano(), second.Time.UnixNano())
+	})
+}
+
+// WriteTable writes the history to writer as a table with one event per line.
+func (history *History) WriteTable(writer io.Writer) error {
+	fmt.Fprintf(writer, "CGU %s/%s from %s to %s\n", history.Namespace, history.Name,
+		formatTime(history.Start), formatTime(history.End))
+
+	if len(history.Events) == 0 {
+		_, err := io.WriteString(writer, "No events were recorded.\n")
+
+		return err
+	}
+
+	tableWriter := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tableWriter, "TIME\tOFFSET\tKIND\tSUBJECT\tVALUE\tREASON\tMESSAGE")
+
+	for _, event := range history.Events {
+		fmt.Fprintf(tableWriter, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", formatTime(event.Time),
+			event.Time.Sub(history.Start).Truncate(time.Second), event.Kind, event.Subject, event.Value, event.Reason,
+			event.Message)
+	}
+
+	return tableWriter.Flush()
+}
+
+// String returns the history as a table.
+func (history *History) String() string {
+	var builder strings.Builder
+
+	_ = history.WriteTable(&builder)
+
+	return builder.String()
+}
+
+// formatTime returns the time in UTC with second precision, which matches the precision of the CGU status.
+func formatTime(timestamp time.Time) string {
+	return timestamp.UTC().Format(time.RFC3339)
+}
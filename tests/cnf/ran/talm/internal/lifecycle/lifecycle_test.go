@@ -0,0 +1,245 @@
+package lifecycle
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+)
+
+var testStart = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+// at returns the time offset seconds after testStart.
+func at(offset int) time.Time {
+	return testStart.Add(time.Duration(offset) * time.Second)
+}
+
+// newSnapshot returns a snapshot of a CGU with the provided status and policy compliance.
+func newSnapshot(status v1alpha1.ClusterGroupUpgradeStatus, compliance map[string]string) snapshot {
+	return snapshot{cgu: &v1alpha1.ClusterGroupUpgrade{Status: status}, compliance: compliance}
+}
+
+// batchingHistory returns the history of a CGU with spoke1 in the first batch and spoke2 in the second, as recorded
+// from a poll every 15 seconds. The first batch times out on spoke1 and the CGU continues to the second batch, which
+// completes before the CGU times out.
+func batchingHistory() *History {
+	progressing := metav1.Condition{
+		Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress", LastTransitionTime: metav1.NewTime(at(1)),
+	}
+	timedOut := metav1.Condition{
+		Type: succeededType, Status: metav1.ConditionFalse, Reason: "TimedOut",
+		Message: "Policy remediation took too long", LastTransitionTime: metav1.NewTime(at(58)),
+	}
+
+	snapshots := []snapshot{
+		newSnapshot(v1alpha1.ClusterGroupUpgradeStatus{}, nil),
+		newSnapshot(v1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{progressing},
+			Status: v1alpha1.UpgradeStatus{
+				StartedAt:             metav1.NewTime(at(1)),
+				CurrentBatch:          1,
+				CurrentBatchStartedAt: metav1.NewTime(at(1)),
+				CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: v1alpha1.InProgress},
+				},
+			},
+		}, map[string]string{"policy/spoke1": "NonCompliant", "policy/spoke2": "NonCompliant"}),
+		newSnapshot(v1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{progressing},
+			Clusters:   []v1alpha1.ClusterState{{Name: "spoke1", State: "timedout"}},
+			Status: v1alpha1.UpgradeStatus{
+				StartedAt:             metav1.NewTime(at(1)),
+				CurrentBatch:          2,
+				CurrentBatchStartedAt: metav1.NewTime(at(25)),
+				CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+					"spoke2": {State: v1alpha1.InProgress},
+				},
+			},
+		}, map[string]string{"policy/spoke1": "NonCompliant", "policy/spoke2": "NonCompliant"}),
+		newSnapshot(v1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{progressing},
+			Clusters:   []v1alpha1.ClusterState{{Name: "spoke1", State: "timedout"}},
+			Status: v1alpha1.UpgradeStatus{
+				StartedAt:             metav1.NewTime(at(1)),
+				CurrentBatch:          2,
+				CurrentBatchStartedAt: metav1.NewTime(at(25)),
+				CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+					"spoke2": {State: v1alpha1.Completed, FirstCompliantAt: metav1.NewTime(at(42))},
+				},
+			},
+		}, map[string]string{"policy/spoke1": "NonCompliant", "policy/spoke2": "Compliant"}),
+		newSnapshot(v1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{progressing, timedOut},
+			Clusters: []v1alpha1.ClusterState{
+				{Name: "spoke1", State: "timedout"}, {Name: "spoke2", State: "complete"},
+			},
+			Status: v1alpha1.UpgradeStatus{
+				StartedAt:             metav1.NewTime(at(1)),
+				CurrentBatch:          2,
+				CurrentBatchStartedAt: metav1.NewTime(at(25)),
+			},
+		}, map[string]string{"policy/spoke1": "NonCompliant", "policy/spoke2": "Compliant"}),
+	}
+
+	history := &History{Name: "cgu", Namespace: "talm-test", Start: testStart, End: at(70)}
+
+	for index := 1; index < len(snapshots); index++ {
+		history.Events = append(history.Events, diffSnapshots(snapshots[index-1], snapshots[index], at(index*15))...)
+		history.sort()
+	}
+
+	return history
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	history := batchingHistory()
+
+	var described []string
+	for _, event := range history.Events {
+		described = append(described, event.Time.Sub(testStart).String()+" "+event.String())
+	}
+
+	assert.Equal(t, []string{
+		"1s CGU Started",
+		"1s Condition Progressing True (InProgress)",
+		"1s Batch 1 Started",
+		"15s Remediation spoke1 InProgress",
+		"15s Policy policy/spoke1 NonCompliant",
+		"15s Policy policy/spoke2 NonCompliant",
+		"25s Batch 1 Ended",
+		"25s Batch 2 Started",
+		"30s Remediation spoke2 InProgress",
+		"30s Cluster spoke1 timedout",
+		"42s Remediation spoke2 Completed",
+		"45s Policy policy/spoke2 Compliant",
+		"58s Condition Succeeded False (TimedOut): Policy remediation took too long",
+		"58s Batch 2 Ended",
+		"58s CGU Finished",
+		"1m0s Cluster spoke2 complete",
+	}, described)
+}
+
+func TestDiffSnapshotsNoChanges(t *testing.T) {
+	current := newSnapshot(v1alpha1.ClusterGroupUpgradeStatus{
+		Status: v1alpha1.UpgradeStatus{StartedAt: metav1.NewTime(at(1)), CurrentBatch: 1},
+	}, map[string]string{"policy/spoke1": "Compliant"})
+
+	assert.Empty(t, diffSnapshots(current, current, at(10)))
+}
+
+func TestConditionMatcher(t *testing.T) {
+	event := Event{
+		Kind: KindCondition, Subject: succeededType, Value: "False", Reason: "TimedOut",
+		Message: "Policy remediation took too long",
+	}
+
+	assert.True(t, Condition(metav1.Condition{Type: succeededType, Reason: "TimedOut"}).Matches(event))
+	assert.True(t, Condition(metav1.Condition{Type: succeededType, Message: "took too long"}).Matches(event))
+	assert.False(t, Condition(metav1.Condition{Type: succeededType, Status: metav1.ConditionTrue}).Matches(event))
+	assert.False(t, Condition(metav1.Condition{Type: "Progressing"}).Matches(event))
+	assert.Equal(t, "Condition Succeeded * (TimedOut)", Condition(metav1.Condition{
+		Type: succeededType, Reason: "TimedOut",
+	}).String())
+}
+
+func TestVerify(t *testing.T) {
+	history := batchingHistory()
+
+	testCases := []struct {
+		name      string
+		assertion Assertion
+		expected  string
+	}{
+		{
+			name:      "batch order",
+			assertion: Happened(BatchStarted(2)).After(BatchEnded(1)),
+		},
+		{
+			name:      "canary order",
+			assertion: Happened(Remediation("spoke1", v1alpha1.InProgress)).Before(Remediation("spoke2", "")),
+		},
+		{
+			name:      "timeout",
+			assertion: Happened(ClusterState("spoke1", "timedout")).Before(CguFinished()).Within(time.Minute),
+		},
+		{
+			name:      "policy compliance",
+			assertion: Happened(PolicyCompliance("policy", "spoke2", policiesv1.Compliant)).After(BatchStarted(2)),
+		},
+		{
+			name:      "not before",
+			assertion: Happened(Remediation("spoke2", v1alpha1.Completed)).NotBefore(at(42)),
+		},
+		{
+			name:      "never",
+			assertion: Never(Remediation("spoke1", v1alpha1.Completed)),
+		},
+		{
+			name:      "wrong order",
+			assertion: Happened(BatchStarted(2)).Before(BatchEnded(1)),
+			expected:  "expected Batch 2 Started to happen before Batch 1 Ended but it happened 0s after",
+		},
+		{
+			name:      "other missing",
+			assertion: Happened(CguFinished()).After(Precache("spoke1", "Succeeded")),
+			expected:  "but Precache spoke1 Succeeded did not happen",
+		},
+		{
+			name:      "missing",
+			assertion: Happened(BatchStarted(3)),
+			expected:  "expected Batch 3 Started to happen but it did not",
+		},
+		{
+			name:      "too late",
+			assertion: Happened(CguFinished()).Within(50 * time.Second),
+			expected:  "expected CGU Finished to happen within 50s but it happened after 58s",
+		},
+		{
+			name:      "too early",
+			assertion: Happened(BatchStarted(2)).NotBefore(at(30)),
+			expected:  "to happen at or after 2025-03-01T10:00:30Z but it happened at 2025-03-01T10:00:25Z",
+		},
+		{
+			name:      "happened",
+			assertion: Never(Remediation("spoke2", v1alpha1.Completed)),
+			expected:  "expected Remediation spoke2 Completed to never happen but it happened at 2025-03-01T10:00:42Z",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := history.Verify(testCase.assertion)
+
+			if testCase.expected == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), testCase.expected)
+				assert.Contains(t, err.Error(), "CGU talm-test/cgu from 2025-03-01T10:00:00Z to 2025-03-01T10:01:10Z")
+			}
+		})
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	table := batchingHistory().String()
+	lines := strings.Split(strings.TrimSpace(table), "\n")
+
+	assert.Len(t, lines, 18)
+	assert.True(t, strings.HasPrefix(lines[1], "TIME"))
+	assert.Contains(t, lines[2], "2025-03-01T10:00:01Z  1s")
+
+	event, found := batchingHistory().Find(CguFinished())
+	assert.True(t, found)
+	assert.Equal(t, at(58), event.Time)
+
+	empty := &History{Name: "cgu", Namespace: "talm-test", Start: testStart, End: testStart}
+	assert.Contains(t, empty.String(), "No events were recorded.")
+}
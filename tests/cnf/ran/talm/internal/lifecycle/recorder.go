@@ -0,0 +1,212 @@
+package lifecycle
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/cgu"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ocm"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
+	"k8s.io/klog/v2"
+)
+
+// defaultInterval is how often the Recorder polls the CGU and its managed policies by default. It is shorter than the
+// intervals the specs wait with so short lived states, such as a cluster being in progress, are still recorded.
+const defaultInterval = 2 * time.Second
+
+// Recorder polls a CGU and its managed policies in the background, recording every change as an event. Since it
+// polls, states lasting less than the interval may be missed, although timestamps from the CGU status are used when
+// available so the order of events is not affected by the interval.
+type Recorder struct {
+	client    *clients.Settings
+	name      string
+	namespace string
+	interval  time.Duration
+
+	mutex    sync.Mutex
+	history  History
+	previous snapshot
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewRecorder creates a Recorder for the CGU with the provided name and namespace on the cluster of client. The CGU
+// does not need to exist until it is started, so a Recorder can be started before the CGU is created.
+func NewRecorder(client *clients.Settings, name, namespace string) *Recorder {
+	return &Recorder{
+		client:    client,
+		name:      name,
+		namespace: namespace,
+		interval:  defaultInterval,
+		history:   History{Name: name, Namespace: namespace},
+	}
+}
+
+// WithInterval sets how often the CGU is polled. Values less than or equal to zero are ignored.
+func (recorder *Recorder) WithInterval(interval time.Duration) *Recorder {
+	if interval > 0 {
+		recorder.interval = interval
+	}
+
+	return recorder
+}
+
+// Start starts polling the CGU in the background until Stop is called. Calling Start on a Recorder that has already
+// been started does nothing.
+func (recorder *Recorder) Start() *Recorder {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.stop != nil {
+		return recorder
+	}
+
+	klog.V(ranparam.LogLevel).Infof("Starting to record CGU %s in namespace %s", recorder.name, recorder.namespace)
+
+	recorder.history.Start = time.Now()
+	recorder.stop = make(chan struct{})
+	recorder.done = make(chan struct{})
+
+	go recorder.run()
+
+	return recorder
+}
+
+// Stop stops polling the CGU, after polling it one last time, and returns the recorded history. It is safe to call
+// Stop more than once, such as in both the spec and a DeferCleanup, and to call it on a Recorder that was never
+// started.
+func (recorder *Recorder) Stop() *History {
+	recorder.mutex.Lock()
+	stop, done := recorder.stop, recorder.done
+	recorder.mutex.Unlock()
+
+	if stop == nil {
+		return recorder.History()
+	}
+
+	select {
+	case <-stop:
+	default:
+		close(stop)
+	}
+
+	<-done
+
+	return recorder.History()
+}
+
+// History returns a copy of the events recorded so far. It may be called while the Recorder is running.
+func (recorder *Recorder) History() *History {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	history := recorder.history
+	history.Events = append([]Event(nil), recorder.history.Events...)
+
+	if history.End.IsZero() {
+		history.End = time.Now()
+	}
+
+	return &history
+}
+
+// run polls the CGU every interval until the stop channel is closed, polling once more before returning.
+func (recorder *Recorder) run() {
+	defer close(recorder.done)
+
+	ticker := time.NewTicker(recorder.interval)
+	defer ticker.Stop()
+
+	recorder.poll()
+
+	for {
+		select {
+		case <-recorder.stop:
+			recorder.poll()
+
+			recorder.mutex.Lock()
+			recorder.history.End = time.Now()
+			eventCount := len(recorder.history.Events)
+			recorder.mutex.Unlock()
+
+			klog.V(ranparam.LogLevel).Infof("Stopped recording CGU %s in namespace %s with %d events",
+				recorder.name, recorder.namespace, eventCount)
+
+			return
+		case <-ticker.C:
+			recorder.poll()
+		}
+	}
+}
+
+// poll takes a snapshot of the CGU and its managed policies and records the changes since the previous snapshot.
+func (recorder *Recorder) poll() {
+	current, ok := recorder.takeSnapshot()
+	if !ok {
+		return
+	}
+
+	observedAt := time.Now()
+	events := diffSnapshots(recorder.previous, current, observedAt)
+	recorder.previous = current
+
+	if len(events) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		klog.V(ranparam.LogLevel).Infof("CGU %s in namespace %s: %s", recorder.name, recorder.namespace, event)
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.history.Events = append(recorder.history.Events, events...)
+	recorder.history.sort()
+}
+
+// takeSnapshot returns the current state of the CGU and its managed policies. If the CGU cannot be pulled, including
+// when it does not exist yet, false is returned and the poll is skipped. Policies that cannot be pulled keep their
+// previous compliance so failing to pull them does not produce events.
+func (recorder *Recorder) takeSnapshot() (snapshot, bool) {
+	cguBuilder, err := cgu.Pull(recorder.client, recorder.name, recorder.namespace)
+	if err != nil {
+		klog.V(ranparam.LogLevel).Infof("Skipping poll of CGU %s in namespace %s: %v", recorder.name, recorder.namespace, err)
+
+		return snapshot{}, false
+	}
+
+	current := snapshot{cgu: cguBuilder.Object, compliance: make(map[string]string)}
+
+	for _, policyName := range cguBuilder.Object.Spec.ManagedPolicies {
+		policyNamespace := cguBuilder.Object.Status.ManagedPoliciesNs[policyName]
+		if policyNamespace == "" {
+			policyNamespace = recorder.namespace
+		}
+
+		policyBuilder, err := ocm.PullPolicy(recorder.client, policyName, policyNamespace)
+		if err != nil {
+			klog.V(ranparam.LogLevel).Infof("Failed to pull policy %s in namespace %s: %v", policyName, policyNamespace, err)
+
+			for subject, state := range recorder.previous.compliance {
+				if strings.HasPrefix(subject, policyName+"/") {
+					current.compliance[subject] = state
+				}
+			}
+
+			continue
+		}
+
+		for _, clusterStatus := range policyBuilder.Object.Status.Status {
+			if clusterStatus == nil {
+				continue
+			}
+
+			current.compliance[policySubject(policyName, clusterStatus.ClusterName)] = string(clusterStatus.ComplianceState)
+		}
+	}
+
+	return current, true
+}
@@ -26,6 +26,10 @@ func TestTalm(t *testing.T) {
 	RunSpecs(t, "TALM Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	err := setup.VerifyTalmIsInstalled()
 	Expect(err).ToNot(HaveOccurred(), "Failed to verify that TALM is installed")
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/cgu"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
@@ -16,6 +17,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/lifecycle"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/setup"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/tsparams"
 	corev1 "k8s.io/api/core/v1"
@@ -121,6 +123,9 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 			cguBuilder, err = helper.SetupCguWithCatSrc(cguBuilder)
 			Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU")
 
+			recorder := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace).Start()
+			DeferCleanup(func() { recorder.Stop() })
+
 			By("waiting to enable the CGU")
 
 			cguBuilder, err = helper.WaitToEnableCgu(cguBuilder)
@@ -164,6 +169,15 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 
 			_, err = cguBuilder.WaitForCondition(tsparams.CguTimeoutMessageCondition, time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "Failed to wait for CGU to have matching condition")
+
+			By("validating that the CGU aborted before starting the second batch")
+
+			history := recorder.Stop()
+			Expect(history.Verify(
+				lifecycle.Happened(lifecycle.Condition(tsparams.CguTimeoutReasonCondition)).After(lifecycle.BatchStarted(1)),
+				lifecycle.Never(lifecycle.BatchStarted(2)),
+				lifecycle.Never(lifecycle.Remediation(RANConfig.Spoke1Name, v1alpha1.Completed)),
+			)).To(Succeed(), "CGU lifecycle did not match the Abort batch timeout action")
 		})
 
 		// 47952 - Tests upgrade failure of one cluster would not affect other clusters
@@ -238,6 +252,9 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 			cguBuilder, err = helper.SetupCguWithCatSrc(cguBuilder)
 			Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU")
 
+			recorder := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace).Start()
+			DeferCleanup(func() { recorder.Stop() })
+
 			By("waiting to enable the CGU")
 
 			cguBuilder, err = helper.WaitToEnableCgu(cguBuilder)
@@ -259,6 +276,16 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 			catSrcExistsOnSpoke1 := olm.NewCatalogSourceBuilder(
 				Spoke1APIClient, tsparams.CatalogSourceName, tsparams.TemporaryNamespace).Exists()
 			Expect(catSrcExistsOnSpoke1).To(BeFalse(), "Catalog source exists on spoke 1")
+
+			By("validating that the second batch started after the first batch timed out")
+
+			history := recorder.Stop()
+			Expect(history.Verify(
+				lifecycle.Happened(lifecycle.BatchStarted(2)).After(lifecycle.BatchEnded(1)),
+				lifecycle.Happened(lifecycle.ClusterState(RANConfig.Spoke2Name, "complete")).
+					After(lifecycle.BatchStarted(2)),
+				lifecycle.Never(lifecycle.Remediation(RANConfig.Spoke1Name, v1alpha1.Completed)),
+			)).To(Succeed(), "CGU lifecycle did not match the Continue batch timeout action")
 		})
 
 		// 54296 - Batch Timeout Calculation
@@ -288,6 +315,9 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 				cguBuilder, err = helper.SetupCguWithCatSrc(cguBuilder)
 				Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU")
 
+				recorder := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace).Start()
+				DeferCleanup(func() { recorder.Stop() })
+
 				By("waiting to enable the CGU")
 
 				cguBuilder, err = helper.WaitToEnableCgu(cguBuilder)
@@ -310,6 +340,16 @@ var _ = Describe("TALM Batching Tests", Label(tsparams.LabelBatchingTestCases),
 					Spoke2APIClient, tsparams.CatalogSourceName, tsparams.TemporaryNamespace).Exists()
 				Expect(catSrcExistsOnSpoke2).To(BeFalse(), "Catalog source exists on spoke 2")
 
+				By("validating that the second batch started after spoke1 completed in the first batch")
+
+				history := recorder.Stop()
+				Expect(history.Verify(
+					lifecycle.Happened(lifecycle.ClusterState(RANConfig.Spoke1Name, "complete")).
+						Before(lifecycle.ClusterState(RANConfig.Spoke2Name, "")),
+					lifecycle.Happened(lifecycle.BatchStarted(2)).After(lifecycle.BatchEnded(1)),
+					lifecycle.Never(lifecycle.Remediation(RANConfig.Spoke2Name, v1alpha1.Completed)),
+				)).To(Succeed(), "CGU lifecycle did not match the Continue batch timeout action")
+
 				By("validating that CGU timeout is recalculated for later batches after earlier batches complete")
 
 				startTime := cguBuilder.Object.Status.Status.StartedAt.Time
@@ -14,6 +14,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/ranparam"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/version"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/lifecycle"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/setup"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/tsparams"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,6 +72,8 @@ var _ = Describe("TALM Blocking CRs Tests", Label(tsparams.LabelBlockingCRTestCa
 				Namespace: tsparams.TestNamespace,
 			}}
 
+			recorderA, recorderB := startBlockingRecorders()
+
 			cguA, err = helper.SetupCguWithNamespace(cguA, blockingA)
 			Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU A")
 
@@ -94,6 +97,10 @@ var _ = Describe("TALM Blocking CRs Tests", Label(tsparams.LabelBlockingCRTestCa
 
 			_, err = cguB.WaitForCondition(tsparams.CguSuccessfulFinishCondition, 17*time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "Failed to wait for CGU B to succeed")
+
+			By("Validating that CGU B only started remediating after CGU A finished")
+
+			verifyBlockedUntilFinished(recorderA.Stop(), recorderB.Stop(), blockedMessage)
 		})
 	})
 
@@ -121,6 +128,8 @@ var _ = Describe("TALM Blocking CRs Tests", Label(tsparams.LabelBlockingCRTestCa
 				HubAPIClient, blockingA, cguA.Definition.Spec.Clusters, metav1.LabelSelector{})
 			Expect(err).ToNot(HaveOccurred(), "Failed to create policy components in testing namespace")
 
+			recorderA, recorderB := startBlockingRecorders()
+
 			cguA, err = cguA.Create()
 			Expect(err).ToNot(HaveOccurred(), "Failed to create CGU")
 
@@ -147,6 +156,19 @@ var _ = Describe("TALM Blocking CRs Tests", Label(tsparams.LabelBlockingCRTestCa
 
 			err = helper.WaitForCguBlocked(cguB, blockedMessage)
 			Expect(err).ToNot(HaveOccurred(), "Failed to verify that CGU B is still blocked")
+
+			By("Validating that CGU B never started remediating after CGU A failed")
+
+			historyA := recorderA.Stop()
+			Expect(historyA.Verify(
+				lifecycle.Happened(lifecycle.Condition(tsparams.CguTimeoutMessageCondition)),
+			)).To(Succeed(), "CGU A lifecycle did not time out")
+
+			historyB := recorderB.Stop()
+			Expect(historyB.Verify(
+				lifecycle.Happened(lifecycle.Condition(helper.CguBlockedCondition(blockedMessage))),
+				lifecycle.Never(lifecycle.Remediation(RANConfig.Spoke1Name, "")),
+			)).To(Succeed(), "CGU B lifecycle was not blocked by CGU A")
 		})
 	})
 
@@ -162,6 +184,8 @@ var _ = Describe("TALM Blocking CRs Tests", Label(tsparams.LabelBlockingCRTestCa
 				Namespace: tsparams.TestNamespace,
 			}}
 
+			recorderA, recorderB := startBlockingRecorders()
+
 			By("Setting up CGU B")
 
 			cguB, err = helper.SetupCguWithNamespace(cguB, blockingB)
@@ -202,6 +226,10 @@ var _ = Describe("TALM Blocking CRs Tests", Label(tsparams.LabelBlockingCRTestCa
 
 			_, err = cguB.WaitForCondition(tsparams.CguSucceededCondition, 17*time.Minute)
 			Expect(err).ToNot(HaveOccurred(), "Failed to wait for CGU B to succeed")
+
+			By("Validating that CGU B only started remediating after CGU A finished")
+
+			verifyBlockedUntilFinished(recorderA.Stop(), recorderB.Stop(), blockedMessage)
 		})
 	})
 })
@@ -234,3 +262,29 @@ func waitToEnableCgus(cguA *cgu.CguBuilder, cguB *cgu.CguBuilder) (*cgu.CguBuild
 
 	return cguA, cguB
 }
+
+// startBlockingRecorders starts recording the lifecycles of CGU A and CGU B, stopping them when the spec ends.
+func startBlockingRecorders() (*lifecycle.Recorder, *lifecycle.Recorder) {
+	recorderA := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName+blockingA, tsparams.TestNamespace).Start()
+	recorderB := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName+blockingB, tsparams.TestNamespace).Start()
+
+	DeferCleanup(func() {
+		recorderA.Stop()
+		recorderB.Stop()
+	})
+
+	return recorderA, recorderB
+}
+
+// verifyBlockedUntilFinished verifies that CGU B was blocked with blockedMessage before it started remediating and
+// that it only started remediating once CGU A had finished.
+func verifyBlockedUntilFinished(historyA, historyB *lifecycle.History, blockedMessage string) {
+	finishedA, found := historyA.Find(lifecycle.CguFinished())
+	Expect(found).To(BeTrue(), "CGU A never finished:\n%s", historyA)
+
+	Expect(historyB.Verify(
+		lifecycle.Happened(lifecycle.Condition(helper.CguBlockedCondition(blockedMessage))).
+			Before(lifecycle.Remediation(RANConfig.Spoke1Name, "")),
+		lifecycle.Happened(lifecycle.Remediation(RANConfig.Spoke1Name, "")).NotBefore(finishedA.Time),
+	)).To(Succeed(), "CGU B lifecycle was not blocked until CGU A finished")
+}
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/cgu"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
@@ -12,6 +13,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/rancluster"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/internal/raninittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/helper"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/lifecycle"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/setup"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/cnf/ran/talm/internal/tsparams"
 	"k8s.io/utils/ptr"
@@ -64,6 +66,9 @@ var _ = Describe("TALM Canary Tests", Label(tsparams.LabelCanaryTestCases), func
 		cguBuilder, err = helper.SetupCguWithCatSrc(cguBuilder)
 		Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU")
 
+		recorder := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace).Start()
+		DeferCleanup(func() { recorder.Stop() })
+
 		By("Waiting for the system to settle")
 		time.Sleep(tsparams.TalmSystemStablizationTime)
 
@@ -89,6 +94,16 @@ var _ = Describe("TALM Canary Tests", Label(tsparams.LabelCanaryTestCases), func
 
 		_, err = cguBuilder.WaitForCondition(tsparams.CguTimeoutCanaryCondition, 11*time.Minute)
 		Expect(err).ToNot(HaveOccurred(), "Failed to wait for timeout due to canary failure")
+
+		By("validating that the non-canary cluster (spoke 1) never started")
+
+		history := recorder.Stop()
+		Expect(history.Verify(
+			lifecycle.Happened(lifecycle.Remediation(RANConfig.Spoke2Name, v1alpha1.InProgress)).
+				Before(lifecycle.CguFinished()),
+			lifecycle.Never(lifecycle.Remediation(RANConfig.Spoke1Name, v1alpha1.InProgress)),
+			lifecycle.Never(lifecycle.BatchStarted(2)),
+		)).To(Succeed(), "CGU lifecycle did not stop after the canary failed")
 	})
 
 	// 47947 - Tests successful ocp and operator upgrade with canaries and multiple batches.
@@ -101,6 +116,10 @@ var _ = Describe("TALM Canary Tests", Label(tsparams.LabelCanaryTestCases), func
 			WithCanary(RANConfig.Spoke2Name).
 			WithManagedPolicy(tsparams.PolicyName)
 		cguBuilder.Definition.Spec.RemediationStrategy.Timeout = 9
+
+		recorder := lifecycle.NewRecorder(HubAPIClient, tsparams.CguName, tsparams.TestNamespace).Start()
+		DeferCleanup(func() { recorder.Stop() })
+
 		cguBuilder, err = helper.SetupCguWithNamespace(cguBuilder, "")
 		Expect(err).ToNot(HaveOccurred(), "Failed to setup CGU")
 
@@ -120,5 +139,17 @@ var _ = Describe("TALM Canary Tests", Label(tsparams.LabelCanaryTestCases), func
 
 		_, err = cguBuilder.WaitForCondition(tsparams.CguSuccessfulFinishCondition, 10*time.Minute)
 		Expect(err).ToNot(HaveOccurred(), "Failed to wait for CGU to finish successfully")
+
+		By("validating that the canary cluster (spoke 2) completed before spoke 1 started")
+
+		history := recorder.Stop()
+		Expect(history.Verify(
+			lifecycle.Happened(lifecycle.ClusterState(RANConfig.Spoke2Name, "complete")).
+				Before(lifecycle.ClusterState(RANConfig.Spoke1Name, "")),
+			lifecycle.Happened(lifecycle.BatchStarted(2)).After(lifecycle.BatchEnded(1)),
+			lifecycle.Happened(lifecycle.Remediation(RANConfig.Spoke1Name, "")).After(lifecycle.BatchStarted(2)),
+			lifecycle.Happened(lifecycle.ClusterState(RANConfig.Spoke1Name, "complete")).
+				After(lifecycle.BatchStarted(2)),
+		)).To(Succeed(), "CGU lifecycle did not remediate the canary first")
 	})
 })
@@ -441,20 +441,20 @@ var _ = Describe("TALM precache", Label(tsparams.LabelPreCacheTestCases), func()
 				}
 			}
 
-			if BMCClient == nil {
+			if PowerController == nil {
 				Skip("Tests where one spoke is powered off require the BMC configuration be set.")
 			}
 
 			By("powering off spoke 1")
 
-			err := rancluster.PowerOffWithRetries(BMCClient, 3)
+			err := rancluster.PowerOffWithRetries(PowerController, 3)
 			Expect(err).ToNot(HaveOccurred(), "Failed to power off spoke 1")
 		})
 
 		AfterAll(func() {
 			By("powering on spoke 1")
 
-			err := rancluster.PowerOnWithRetries(BMCClient, 3)
+			err := rancluster.PowerOnWithRetries(PowerController, 3)
 			Expect(err).ToNot(HaveOccurred(), "Failed to power on spoke 1")
 
 			By("waiting until all spoke 1 pods are ready")
@@ -23,6 +23,10 @@ func TestBasic(t *testing.T) {
 	RunSpecs(t, "AMD GPU Basic Suite", Label(amdparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	amdgpuparams "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/amdgpu/params"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 // amdGPUConfigHelper Helps to convert different strings to bool.
@@ -72,5 +73,7 @@ func NewAMDConfig() *AMDConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("amdgpu", AMDConfig)
+
 	return AMDConfig
 }
@@ -19,5 +19,7 @@ func NewHwAccelConfig() *HwAccelConfig {
 
 	hwaccelConfig.GeneralConfig = config.NewConfig()
 
+	config.RegisterSuiteConfig("hwaccel", &hwaccelConfig)
+
 	return &hwaccelConfig
 }
@@ -25,6 +25,10 @@ func TestUpgrade(tt *testing.T) {
 	RunSpecs(tt, "1upgrade", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = ReportAfterSuite("1upgrade", func(report Report) {
 	reportxml.Create(report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
 })
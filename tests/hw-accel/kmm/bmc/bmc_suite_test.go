@@ -44,6 +44,10 @@ func TestBMC(t *testing.T) {
 	RunSpecs(t, "KMM-BMC", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Prepare environment for BMC tests execution")
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/kmm/internal/kmmparams"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"k8s.io/klog/v2"
 
 	"github.com/kelseyhightower/envconfig"
@@ -46,5 +47,7 @@ func NewModulesConfig() *ModulesConfig {
 		}
 	}
 
+	config.RegisterSuiteConfig("kmm", modulesConfig)
+
 	return modulesConfig
 }
@@ -42,6 +42,10 @@ func TestManagedClusterModules(t *testing.T) {
 	RunSpecs(t, "KMM-HUB", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
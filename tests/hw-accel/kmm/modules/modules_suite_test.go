@@ -41,6 +41,10 @@ func TestModules(t *testing.T) {
 	RunSpecs(t, "KMM", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Prepare environment for KMM tests execution")
 
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/params"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"k8s.io/klog/v2"
 )
 
@@ -47,7 +48,7 @@ type NeuronConfig struct {
 
 // NewNeuronConfig creates a new NeuronConfig from environment variables.
 func NewNeuronConfig() *NeuronConfig {
-	config := &NeuronConfig{
+	neuronConfig := &NeuronConfig{
 		VLLMImage:                 os.Getenv("ECO_HWACCEL_NEURON_VLLM_IMAGE"),
 		ModelName:                 os.Getenv("ECO_HWACCEL_NEURON_MODEL_NAME"),
 		HuggingFaceToken:          os.Getenv("ECO_HWACCEL_NEURON_HF_TOKEN"),
@@ -68,33 +69,36 @@ func NewNeuronConfig() *NeuronConfig {
 	}
 
 	// Set defaults
-	if config.CatalogSourceNamespace == "" {
-		config.CatalogSourceNamespace = "openshift-marketplace"
+	if neuronConfig.CatalogSourceNamespace == "" {
+		neuronConfig.CatalogSourceNamespace = "openshift-marketplace"
 	}
 
-	if config.SubscriptionName == "" {
-		config.SubscriptionName = "aws-neuron-operator"
+	if neuronConfig.SubscriptionName == "" {
+		neuronConfig.SubscriptionName = "aws-neuron-operator"
 	}
 
-	if config.ModelName == "" {
+	if neuronConfig.ModelName == "" {
 		// Default to Llama-3.1-8B-Instruct
-		config.ModelName = "meta-llama/Llama-3.1-8B-Instruct"
+		neuronConfig.ModelName = "meta-llama/Llama-3.1-8B-Instruct"
 	}
 
-	if config.VLLMImage == "" {
+	if neuronConfig.VLLMImage == "" {
 		// Default vLLM image with Neuron support
-		config.VLLMImage = "public.ecr.aws/neuron/pytorch-inference-vllm-neuronx:0.7.2-neuronx-py310-sdk2.24.1-ubuntu22.04"
+		neuronConfig.VLLMImage =
+			"public.ecr.aws/neuron/pytorch-inference-vllm-neuronx:0.7.2-neuronx-py310-sdk2.24.1-ubuntu22.04"
 	}
 
-	if config.StorageClassName == "" {
+	if neuronConfig.StorageClassName == "" {
 		// Default storage class for ROSA/AWS
-		config.StorageClassName = "gp3-csi"
+		neuronConfig.StorageClassName = "gp3-csi"
 	}
 
 	klog.V(params.NeuronLogLevel).Infof("NeuronConfig loaded: DriversImage=%s, DevicePluginImage=%s, NodeMetricsImage=%s",
-		config.DriversImage, config.DevicePluginImage, config.NodeMetricsImage)
+		neuronConfig.DriversImage, neuronConfig.DevicePluginImage, neuronConfig.NodeMetricsImage)
 
-	return config
+	config.RegisterSuiteConfig("neuron", neuronConfig)
+
+	return neuronConfig
 }
 
 // IsValid checks if the minimum required configuration is present.
@@ -9,6 +9,7 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/hw-accel/neuron/vllm/tests"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 )
 
 var _, currentFile, _, _ = runtime.Caller(0)
@@ -21,6 +22,10 @@ func TestVLLM(t *testing.T) {
 	RunSpecs(t, "Neuron vLLM Suite", reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Setting up Neuron vLLM test suite")
 })
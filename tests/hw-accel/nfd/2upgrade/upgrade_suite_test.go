@@ -24,6 +24,10 @@ func TestUpgrade(tt *testing.T) {
 	RunSpecs(tt, tsparams.NfdUpgradeLabel, Label(nfdparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = ReportAfterSuite(tsparams.NfdUpgradeLabel, func(report Report) {
 	reportxml.Create(
 		report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
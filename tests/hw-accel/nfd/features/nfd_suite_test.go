@@ -35,6 +35,10 @@ func TestFeatures(t *testing.T) {
 	RunSpecs(t, "NFD", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	nfdConfig := nfdconfig.NewNfdConfig()
 
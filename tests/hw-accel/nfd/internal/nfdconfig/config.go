@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 // NfdConfig contains environment information related to nfd tests.
@@ -30,5 +31,7 @@ func NewNfdConfig() *NfdConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("nfd", nfdConfig)
+
 	return nfdConfig
 }
@@ -25,6 +25,10 @@ func TestGPUDeploy(t *testing.T) {
 	RunSpecs(t, "GPU", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = ReportAfterSuite("", func(report Report) {
 	reportxml.Create(
 		report, GeneralConfig.GetReportPath(), GeneralConfig.TCPrefix)
@@ -4,6 +4,7 @@ import (
 	"log"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 )
 
 // NvidiaGPUConfig contains environment information related to nvidiagpu tests.
@@ -26,5 +27,7 @@ func NewNvidiaGPUConfig() *NvidiaGPUConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("nvidiagpu", nvidiaGPUConfig)
+
 	return nvidiaGPUConfig
 }
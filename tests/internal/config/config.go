@@ -1,20 +1,35 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
 	// PathToDefaultParamsFile path to config file with default parameters.
 	PathToDefaultParamsFile = "./default.yaml"
+	// ConfigFileEnvVar is the environment variable with the path to an optional config file applied on top of the
+	// default parameters.
+	ConfigFileEnvVar = "ECO_CONFIG_FILE"
+	// ConfigDirEnvVar is the environment variable with the path to an optional directory of config files applied on
+	// top of ConfigFileEnvVar. Only files ending in .yaml or .yml are read and they are applied in lexical order.
+	ConfigDirEnvVar = "ECO_CONFIG_DIR"
+	// maxEnvTypoDistance is the maximum number of edits between an unknown ECO_ environment variable and a known one
+	// for the unknown variable to be reported as a typo.
+	maxEnvTypoDistance = 2
 )
 
 // GeneralConfig type keeps general configuration.
@@ -22,13 +37,16 @@ type GeneralConfig struct {
 	ReportsDirAbsPath         string `yaml:"reports_dump_dir" envconfig:"ECO_REPORTS_DUMP_DIR"`
 	VerboseLevel              string `yaml:"verbose_level" envconfig:"ECO_VERBOSE_LEVEL"`
 	DumpFailedTests           bool   `yaml:"dump_failed_tests" envconfig:"ECO_DUMP_FAILED_TESTS"`
+	DumpFailedTestsMaxSizeMB  int    `yaml:"dump_failed_tests_max_size_mb" envconfig:"ECO_DUMP_FAILED_TESTS_MAX_SIZE_MB"`
 	EnableReport              bool   `yaml:"enable_report" envconfig:"ECO_ENABLE_REPORT"`
 	DryRun                    bool   `yaml:"dry_run" envconfig:"ECO_DRY_RUN"`
-	SSHKeyPath                string `envconfig:"ECO_SSH_KEY_PATH"`
+	PrintEffectiveConfig      bool   `yaml:"print_effective_config" envconfig:"ECO_PRINT_EFFECTIVE_CONFIG"`
+	ClusterFactsFile          string `yaml:"cluster_facts_file" envconfig:"ECO_CLUSTER_FACTS_FILE"`
+	SSHKeyPath                string `yaml:"ssh_key_path" envconfig:"ECO_SSH_KEY_PATH"`
 	SSHUser                   string `yaml:"ssh_user" envconfig:"ECO_SSH_USER"`
 	KubernetesRolePrefix      string `yaml:"kubernetes_role_prefix" envconfig:"ECO_KUBERNETES_ROLE_PREFIX"`
 	WorkerLabelEnvVar         string `yaml:"worker_label" envconfig:"ECO_WORKER_LABEL"`
-	WorkerLabel               string
+	WorkerLabel               string `yaml:"-"`
 	ControlPlaneLabel         string `yaml:"control_plane_label" envconfig:"ECO_CONTROL_PLANE_LABEL"`
 	TCPrefix                  string `yaml:"tc_prefix" envconfig:"ECO_TC_PREFIX"`
 	MCONamespace              string `yaml:"mco_namespace" envconfig:"ECO_MCO_NAMESPACE"`
@@ -37,42 +55,130 @@ type GeneralConfig struct {
 	SriovOperatorNamespace    string `yaml:"sriov_operator_namespace" envconfig:"ECO_SRIOV_OPERATOR_NAMESPACE"`
 	NMStateOperatorNamespace  string `yaml:"nmstate_operator_namespace" envconfig:"ECO_NMSTATE_OPERATOR_NAMESPACE"`
 	SriovFecOperatorNamespace string `yaml:"sriov_fec_operator_namespace" envconfig:"ECO_SRIOV_FEC_OPERATOR_NAMESPACE"`
-	WorkerLabelMap            map[string]string
-	ControlPlaneLabelMap      map[string]string
+
+	// WorkerLabelMap and ControlPlaneLabelMap are derived from the labels after the environment is read.
+	WorkerLabelMap       map[string]string `yaml:"-"`
+	ControlPlaneLabelMap map[string]string `yaml:"-"`
+	// Layers are the config files applied to this config in order, starting with the default parameters.
+	Layers []string `yaml:"-"`
 }
 
-// NewConfig returns instance of GeneralConfig config type.
+// NewConfig returns instance of GeneralConfig config type. If the config cannot be loaded, the error is logged and nil
+// is returned. Use LoadConfig to get the error instead.
 func NewConfig() *GeneralConfig {
 	log.Print("Creating new GeneralConfig struct")
 
+	conf, err := LoadConfig()
+	if err != nil {
+		log.Printf("Error to load general config: %v", err)
+
+		return nil
+	}
+
+	return conf
+}
+
+// LoadConfig returns instance of GeneralConfig config type built from the following layers, with later layers
+// overriding earlier ones: the default parameters, the file in ECO_CONFIG_FILE, the files in ECO_CONFIG_DIR, and the
+// environment variables. Config files are decoded strictly so unknown keys are an error. Once all layers are applied,
+// the config is validated and the returned error names every invalid field.
+func LoadConfig() (*GeneralConfig, error) {
 	var conf GeneralConfig
 
 	_, filename, _, _ := runtime.Caller(0)
 	baseDir := filepath.Dir(filename)
 	confFile := filepath.Join(baseDir, PathToDefaultParamsFile)
 
-	err := readFile(&conf, confFile)
+	overlayFiles, err := getOverlayFiles()
 	if err != nil {
-		log.Printf("Error to read config file %s", confFile)
+		return nil, err
+	}
 
-		return nil
+	for _, layerFile := range append([]string{confFile}, overlayFiles...) {
+		err := readFile(&conf, layerFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", layerFile, err)
+		}
+
+		conf.Layers = append(conf.Layers, layerFile)
 	}
 
 	err = readEnv(&conf)
 	if err != nil {
-		log.Print("Error to read environment variables")
+		return nil, fmt.Errorf("failed to read environment variables: %w", err)
+	}
 
-		return nil
+	err = conf.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid general config: %w", err)
 	}
 
 	err = deployReportDir(conf.ReportsDirAbsPath)
 	if err != nil {
-		log.Printf("Error to deploy report directory %s due to %s", conf.ReportsDirAbsPath, err.Error())
+		return nil, fmt.Errorf("failed to deploy report directory %s: %w", conf.ReportsDirAbsPath, err)
+	}
 
-		return nil
+	return &conf, nil
+}
+
+// EffectiveConfig returns the config as YAML, preceded by comments listing the config files it was loaded from. Values
+// are shown after all layers have been applied, in the same form as the config files, so the output can be used as an
+// override file.
+func (cfg *GeneralConfig) EffectiveConfig() (string, error) {
+	// The role prefix is added to the control plane label after the environment is read, so it is removed again to
+	// avoid adding it twice when the output is loaded.
+	effectiveConfig := *cfg
+	effectiveConfig.ControlPlaneLabel = strings.TrimPrefix(cfg.ControlPlaneLabel, cfg.KubernetesRolePrefix+"/")
+
+	content, err := yaml.Marshal(&effectiveConfig)
+	if err != nil {
+		return "", err
 	}
 
-	return &conf
+	builder := &strings.Builder{}
+
+	for _, layer := range cfg.Layers {
+		fmt.Fprintf(builder, "# layer: %s\n", layer)
+	}
+
+	builder.Write(content)
+
+	return builder.String(), nil
+}
+
+// FindEnvTypos returns a message for every ECO_ environment variable that is not used by GeneralConfig but is close
+// enough to one that is that it is most likely a typo. Since suites define their own ECO_ variables, unknown variables
+// that are not close to a known one are ignored.
+func FindEnvTypos() []string {
+	knownVars := []string{ConfigFileEnvVar, ConfigDirEnvVar}
+	configType := reflect.TypeFor[GeneralConfig]()
+
+	for index := range configType.NumField() {
+		if envVar := configType.Field(index).Tag.Get("envconfig"); envVar != "" {
+			knownVars = append(knownVars, envVar)
+		}
+	}
+
+	var typos []string
+
+	for _, envEntry := range os.Environ() {
+		envVar, _, _ := strings.Cut(envEntry, "=")
+		if !strings.HasPrefix(envVar, "ECO_") || slices.Contains(knownVars, envVar) {
+			continue
+		}
+
+		for _, knownVar := range knownVars {
+			if editDistance(envVar, knownVar) <= maxEnvTypoDistance {
+				typos = append(typos, fmt.Sprintf("%s is not used, did you mean %s?", envVar, knownVar))
+
+				break
+			}
+		}
+	}
+
+	slices.Sort(typos)
+
+	return typos
 }
 
 // GetJunitReportPath returns full path to the junit report file.
@@ -111,6 +217,38 @@ func (cfg *GeneralConfig) GetDumpFailedTestReportLocation(file string) string {
 	return ""
 }
 
+// getOverlayFiles returns the config files to apply on top of the default parameters based on ECO_CONFIG_FILE and
+// ECO_CONFIG_DIR. Both are optional but must exist when set.
+func getOverlayFiles() ([]string, error) {
+	var overlayFiles []string
+
+	if configFile := os.Getenv(ConfigFileEnvVar); configFile != "" {
+		overlayFiles = append(overlayFiles, configFile)
+	}
+
+	configDir := os.Getenv(ConfigDirEnvVar)
+	if configDir == "" {
+		return overlayFiles, nil
+	}
+
+	dirEntries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s directory %s: %w", ConfigDirEnvVar, configDir, err)
+	}
+
+	// ReadDir returns the entries sorted by name so they are already in lexical order.
+	for _, dirEntry := range dirEntries {
+		extension := filepath.Ext(dirEntry.Name())
+		if dirEntry.IsDir() || (extension != ".yaml" && extension != ".yml") {
+			continue
+		}
+
+		overlayFiles = append(overlayFiles, filepath.Join(configDir, dirEntry.Name()))
+	}
+
+	return overlayFiles, nil
+}
+
 func readFile(cfg *GeneralConfig, cfgFile string) error {
 	openedCfgFile, err := os.Open(cfgFile)
 	if err != nil {
@@ -122,9 +260,11 @@ func readFile(cfg *GeneralConfig, cfgFile string) error {
 	}()
 
 	decoder := yaml.NewDecoder(openedCfgFile)
+	decoder.SetStrict(true)
 
+	// Empty overlay files are allowed and leave the config unchanged.
 	err = decoder.Decode(&cfg)
-	if err != nil {
+	if err != nil && !errors.Is(err, io.EOF) {
 		return err
 	}
 
@@ -145,6 +285,76 @@ func readEnv(cfg *GeneralConfig) error {
 	return nil
 }
 
+// validate checks the fields of cfg once all layers have been applied. Every invalid field is included in the returned
+// error along with the config key and environment variable that set it.
+func (cfg *GeneralConfig) validate() error {
+	var errs []error
+
+	check := func(fieldName string, problems ...string) {
+		if len(problems) == 0 {
+			return
+		}
+
+		field, _ := reflect.TypeFor[GeneralConfig]().FieldByName(fieldName)
+		errs = append(errs, fmt.Errorf("%s (%s): %s",
+			field.Tag.Get("yaml"), field.Tag.Get("envconfig"), strings.Join(problems, "; ")))
+	}
+
+	if !filepath.IsAbs(cfg.ReportsDirAbsPath) {
+		check("ReportsDirAbsPath", fmt.Sprintf("must be an absolute path but was \"%s\"", cfg.ReportsDirAbsPath))
+	}
+
+	if verboseLevel, err := strconv.Atoi(cfg.VerboseLevel); err != nil || verboseLevel < 0 {
+		check("VerboseLevel", fmt.Sprintf("must be a non-negative integer but was \"%s\"", cfg.VerboseLevel))
+	}
+
+	if cfg.DumpFailedTestsMaxSizeMB < 0 {
+		check("DumpFailedTestsMaxSizeMB", fmt.Sprintf("must not be negative but was %d", cfg.DumpFailedTestsMaxSizeMB))
+	}
+
+	if cfg.SSHUser == "" {
+		check("SSHUser", "must not be empty")
+	}
+
+	check("KubernetesRolePrefix", validation.IsDNS1123Subdomain(cfg.KubernetesRolePrefix)...)
+	check("WorkerLabelEnvVar", validation.IsQualifiedName(cfg.WorkerLabel)...)
+	check("ControlPlaneLabel", validation.IsQualifiedName(cfg.ControlPlaneLabel)...)
+	check("MCONamespace", validation.IsDNS1123Label(cfg.MCONamespace)...)
+	check("LoggingOperatorNamespace", validation.IsDNS1123Label(cfg.LoggingOperatorNamespace)...)
+	check("SriovOperatorNamespace", validation.IsDNS1123Label(cfg.SriovOperatorNamespace)...)
+	check("NMStateOperatorNamespace", validation.IsDNS1123Label(cfg.NMStateOperatorNamespace)...)
+	check("SriovFecOperatorNamespace", validation.IsDNS1123Label(cfg.SriovFecOperatorNamespace)...)
+
+	return errors.Join(errs...)
+}
+
+// editDistance returns the Levenshtein distance between first and second.
+func editDistance(first, second string) int {
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+
+	for index := range previous {
+		previous[index] = index
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(second); j++ {
+			substitution := previous[j-1]
+			if first[i-1] != second[j-1] {
+				substitution++
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
 func deployReportDir(dirName string) error {
 	_, err := os.Stat(dirName)
 
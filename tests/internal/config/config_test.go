@@ -1 +1,183 @@
 package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfigLayers(t *testing.T) {
+	testCases := []struct {
+		name              string
+		configFile        string
+		configDirFiles    map[string]string
+		envVars           map[string]string
+		expectedSSHUser   string
+		expectedTCPrefix  string
+		expectedNumLayers int
+	}{
+		{
+			name:              "defaults",
+			expectedSSHUser:   "core",
+			expectedTCPrefix:  "TC-",
+			expectedNumLayers: 1,
+		},
+		{
+			name:              "config file",
+			configFile:        "ssh_user: file\n",
+			expectedSSHUser:   "file",
+			expectedTCPrefix:  "TC-",
+			expectedNumLayers: 2,
+		},
+		{
+			name:       "config dir",
+			configFile: "ssh_user: file\ntc_prefix: FILE-\n",
+			configDirFiles: map[string]string{
+				"01-first.yaml":  "ssh_user: first\n",
+				"02-second.yml":  "ssh_user: second\n",
+				"03-ignored.txt": "ssh_user: ignored\n",
+				"04-empty.yaml":  "",
+			},
+			expectedSSHUser:   "second",
+			expectedTCPrefix:  "FILE-",
+			expectedNumLayers: 5,
+		},
+		{
+			name:              "environment variables",
+			configFile:        "ssh_user: file\n",
+			envVars:           map[string]string{"ECO_SSH_USER": "env"},
+			expectedSSHUser:   "env",
+			expectedTCPrefix:  "TC-",
+			expectedNumLayers: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			setupTestEnv(t, testCase.configFile, testCase.configDirFiles, testCase.envVars)
+
+			conf, err := LoadConfig()
+			assert.Nil(t, err)
+
+			if assert.NotNil(t, conf) {
+				assert.Equal(t, testCase.expectedSSHUser, conf.SSHUser)
+				assert.Equal(t, testCase.expectedTCPrefix, conf.TCPrefix)
+				assert.Len(t, conf.Layers, testCase.expectedNumLayers)
+				assert.Equal(t, "node-role.kubernetes.io/worker", conf.WorkerLabel)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	testCases := []struct {
+		configFile    string
+		envVars       map[string]string
+		expectedError string
+	}{
+		{
+			configFile:    "ssh_usr: typo\n",
+			expectedError: "field ssh_usr not found",
+		},
+		{
+			envVars:       map[string]string{"ECO_REPORTS_DUMP_DIR": "relative/path"},
+			expectedError: "reports_dump_dir (ECO_REPORTS_DUMP_DIR): must be an absolute path",
+		},
+		{
+			envVars:       map[string]string{"ECO_VERBOSE_LEVEL": "loud"},
+			expectedError: "verbose_level (ECO_VERBOSE_LEVEL): must be a non-negative integer",
+		},
+		{
+			envVars:       map[string]string{"ECO_DUMP_FAILED_TESTS_MAX_SIZE_MB": "-1"},
+			expectedError: "dump_failed_tests_max_size_mb (ECO_DUMP_FAILED_TESTS_MAX_SIZE_MB): must not be negative",
+		},
+		{
+			configFile:    "ssh_user: \"\"\n",
+			expectedError: "ssh_user (ECO_SSH_USER): must not be empty",
+		},
+		{
+			configFile:    "mco_namespace: Not_A_Namespace\n",
+			expectedError: "mco_namespace (ECO_MCO_NAMESPACE)",
+		},
+		{
+			envVars:       map[string]string{"ECO_DRY_RUN": "maybe"},
+			expectedError: "ECO_DRY_RUN",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expectedError, func(t *testing.T) {
+			setupTestEnv(t, testCase.configFile, nil, testCase.envVars)
+
+			conf, err := LoadConfig()
+			assert.Nil(t, conf)
+
+			if assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	setupTestEnv(t, "ssh_user: file\n", nil, nil)
+
+	conf, err := LoadConfig()
+	assert.Nil(t, err)
+
+	effectiveConfig, err := conf.EffectiveConfig()
+	assert.Nil(t, err)
+	assert.Contains(t, effectiveConfig, "# layer: "+os.Getenv(ConfigFileEnvVar))
+	assert.Contains(t, effectiveConfig, "ssh_user: file")
+	assert.NotContains(t, effectiveConfig, "workerlabelmap")
+
+	// Loading the effective config as an override file must result in the same labels.
+	setupTestEnv(t, effectiveConfig, nil, nil)
+
+	reloaded, err := LoadConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, conf.ControlPlaneLabel, reloaded.ControlPlaneLabel)
+	assert.Equal(t, conf.WorkerLabel, reloaded.WorkerLabel)
+}
+
+func TestFindEnvTypos(t *testing.T) {
+	t.Setenv("ECO_REPORT_DUMP_DIR", "/tmp/reports")
+	t.Setenv("ECO_CNF_RAN_SKIP_TLS_VERIFY", "true")
+	t.Setenv("ECO_DUMP_FAILED_TESTS", "true")
+
+	assert.Equal(t, []string{"ECO_REPORT_DUMP_DIR is not used, did you mean ECO_REPORTS_DUMP_DIR?"}, FindEnvTypos())
+}
+
+// setupTestEnv writes configFile and configDirFiles to a temporary directory and sets the environment variables to use
+// them. The reports directory is also set to a temporary directory so loading the config does not create it in /tmp.
+func setupTestEnv(t *testing.T, configFile string, configDirFiles, envVars map[string]string) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	t.Setenv("ECO_REPORTS_DUMP_DIR", filepath.Join(tempDir, "reports"))
+	t.Setenv(ConfigFileEnvVar, "")
+	t.Setenv(ConfigDirEnvVar, "")
+
+	if configFile != "" {
+		configFilePath := filepath.Join(tempDir, "config.yaml")
+		assert.Nil(t, os.WriteFile(configFilePath, []byte(configFile), 0600))
+		t.Setenv(ConfigFileEnvVar, configFilePath)
+	}
+
+	if configDirFiles != nil {
+		configDir := filepath.Join(tempDir, "config.d")
+		assert.Nil(t, os.Mkdir(configDir, 0700))
+
+		for fileName, content := range configDirFiles {
+			assert.Nil(t, os.WriteFile(filepath.Join(configDir, fileName), []byte(content), 0600))
+		}
+
+		t.Setenv(ConfigDirEnvVar, configDir)
+	}
+
+	for envVar, value := range envVars {
+		t.Setenv(envVar, value)
+	}
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// RedactedValue replaces the value of secret fields in the suite config dump.
+	RedactedValue = "<redacted>"
+)
+
+var (
+	// secretFieldPattern matches the names of fields whose values are redacted from the suite config dump.
+	secretFieldPattern = regexp.MustCompile(`(?i)(password|passwd|pass$|secret|token|credential|privatekey|apikey)`)
+
+	suiteConfigsMutex sync.Mutex
+	suiteConfigs      = make(map[string]any)
+)
+
+// RegisterSuiteConfig adds suiteConfig to the configs written by DumpSuiteConfigs under name. It should be a pointer to
+// the fully resolved config and is meant to be called by the constructor of each suite config package. Registering
+// the same name again replaces the previous config.
+func RegisterSuiteConfig(name string, suiteConfig any) {
+	if suiteConfig == nil || reflect.ValueOf(suiteConfig).IsNil() {
+		return
+	}
+
+	suiteConfigsMutex.Lock()
+	defer suiteConfigsMutex.Unlock()
+
+	suiteConfigs[name] = suiteConfig
+}
+
+// DumpSuiteConfigs writes every registered suite config as YAML to dumpFile, keyed by the name it was registered
+// under. Only fields with a yaml or envconfig tag and embedded structs are included, so API clients and other runtime
+// values are left out. Fields and map entries that look like secrets, such as passwords and tokens, are redacted unless
+// empty, including those in structs and maps nested in other values. Configs embedded in another registered config
+// are only written once as part of the config that embeds them. Nothing is written if no configs are registered.
+func DumpSuiteConfigs(dumpFile string) error {
+	suiteConfigsMutex.Lock()
+	defer suiteConfigsMutex.Unlock()
+
+	if len(suiteConfigs) == 0 {
+		return nil
+	}
+
+	log.Printf("Dumping %d suite configs to %s", len(suiteConfigs), dumpFile)
+
+	embedded := make(map[any]bool)
+	dumped := make(map[string]any)
+
+	for name, suiteConfig := range suiteConfigs {
+		dumped[name] = dumpValue(reflect.ValueOf(suiteConfig), embedded)
+	}
+
+	var dump yaml.MapSlice
+
+	for _, name := range slices.Sorted(maps.Keys(suiteConfigs)) {
+		if embedded[suiteConfigs[name]] {
+			continue
+		}
+
+		dump = append(dump, yaml.MapItem{Key: name, Value: dumped[name]})
+	}
+
+	content, err := yaml.Marshal(dump)
+	if err != nil {
+		return fmt.Errorf("failed to marshal suite configs: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(dumpFile), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(dumpFile, content, 0644)
+}
+
+// GetConfigDumpPath returns full path to the suite config dump file, next to the junit report file.
+func (cfg *GeneralConfig) GetConfigDumpPath(file string) string {
+	reportFileName := strings.TrimSuffix(filepath.Base(file), filepath.Ext(filepath.Base(file)))
+
+	return fmt.Sprintf("%s_config.yaml", filepath.Join(cfg.ReportsDirAbsPath, reportFileName))
+}
+
+// dumpValue converts value to a form that can be marshaled to YAML while following the rules of DumpSuiteConfigs.
+// Map entries with keys that look like secrets are redacted the same way as fields. Pointers to embedded structs are
+// added to embedded so registered configs that are embedded can be skipped.
+func dumpValue(value reflect.Value, embedded map[any]bool) any {
+	switch value.Kind() {
+	case reflect.Invalid, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+
+		return dumpValue(value.Elem(), embedded)
+	case reflect.Struct:
+		return dumpStruct(value, embedded)
+	case reflect.Slice, reflect.Array:
+		var items []any
+
+		for index := range value.Len() {
+			items = append(items, dumpValue(value.Index(index), embedded))
+		}
+
+		return items
+	case reflect.Map:
+		items := make(map[any]any)
+
+		for iter := value.MapRange(); iter.Next(); {
+			key := fmt.Sprint(iter.Key().Interface())
+
+			if secretFieldPattern.MatchString(key) && !iter.Value().IsZero() {
+				items[key] = RedactedValue
+
+				continue
+			}
+
+			items[key] = dumpValue(iter.Value(), embedded)
+		}
+
+		return items
+	default:
+		if duration, ok := value.Interface().(time.Duration); ok {
+			return duration.String()
+		}
+
+		return value.Interface()
+	}
+}
+
+// dumpStruct converts the struct value to a yaml.MapSlice, keeping the order of its fields. Fields of embedded structs
+// are inlined. Structs with a String method, such as time.Time, are converted to a string instead. In structs with
+// config fields, fields without a yaml or envconfig tag are skipped. Other structs, such as Kubernetes types used as
+// config values, have all of their exported fields included.
+func dumpStruct(value reflect.Value, embedded map[any]bool) any {
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	isConfig := hasConfigFields(value.Type())
+
+	var items yaml.MapSlice
+
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		fieldValue := value.Field(index)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous {
+			if fieldValue.Kind() == reflect.Pointer && !fieldValue.IsNil() {
+				embedded[fieldValue.Interface()] = true
+			}
+
+			if inlined, ok := dumpValue(fieldValue, embedded).(yaml.MapSlice); ok {
+				items = append(items, inlined...)
+			}
+
+			continue
+		}
+
+		key := getDumpKey(field)
+		if key == "-" || (isConfig && key == "") {
+			continue
+		}
+
+		if key == "" {
+			key = field.Name
+		}
+
+		if secretFieldPattern.MatchString(field.Name) && !fieldValue.IsZero() {
+			items = append(items, yaml.MapItem{Key: key, Value: RedactedValue})
+
+			continue
+		}
+
+		items = append(items, yaml.MapItem{Key: key, Value: dumpValue(fieldValue, embedded)})
+	}
+
+	return items
+}
+
+// hasConfigFields returns true if any field of structType has a yaml or envconfig tag.
+func hasConfigFields(structType reflect.Type) bool {
+	for index := range structType.NumField() {
+		field := structType.Field(index)
+		if field.Tag.Get("yaml") != "" || field.Tag.Get("envconfig") != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getDumpKey returns the key for field in the suite config dump, which is the first of the yaml, envconfig, and json
+// tags that is set. It returns an empty string if none are set and "-" if the field is excluded from YAML.
+func getDumpKey(field reflect.StructField) string {
+	yamlName, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if yamlName != "" {
+		return yamlName
+	}
+
+	if envName := field.Tag.Get("envconfig"); envName != "" {
+		return envName
+	}
+
+	jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+	return jsonName
+}
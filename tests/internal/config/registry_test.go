@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+// InnerTestConfig is a suite config embedding GeneralConfig with a secret field.
+type InnerTestConfig struct {
+	*GeneralConfig
+
+	Image    string `yaml:"image" envconfig:"ECO_TEST_IMAGE"`
+	Password string `envconfig:"ECO_TEST_PASSWORD"`
+	Token    string `envconfig:"ECO_TEST_TOKEN"`
+}
+
+// OuterTestConfig is a suite config embedding InnerTestConfig along with fields that are not dumped.
+type OuterTestConfig struct {
+	*InnerTestConfig
+
+	Timeout   time.Duration              `yaml:"timeout"`
+	Nodes     []NodeTestDetails          `yaml:"nodes"`
+	NodesBMC  map[string]NodeTestDetails `yaml:"nodes_bmc"`
+	Variables map[string]string          `yaml:"variables"`
+	APIClient *struct{ Host string }
+	Derived   string
+}
+
+// NodeTestDetails is a non-config struct used as a config value.
+type NodeTestDetails struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+func TestDumpSuiteConfigs(t *testing.T) {
+	suiteConfigs = make(map[string]any)
+
+	t.Cleanup(func() {
+		suiteConfigs = make(map[string]any)
+	})
+
+	innerConfig := &InnerTestConfig{
+		GeneralConfig: &GeneralConfig{SSHUser: "core", WorkerLabel: "node-role.kubernetes.io/worker"},
+		Image:         "quay.io/test:latest",
+		Password:      "hunter2",
+	}
+	outerConfig := &OuterTestConfig{
+		InnerTestConfig: innerConfig,
+		Timeout:         5 * time.Minute,
+		Nodes:           []NodeTestDetails{{Name: "node-0", Password: "hunter2"}},
+		NodesBMC:        map[string]NodeTestDetails{"node-0": {Name: "bmc-0", Password: "hunter2"}},
+		Variables:       map[string]string{"registry": "quay.io", "registry_password": "hunter2", "token": ""},
+		APIClient:       &struct{ Host string }{Host: "api.cluster"},
+		Derived:         "derived",
+	}
+
+	RegisterSuiteConfig("inner", innerConfig)
+	RegisterSuiteConfig("outer", outerConfig)
+	RegisterSuiteConfig("nil", (*InnerTestConfig)(nil))
+
+	dumpFile := filepath.Join(t.TempDir(), "reports", "suite_config.yaml")
+	assert.Nil(t, DumpSuiteConfigs(dumpFile))
+
+	content, err := os.ReadFile(dumpFile)
+	assert.Nil(t, err)
+
+	var dump map[string]map[string]any
+
+	assert.Nil(t, yaml.Unmarshal(content, &dump))
+	assert.NotContains(t, string(content), "hunter2")
+	assert.NotContains(t, string(content), "api.cluster")
+	assert.NotContains(t, string(content), "derived")
+
+	// The inner config is embedded in the outer config so it is only dumped as part of the outer config.
+	assert.Len(t, dump, 1)
+
+	if assert.Contains(t, dump, "outer") {
+		outerDump := dump["outer"]
+		assert.Equal(t, "core", outerDump["ssh_user"])
+		assert.Equal(t, "quay.io/test:latest", outerDump["image"])
+		assert.Equal(t, RedactedValue, outerDump["ECO_TEST_PASSWORD"])
+		assert.Equal(t, "", outerDump["ECO_TEST_TOKEN"])
+		assert.Equal(t, "5m0s", outerDump["timeout"])
+		assert.NotContains(t, outerDump, "workerlabel")
+		assert.Equal(t, map[any]any{"registry": "quay.io", "registry_password": RedactedValue, "token": ""},
+			outerDump["variables"])
+		assert.Equal(t, map[any]any{"node-0": map[any]any{"name": "bmc-0", "password": RedactedValue}},
+			outerDump["nodes_bmc"])
+	}
+}
+
+func TestGetConfigDumpPath(t *testing.T) {
+	conf := &GeneralConfig{ReportsDirAbsPath: "/tmp/reports"}
+
+	assert.Equal(t, "/tmp/reports/ptp_suite_test_config.yaml", conf.GetConfigDumpPath("/src/ptp/ptp_suite_test.go"))
+	assert.Equal(t,
+		"/tmp/reports/ptp_suite_test_junit.xml", conf.GetJunitReportPath("/src/ptp/ptp_suite_test.go"))
+}
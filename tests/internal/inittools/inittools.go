@@ -32,12 +32,27 @@ func init() {
 		return
 	}
 
-	if GeneralConfig = config.NewConfig(); GeneralConfig == nil {
-		klog.Fatalf("error to load general config")
+	var err error
+
+	if GeneralConfig, err = config.LoadConfig(); err != nil {
+		klog.Fatalf("error to load general config: %v", err)
 	}
 
 	_ = flag.Set("v", GeneralConfig.VerboseLevel)
 
+	for _, typo := range config.FindEnvTypos() {
+		klog.Warningf("Environment variable %s", typo)
+	}
+
+	if GeneralConfig.PrintEffectiveConfig {
+		effectiveConfig, err := GeneralConfig.EffectiveConfig()
+		if err != nil {
+			klog.Fatalf("error to print effective general config: %v", err)
+		}
+
+		klog.Infof("Effective general config:\n%s", effectiveConfig)
+	}
+
 	if APIClient = clients.New(""); APIClient == nil {
 		if GeneralConfig.DryRun {
 			return
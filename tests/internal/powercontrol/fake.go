@@ -0,0 +1,149 @@
+package powercontrol
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Operation names a Controller method, as recorded by FakeController.
+type Operation string
+
+const (
+	// OperationPowerOn is recorded by FakeController.PowerOn.
+	OperationPowerOn Operation = "PowerOn"
+	// OperationPowerOff is recorded by FakeController.PowerOff.
+	OperationPowerOff Operation = "PowerOff"
+	// OperationPowerCycle is recorded by FakeController.PowerCycle.
+	OperationPowerCycle Operation = "PowerCycle"
+	// OperationGracefulShutdown is recorded by FakeController.GracefulShutdown.
+	OperationGracefulShutdown Operation = "GracefulShutdown"
+	// OperationNMI is recorded by FakeController.NMI.
+	OperationNMI Operation = "NMI"
+	// OperationSetBootDevice is recorded by FakeController.SetBootDevice.
+	OperationSetBootDevice Operation = "SetBootDevice"
+	// OperationPowerState is recorded by FakeController.PowerState.
+	OperationPowerState Operation = "PowerState"
+)
+
+// FakeController is an in-memory Controller for unit tests. Operations update the power state immediately and every
+// call is recorded, including failed ones. It is safe for concurrent use.
+type FakeController struct {
+	mutex      sync.Mutex
+	state      PowerState
+	bootDevice BootDevice
+	nmiCount   int
+	calls      []Operation
+	errors     map[Operation][]error
+}
+
+// NewFake returns a FakeController whose node starts in state.
+func NewFake(state PowerState) *FakeController {
+	return &FakeController{state: state, errors: make(map[Operation][]error)}
+}
+
+// FailNext makes the next len(errs) calls to operation return errs in order without changing the state of the node.
+// A nil entry lets the corresponding call succeed.
+func (fake *FakeController) FailNext(operation Operation, errs ...error) *FakeController {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.errors[operation] = append(fake.errors[operation], errs...)
+
+	return fake
+}
+
+// SetState changes the power state of the node without recording a call, for example to simulate a node that does
+// not power on.
+func (fake *FakeController) SetState(state PowerState) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.state = state
+}
+
+// Calls returns the operations called on the controller in order.
+func (fake *FakeController) Calls() []Operation {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	return slices.Clone(fake.calls)
+}
+
+// BootDevice returns the device set by the last successful SetBootDevice call.
+func (fake *FakeController) BootDevice() BootDevice {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	return fake.bootDevice
+}
+
+// NMICount returns the number of successful NMI calls.
+func (fake *FakeController) NMICount() int {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	return fake.nmiCount
+}
+
+// PowerOn powers on the in-memory node.
+func (fake *FakeController) PowerOn() error {
+	return fake.apply(OperationPowerOn, func() { fake.state = PowerStateOn })
+}
+
+// PowerOff powers off the in-memory node.
+func (fake *FakeController) PowerOff() error {
+	return fake.apply(OperationPowerOff, func() { fake.state = PowerStateOff })
+}
+
+// PowerCycle leaves the in-memory node powered on.
+func (fake *FakeController) PowerCycle() error {
+	return fake.apply(OperationPowerCycle, func() { fake.state = PowerStateOn })
+}
+
+// GracefulShutdown powers off the in-memory node.
+func (fake *FakeController) GracefulShutdown() error {
+	return fake.apply(OperationGracefulShutdown, func() { fake.state = PowerStateOff })
+}
+
+// NMI counts the interrupt, leaving the in-memory node powered on as it would be after a kdump reboot.
+func (fake *FakeController) NMI() error {
+	return fake.apply(OperationNMI, func() { fake.nmiCount++ })
+}
+
+// SetBootDevice records device as the next boot device of the in-memory node.
+func (fake *FakeController) SetBootDevice(device BootDevice) error {
+	return fake.apply(OperationSetBootDevice, func() { fake.bootDevice = device })
+}
+
+// PowerState returns the power state of the in-memory node.
+func (fake *FakeController) PowerState() (PowerState, error) {
+	var state PowerState
+
+	err := fake.apply(OperationPowerState, func() { state = fake.state })
+	if err != nil {
+		return PowerStateUnknown, err
+	}
+
+	return state, nil
+}
+
+// apply records operation and either returns its next injected error or runs update while holding the lock.
+func (fake *FakeController) apply(operation Operation, update func()) error {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+
+	fake.calls = append(fake.calls, operation)
+
+	if injected := fake.errors[operation]; len(injected) > 0 {
+		fake.errors[operation] = injected[1:]
+
+		if injected[0] != nil {
+			return fmt.Errorf("fake %s failed: %w", operation, injected[0])
+		}
+	}
+
+	update()
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package powercontrol
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// CommandRunner runs ipmitool with args, which do not include the interface or BMC options, and returns its combined
+// output.
+type CommandRunner func(args ...string) (string, error)
+
+// ipmiBootDevices maps the boot devices to their ipmitool chassis bootdev arguments.
+var ipmiBootDevices = map[BootDevice]string{
+	BootDevicePXE:   "pxe",
+	BootDeviceDisk:  "disk",
+	BootDeviceCDROM: "cdrom",
+	BootDeviceBIOS:  "bios",
+}
+
+// IPMIController controls the power of a node using ipmitool chassis commands. Where ipmitool runs is left to the
+// CommandRunner, so the same controller works from the test host or from a privileged pod on the node.
+type IPMIController struct {
+	runner CommandRunner
+}
+
+// NewIPMI returns an IPMIController running ipmitool through runner.
+func NewIPMI(runner CommandRunner) *IPMIController {
+	return &IPMIController{runner: runner}
+}
+
+// LANPlusRunner returns a CommandRunner that runs ipmitool on the test host against the BMC at address over the IPMI
+// v2.0 lanplus interface.
+func LANPlusRunner(address, username, password string) CommandRunner {
+	return func(args ...string) (string, error) {
+		fullArgs := append([]string{"-I", "lanplus", "-H", address, "-U", username, "-P", password}, args...)
+
+		klog.V(90).Infof("Running ipmitool %s against BMC %s", strings.Join(args, " "), address)
+
+		var output bytes.Buffer
+
+		command := exec.Command("ipmitool", fullArgs...)
+		command.Stdout = &output
+		command.Stderr = &output
+
+		err := command.Run()
+		if err != nil {
+			return output.String(), fmt.Errorf("failed to run ipmitool %s: %w: %s",
+				strings.Join(args, " "), err, strings.TrimSpace(output.String()))
+		}
+
+		return output.String(), nil
+	}
+}
+
+// PowerOn powers on the node using ipmitool chassis power on.
+func (controller *IPMIController) PowerOn() error {
+	return controller.run("chassis", "power", "on")
+}
+
+// PowerOff powers off the node using ipmitool chassis power off.
+func (controller *IPMIController) PowerOff() error {
+	return controller.run("chassis", "power", "off")
+}
+
+// PowerCycle power cycles the node using ipmitool chassis power cycle.
+func (controller *IPMIController) PowerCycle() error {
+	return controller.run("chassis", "power", "cycle")
+}
+
+// GracefulShutdown shuts down the node using ipmitool chassis power soft, which emulates an ACPI power button press.
+func (controller *IPMIController) GracefulShutdown() error {
+	return controller.run("chassis", "power", "soft")
+}
+
+// NMI sends a Non-Maskable Interrupt to the node using ipmitool chassis power diag.
+func (controller *IPMIController) NMI() error {
+	return controller.run("chassis", "power", "diag")
+}
+
+// SetBootDevice sets the device the node boots from on its next boot using ipmitool chassis bootdev.
+func (controller *IPMIController) SetBootDevice(device BootDevice) error {
+	bootDevice, ok := ipmiBootDevices[device]
+	if !ok {
+		return fmt.Errorf("boot device %q is not supported by ipmi power controller", device)
+	}
+
+	return controller.run("chassis", "bootdev", bootDevice)
+}
+
+// PowerState returns the power state of the node parsed from the output of ipmitool chassis power status.
+func (controller *IPMIController) PowerState() (PowerState, error) {
+	output, err := controller.runner("chassis", "power", "status")
+	if err != nil {
+		return PowerStateUnknown, err
+	}
+
+	return parseIPMIPowerStatus(output)
+}
+
+// run runs ipmitool with args, discarding its output.
+func (controller *IPMIController) run(args ...string) error {
+	_, err := controller.runner(args...)
+
+	return err
+}
+
+// parseIPMIPowerStatus parses the output of ipmitool chassis power status, which is of the form
+// "Chassis Power is on".
+func parseIPMIPowerStatus(output string) (PowerState, error) {
+	trimmedOutput := strings.ToLower(strings.TrimSpace(output))
+
+	switch {
+	case strings.HasSuffix(trimmedOutput, "power is on"):
+		return PowerStateOn, nil
+	case strings.HasSuffix(trimmedOutput, "power is off"):
+		return PowerStateOff, nil
+	default:
+		return PowerStateUnknown, fmt.Errorf("unexpected ipmitool power status output: %q", strings.TrimSpace(output))
+	}
+}
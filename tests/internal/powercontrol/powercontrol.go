@@ -0,0 +1,95 @@
+package powercontrol
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PowerState is the power state of a node as reported by its BMC.
+type PowerState string
+
+const (
+	// PowerStateOn is the power state of a node that is powered on.
+	PowerStateOn PowerState = "On"
+	// PowerStateOff is the power state of a node that is powered off.
+	PowerStateOff PowerState = "Off"
+	// PowerStateUnknown is the power state of a node whose BMC reported a state that is neither on nor off, for
+	// example while powering on.
+	PowerStateUnknown PowerState = "Unknown"
+)
+
+// BootDevice is the device a node boots from on its next boot.
+type BootDevice string
+
+const (
+	// BootDevicePXE boots the node from the network.
+	BootDevicePXE BootDevice = "pxe"
+	// BootDeviceDisk boots the node from its default hard drive.
+	BootDeviceDisk BootDevice = "disk"
+	// BootDeviceCDROM boots the node from its CD/DVD drive or virtual media.
+	BootDeviceCDROM BootDevice = "cdrom"
+	// BootDeviceBIOS boots the node into its BIOS setup.
+	BootDeviceBIOS BootDevice = "bios"
+)
+
+// Type is the kind of BMC used to control the power of a node.
+type Type string
+
+const (
+	// TypeRedfish controls the node through the Redfish API of its BMC.
+	TypeRedfish Type = "redfish"
+	// TypeIPMI controls the node using ipmitool over the IPMI v2.0 lanplus interface of its BMC.
+	TypeIPMI Type = "ipmi"
+	// TypeFake controls an in-memory node and is meant for unit tests.
+	TypeFake Type = "fake"
+)
+
+// Controller controls the power of a single node through its BMC. All operations return once the BMC accepted the
+// request; callers needing the node to reach a given state should poll PowerState.
+type Controller interface {
+	// PowerOn powers on the node.
+	PowerOn() error
+	// PowerOff forcefully powers off the node.
+	PowerOff() error
+	// PowerCycle forcefully powers off the node then powers it back on.
+	PowerCycle() error
+	// GracefulShutdown asks the operating system of the node to shut down.
+	GracefulShutdown() error
+	// NMI sends a Non-Maskable Interrupt to the node, causing a kernel crash if kdump is configured.
+	NMI() error
+	// SetBootDevice overrides the device the node boots from on its next boot.
+	SetBootDevice(device BootDevice) error
+	// PowerState returns the current power state of the node.
+	PowerState() (PowerState, error)
+}
+
+// Config holds the details needed to control the power of a node. An empty Type defaults to TypeRedfish, which is what
+// the BMC credentials of the suites have historically been used for.
+type Config struct {
+	Type     Type
+	Address  string
+	Username string
+	Password string
+}
+
+// New returns the Controller matching the type of config.
+func New(config Config) (Controller, error) {
+	switch Type(strings.ToLower(string(config.Type))) {
+	case TypeRedfish, "":
+		if config.Address == "" {
+			return nil, fmt.Errorf("cannot create redfish power controller: address is empty")
+		}
+
+		return NewRedfish(config), nil
+	case TypeIPMI:
+		if config.Address == "" {
+			return nil, fmt.Errorf("cannot create ipmi power controller: address is empty")
+		}
+
+		return NewIPMI(LANPlusRunner(config.Address, config.Username, config.Password)), nil
+	case TypeFake:
+		return NewFake(PowerStateOn), nil
+	default:
+		return nil, fmt.Errorf("unknown power controller type %q", config.Type)
+	}
+}
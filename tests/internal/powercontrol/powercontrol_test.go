@@ -0,0 +1,131 @@
+package powercontrol
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		config       Config
+		expectedType any
+		expectError  bool
+	}{
+		{config: Config{Address: "10.0.0.1"}, expectedType: &RedfishController{}},
+		{config: Config{Type: TypeRedfish, Address: "10.0.0.1"}, expectedType: &RedfishController{}},
+		{config: Config{Type: "IPMI", Address: "10.0.0.1"}, expectedType: &IPMIController{}},
+		{config: Config{Type: TypeFake}, expectedType: &FakeController{}},
+		{config: Config{Type: TypeRedfish}, expectError: true},
+		{config: Config{Type: TypeIPMI}, expectError: true},
+		{config: Config{Type: "serial", Address: "10.0.0.1"}, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		controller, err := New(testCase.config)
+
+		if testCase.expectError {
+			assert.NotNil(t, err)
+			assert.Nil(t, controller)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.IsType(t, testCase.expectedType, controller)
+	}
+}
+
+func TestIPMIController(t *testing.T) {
+	var recordedArgs [][]string
+
+	runner := func(args ...string) (string, error) {
+		recordedArgs = append(recordedArgs, args)
+
+		return "Chassis Power is on\n", nil
+	}
+
+	controller := NewIPMI(runner)
+
+	assert.Nil(t, controller.PowerOn())
+	assert.Nil(t, controller.PowerOff())
+	assert.Nil(t, controller.PowerCycle())
+	assert.Nil(t, controller.GracefulShutdown())
+	assert.Nil(t, controller.NMI())
+	assert.Nil(t, controller.SetBootDevice(BootDevicePXE))
+	assert.NotNil(t, controller.SetBootDevice("floppy"))
+
+	state, err := controller.PowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOn, state)
+
+	assert.Equal(t, [][]string{
+		{"chassis", "power", "on"},
+		{"chassis", "power", "off"},
+		{"chassis", "power", "cycle"},
+		{"chassis", "power", "soft"},
+		{"chassis", "power", "diag"},
+		{"chassis", "bootdev", "pxe"},
+		{"chassis", "power", "status"},
+	}, recordedArgs)
+
+	failingController := NewIPMI(func(args ...string) (string, error) {
+		return "", errors.New("bmc unreachable")
+	})
+
+	state, err = failingController.PowerState()
+	assert.NotNil(t, err)
+	assert.Equal(t, PowerStateUnknown, state)
+}
+
+func TestParseIPMIPowerStatus(t *testing.T) {
+	testCases := []struct {
+		output        string
+		expectedState PowerState
+		expectError   bool
+	}{
+		{output: "Chassis Power is on\n", expectedState: PowerStateOn},
+		{output: "Chassis Power is off", expectedState: PowerStateOff},
+		{output: "Error: Unable to establish IPMI v2 / RMCP+ session", expectedState: PowerStateUnknown,
+			expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		state, err := parseIPMIPowerStatus(testCase.output)
+
+		assert.Equal(t, testCase.expectError, err != nil)
+		assert.Equal(t, testCase.expectedState, state)
+	}
+}
+
+func TestFakeController(t *testing.T) {
+	fake := NewFake(PowerStateOn).FailNext(OperationPowerOn, errors.New("busy"), nil)
+
+	assert.Nil(t, fake.PowerOff())
+
+	state, err := fake.PowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOff, state)
+
+	assert.NotNil(t, fake.PowerOn())
+
+	state, _ = fake.PowerState()
+	assert.Equal(t, PowerStateOff, state)
+
+	assert.Nil(t, fake.PowerOn())
+
+	state, _ = fake.PowerState()
+	assert.Equal(t, PowerStateOn, state)
+
+	assert.Nil(t, fake.NMI())
+	assert.Nil(t, fake.SetBootDevice(BootDeviceCDROM))
+	assert.Nil(t, fake.GracefulShutdown())
+
+	assert.Equal(t, 1, fake.NMICount())
+	assert.Equal(t, BootDeviceCDROM, fake.BootDevice())
+	assert.Equal(t, []Operation{
+		OperationPowerOff, OperationPowerState, OperationPowerOn, OperationPowerState, OperationPowerOn,
+		OperationPowerState, OperationNMI, OperationSetBootDevice, OperationGracefulShutdown,
+	}, fake.Calls())
+}
@@ -0,0 +1,154 @@
+package powercontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/redfish"
+	"k8s.io/klog/v2"
+)
+
+// redfishBootTargets maps the boot devices to their Redfish boot source override targets.
+var redfishBootTargets = map[BootDevice]redfish.BootSourceOverrideTarget{
+	BootDevicePXE:   redfish.PxeBootSourceOverrideTarget,
+	BootDeviceDisk:  redfish.HddBootSourceOverrideTarget,
+	BootDeviceCDROM: redfish.CdBootSourceOverrideTarget,
+	BootDeviceBIOS:  redfish.BiosSetupBootSourceOverrideTarget,
+}
+
+// RedfishController controls the power of a node through the Redfish API of its BMC. Power operations go through the
+// eco-goinfra bmc package while the boot device override, which it does not support, uses gofish directly.
+type RedfishController struct {
+	bmcClient *bmc.BMC
+	config    Config
+	timeout   time.Duration
+}
+
+// NewRedfish returns a RedfishController for the BMC at the address of config, using the default Redfish timeout of
+// the bmc package. The address may be a host, optionally with a port, or an https URL.
+func NewRedfish(config Config) *RedfishController {
+	klog.V(90).Infof("Creating redfish power controller for BMC %s", config.Address)
+
+	return &RedfishController{
+		bmcClient: bmc.New(strings.TrimPrefix(config.Address, "https://")).WithRedfishUser(config.Username, config.Password),
+		config:    config,
+		timeout:   bmc.DefaultTimeOuts.Redfish,
+	}
+}
+
+// NewRedfishFromBMC returns a RedfishController wrapping an already configured bmcClient. Since the credentials of
+// bmcClient cannot be retrieved, SetBootDevice always fails on the returned controller.
+func NewRedfishFromBMC(bmcClient *bmc.BMC) *RedfishController {
+	return &RedfishController{bmcClient: bmcClient, timeout: bmc.DefaultTimeOuts.Redfish}
+}
+
+// WithTimeout sets the timeout of the Redfish sessions used by the controller.
+func (controller *RedfishController) WithTimeout(timeout time.Duration) *RedfishController {
+	controller.bmcClient = controller.bmcClient.WithRedfishTimeout(timeout)
+	controller.timeout = timeout
+
+	return controller
+}
+
+// PowerOn powers on the node using the Redfish On reset action.
+func (controller *RedfishController) PowerOn() error {
+	return controller.bmcClient.SystemPowerOn()
+}
+
+// PowerOff powers off the node using the Redfish ForceOff reset action.
+func (controller *RedfishController) PowerOff() error {
+	return controller.bmcClient.SystemPowerOff()
+}
+
+// PowerCycle power cycles the node, falling back to ForceOff and On if the BMC does not support PowerCycle.
+func (controller *RedfishController) PowerCycle() error {
+	return controller.bmcClient.SystemPowerCycle()
+}
+
+// GracefulShutdown shuts down the node using the Redfish GracefulShutdown reset action.
+func (controller *RedfishController) GracefulShutdown() error {
+	return controller.bmcClient.SystemGracefulShutdown()
+}
+
+// NMI sends a Non-Maskable Interrupt to the node using the Redfish Nmi reset action.
+func (controller *RedfishController) NMI() error {
+	return controller.bmcClient.SystemResetAction(redfish.NmiResetType)
+}
+
+// SetBootDevice sets a one time boot source override to device on the first system of the BMC.
+func (controller *RedfishController) SetBootDevice(device BootDevice) error {
+	target, ok := redfishBootTargets[device]
+	if !ok {
+		return fmt.Errorf("boot device %q is not supported by redfish power controller", device)
+	}
+
+	if controller.config.Address == "" {
+		return fmt.Errorf("cannot set boot device: redfish power controller was created without credentials")
+	}
+
+	klog.V(90).Infof("Setting boot device of BMC %s to %s", controller.config.Address, target)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), controller.timeout)
+	defer cancel()
+
+	redfishClient, err := gofish.ConnectContext(ctx, gofish.ClientConfig{
+		Endpoint: getRedfishEndpoint(controller.config.Address),
+		Username: controller.config.Username,
+		Password: controller.config.Password,
+		Insecure: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to connect to redfish endpoint %s: %w", controller.config.Address, err)
+	}
+
+	defer redfishClient.Logout()
+
+	systems, err := redfishClient.GetService().Systems()
+	if err != nil {
+		return fmt.Errorf("failed to get redfish systems: %w", err)
+	}
+
+	if len(systems) == 0 {
+		return fmt.Errorf("no redfish systems found on BMC %s", controller.config.Address)
+	}
+
+	err = systems[0].SetBoot(redfish.Boot{
+		BootSourceOverrideEnabled: redfish.OnceBootSourceOverrideEnabled,
+		BootSourceOverrideTarget:  target,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set boot source override to %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// PowerState returns the power state of the node, mapping the transitional Redfish states to PowerStateUnknown.
+func (controller *RedfishController) PowerState() (PowerState, error) {
+	powerState, err := controller.bmcClient.SystemPowerState()
+	if err != nil {
+		return PowerStateUnknown, err
+	}
+
+	switch redfish.PowerState(powerState) {
+	case redfish.OnPowerState:
+		return PowerStateOn, nil
+	case redfish.OffPowerState:
+		return PowerStateOff, nil
+	default:
+		return PowerStateUnknown, nil
+	}
+}
+
+// getRedfishEndpoint returns the URL of the Redfish API at address, adding the https scheme if address has none.
+func getRedfishEndpoint(address string) string {
+	if strings.Contains(address, "://") {
+		return address
+	}
+
+	return "https://" + address
+}
@@ -0,0 +1,76 @@
+package powercontrol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/redfishmock"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedfishController(t *testing.T) {
+	server := redfishmock.NewServer("admin", "password")
+	defer server.Close()
+
+	controller := NewRedfish(Config{Type: TypeRedfish, Address: server.Address(), Username: "admin",
+		Password: "password"}).WithTimeout(5 * time.Second)
+
+	assert.Nil(t, controller.PowerOff())
+
+	state, err := controller.PowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOff, state)
+
+	assert.Nil(t, controller.PowerOn())
+	assert.Nil(t, controller.NMI())
+	assert.Nil(t, controller.GracefulShutdown())
+	assert.Nil(t, controller.PowerCycle())
+	assert.Nil(t, controller.SetBootDevice(BootDevicePXE))
+	assert.NotNil(t, controller.SetBootDevice("floppy"))
+
+	enabled, target := server.BootOverride()
+	assert.Equal(t, redfish.OnceBootSourceOverrideEnabled, enabled)
+	assert.Equal(t, redfish.PxeBootSourceOverrideTarget, target)
+	assert.Equal(t, 1, server.NMICount())
+	assert.Equal(t, []redfish.ResetType{
+		redfish.ForceOffResetType,
+		redfish.OnResetType,
+		redfish.NmiResetType,
+		redfish.GracefulShutdownResetType,
+		redfish.PowerCycleResetType,
+	}, server.Resets())
+
+	server.SetTransitionReads(1)
+	assert.Nil(t, controller.PowerOff())
+
+	state, err = controller.PowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateUnknown, state)
+
+	fromBMC := NewRedfishFromBMC(
+		bmc.New(server.Address()).WithRedfishUser("admin", "password").WithRedfishTimeout(5 * time.Second))
+
+	state, err = fromBMC.PowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, PowerStateOff, state)
+	assert.NotNil(t, fromBMC.SetBootDevice(BootDeviceDisk))
+}
+
+func TestRedfishControllerURLAddress(t *testing.T) {
+	server := redfishmock.NewServer("admin", "password")
+	defer server.Close()
+
+	// Addresses with the https scheme, as documented for the node BMC maps, work the same as bare hosts.
+	controller := NewRedfish(Config{Type: TypeRedfish, Address: server.URL(), Username: "admin",
+		Password: "password"}).WithTimeout(5 * time.Second)
+
+	assert.Nil(t, controller.PowerOff())
+	assert.Nil(t, controller.SetBootDevice(BootDeviceDisk))
+
+	_, target := server.BootOverride()
+	assert.Equal(t, redfish.HddBootSourceOverrideTarget, target)
+	assert.Equal(t, "https://bmc:443", getRedfishEndpoint("bmc:443"))
+	assert.Equal(t, "https://bmc:443", getRedfishEndpoint("https://bmc:443"))
+}
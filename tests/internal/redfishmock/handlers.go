@@ -0,0 +1,435 @@
+package redfishmock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+const (
+	serviceRootPath            = "/redfish/v1/"
+	sessionsPath               = "/redfish/v1/SessionService/Sessions"
+	systemsPath                = "/redfish/v1/Systems"
+	systemPath                 = systemsPath + "/1"
+	resetPath                  = systemPath + "/Actions/ComputerSystem.Reset"
+	managersPath               = "/redfish/v1/Managers"
+	managerPath                = managersPath + "/1"
+	virtualMediaCollectionPath = managerPath + "/VirtualMedia"
+	virtualMediaPath           = virtualMediaCollectionPath + "/Cd"
+	insertMediaPath            = virtualMediaPath + "/Actions/VirtualMedia.InsertMedia"
+	ejectMediaPath             = virtualMediaPath + "/Actions/VirtualMedia.EjectMedia"
+)
+
+// supportedBootTargets are the boot source override targets accepted by the system.
+var supportedBootTargets = []redfish.BootSourceOverrideTarget{
+	redfish.NoneBootSourceOverrideTarget,
+	redfish.PxeBootSourceOverrideTarget,
+	redfish.CdBootSourceOverrideTarget,
+	redfish.HddBootSourceOverrideTarget,
+	redfish.BiosSetupBootSourceOverrideTarget,
+}
+
+// handler returns the HTTP handler of the server, which applies the latency, authentication and injected failures
+// before dispatching the request.
+func (server *Server) handler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		path := strings.TrimSuffix(request.URL.Path, "/")
+		if path == "/redfish/v1" {
+			path = serviceRootPath
+		}
+
+		route := routeOf(path)
+
+		server.mutex.Lock()
+		latency := server.latency
+		server.mutex.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-request.Context().Done():
+				return
+			}
+		}
+
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		if statusCode, ok := server.popFailure(route); ok {
+			writeError(writer, statusCode, fmt.Sprintf("injected failure for %s", route))
+
+			return
+		}
+
+		if !isUnauthenticated(request.Method, path) && !server.isAuthenticated(request) {
+			writeError(writer, http.StatusUnauthorized, "authentication required")
+
+			return
+		}
+
+		server.dispatch(writer, request, path)
+	})
+}
+
+// dispatch serves the request for path. It must be called with the mutex held.
+func (server *Server) dispatch(writer http.ResponseWriter, request *http.Request, path string) {
+	switch {
+	case path == serviceRootPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, serviceRootBody())
+	case path == sessionsPath && request.Method == http.MethodPost:
+		server.createSession(writer, request)
+	case strings.HasPrefix(path, sessionsPath+"/") && request.Method == http.MethodDelete:
+		server.deleteSession(writer, path)
+	case path == systemsPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, collectionBody(systemsPath, systemPath))
+	case path == systemPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, server.systemBody())
+	case path == systemPath && request.Method == http.MethodPatch:
+		server.patchSystem(writer, request)
+	case path == resetPath && request.Method == http.MethodPost:
+		server.reset(writer, request)
+	case path == managersPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, collectionBody(managersPath, managerPath))
+	case path == managerPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, managerBody())
+	case path == virtualMediaCollectionPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, collectionBody(virtualMediaCollectionPath, virtualMediaPath))
+	case path == virtualMediaPath && request.Method == http.MethodGet:
+		writeJSON(writer, http.StatusOK, server.virtualMediaBody())
+	case path == insertMediaPath && request.Method == http.MethodPost:
+		server.insertMedia(writer, request)
+	case path == ejectMediaPath && request.Method == http.MethodPost:
+		server.mediaImage = ""
+		server.mediaInserted = false
+
+		writer.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(writer, http.StatusNotFound, fmt.Sprintf("%s %s not found", request.Method, path))
+	}
+}
+
+// createSession logs in with the credentials in the request body, returning the token and session location in the
+// headers like a real BMC.
+func (server *Server) createSession(writer http.ResponseWriter, request *http.Request) {
+	var credentials struct {
+		UserName string
+		Password string
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&credentials)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("invalid session request: %v", err))
+
+		return
+	}
+
+	if credentials.UserName != server.username || credentials.Password != server.password {
+		writeError(writer, http.StatusUnauthorized, "invalid credentials")
+
+		return
+	}
+
+	server.sessionCount++
+
+	token := fmt.Sprintf("token-%d", server.sessionCount)
+	location := fmt.Sprintf("%s/%d", sessionsPath, server.sessionCount)
+	server.sessions[token] = location
+
+	writer.Header().Set("X-Auth-Token", token)
+	writer.Header().Set("Location", location)
+	writeJSON(writer, http.StatusCreated, map[string]any{"@odata.id": location, "UserName": credentials.UserName})
+}
+
+// deleteSession logs out of the session at path.
+func (server *Server) deleteSession(writer http.ResponseWriter, path string) {
+	for token, location := range server.sessions {
+		if location == path {
+			delete(server.sessions, token)
+			writer.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+	}
+
+	writeError(writer, http.StatusNotFound, fmt.Sprintf("session %s not found", path))
+}
+
+// patchSystem updates the boot source override of the system.
+func (server *Server) patchSystem(writer http.ResponseWriter, request *http.Request) {
+	var patch struct {
+		Boot *redfish.Boot
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&patch)
+	if err != nil || patch.Boot == nil {
+		writeError(writer, http.StatusBadRequest, "only the Boot property of the system can be patched")
+
+		return
+	}
+
+	if patch.Boot.BootSourceOverrideTarget != "" {
+		if !slices.Contains(supportedBootTargets, patch.Boot.BootSourceOverrideTarget) {
+			writeError(writer, http.StatusBadRequest,
+				fmt.Sprintf("unsupported boot source override target %s", patch.Boot.BootSourceOverrideTarget))
+
+			return
+		}
+
+		server.boot.BootSourceOverrideTarget = patch.Boot.BootSourceOverrideTarget
+	}
+
+	if patch.Boot.BootSourceOverrideEnabled != "" {
+		server.boot.BootSourceOverrideEnabled = patch.Boot.BootSourceOverrideEnabled
+	}
+
+	if len(patch.Boot.BootOrder) > 0 {
+		server.boot.BootOrder = slices.Clone(patch.Boot.BootOrder)
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// reset applies a ComputerSystem.Reset action to the power state of the system.
+func (server *Server) reset(writer http.ResponseWriter, request *http.Request) {
+	var action struct {
+		ResetType redfish.ResetType
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&action)
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("invalid reset request: %v", err))
+
+		return
+	}
+
+	if !slices.Contains(server.supportedResetTypes, action.ResetType) {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("unsupported reset type %s", action.ResetType))
+
+		return
+	}
+
+	newState := server.powerState
+
+	switch action.ResetType {
+	case redfish.OnResetType, redfish.ForceOnResetType, redfish.ForceRestartResetType,
+		redfish.GracefulRestartResetType, redfish.PowerCycleResetType:
+		newState = redfish.OnPowerState
+	case redfish.ForceOffResetType, redfish.GracefulShutdownResetType:
+		newState = redfish.OffPowerState
+	case redfish.PushPowerButtonResetType:
+		newState = redfish.OffPowerState
+
+		if server.powerState == redfish.OffPowerState {
+			newState = redfish.OnPowerState
+		}
+	case redfish.NmiResetType:
+		if server.powerState != redfish.OnPowerState {
+			writeError(writer, http.StatusConflict, "cannot send NMI to a system that is not powered on")
+
+			return
+		}
+
+		server.nmiCount++
+	default:
+	}
+
+	if newState != server.powerState {
+		server.pendingTransitions = server.transitionReads
+	}
+
+	server.powerState = newState
+	server.resets = append(server.resets, action.ResetType)
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// insertMedia inserts the image in the request body into the CD virtual media.
+func (server *Server) insertMedia(writer http.ResponseWriter, request *http.Request) {
+	var media struct {
+		Image    string
+		Inserted bool
+	}
+
+	err := json.NewDecoder(request.Body).Decode(&media)
+	if err != nil || media.Image == "" {
+		writeError(writer, http.StatusBadRequest, "insert media request must contain an image")
+
+		return
+	}
+
+	server.mediaImage = media.Image
+	server.mediaInserted = media.Inserted
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// systemBody returns the computer system resource, reporting a transitional power state while a transition is
+// pending.
+func (server *Server) systemBody() map[string]any {
+	powerState := server.powerState
+
+	if server.pendingTransitions > 0 {
+		server.pendingTransitions--
+
+		powerState = redfish.PoweringOffPowerState
+		if server.powerState == redfish.OnPowerState {
+			powerState = redfish.PoweringOnPowerState
+		}
+	}
+
+	return map[string]any{
+		"@odata.id":    systemPath,
+		"@odata.type":  "#ComputerSystem.v1_13_0.ComputerSystem",
+		"Id":           "1",
+		"Name":         "Mock System",
+		"Manufacturer": "eco-gotests",
+		"PowerState":   powerState,
+		"Boot": map[string]any{
+			"BootSourceOverrideEnabled":                        server.boot.BootSourceOverrideEnabled,
+			"BootSourceOverrideTarget":                         server.boot.BootSourceOverrideTarget,
+			"BootSourceOverrideTarget@Redfish.AllowableValues": supportedBootTargets,
+			"BootOrder": server.boot.BootOrder,
+		},
+		"Actions": map[string]any{
+			"#ComputerSystem.Reset": map[string]any{
+				"target":                            resetPath,
+				"ResetType@Redfish.AllowableValues": server.supportedResetTypes,
+			},
+		},
+		"VirtualMedia": map[string]any{"@odata.id": virtualMediaCollectionPath},
+		"Links": map[string]any{
+			"ManagedBy": []map[string]any{{"@odata.id": managerPath}},
+		},
+	}
+}
+
+// virtualMediaBody returns the CD virtual media resource.
+func (server *Server) virtualMediaBody() map[string]any {
+	return map[string]any{
+		"@odata.id":   virtualMediaPath,
+		"@odata.type": "#VirtualMedia.v1_3_0.VirtualMedia",
+		"Id":          "Cd",
+		"Name":        "Virtual CD",
+		"MediaTypes":  []string{"CD", "DVD"},
+		"Image":       server.mediaImage,
+		"Inserted":    server.mediaInserted,
+		"Actions": map[string]any{
+			"#VirtualMedia.InsertMedia": map[string]any{"target": insertMediaPath},
+			"#VirtualMedia.EjectMedia":  map[string]any{"target": ejectMediaPath},
+		},
+	}
+}
+
+// popFailure returns the status code of the next injected failure for route, if any. It must be called with the mutex
+// held.
+func (server *Server) popFailure(route Route) (int, bool) {
+	failures := server.failures[route]
+	if len(failures) == 0 {
+		return 0, false
+	}
+
+	server.failures[route] = failures[1:]
+
+	return failures[0], true
+}
+
+// isAuthenticated returns whether the request carries the token of an active session or valid basic auth
+// credentials. It must be called with the mutex held.
+func (server *Server) isAuthenticated(request *http.Request) bool {
+	if _, ok := server.sessions[request.Header.Get("X-Auth-Token")]; ok {
+		return true
+	}
+
+	username, password, ok := request.BasicAuth()
+
+	return ok && username == server.username && password == server.password
+}
+
+// isUnauthenticated returns whether the request is allowed without credentials, which is only the case for reading
+// the service root and logging in.
+func isUnauthenticated(method, path string) bool {
+	return (method == http.MethodGet && path == serviceRootPath) || (method == http.MethodPost && path == sessionsPath)
+}
+
+// routeOf returns the route that path belongs to.
+func routeOf(path string) Route {
+	switch {
+	case path == serviceRootPath:
+		return RouteServiceRoot
+	case strings.HasPrefix(path, sessionsPath):
+		return RouteSessions
+	case path == resetPath:
+		return RouteReset
+	case strings.HasPrefix(path, virtualMediaCollectionPath):
+		return RouteVirtualMedia
+	case strings.HasPrefix(path, managersPath):
+		return RouteManager
+	default:
+		return RouteSystem
+	}
+}
+
+// serviceRootBody returns the Redfish service root resource.
+func serviceRootBody() map[string]any {
+	return map[string]any{
+		"@odata.id":      serviceRootPath,
+		"@odata.type":    "#ServiceRoot.v1_5_0.ServiceRoot",
+		"Id":             "RootService",
+		"Name":           "Mock Redfish Service",
+		"RedfishVersion": "1.6.0",
+		"Systems":        map[string]any{"@odata.id": systemsPath},
+		"Managers":       map[string]any{"@odata.id": managersPath},
+		"Links": map[string]any{
+			"Sessions": map[string]any{"@odata.id": sessionsPath},
+		},
+	}
+}
+
+// managerBody returns the BMC manager resource.
+func managerBody() map[string]any {
+	return map[string]any{
+		"@odata.id":    managerPath,
+		"@odata.type":  "#Manager.v1_5_0.Manager",
+		"Id":           "1",
+		"Name":         "Mock Manager",
+		"ManagerType":  "BMC",
+		"VirtualMedia": map[string]any{"@odata.id": virtualMediaCollectionPath},
+	}
+}
+
+// collectionBody returns a collection resource at path containing members.
+func collectionBody(path string, members ...string) map[string]any {
+	memberLinks := make([]map[string]any, 0, len(members))
+	for _, member := range members {
+		memberLinks = append(memberLinks, map[string]any{"@odata.id": member})
+	}
+
+	return map[string]any{
+		"@odata.id":           path,
+		"Members":             memberLinks,
+		"Members@odata.count": len(members),
+	}
+}
+
+// writeJSON writes body as the JSON response with statusCode.
+func writeJSON(writer http.ResponseWriter, statusCode int, body any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+// writeError writes a Redfish error response with statusCode and message.
+func writeError(writer http.ResponseWriter, statusCode int, message string) {
+	writeJSON(writer, statusCode, map[string]any{
+		"error": map[string]any{
+			"code":    "Base.1.0.GeneralError",
+			"message": message,
+		},
+	})
+}
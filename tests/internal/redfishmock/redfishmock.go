@@ -0,0 +1,191 @@
+package redfishmock
+
+import (
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stmcginnis/gofish/redfish"
+)
+
+// Route groups the endpoints of the mock server that share injected failures.
+type Route string
+
+const (
+	// RouteServiceRoot is the Redfish service root.
+	RouteServiceRoot Route = "ServiceRoot"
+	// RouteSessions is the session service used to log in and out.
+	RouteSessions Route = "Sessions"
+	// RouteSystem is the systems collection and the computer system, including boot override PATCH requests.
+	RouteSystem Route = "System"
+	// RouteReset is the ComputerSystem.Reset action, used for power operations and NMI.
+	RouteReset Route = "Reset"
+	// RouteManager is the managers collection and the manager.
+	RouteManager Route = "Manager"
+	// RouteVirtualMedia is the virtual media collection, the CD virtual media and its insert and eject actions.
+	RouteVirtualMedia Route = "VirtualMedia"
+)
+
+// DefaultSupportedResetTypes are the reset types supported by a new Server.
+var DefaultSupportedResetTypes = []redfish.ResetType{
+	redfish.OnResetType,
+	redfish.ForceOffResetType,
+	redfish.GracefulShutdownResetType,
+	redfish.GracefulRestartResetType,
+	redfish.ForceRestartResetType,
+	redfish.PowerCycleResetType,
+	redfish.NmiResetType,
+}
+
+// Server is an in-process Redfish BMC serving a single computer system and manager over HTTPS. It implements enough
+// of the API for gofish, and so the eco-goinfra bmc package, to log in, control power, send NMIs, override the boot
+// device and insert virtual media. Its address can be passed directly to bmc.New.
+type Server struct {
+	httpServer *httptest.Server
+
+	mutex               sync.Mutex
+	username            string
+	password            string
+	sessions            map[string]string
+	sessionCount        int
+	powerState          redfish.PowerState
+	transitionReads     int
+	pendingTransitions  int
+	supportedResetTypes []redfish.ResetType
+	resets              []redfish.ResetType
+	nmiCount            int
+	boot                redfish.Boot
+	mediaImage          string
+	mediaInserted       bool
+	latency             time.Duration
+	failures            map[Route][]int
+}
+
+// NewServer starts a Server accepting username and password, with the system powered on. The caller must Close it.
+func NewServer(username, password string) *Server {
+	server := &Server{
+		username:            username,
+		password:            password,
+		sessions:            make(map[string]string),
+		powerState:          redfish.OnPowerState,
+		supportedResetTypes: slices.Clone(DefaultSupportedResetTypes),
+		boot: redfish.Boot{
+			BootSourceOverrideEnabled: redfish.DisabledBootSourceOverrideEnabled,
+			BootSourceOverrideTarget:  redfish.NoneBootSourceOverrideTarget,
+		},
+		failures: make(map[Route][]int),
+	}
+
+	server.httpServer = httptest.NewTLSServer(server.handler())
+
+	return server
+}
+
+// Close shuts down the server, blocking until all outstanding requests have completed.
+func (server *Server) Close() {
+	server.httpServer.Close()
+}
+
+// Address returns the host and port of the server, without the scheme, in the form expected by bmc.New.
+func (server *Server) Address() string {
+	return strings.TrimPrefix(server.httpServer.URL, "https://")
+}
+
+// URL returns the base URL of the server, in the form expected by gofish.
+func (server *Server) URL() string {
+	return server.httpServer.URL
+}
+
+// SetPowerState sets the power state of the system, cancelling any pending transition.
+func (server *Server) SetPowerState(powerState redfish.PowerState) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.powerState = powerState
+	server.pendingTransitions = 0
+}
+
+// SetTransitionReads makes the system report PoweringOn or PoweringOff for the next reads reads of the system after
+// each reset that changes its power state, emulating a BMC that takes time to apply it.
+func (server *Server) SetTransitionReads(reads int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.transitionReads = reads
+}
+
+// SetSupportedResetTypes replaces the reset types advertised and accepted by the system.
+func (server *Server) SetSupportedResetTypes(resetTypes ...redfish.ResetType) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.supportedResetTypes = slices.Clone(resetTypes)
+}
+
+// SetLatency delays every response of the server by latency.
+func (server *Server) SetLatency(latency time.Duration) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.latency = latency
+}
+
+// FailNext makes the next count requests to route fail with statusCode without changing the state of the server.
+func (server *Server) FailNext(route Route, statusCode, count int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	for range count {
+		server.failures[route] = append(server.failures[route], statusCode)
+	}
+}
+
+// PowerState returns the power state of the system, ignoring any pending transition.
+func (server *Server) PowerState() redfish.PowerState {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.powerState
+}
+
+// Resets returns the reset types of all the accepted reset actions in order.
+func (server *Server) Resets() []redfish.ResetType {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return slices.Clone(server.resets)
+}
+
+// NMICount returns the number of accepted Nmi reset actions.
+func (server *Server) NMICount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.nmiCount
+}
+
+// BootOverride returns the boot source override of the system.
+func (server *Server) BootOverride() (redfish.BootSourceOverrideEnabled, redfish.BootSourceOverrideTarget) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.boot.BootSourceOverrideEnabled, server.boot.BootSourceOverrideTarget
+}
+
+// VirtualMedia returns the image of the CD virtual media and whether it is inserted.
+func (server *Server) VirtualMedia() (string, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.mediaImage, server.mediaInserted
+}
+
+// ActiveSessions returns the number of sessions that have been created and not deleted.
+func (server *Server) ActiveSessions() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return len(server.sessions)
+}
@@ -0,0 +1,129 @@
+package redfishmock
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testUsername = "admin"
+	testPassword = "password"
+)
+
+func TestServerPowerActions(t *testing.T) {
+	server := NewServer(testUsername, testPassword)
+	defer server.Close()
+
+	bmcClient := newTestBMC(server, testPassword)
+
+	powerState, err := bmcClient.SystemPowerState()
+	assert.Nil(t, err)
+	assert.Equal(t, string(redfish.OnPowerState), powerState)
+
+	assert.Nil(t, bmcClient.SystemPowerOff())
+	assert.Equal(t, redfish.OffPowerState, server.PowerState())
+
+	assert.Nil(t, bmcClient.SystemPowerOn())
+	assert.Nil(t, bmcClient.SystemResetAction(redfish.NmiResetType))
+	assert.Nil(t, bmcClient.SystemGracefulShutdown())
+	assert.NotNil(t, bmcClient.SystemResetAction(redfish.NmiResetType))
+	assert.Nil(t, bmcClient.SystemPowerCycle())
+
+	assert.Equal(t, 1, server.NMICount())
+	assert.Equal(t, redfish.OnPowerState, server.PowerState())
+	assert.Equal(t, []redfish.ResetType{
+		redfish.ForceOffResetType,
+		redfish.OnResetType,
+		redfish.NmiResetType,
+		redfish.GracefulShutdownResetType,
+		redfish.PowerCycleResetType,
+	}, server.Resets())
+	assert.Equal(t, 0, server.ActiveSessions())
+}
+
+func TestServerPowerCycleFallback(t *testing.T) {
+	server := NewServer(testUsername, testPassword)
+	defer server.Close()
+
+	server.SetSupportedResetTypes(redfish.OnResetType, redfish.ForceOffResetType)
+
+	assert.Nil(t, newTestBMC(server, testPassword).SystemPowerCycle())
+	assert.Equal(t, []redfish.ResetType{redfish.ForceOffResetType, redfish.OnResetType}, server.Resets())
+	assert.Equal(t, redfish.OnPowerState, server.PowerState())
+}
+
+func TestServerTransitionReads(t *testing.T) {
+	server := NewServer(testUsername, testPassword)
+	defer server.Close()
+
+	server.SetPowerState(redfish.OffPowerState)
+	server.SetTransitionReads(2)
+
+	bmcClient := newTestBMC(server, testPassword)
+	assert.Nil(t, bmcClient.SystemPowerOn())
+
+	var powerStates []string
+
+	for range 3 {
+		powerState, err := bmcClient.SystemPowerState()
+		assert.Nil(t, err)
+
+		powerStates = append(powerStates, powerState)
+	}
+
+	assert.Equal(t, []string{"PoweringOn", "PoweringOn", "On"}, powerStates)
+}
+
+func TestServerBootFromCD(t *testing.T) {
+	server := NewServer(testUsername, testPassword)
+	defer server.Close()
+
+	assert.Nil(t, newTestBMC(server, testPassword).BootFromCD("http://example.com/live.iso", "Cd"))
+
+	image, inserted := server.VirtualMedia()
+	assert.Equal(t, "http://example.com/live.iso", image)
+	assert.True(t, inserted)
+
+	enabled, target := server.BootOverride()
+	assert.Equal(t, redfish.OnceBootSourceOverrideEnabled, enabled)
+	assert.Equal(t, redfish.CdBootSourceOverrideTarget, target)
+}
+
+func TestServerFailures(t *testing.T) {
+	server := NewServer(testUsername, testPassword)
+	defer server.Close()
+
+	_, err := newTestBMC(server, "wrong").SystemPowerState()
+	assert.NotNil(t, err)
+
+	server.FailNext(RouteReset, http.StatusInternalServerError, 2)
+
+	bmcClient := newTestBMC(server, testPassword)
+	assert.NotNil(t, bmcClient.SystemPowerOff())
+	assert.NotNil(t, bmcClient.SystemPowerOff())
+	assert.Equal(t, redfish.OnPowerState, server.PowerState())
+	assert.Nil(t, bmcClient.SystemPowerOff())
+	assert.Equal(t, redfish.OffPowerState, server.PowerState())
+
+	server.SetLatency(200 * time.Millisecond)
+
+	_, err = newTestBMC(server, testPassword).WithRedfishTimeout(100 * time.Millisecond).SystemPowerState()
+	assert.NotNil(t, err)
+
+	server.SetLatency(0)
+
+	response, err := server.httpServer.Client().Get(server.URL() + systemPath)
+	if assert.Nil(t, err) {
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		assert.Nil(t, response.Body.Close())
+	}
+}
+
+func newTestBMC(server *Server, password string) *bmc.BMC {
+	return bmc.New(server.Address()).WithRedfishUser(testUsername, password).WithRedfishTimeout(5 * time.Second)
+}
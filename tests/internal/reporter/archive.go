@@ -0,0 +1,334 @@
+package reporter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DumpArchiveExtension is the extension of the archive written for each failed spec.
+	DumpArchiveExtension = ".tar.gz"
+	// maxDumpNameLength is the maximum length of the sanitized spec name, not including the hash suffix, so the names
+	// of dump archives stay well below file system limits.
+	maxDumpNameLength = 100
+	// dumpNameHashLength is the number of hex characters of the spec name hash appended to the dump name so truncated
+	// names remain unique.
+	dumpNameHashLength = 8
+	// failedTestsDirPattern matches the dump directories of every suite, which GetDumpFailedTestReportLocation names
+	// failed_ followed by the name of the suite file.
+	failedTestsDirPattern = "failed_*"
+)
+
+var (
+	// invalidDumpNameCharacters matches runs of characters that are not kept in dump names.
+	invalidDumpNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+	clusterScopedHashesMutex sync.Mutex
+	// clusterScopedHashes maps the path of each cluster-scoped file from the previous failed spec to its hash and the
+	// archive containing it.
+	clusterScopedHashes = make(map[string]clusterScopedFile)
+)
+
+// ClusterScopedCollector is implemented by collectors that write some files containing only cluster-scoped resources,
+// such as nodes. If such a file is identical to the same file from the previous failed spec, it is left out of the
+// archive and the manifest refers to the archive that contains it instead.
+type ClusterScopedCollector interface {
+	Collector
+	// IsClusterScoped returns true if the file at path, relative to the output directory of the collector, only
+	// contains cluster-scoped resources.
+	IsClusterScoped(path string) bool
+}
+
+// DeduplicatedFile records a file left out of the archive of a failed spec since it is identical to the same file in
+// an earlier archive.
+type DeduplicatedFile struct {
+	// File is the path of the file relative to the dump directory of the spec.
+	File string `json:"file"`
+	// Archive is the name of the archive in the same directory that contains the file.
+	Archive string `json:"archive"`
+}
+
+// clusterScopedFile is the hash of a cluster-scoped file along with the archive that contains it.
+type clusterScopedFile struct {
+	hash    string
+	archive string
+}
+
+// getDumpName returns the name of the dump directory and archive for the spec with the provided full text. Characters
+// other than letters, digits, dots, dashes, and underscores are replaced with underscores and the name is truncated,
+// then a hash of the full text is appended so different specs never share a name.
+func getDumpName(fullText string) string {
+	hash := sha256.Sum256([]byte(fullText))
+	name := strings.Trim(invalidDumpNameCharacters.ReplaceAllString(fullText, "_"), "_.")
+
+	if len(name) > maxDumpNameLength {
+		name = strings.TrimRight(name[:maxDumpNameLength], "_.")
+	}
+
+	if name == "" {
+		name = "spec"
+	}
+
+	return fmt.Sprintf("%s_%s", name, hex.EncodeToString(hash[:])[:dumpNameHashLength])
+}
+
+// deduplicateClusterScopedFiles removes the cluster-scoped files in specDir that are identical to the files from the
+// previous failed spec, as long as the archive containing them still exists. The removed files are returned and the
+// Files of each result in manifest are updated. The hashes are then replaced with those of the files in specDir, so
+// only consecutive failures are compared.
+func deduplicateClusterScopedFiles(
+	manifest *Manifest, specDir string, toRun []registeredCollector) ([]DeduplicatedFile, error) {
+	clusterScopedHashesMutex.Lock()
+	defer clusterScopedHashesMutex.Unlock()
+
+	archiveDir := filepath.Dir(specDir)
+	currentArchive := filepath.Base(specDir) + DumpArchiveExtension
+	currentHashes := make(map[string]clusterScopedFile)
+
+	var deduplicated []DeduplicatedFile
+
+	for index, registered := range toRun {
+		scopedCollector, ok := registered.collector.(ClusterScopedCollector)
+		if !ok || index >= len(manifest.Collectors) {
+			continue
+		}
+
+		result := &manifest.Collectors[index]
+		name := scopedCollector.Name()
+
+		var keptFiles []string
+
+		for _, file := range result.Files {
+			relativePath, err := filepath.Rel(name, file)
+			if err != nil || !scopedCollector.IsClusterScoped(relativePath) {
+				keptFiles = append(keptFiles, file)
+
+				continue
+			}
+
+			hash, err := hashFile(filepath.Join(specDir, file))
+			if err != nil {
+				return nil, err
+			}
+
+			previous, found := clusterScopedHashes[file]
+			if !found || previous.hash != hash || !fileExists(filepath.Join(archiveDir, previous.archive)) {
+				currentHashes[file] = clusterScopedFile{hash: hash, archive: currentArchive}
+				keptFiles = append(keptFiles, file)
+
+				continue
+			}
+
+			err = os.Remove(filepath.Join(specDir, file))
+			if err != nil {
+				return nil, err
+			}
+
+			currentHashes[file] = previous
+			deduplicated = append(deduplicated, DeduplicatedFile{File: file, Archive: previous.archive})
+		}
+
+		result.Files = append([]string{}, keptFiles...)
+	}
+
+	clusterScopedHashes = currentHashes
+
+	return deduplicated, nil
+}
+
+// archiveDumpDir writes every regular file in specDir to a gzip compressed tarball next to it, under a directory with
+// the same name as specDir, then removes specDir. The path of the archive is returned.
+func archiveDumpDir(specDir string) (string, error) {
+	archivePath := specDir + DumpArchiveExtension
+
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create archive %s: %w", archivePath, err)
+	}
+
+	defer func() {
+		_ = archiveFile.Close()
+	}()
+
+	gzipWriter := gzip.NewWriter(archiveFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err = filepath.WalkDir(specDir, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !dirEntry.Type().IsRegular() {
+			return nil
+		}
+
+		return addFileToArchive(tarWriter, filepath.Dir(specDir), path)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to archive %s: %w", specDir, err)
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return "", err
+	}
+
+	err = archiveFile.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return archivePath, os.RemoveAll(specDir)
+}
+
+// addFileToArchive writes the file at path to tarWriter, naming it relative to baseDir.
+func addFileToArchive(tarWriter *tar.Writer, baseDir, path string) error {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(fileInfo, "")
+	if err != nil {
+		return err
+	}
+
+	header.Name, err = filepath.Rel(baseDir, path)
+	if err != nil {
+		return err
+	}
+
+	err = tarWriter.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = io.Copy(tarWriter, file)
+
+	return err
+}
+
+// getDumpArchivePattern returns the glob pattern matching the archives of failed specs in the dump directories of all
+// suites in reportsDir. Other archives in reportsDir are not matched, so they are never removed by the size limit.
+func getDumpArchivePattern(reportsDir string) string {
+	return filepath.Join(reportsDir, failedTestsDirPattern, "*"+DumpArchiveExtension)
+}
+
+// enforceDumpSizeLimit removes the oldest archives matching pattern until their total size is at most maxBytes. The
+// archive at keepPath is never removed, even if it alone exceeds maxBytes. A maxBytes of zero disables the limit.
+// Removing an archive leaves references to it in the manifests of later archives dangling, which is preferred to
+// keeping large archives around.
+func enforceDumpSizeLimit(pattern string, maxBytes int64, keepPath string) error {
+	if maxBytes <= 0 {
+		return nil
+	}
+
+	archivePaths, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+
+	type archiveInfo struct {
+		path string
+		info fs.FileInfo
+	}
+
+	var (
+		archives  []archiveInfo
+		totalSize int64
+	)
+
+	for _, archivePath := range archivePaths {
+		fileInfo, err := os.Stat(archivePath)
+		if err != nil {
+			return err
+		}
+
+		archives = append(archives, archiveInfo{path: archivePath, info: fileInfo})
+		totalSize += fileInfo.Size()
+	}
+
+	slices.SortStableFunc(archives, func(first, second archiveInfo) int {
+		return first.info.ModTime().Compare(second.info.ModTime())
+	})
+
+	for _, archive := range archives {
+		if totalSize <= maxBytes {
+			break
+		}
+
+		if archive.path == keepPath {
+			continue
+		}
+
+		klog.V(100).Infof("Removing failed test archive %s to keep dumps below %d bytes", archive.path, maxBytes)
+
+		err = os.Remove(archive.path)
+		if err != nil {
+			return err
+		}
+
+		totalSize -= archive.info.Size()
+	}
+
+	if totalSize > maxBytes {
+		klog.Warningf("Failed test archive %s alone exceeds the maximum dump size of %d bytes", keepPath, maxBytes)
+	}
+
+	return nil
+}
+
+// hashFile returns the hex encoded SHA-256 hash of the file at path.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fileExists returns true if there is a file at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
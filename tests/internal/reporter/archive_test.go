@@ -0,0 +1,271 @@
+package reporter
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// clusterScopedTestCollector is a collector that writes a fixed set of files, some of which are cluster-scoped.
+type clusterScopedTestCollector struct {
+	files         map[string]string
+	clusterScoped map[string]bool
+}
+
+// Name returns the name of the clusterScopedTestCollector.
+func (collector *clusterScopedTestCollector) Name() string {
+	return "scoped"
+}
+
+// Collect writes the files of the clusterScopedTestCollector to outputDir.
+func (collector *clusterScopedTestCollector) Collect(_ context.Context, _ types.SpecReport, outputDir string) error {
+	for name, content := range collector.files {
+		err := os.WriteFile(filepath.Join(outputDir, name), []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsClusterScoped returns true if path is in the clusterScoped files of the clusterScopedTestCollector.
+func (collector *clusterScopedTestCollector) IsClusterScoped(path string) bool {
+	return collector.clusterScoped[path]
+}
+
+func TestGetDumpName(t *testing.T) {
+	testCases := []struct {
+		fullText       string
+		expectedPrefix string
+	}{
+		{
+			fullText:       "PTP Events and Metrics verifies metrics",
+			expectedPrefix: "PTP_Events_and_Metrics_verifies_metrics_",
+		},
+		{
+			fullText:       "TALM precache/with ../relative path: works!",
+			expectedPrefix: "TALM_precache_with_.._relative_path_works_",
+		},
+		{
+			fullText:       "///",
+			expectedPrefix: "spec_",
+		},
+		{
+			fullText:       strings.Repeat("long spec name ", 20),
+			expectedPrefix: strings.TrimRight(strings.Repeat("long_spec_name_", 20)[:maxDumpNameLength], "_") + "_",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.fullText, func(t *testing.T) {
+			dumpName := getDumpName(testCase.fullText)
+
+			assert.True(t, strings.HasPrefix(dumpName, testCase.expectedPrefix), dumpName)
+			assert.Len(t, dumpName, len(testCase.expectedPrefix)+dumpNameHashLength)
+			assert.NotContains(t, dumpName, "/")
+		})
+	}
+
+	assert.NotEqual(t, getDumpName("spec a"), getDumpName("spec_a"))
+}
+
+func TestArchiveDumpDir(t *testing.T) {
+	specDir := filepath.Join(t.TempDir(), "spec_12345678")
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(specDir, "collector"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(specDir, ManifestFileName), []byte("{}"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(specDir, "collector", "artifact.log"), []byte("artifact"), 0644))
+
+	archivePath, err := archiveDumpDir(specDir)
+	assert.Nil(t, err)
+	assert.Equal(t, specDir+DumpArchiveExtension, archivePath)
+	assert.NoDirExists(t, specDir)
+
+	contents := readTestArchive(t, archivePath)
+	assert.Equal(t, map[string]string{
+		"spec_12345678/collector/artifact.log": "artifact",
+		"spec_12345678/" + ManifestFileName:    "{}",
+	}, contents)
+}
+
+func TestEnforceDumpSizeLimit(t *testing.T) {
+	testCases := []struct {
+		name              string
+		maxBytes          int64
+		keep              string
+		expectedRemaining []string
+	}{
+		{
+			name:              "no limit",
+			maxBytes:          0,
+			keep:              "newest",
+			expectedRemaining: []string{"middle", "newest", "oldest"},
+		},
+		{
+			name:              "below limit",
+			maxBytes:          30,
+			keep:              "newest",
+			expectedRemaining: []string{"middle", "newest", "oldest"},
+		},
+		{
+			name:              "evicts oldest",
+			maxBytes:          25,
+			keep:              "newest",
+			expectedRemaining: []string{"middle", "newest"},
+		},
+		{
+			name:              "keeps newest when it exceeds limit",
+			maxBytes:          5,
+			keep:              "newest",
+			expectedRemaining: []string{"newest"},
+		},
+		{
+			name:              "keeps archive even if oldest",
+			maxBytes:          15,
+			keep:              "oldest",
+			expectedRemaining: []string{"oldest"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reportsDir := t.TempDir()
+			suiteDirs := map[string]string{"oldest": "failed_first", "middle": "failed_second", "newest": "failed_first"}
+			modTime := time.Now().Add(-time.Hour)
+
+			for _, name := range []string{"oldest", "middle", "newest"} {
+				archivePath := filepath.Join(reportsDir, suiteDirs[name], name+DumpArchiveExtension)
+
+				assert.Nil(t, os.MkdirAll(filepath.Dir(archivePath), 0755))
+				assert.Nil(t, os.WriteFile(archivePath, []byte(strings.Repeat("x", 10)), 0644))
+				assert.Nil(t, os.Chtimes(archivePath, modTime, modTime))
+
+				modTime = modTime.Add(time.Minute)
+			}
+
+			// Archives outside the dump directories of the suites are never counted or removed.
+			otherArchivePath := filepath.Join(reportsDir, "other", "other"+DumpArchiveExtension)
+
+			assert.Nil(t, os.MkdirAll(filepath.Dir(otherArchivePath), 0755))
+			assert.Nil(t, os.WriteFile(otherArchivePath, []byte(strings.Repeat("x", 100)), 0644))
+			assert.Nil(t, os.Chtimes(otherArchivePath, modTime.Add(-time.Hour), modTime.Add(-time.Hour)))
+
+			keepPath := filepath.Join(reportsDir, suiteDirs[testCase.keep], testCase.keep+DumpArchiveExtension)
+			pattern := getDumpArchivePattern(reportsDir)
+
+			assert.Nil(t, enforceDumpSizeLimit(pattern, testCase.maxBytes, keepPath))
+			assert.FileExists(t, otherArchivePath)
+
+			remainingPaths, err := filepath.Glob(pattern)
+			assert.Nil(t, err)
+
+			var remaining []string
+
+			for _, remainingPath := range remainingPaths {
+				remaining = append(remaining, strings.TrimSuffix(filepath.Base(remainingPath), DumpArchiveExtension))
+			}
+
+			assert.ElementsMatch(t, testCase.expectedRemaining, remaining)
+		})
+	}
+}
+
+func TestDeduplicateClusterScopedFiles(t *testing.T) {
+	t.Cleanup(func() {
+		clusterScopedHashes = make(map[string]clusterScopedFile)
+	})
+
+	clusterScopedHashes = make(map[string]clusterScopedFile)
+
+	dumpDir := t.TempDir()
+	collector := &clusterScopedTestCollector{
+		files:         map[string]string{"nodes.log": "nodes", "pods.log": "pods", "crs.log": "crs"},
+		clusterScoped: map[string]bool{"nodes.log": true, "crs.log": true},
+	}
+	toRun := []registeredCollector{{collector: collector, timeout: time.Second}}
+
+	// The first failure has nothing to compare against so everything is kept.
+	firstDir := filepath.Join(dumpDir, "first")
+	manifest := runAndArchiveTestCollectors(t, firstDir, toRun)
+	assert.Empty(t, manifest.Deduplicated)
+	assert.Equal(t, []string{"scoped/crs.log", "scoped/nodes.log", "scoped/pods.log"}, manifest.Collectors[0].Files)
+
+	// Only the unchanged cluster-scoped file is left out of the second failure.
+	collector.files["crs.log"] = "changed crs"
+	secondDir := filepath.Join(dumpDir, "second")
+	manifest = runAndArchiveTestCollectors(t, secondDir, toRun)
+	assert.Equal(t, []DeduplicatedFile{{File: "scoped/nodes.log", Archive: "first" + DumpArchiveExtension}},
+		manifest.Deduplicated)
+	assert.Equal(t, []string{"scoped/crs.log", "scoped/pods.log"}, manifest.Collectors[0].Files)
+	assert.NotContains(t, readTestArchive(t, secondDir+DumpArchiveExtension), "second/scoped/nodes.log")
+
+	// Once the archive with the original file is gone, the file is kept again.
+	assert.Nil(t, os.Remove(firstDir+DumpArchiveExtension))
+
+	manifest = runAndArchiveTestCollectors(t, filepath.Join(dumpDir, "third"), toRun)
+	assert.Equal(t, []DeduplicatedFile{{File: "scoped/crs.log", Archive: "second" + DumpArchiveExtension}},
+		manifest.Deduplicated)
+	assert.Equal(t, []string{"scoped/nodes.log", "scoped/pods.log"}, manifest.Collectors[0].Files)
+}
+
+// runAndArchiveTestCollectors runs the collectors for a failed spec in specDir and archives the output, returning the
+// manifest.
+func runAndArchiveTestCollectors(t *testing.T, specDir string, toRun []registeredCollector) *Manifest {
+	t.Helper()
+
+	manifest, err := runCollectors(types.SpecReport{State: types.SpecStateFailed}, specDir, toRun)
+	assert.Nil(t, err)
+
+	_, err = archiveDumpDir(specDir)
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, manifest) {
+		assert.Len(t, manifest.Collectors, 1)
+	}
+
+	return manifest
+}
+
+// readTestArchive returns the contents of the files in the archive at archivePath keyed by their names.
+func readTestArchive(t *testing.T, archivePath string) map[string]string {
+	t.Helper()
+
+	archiveFile, err := os.Open(archivePath)
+	assert.Nil(t, err)
+
+	defer func() {
+		_ = archiveFile.Close()
+	}()
+
+	gzipReader, err := gzip.NewReader(archiveFile)
+	assert.Nil(t, err)
+
+	tarReader := tar.NewReader(gzipReader)
+	contents := make(map[string]string)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		assert.Nil(t, err)
+
+		content, err := io.ReadAll(tarReader)
+		assert.Nil(t, err)
+
+		contents[header.Name] = string(content)
+	}
+
+	return contents
+}
@@ -0,0 +1,249 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultCollectorTimeout is used for collectors registered without a timeout.
+	DefaultCollectorTimeout = 5 * time.Minute
+	// ManifestFileName is the name of the manifest written to the dump directory of each failed spec.
+	ManifestFileName = "index.json"
+)
+
+// Collector gathers a single kind of artifact when a spec fails. Collectors are run one after another for every failed
+// spec, each with its own timeout, and write their artifacts to a separate directory.
+type Collector interface {
+	// Name identifies the collector. It is used as the name of the directory the collector writes to, so it should
+	// be short and only contain characters that are valid in file names.
+	Name() string
+	// Collect writes the artifacts for the failed spec in report to outputDir, which already exists. The context is
+	// canceled once the timeout of the collector expires, after which Collect should return promptly since the
+	// remaining collectors wait for it and nothing may be written to outputDir after it returns.
+	Collect(ctx context.Context, report types.SpecReport, outputDir string) error
+}
+
+// CollectorFunc allows using an ordinary function as a Collector. The name is provided separately since functions do
+// not have one.
+type CollectorFunc struct {
+	CollectorName string
+	CollectFunc   func(ctx context.Context, report types.SpecReport, outputDir string) error
+}
+
+// Name returns the CollectorName of the CollectorFunc.
+func (collectorFunc CollectorFunc) Name() string {
+	return collectorFunc.CollectorName
+}
+
+// Collect calls the CollectFunc of the CollectorFunc.
+func (collectorFunc CollectorFunc) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	return collectorFunc.CollectFunc(ctx, report, outputDir)
+}
+
+// CollectorResult records the outcome of running a single collector for a failed spec.
+type CollectorResult struct {
+	Name string `json:"name"`
+	// Files are the paths of the files the collector wrote, relative to the dump directory of the spec.
+	Files    []string `json:"files"`
+	Duration string   `json:"duration"`
+	TimedOut bool     `json:"timedOut,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// Manifest is the index of everything captured for a single failed spec. It is written as JSON to the dump directory
+// of the spec.
+type Manifest struct {
+	Spec           string            `json:"spec"`
+	State          string            `json:"state"`
+	FailureMessage string            `json:"failureMessage,omitempty"`
+	StartTime      time.Time         `json:"startTime"`
+	CollectedAt    time.Time         `json:"collectedAt"`
+	Collectors     []CollectorResult `json:"collectors"`
+	// Deduplicated are the cluster-scoped files left out since they are identical to those in an earlier archive.
+	Deduplicated []DeduplicatedFile `json:"deduplicated,omitempty"`
+}
+
+// registeredCollector is a collector along with the timeout it was registered with.
+type registeredCollector struct {
+	collector Collector
+	timeout   time.Duration
+}
+
+var (
+	collectorsMutex sync.Mutex
+	collectors      []registeredCollector
+)
+
+// RegisterCollector adds collector to the collectors run for every failed spec, after the built-in collectors. If
+// timeout is not positive, DefaultCollectorTimeout is used. Registering a collector with the same name as an existing
+// one replaces it.
+func RegisterCollector(collector Collector, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultCollectorTimeout
+	}
+
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	collectors = slices.DeleteFunc(collectors, func(registered registeredCollector) bool {
+		return registered.collector.Name() == collector.Name()
+	})
+	collectors = append(collectors, registeredCollector{collector: collector, timeout: timeout})
+}
+
+// UnregisterCollector removes the collector with the provided name. It is a no-op if there is no such collector.
+func UnregisterCollector(name string) {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	collectors = slices.DeleteFunc(collectors, func(registered registeredCollector) bool {
+		return registered.collector.Name() == name
+	})
+}
+
+// getRegisteredCollectors returns a copy of the registered collectors so they can be run without holding the lock.
+func getRegisteredCollectors() []registeredCollector {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+
+	return slices.Clone(collectors)
+}
+
+// runCollectors runs every collector for the failed spec in report, giving each its own subdirectory of specDir, then
+// removes duplicate cluster-scoped files and writes the Manifest to specDir. Collector errors and timeouts are recorded
+// in the manifest and logged rather than stopping the remaining collectors.
+func runCollectors(report types.SpecReport, specDir string, toRun []registeredCollector) (*Manifest, error) {
+	manifest := &Manifest{
+		Spec:           report.FullText(),
+		State:          report.State.String(),
+		FailureMessage: report.FailureMessage(),
+		StartTime:      report.StartTime,
+	}
+
+	err := os.MkdirAll(specDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, registered := range toRun {
+		result := runCollector(report, specDir, registered)
+		if result.Error != "" {
+			klog.Errorf("Collector %s failed for spec %q: %s", result.Name, manifest.Spec, result.Error)
+		}
+
+		manifest.Collectors = append(manifest.Collectors, result)
+	}
+
+	manifest.CollectedAt = time.Now()
+
+	manifest.Deduplicated, err = deduplicateClusterScopedFiles(manifest, specDir, toRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deduplicate cluster-scoped files: %w", err)
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(filepath.Join(specDir, ManifestFileName), content, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// runCollector runs a single collector with its timeout. The collector is always waited for, even after its context
+// is canceled, so nothing writes to specDir once it returns. A collector still running when its timeout expires is
+// recorded as timed out.
+func runCollector(report types.SpecReport, specDir string, registered registeredCollector) CollectorResult {
+	name := registered.collector.Name()
+	result := CollectorResult{Name: name}
+	outputDir := filepath.Join(specDir, name)
+	startTime := time.Now()
+
+	klog.V(100).Infof("Running collector %s with timeout %s", name, registered.timeout)
+
+	err := os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), registered.timeout)
+	defer cancel()
+
+	err = callCollector(ctx, registered.collector, report, outputDir)
+
+	result.Duration = time.Since(startTime).Round(time.Millisecond).String()
+	result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+
+	if err == nil && result.TimedOut {
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	result.Files, err = listFiles(specDir, outputDir)
+	if err != nil && result.Error == "" {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// callCollector calls Collect on collector, returning panics as errors so they do not stop the remaining collectors.
+func callCollector(
+	ctx context.Context, collector Collector, report types.SpecReport, outputDir string) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("collector panicked: %v", recovered)
+		}
+	}()
+
+	return collector.Collect(ctx, report, outputDir)
+}
+
+// listFiles returns the paths of all regular files under directory relative to baseDir, sorted lexicographically.
+func listFiles(baseDir, directory string) ([]string, error) {
+	files := []string{}
+
+	err := filepath.WalkDir(directory, func(path string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !dirEntry.Type().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(baseDir, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, relativePath)
+
+		return nil
+	})
+
+	slices.Sort(files)
+
+	return files, err
+}
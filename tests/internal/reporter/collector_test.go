@@ -0,0 +1,168 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterCollector(t *testing.T) {
+	t.Cleanup(func() {
+		collectors = nil
+	})
+
+	collectors = nil
+
+	RegisterCollector(newTestCollector("first", nil), 0)
+	RegisterCollector(newTestCollector("second", nil), time.Minute)
+	RegisterCollector(newTestCollector("first", nil), 2*time.Minute)
+
+	registered := getRegisteredCollectors()
+	if assert.Len(t, registered, 2) {
+		assert.Equal(t, "second", registered[0].collector.Name())
+		assert.Equal(t, time.Minute, registered[0].timeout)
+		assert.Equal(t, "first", registered[1].collector.Name())
+		assert.Equal(t, 2*time.Minute, registered[1].timeout)
+	}
+
+	UnregisterCollector("second")
+	UnregisterCollector("missing")
+
+	registered = getRegisteredCollectors()
+	if assert.Len(t, registered, 1) {
+		assert.Equal(t, "first", registered[0].collector.Name())
+	}
+}
+
+func TestRunCollectors(t *testing.T) {
+	testCases := []struct {
+		name             string
+		collect          func(ctx context.Context, report types.SpecReport, outputDir string) error
+		expectedFiles    []string
+		expectedError    string
+		expectedTimedOut bool
+	}{
+		{
+			name: "success",
+			collect: func(_ context.Context, _ types.SpecReport, outputDir string) error {
+				return os.WriteFile(filepath.Join(outputDir, "artifact.log"), []byte("artifact"), 0644)
+			},
+			expectedFiles: []string{"success/artifact.log"},
+		},
+		{
+			name: "error",
+			collect: func(_ context.Context, _ types.SpecReport, outputDir string) error {
+				err := os.WriteFile(filepath.Join(outputDir, "partial.log"), []byte("partial"), 0644)
+				if err != nil {
+					return err
+				}
+
+				return errors.New("collection failed")
+			},
+			expectedFiles: []string{"error/partial.log"},
+			expectedError: "collection failed",
+		},
+		{
+			name: "timeout",
+			collect: func(ctx context.Context, _ types.SpecReport, _ string) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			expectedFiles:    []string{},
+			expectedError:    context.DeadlineExceeded.Error(),
+			expectedTimedOut: true,
+		},
+		{
+			name: "ignores context",
+			collect: func(_ context.Context, _ types.SpecReport, outputDir string) error {
+				time.Sleep(500 * time.Millisecond)
+
+				// The collector is waited for, so files it writes after the timeout are still listed.
+				return os.WriteFile(filepath.Join(outputDir, "late.log"), []byte("late"), 0644)
+			},
+			expectedFiles:    []string{"ignores context/late.log"},
+			expectedError:    context.DeadlineExceeded.Error(),
+			expectedTimedOut: true,
+		},
+		{
+			name: "panic",
+			collect: func(_ context.Context, _ types.SpecReport, _ string) error {
+				panic("collector bug")
+			},
+			expectedFiles: []string{},
+			expectedError: "collector panicked: collector bug",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			specDir := filepath.Join(t.TempDir(), "spec")
+			toRun := []registeredCollector{{
+				collector: newTestCollector(testCase.name, testCase.collect),
+				timeout:   100 * time.Millisecond,
+			}}
+
+			manifest, err := runCollectors(types.SpecReport{State: types.SpecStateFailed}, specDir, toRun)
+			assert.Nil(t, err)
+
+			if !assert.NotNil(t, manifest) || !assert.Len(t, manifest.Collectors, 1) {
+				return
+			}
+
+			result := manifest.Collectors[0]
+			assert.Equal(t, testCase.name, result.Name)
+			assert.Equal(t, testCase.expectedFiles, result.Files)
+			assert.Equal(t, testCase.expectedError, result.Error)
+			assert.Equal(t, testCase.expectedTimedOut, result.TimedOut)
+
+			content, err := os.ReadFile(filepath.Join(specDir, ManifestFileName))
+			assert.Nil(t, err)
+
+			var writtenManifest Manifest
+
+			assert.Nil(t, json.Unmarshal(content, &writtenManifest))
+			assert.Equal(t, "failed", writtenManifest.State)
+			assert.Equal(t, manifest.Collectors, writtenManifest.Collectors)
+		})
+	}
+}
+
+func TestRunCollectorsContinuesAfterFailure(t *testing.T) {
+	toRun := []registeredCollector{
+		{
+			collector: newTestCollector("failing", func(context.Context, types.SpecReport, string) error {
+				return errors.New("collection failed")
+			}),
+			timeout: time.Second,
+		},
+		{collector: newTestCollector("succeeding", nil), timeout: time.Second},
+	}
+
+	manifest, err := runCollectors(types.SpecReport{State: types.SpecStateFailed}, t.TempDir(), toRun)
+	assert.Nil(t, err)
+
+	if assert.NotNil(t, manifest) && assert.Len(t, manifest.Collectors, 2) {
+		assert.Equal(t, "collection failed", manifest.Collectors[0].Error)
+		assert.Empty(t, manifest.Collectors[1].Error)
+	}
+}
+
+// newTestCollector returns a CollectorFunc with the provided name. If collect is nil, the collector does nothing.
+func newTestCollector(
+	name string, collect func(ctx context.Context, report types.SpecReport, outputDir string) error) Collector {
+	if collect == nil {
+		collect = func(context.Context, types.SpecReport, string) error {
+			return nil
+		}
+	}
+
+	return CollectorFunc{CollectorName: name, CollectFunc: collect}
+}
@@ -0,0 +1,303 @@
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// collectorStartMargin is subtracted from the start time of the spec when collecting artifacts since a certain
+	// time, so artifacts from the setup right before the spec are included.
+	collectorStartMargin = 30 * time.Second
+)
+
+// NodeJournalCollector saves the journal of every selected node since the failed spec started. Commands are run
+// through the machine config daemon pod on each node.
+type NodeJournalCollector struct {
+	apiClient   *clients.Settings
+	units       []string
+	listOptions metav1.ListOptions
+}
+
+// NewNodeJournalCollector creates a NodeJournalCollector for the nodes selected by options, or all nodes if no options
+// are provided. If units is not empty, only the journal entries for those systemd units are saved.
+func NewNodeJournalCollector(
+	apiClient *clients.Settings, units []string, options ...metav1.ListOptions) *NodeJournalCollector {
+	collector := &NodeJournalCollector{apiClient: apiClient, units: units}
+
+	if len(options) > 0 {
+		collector.listOptions = options[0]
+	}
+
+	return collector
+}
+
+// Name returns the name of the NodeJournalCollector.
+func (collector *NodeJournalCollector) Name() string {
+	return "node-journal"
+}
+
+// Collect writes the journal of each node to a separate file named after the node. The journal of each node is read
+// with the time left before ctx expires, and no more nodes are read once it does.
+func (collector *NodeJournalCollector) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	if generalCfg == nil {
+		return fmt.Errorf("no reporter configuration available to find the machine config daemon pods")
+	}
+
+	command := fmt.Sprintf("journalctl --no-pager --since @%d", report.StartTime.Add(-collectorStartMargin).Unix())
+
+	for _, unit := range collector.units {
+		command += " -u " + unit
+	}
+
+	nodeList, err := collector.apiClient.CoreV1Interface.Nodes().List(ctx, collector.listOptions)
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodeList.Items {
+		output, err := collector.execOnNode(ctx, node.Name, command)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filepath.Join(outputDir, node.Name+".log"), []byte(output), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// execOnNode runs command on the host of nodeName through its machine config daemon pod, stopping once ctx expires.
+func (collector *NodeJournalCollector) execOnNode(ctx context.Context, nodeName, command string) (string, error) {
+	mcdPods, err := pod.List(collector.apiClient, generalCfg.MCONamespace, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		LabelSelector: labels.SelectorFromSet(labels.Set{"k8s-app": generalCfg.MCOConfigDaemonName}).String(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(mcdPods) == 0 {
+		return "", fmt.Errorf("no machine config daemon pod found on node %s", nodeName)
+	}
+
+	timeout := DefaultCollectorTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	output, err := mcdPods[0].ExecCommandWithTimeout(
+		[]string{"sh", "-c", fmt.Sprintf("nsenter --mount=/proc/1/ns/mnt -- sh -c '%s'", command)}, timeout)
+	if err != nil {
+		return "", fmt.Errorf("failed to read journal of node %s: %w", nodeName, err)
+	}
+
+	return strings.ReplaceAll(output.String(), "\r", ""), nil
+}
+
+// EventsCollector saves the events in a set of namespaces that occurred since the failed spec started.
+type EventsCollector struct {
+	apiClient  *clients.Settings
+	namespaces []string
+}
+
+// NewEventsCollector creates an EventsCollector for the provided namespaces. Events from all namespaces are saved if
+// no namespaces are provided.
+func NewEventsCollector(apiClient *clients.Settings, namespaces ...string) *EventsCollector {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	return &EventsCollector{apiClient: apiClient, namespaces: namespaces}
+}
+
+// Name returns the name of the EventsCollector.
+func (collector *EventsCollector) Name() string {
+	return "events"
+}
+
+// Collect writes the events of each namespace to a separate file, one event per line sorted by time.
+func (collector *EventsCollector) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	since := report.StartTime.Add(-collectorStartMargin)
+
+	for _, namespace := range collector.namespaces {
+		eventList, err := collector.apiClient.CoreV1Interface.Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		var lines []string
+
+		for _, event := range eventList.Items {
+			eventTime := getEventTime(event)
+			if eventTime.Before(since) {
+				continue
+			}
+
+			lines = append(lines, fmt.Sprintf("%s %s %s %s %s/%s: %s",
+				eventTime.UTC().Format(time.RFC3339), event.Namespace, event.Type, event.Reason,
+				event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message))
+		}
+
+		slices.Sort(lines)
+
+		fileName := namespace + ".log"
+		if namespace == metav1.NamespaceAll {
+			fileName = "all-namespaces.log"
+		}
+
+		err = os.WriteFile(filepath.Join(outputDir, fileName), []byte(strings.Join(lines, "\n")), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PodLogsCollector saves the logs of every container in the selected pods since the failed spec started. It is meant
+// for operator logs, which are often needed to debug failures but are not in the test namespaces.
+type PodLogsCollector struct {
+	apiClient     *clients.Settings
+	name          string
+	namespace     string
+	labelSelector string
+}
+
+// NewPodLogsCollector creates a PodLogsCollector for the pods in namespace matching labelSelector. The name is used to
+// distinguish multiple PodLogsCollectors, such as one per operator.
+func NewPodLogsCollector(apiClient *clients.Settings, name, namespace, labelSelector string) *PodLogsCollector {
+	return &PodLogsCollector{apiClient: apiClient, name: name, namespace: namespace, labelSelector: labelSelector}
+}
+
+// Name returns the name the PodLogsCollector was created with.
+func (collector *PodLogsCollector) Name() string {
+	return collector.name
+}
+
+// Collect writes the logs of each container to a separate file named after the pod and container. Containers whose
+// logs cannot be retrieved are skipped so the logs of the others are still saved.
+func (collector *PodLogsCollector) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	podList, err := collector.apiClient.CoreV1Interface.Pods(collector.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: collector.labelSelector,
+	})
+	if err != nil {
+		return err
+	}
+
+	sinceTime := metav1.NewTime(report.StartTime.Add(-collectorStartMargin))
+
+	for _, logsPod := range podList.Items {
+		for _, container := range logsPod.Spec.Containers {
+			logs, err := collector.apiClient.CoreV1Interface.Pods(collector.namespace).GetLogs(logsPod.Name,
+				&corev1.PodLogOptions{Container: container.Name, SinceTime: &sinceTime}).DoRaw(ctx)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if err != nil {
+				klog.V(100).Infof("Failed to get logs for container %s of pod %s in namespace %s: %v",
+					container.Name, logsPod.Name, collector.namespace, err)
+
+				continue
+			}
+
+			fileName := fmt.Sprintf("%s_%s.log", logsPod.Name, container.Name)
+
+			err = os.WriteFile(filepath.Join(outputDir, fileName), logs, 0644)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// PrometheusCollector saves the results of range queries covering the failed spec.
+type PrometheusCollector struct {
+	api     prometheusv1.API
+	queries map[string]string
+	step    time.Duration
+}
+
+// NewPrometheusCollector creates a PrometheusCollector that runs each query in queries with the provided step. The
+// keys of queries are used as the file names for the results.
+func NewPrometheusCollector(
+	api prometheusv1.API, queries map[string]string, step time.Duration) *PrometheusCollector {
+	return &PrometheusCollector{api: api, queries: queries, step: step}
+}
+
+// Name returns the name of the PrometheusCollector.
+func (collector *PrometheusCollector) Name() string {
+	return "prometheus"
+}
+
+// Collect writes the result of each query as JSON to a separate file. Warnings from Prometheus are logged but do not
+// cause the collector to fail.
+func (collector *PrometheusCollector) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	queryRange := prometheusv1.Range{
+		Start: report.StartTime.Add(-collectorStartMargin),
+		End:   time.Now(),
+		Step:  collector.step,
+	}
+
+	for name, query := range collector.queries {
+		result, warnings, err := collector.api.QueryRange(ctx, query, queryRange)
+		if err != nil {
+			return fmt.Errorf("failed to run query %s: %w", name, err)
+		}
+
+		if len(warnings) > 0 {
+			klog.V(100).Infof("Prometheus returned warnings for query %s: %v", name, warnings)
+		}
+
+		content, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(filepath.Join(outputDir, name+".json"), content, 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getEventTime returns the most recent time recorded on the event, falling back to older fields since not all event
+// sources set all of them.
+func getEventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -1,21 +1,31 @@
 package reporter
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
 	"path"
-	"strings"
+	"path/filepath"
 
 	"github.com/onsi/ginkgo/v2/types"
 	"github.com/openshift-kni/k8sreporter"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// k8sReporterStagingDir is the name of the directory the k8sreporter dumps to before its files are moved into the
+	// output directory of the collector.
+	k8sReporterStagingDir = ".staging"
+	// k8sReporterDumpSubpath is the directory in the staging directory that the k8sreporter dumps to.
+	k8sReporterDumpSubpath = "dump"
+)
+
 var (
 	pathToPodExecLogs = "/tmp/pod_exec_logs.log"
 	// generalCfg holds the configuration for reporter operations.
@@ -74,7 +84,11 @@ func ReportIfFailed(
 }
 
 // ReportIfFailedOnCluster dumps the requested cluster CRs on the cluster specified by kubeconfig if TC is failed to the
-// given directory.
+// given directory. The CRs and pod exec logs are collected first, followed by the collectors added using
+// RegisterCollector. Each collector writes to its own subdirectory of the dump directory for the TC and an index of
+// everything collected is written next to them. Errors from collectors are logged and recorded in the index rather
+// than failing the suite. The dump directory for the TC is then compressed into a single archive and the oldest
+// archives are removed if the total size exceeds the configured maximum.
 func ReportIfFailedOnCluster(
 	kubeconfig string,
 	report types.SpecReport,
@@ -95,34 +109,208 @@ func ReportIfFailedOnCluster(
 	dumpDir := generalCfg.GetDumpFailedTestReportLocation(testSuite)
 
 	if dumpDir != "" {
-		reporter, err := newReporter(dumpDir, kubeconfig, nSpaces, setReporterSchemes, cRDs)
-		if err != nil {
-			klog.Fatalf("Failed to create log reporter due to %s", err)
+		builtinCollectors := []registeredCollector{
+			{collector: newK8sReporterCollector(kubeconfig, nSpaces, cRDs), timeout: DefaultCollectorTimeout},
+			{collector: podExecLogsCollector{}, timeout: DefaultCollectorTimeout},
 		}
 
-		tcReportFolderName := strings.ReplaceAll(report.FullText(), " ", "_")
+		archiveFailureArtifacts(report, dumpDir, append(builtinCollectors, getRegisteredCollectors()...))
+	}
 
-		// Workaround for the fact we are unable to pass a context to specify a logger for the client used by
-		// the reporter. Otherwise, we get megabytes of verbose logging.
-		_ = flag.Set("v", "0")
+	err := removeFile(pathToPodExecLogs)
+	if err != nil {
+		klog.Errorf("Failed to remove pod exec logs: %v", err)
+	}
+}
 
-		reporter.Dump(report.RunTime, tcReportFolderName)
+// DumpSuiteConfigs writes the registered suite configs next to the junit report of the given test suite. It is meant
+// to be called from a ReportBeforeSuite node so the configs are written once at the start of the suite. Nothing is
+// written during dry runs and errors are logged rather than failing the suite.
+func DumpSuiteConfigs(report types.Report, testSuite string) {
+	if report.SuiteConfig.DryRun {
+		return
+	}
 
-		_ = flag.Set("v", generalCfg.VerboseLevel)
+	if generalCfg == nil {
+		klog.V(100).Infof("No reporter configuration available, skipping config dump for test suite: %s", testSuite)
+
+		return
+	}
+
+	dumpFile := generalCfg.GetConfigDumpPath(testSuite)
 
-		_, podExecLogsFName := path.Split(pathToPodExecLogs)
+	err := config.DumpSuiteConfigs(dumpFile)
+	if err != nil {
+		klog.Errorf("Failed to dump suite configs to %s: %v", dumpFile, err)
+	}
+}
+
+// archiveFailureArtifacts runs the collectors for the failed spec in report, archives their output in dumpDir, then
+// enforces the maximum dump size across the failed test directories of all suites. The output is only archived once
+// every collector has returned, since runCollectors waits for each of them. Errors are logged since there is nothing
+// the spec can do about them.
+func archiveFailureArtifacts(report types.SpecReport, dumpDir string, toRun []registeredCollector) {
+	specDir := filepath.Join(dumpDir, getDumpName(report.FullText()))
+
+	_, err := runCollectors(report, specDir, toRun)
+	if err != nil {
+		klog.Errorf("Failed to write failure artifacts index to %s: %v", specDir, err)
+	}
+
+	archivePath, err := archiveDumpDir(specDir)
+	if err != nil {
+		klog.Errorf("Failed to archive failure artifacts in %s: %v", specDir, err)
+
+		return
+	}
+
+	klog.V(100).Infof("Saved failure artifacts to %s", archivePath)
+
+	// Every suite dumps to a separate directory in the reports directory, so the limit applies to all of them.
+	archivePattern := getDumpArchivePattern(filepath.Dir(dumpDir))
+
+	err = enforceDumpSizeLimit(archivePattern, int64(generalCfg.DumpFailedTestsMaxSizeMB)<<20, archivePath)
+	if err != nil {
+		klog.Errorf("Failed to enforce maximum dump size: %v", err)
+	}
+}
+
+// k8sReporterCollector dumps the requested namespaces and CRs using k8sreporter.
+type k8sReporterCollector struct {
+	kubeconfig       string
+	namespacesToDump map[string]string
+	cRDs             []k8sreporter.CRData
+	// clusterScopedFiles are the names of the files k8sreporter writes that are not limited to a namespace.
+	clusterScopedFiles map[string]bool
+}
+
+// newK8sReporterCollector creates a k8sReporterCollector, finding the names of the files for the nodes and the CRs
+// that are not limited to a namespace.
+func newK8sReporterCollector(
+	kubeconfig string, namespacesToDump map[string]string, cRDs []k8sreporter.CRData) *k8sReporterCollector {
+	collector := &k8sReporterCollector{
+		kubeconfig:         kubeconfig,
+		namespacesToDump:   namespacesToDump,
+		cRDs:               cRDs,
+		clusterScopedFiles: map[string]bool{"nodes.log": true},
+	}
+
+	crScheme := runtime.NewScheme()
+
+	err := clientgoscheme.AddToScheme(crScheme)
+	if err == nil {
+		err = setReporterSchemes(crScheme)
+	}
+
+	if err != nil {
+		klog.V(100).Infof("Failed to create scheme for finding cluster-scoped CRs: %v", err)
 
-		err = moveFile(
-			pathToPodExecLogs, path.Join(generalCfg.ReportsDirAbsPath, tcReportFolderName, podExecLogsFName))
+		return collector
+	}
+
+	for _, crData := range cRDs {
+		if crData.Namespace != nil {
+			continue
+		}
+
+		// k8sreporter names the file after the first kind that has a version.
+		gvks, _, err := crScheme.ObjectKinds(crData.Cr)
 		if err != nil {
-			klog.Fatalf("Failed to move pod exec logs %s to report folder: %s", pathToPodExecLogs, err)
+			continue
+		}
+
+		for _, gvk := range gvks {
+			if gvk.Kind != "" && gvk.Version != "" && gvk.Version != runtime.APIVersionInternal {
+				collector.clusterScopedFiles[gvk.Kind+".log"] = true
+
+				break
+			}
 		}
 	}
 
-	err := removeFile(pathToPodExecLogs)
+	return collector
+}
+
+// Name returns the name of the k8sReporterCollector.
+func (collector *k8sReporterCollector) Name() string {
+	return "k8sreporter"
+}
+
+// Collect dumps the requested namespaces and CRs to outputDir. The k8sreporter does not return errors from dumping, so
+// only errors creating it are returned. Since the k8sreporter does not accept a context, it dumps to a staging
+// directory that is moved into outputDir once it is done. If ctx expires first, the staging directory is removed so
+// the rest of the dump fails instead of writing to outputDir.
+func (collector *k8sReporterCollector) Collect(ctx context.Context, report types.SpecReport, outputDir string) error {
+	stagingDir := filepath.Join(outputDir, k8sReporterStagingDir)
+
+	reporter, err := newReporter(
+		stagingDir, collector.kubeconfig, collector.namespacesToDump, setReporterSchemes, collector.cRDs)
+	if err != nil {
+		return fmt.Errorf("failed to create log reporter: %w", err)
+	}
+
+	// Workaround for the fact we are unable to pass a context to specify a logger for the client used by
+	// the reporter. Otherwise, we get megabytes of verbose logging. The flag is only set from the goroutine running
+	// the collectors so it is restored before the next collector runs, even if the dump is abandoned.
+	_ = flag.Set("v", "0")
+
+	defer func() {
+		_ = flag.Set("v", generalCfg.VerboseLevel)
+	}()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		// The dump must be in a subdirectory of stagingDir since the k8sreporter creates the directory it dumps to,
+		// which would otherwise bring back the staging directory after it is removed.
+		reporter.Dump(report.RunTime, k8sReporterDumpSubpath)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		_ = os.RemoveAll(stagingDir)
+
+		return ctx.Err()
+	}
+
+	dumpDir := filepath.Join(stagingDir, k8sReporterDumpSubpath)
+
+	entries, err := os.ReadDir(dumpDir)
 	if err != nil {
-		klog.Fatal(err.Error())
+		return err
 	}
+
+	for _, entry := range entries {
+		err = os.Rename(filepath.Join(dumpDir, entry.Name()), filepath.Join(outputDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.RemoveAll(stagingDir)
+}
+
+// IsClusterScoped returns true for the nodes and the CRs that are not limited to a namespace.
+func (collector *k8sReporterCollector) IsClusterScoped(path string) bool {
+	return collector.clusterScopedFiles[path]
+}
+
+// podExecLogsCollector saves the logs of commands executed in pods during the TC.
+type podExecLogsCollector struct{}
+
+// Name returns the name of the podExecLogsCollector.
+func (podExecLogsCollector) Name() string {
+	return "pod-exec-logs"
+}
+
+// Collect copies the pod exec logs to outputDir if they exist.
+func (podExecLogsCollector) Collect(_ context.Context, _ types.SpecReport, outputDir string) error {
+	_, podExecLogsFName := path.Split(pathToPodExecLogs)
+
+	return moveFile(pathToPodExecLogs, path.Join(outputDir, podExecLogsFName))
 }
 
 func moveFile(sourcePath, destPath string) error {
@@ -0,0 +1,145 @@
+package requirement
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FactsVersion is the version of the facts file format written by this package. Files with a newer version are
+// rejected when loaded since they may contain facts this version does not know how to check.
+const FactsVersion = 1
+
+const (
+	// TopologySNO is the topology of a cluster with a single node that is both a control plane and worker.
+	TopologySNO = "SNO"
+	// TopologySNOPlusOne is the topology of a SNO cluster with one additional worker node.
+	TopologySNOPlusOne = "SNO+1"
+	// TopologyCompact is the topology of a cluster with three control plane nodes that are also workers and no other
+	// nodes.
+	TopologyCompact = "Compact"
+	// TopologyStandard is the topology of any other cluster, usually with dedicated control plane and worker nodes.
+	TopologyStandard = "Standard"
+)
+
+const (
+	// IPFamilyIPv4 is the IP family of a cluster whose cluster networks are all IPv4.
+	IPFamilyIPv4 = "IPv4"
+	// IPFamilyIPv6 is the IP family of a cluster whose cluster networks are all IPv6.
+	IPFamilyIPv6 = "IPv6"
+	// IPFamilyDualStack is the IP family of a cluster with both IPv4 and IPv6 cluster networks.
+	IPFamilyDualStack = "DualStack"
+)
+
+const (
+	// ConnectivityConnected is the connectivity of a cluster that can retrieve updates.
+	ConnectivityConnected = "Connected"
+	// ConnectivityDisconnected is the connectivity of a cluster that cannot retrieve updates.
+	ConnectivityDisconnected = "Disconnected"
+)
+
+const (
+	// ProxyStatusConfigured is the proxy status of a cluster with an HTTP or HTTPS proxy in its cluster-wide proxy.
+	ProxyStatusConfigured = "Configured"
+	// ProxyStatusNone is the proxy status of a cluster without a cluster-wide proxy.
+	ProxyStatusNone = "None"
+)
+
+// Facts are the facts about every cluster a suite uses, keyed by the name the suite registers the cluster with, such
+// as hub or spoke1. It is the format of the cluster facts file.
+type Facts struct {
+	Version    int                      `json:"version" yaml:"version"`
+	GatheredAt time.Time                `json:"gatheredAt,omitempty" yaml:"gatheredAt,omitempty"`
+	Clusters   map[string]*ClusterFacts `json:"clusters" yaml:"clusters"`
+}
+
+// ClusterFacts are the facts about a single cluster that requirements are checked against. Facts that could not be
+// gathered are left empty and the reason is recorded in Errors, keyed by the name of the fact.
+type ClusterFacts struct {
+	Topology          string `json:"topology,omitempty" yaml:"topology,omitempty"`
+	ControlPlaneNodes int    `json:"controlPlaneNodes,omitempty" yaml:"controlPlaneNodes,omitempty"`
+	WorkerNodes       int    `json:"workerNodes,omitempty" yaml:"workerNodes,omitempty"`
+	OCPVersion        string `json:"ocpVersion,omitempty" yaml:"ocpVersion,omitempty"`
+	NetworkType       string `json:"networkType,omitempty" yaml:"networkType,omitempty"`
+	IPFamily          string `json:"ipFamily,omitempty" yaml:"ipFamily,omitempty"`
+	Platform          string `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Connectivity      string `json:"connectivity,omitempty" yaml:"connectivity,omitempty"`
+	Proxy             string `json:"proxy,omitempty" yaml:"proxy,omitempty"`
+	// Operators maps the name of each installed operator, which is the name of its CSV without the version, to its
+	// version.
+	Operators map[string]string `json:"operators,omitempty" yaml:"operators,omitempty"`
+	// NICVendors are the vendors of the NICs reported by the SR-IOV network operator, sorted and without duplicates.
+	// Well known vendor IDs are replaced by their name, such as intel for 8086.
+	NICVendors []string          `json:"nicVendors,omitempty" yaml:"nicVendors,omitempty"`
+	Errors     map[string]string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// ClusterFacts returns the facts for the cluster with the provided name. Since the facts are already gathered, Facts
+// can be used directly as a Source.
+func (facts *Facts) ClusterFacts(cluster string) (*ClusterFacts, error) {
+	clusterFacts, ok := facts.Clusters[cluster]
+	if !ok || clusterFacts == nil {
+		return nil, fmt.Errorf("no facts for cluster %s", cluster)
+	}
+
+	return clusterFacts, nil
+}
+
+// LoadFacts reads the facts file at path, which may be either JSON or YAML.
+func LoadFacts(path string) (*Facts, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read facts file %s: %w", path, err)
+	}
+
+	var facts Facts
+
+	// Since JSON is also valid YAML, both formats can be decoded the same way.
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(&facts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal facts file %s: %w", path, err)
+	}
+
+	if facts.Version > FactsVersion {
+		return nil, fmt.Errorf("facts file %s has version %d but only versions up to %d are supported",
+			path, facts.Version, FactsVersion)
+	}
+
+	return &facts, nil
+}
+
+// SaveFacts writes facts to the file at path, using YAML if path has a .yaml or .yml extension and JSON otherwise. The
+// file can be read back with LoadFacts.
+func SaveFacts(path string, facts *Facts) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		content, err = yaml.Marshal(facts)
+	default:
+		content, err = json.MarshalIndent(facts, "", "  ")
+		content = append(content, '\n')
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal facts: %w", err)
+	}
+
+	err = os.WriteFile(path, content, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write facts file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+// Package gather collects the facts the requirement package checks from live clusters. It is kept separate from the
+// requirement package so tools that only evaluate saved facts do not need to connect to a cluster. It uses the
+// eco-goinfra builders directly rather than the cluster package, since importing that loads the test configuration
+// and requires a KUBECONFIG, which would prevent the snapshot command from gathering facts from arbitrary clusters.
+package gather
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clusterversion"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/infrastructure"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/network"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/olm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/proxy"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/sriov"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+	"k8s.io/klog/v2"
+)
+
+// csvNameRegex matches the name of a CSV, capturing the operator name before the version.
+var csvNameRegex = regexp.MustCompile(`^(.+?)\.v?\d`)
+
+// nicVendors maps the PCI vendor IDs reported by the SR-IOV network operator to names that are easier to use in
+// requirements. Vendors not in this map are kept as their ID.
+var nicVendors = map[string]string{
+	"8086": "intel",
+	"15b3": "mellanox",
+	"14e4": "broadcom",
+	"1077": "qlogic",
+	"1924": "solarflare",
+}
+
+// Options are the cluster specific settings used when gathering facts.
+type Options struct {
+	// ControlPlaneLabel is the label present on control plane nodes.
+	ControlPlaneLabel string
+	// WorkerLabel is the label present on worker nodes, including control plane nodes that are schedulable.
+	WorkerLabel string
+	// SriovOperatorNamespace is the namespace where the SR-IOV network operator creates SriovNetworkNodeStates.
+	SriovOperatorNamespace string
+}
+
+// DefaultOptions returns the options matching the defaults in the general config file.
+func DefaultOptions() Options {
+	return Options{
+		ControlPlaneLabel:      "node-role.kubernetes.io/control-plane",
+		WorkerLabel:            "node-role.kubernetes.io/worker",
+		SriovOperatorNamespace: "openshift-sriov-network-operator",
+	}
+}
+
+// ConfigOptions returns the options from the provided general config, falling back to DefaultOptions if it was not
+// loaded, such as in unit tests.
+func ConfigOptions(generalConfig *config.GeneralConfig) Options {
+	if generalConfig == nil {
+		return DefaultOptions()
+	}
+
+	return Options{
+		ControlPlaneLabel:      generalConfig.ControlPlaneLabel,
+		WorkerLabel:            generalConfig.WorkerLabel,
+		SriovOperatorNamespace: generalConfig.SriovOperatorNamespace,
+	}
+}
+
+// Gather collects every fact about the cluster of client. Failing to gather one fact does not prevent the others from
+// being gathered, instead the error is recorded in the Errors of the returned facts.
+func Gather(client *clients.Settings, options Options) *requirement.ClusterFacts {
+	facts := &requirement.ClusterFacts{}
+
+	if client != nil {
+		klog.V(90).Infof("Gathering requirement facts from cluster at %s", client.KubeconfigPath)
+	}
+
+	gatherers := map[requirement.Fact]func(*clients.Settings, Options, *requirement.ClusterFacts) error{
+		requirement.FactTopology:     gatherTopology,
+		requirement.FactOCPVersion:   gatherOCPVersion,
+		requirement.FactNetworkType:  gatherNetwork,
+		requirement.FactPlatform:     gatherPlatform,
+		requirement.FactConnectivity: gatherConnectivity,
+		requirement.FactProxy:        gatherProxy,
+		requirement.FactOperator:     gatherOperators,
+		requirement.FactNICVendor:    gatherNICVendors,
+	}
+
+	for _, fact := range slices.Sorted(maps.Keys(gatherers)) {
+		err := gatherers[fact](client, options, facts)
+		if err == nil {
+			continue
+		}
+
+		klog.V(90).Infof("Failed to gather %s facts: %v", fact, err)
+
+		if facts.Errors == nil {
+			facts.Errors = make(map[string]string)
+		}
+
+		facts.Errors[string(fact)] = err.Error()
+
+		// The network config provides both the network type and IP family, so it is reported for both.
+		if fact == requirement.FactNetworkType {
+			facts.Errors[string(requirement.FactIPFamily)] = err.Error()
+		}
+	}
+
+	return facts
+}
+
+// GatherAll collects the facts about every cluster in clients, keyed by the cluster name. Clusters with a nil client
+// are left out.
+func GatherAll(clusterClients map[string]*clients.Settings, options Options) *requirement.Facts {
+	facts := &requirement.Facts{
+		Version:    requirement.FactsVersion,
+		GatheredAt: time.Now().UTC().Truncate(time.Second),
+		Clusters:   make(map[string]*requirement.ClusterFacts),
+	}
+
+	for name, client := range clusterClients {
+		if client == nil {
+			continue
+		}
+
+		facts.Clusters[name] = Gather(client, options)
+	}
+
+	return facts
+}
+
+// gatherTopology determines the topology from the number of control plane and dedicated worker nodes.
+func gatherTopology(client *clients.Settings, options Options, facts *requirement.ClusterFacts) error {
+	nodeList, err := nodes.List(client)
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, node := range nodeList {
+		if _, ok := node.Object.Labels[options.ControlPlaneLabel]; ok {
+			facts.ControlPlaneNodes++
+
+			continue
+		}
+
+		if _, ok := node.Object.Labels[options.WorkerLabel]; ok {
+			facts.WorkerNodes++
+		}
+	}
+
+	facts.Topology = getTopology(facts.ControlPlaneNodes, facts.WorkerNodes)
+
+	return nil
+}
+
+// getTopology returns the topology of a cluster with the provided number of control plane nodes and worker nodes that
+// are not also control plane nodes.
+func getTopology(controlPlaneNodes, workerNodes int) string {
+	switch {
+	case controlPlaneNodes == 1 && workerNodes == 0:
+		return requirement.TopologySNO
+	case controlPlaneNodes == 1 && workerNodes == 1:
+		return requirement.TopologySNOPlusOne
+	case controlPlaneNodes == 3 && workerNodes == 0:
+		return requirement.TopologyCompact
+	default:
+		return requirement.TopologyStandard
+	}
+}
+
+// gatherOCPVersion uses the desired version from the clusterversion.
+func gatherOCPVersion(client *clients.Settings, _ Options, facts *requirement.ClusterFacts) error {
+	clusterVersion, err := clusterversion.Pull(client)
+	if err != nil {
+		return fmt.Errorf("failed to get clusterversion: %w", err)
+	}
+
+	facts.OCPVersion = clusterVersion.Object.Status.Desired.Version
+
+	return nil
+}
+
+// gatherNetwork uses the cluster network config for both the network type and IP family.
+func gatherNetwork(client *clients.Settings, _ Options, facts *requirement.ClusterFacts) error {
+	networkConfig, err := network.PullConfig(client)
+	if err != nil {
+		return fmt.Errorf("failed to get network config: %w", err)
+	}
+
+	facts.NetworkType = networkConfig.Object.Status.NetworkType
+
+	var cidrs []string
+	for _, clusterNetwork := range networkConfig.Object.Status.ClusterNetwork {
+		cidrs = append(cidrs, clusterNetwork.CIDR)
+	}
+
+	facts.IPFamily, err = getIPFamily(cidrs)
+
+	return err
+}
+
+// getIPFamily returns the IP family of the provided cluster network CIDRs.
+func getIPFamily(cidrs []string) (string, error) {
+	var hasIPv4, hasIPv6 bool
+
+	for _, cidr := range cidrs {
+		ip, _, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse cluster network CIDR %s: %w", cidr, err)
+		}
+
+		if ip.To4() != nil {
+			hasIPv4 = true
+		} else {
+			hasIPv6 = true
+		}
+	}
+
+	switch {
+	case hasIPv4 && hasIPv6:
+		return requirement.IPFamilyDualStack, nil
+	case hasIPv4:
+		return requirement.IPFamilyIPv4, nil
+	case hasIPv6:
+		return requirement.IPFamilyIPv6, nil
+	default:
+		return "", fmt.Errorf("network config has no cluster networks")
+	}
+}
+
+// gatherPlatform uses the platform type from the infrastructure status.
+func gatherPlatform(client *clients.Settings, _ Options, facts *requirement.ClusterFacts) error {
+	infraConfig, err := infrastructure.Pull(client)
+	if err != nil {
+		return fmt.Errorf("failed to get infrastructure: %w", err)
+	}
+
+	if infraConfig.Object.Status.PlatformStatus != nil {
+		facts.Platform = string(infraConfig.Object.Status.PlatformStatus.Type)
+	}
+
+	if facts.Platform == "" {
+		//nolint:staticcheck // Platform is deprecated but still set on older clusters without a PlatformStatus.
+		facts.Platform = string(infraConfig.Object.Status.Platform)
+	}
+
+	return nil
+}
+
+// gatherConnectivity uses the RetrievedUpdates condition of the clusterversion, the same as cluster.Connected, to
+// determine whether the cluster can retrieve updates.
+func gatherConnectivity(client *clients.Settings, _ Options, facts *requirement.ClusterFacts) error {
+	clusterVersion, err := clusterversion.Pull(client)
+	if err != nil {
+		return fmt.Errorf("failed to get clusterversion: %w", err)
+	}
+
+	facts.Connectivity, err = getConnectivity(clusterVersion.Object.Status.Conditions)
+
+	return err
+}
+
+// getConnectivity returns the connectivity of a cluster with the provided clusterversion conditions. Clusters fail to
+// retrieve updates with the RemoteFailed reason when they cannot reach the update service.
+func getConnectivity(conditions []configv1.ClusterOperatorStatusCondition) (string, error) {
+	for _, condition := range conditions {
+		if condition.Type != configv1.RetrievedUpdates {
+			continue
+		}
+
+		if condition.Reason == "RemoteFailed" {
+			return requirement.ConnectivityDisconnected, nil
+		}
+
+		return requirement.ConnectivityConnected, nil
+	}
+
+	return "", fmt.Errorf("clusterversion does not have the %s condition", configv1.RetrievedUpdates)
+}
+
+// gatherProxy uses the status of the cluster-wide proxy, the same as cluster.GetOCPProxy, to determine whether egress
+// traffic goes through a proxy.
+func gatherProxy(client *clients.Settings, _ Options, facts *requirement.ClusterFacts) error {
+	clusterProxy, err := proxy.Pull(client)
+	if err != nil {
+		return fmt.Errorf("failed to get proxy: %w", err)
+	}
+
+	facts.Proxy = requirement.ProxyStatusNone
+	if clusterProxy.Object.Status.HTTPProxy != "" || clusterProxy.Object.Status.HTTPSProxy != "" {
+		facts.Proxy = requirement.ProxyStatusConfigured
+	}
+
+	return nil
+}
+
+// gatherOperators records the version of every CSV on the cluster, keyed by the CSV name without the version. Since
+// CSVs for operators watching all namespaces are copied into every namespace, the same CSV may be listed many times.
+func gatherOperators(client *clients.Settings, _ Options, facts *requirement.ClusterFacts) error {
+	csvList, err := olm.ListClusterServiceVersionInAllNamespaces(client)
+	if err != nil {
+		return fmt.Errorf("failed to list CSVs: %w", err)
+	}
+
+	facts.Operators = make(map[string]string)
+
+	for _, csv := range csvList {
+		match := csvNameRegex.FindStringSubmatch(csv.Object.Name)
+		if match == nil {
+			klog.V(90).Infof("Skipping CSV %s since its name does not contain a version", csv.Object.Name)
+
+			continue
+		}
+
+		facts.Operators[match[1]] = csv.Object.Spec.Version.String()
+	}
+
+	return nil
+}
+
+// gatherNICVendors records the vendors of every interface reported in the SriovNetworkNodeStates.
+func gatherNICVendors(client *clients.Settings, options Options, facts *requirement.ClusterFacts) error {
+	nodeStates, err := sriov.ListNetworkNodeState(client, options.SriovOperatorNamespace)
+	if err != nil {
+		return fmt.Errorf("failed to list SriovNetworkNodeStates: %w", err)
+	}
+
+	vendors := make(map[string]bool)
+
+	for _, nodeState := range nodeStates {
+		for _, nic := range nodeState.Objects.Status.Interfaces {
+			if nic.Vendor == "" {
+				continue
+			}
+
+			vendors[vendorName(strings.ToLower(nic.Vendor))] = true
+		}
+	}
+
+	facts.NICVendors = slices.Sorted(maps.Keys(vendors))
+
+	return nil
+}
+
+// vendorName returns the name of the vendor with the provided ID, or the ID itself if the vendor is not known.
+func vendorName(vendorID string) string {
+	if name, ok := nicVendors[vendorID]; ok {
+		return name
+	}
+
+	return vendorID
+}
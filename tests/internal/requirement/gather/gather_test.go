@@ -0,0 +1,134 @@
+package gather
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTopology(t *testing.T) {
+	testCases := []struct {
+		controlPlaneNodes int
+		workerNodes       int
+		expected          string
+	}{
+		{controlPlaneNodes: 1, workerNodes: 0, expected: requirement.TopologySNO},
+		{controlPlaneNodes: 1, workerNodes: 1, expected: requirement.TopologySNOPlusOne},
+		{controlPlaneNodes: 3, workerNodes: 0, expected: requirement.TopologyCompact},
+		{controlPlaneNodes: 3, workerNodes: 2, expected: requirement.TopologyStandard},
+		{controlPlaneNodes: 1, workerNodes: 2, expected: requirement.TopologyStandard},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, getTopology(testCase.controlPlaneNodes, testCase.workerNodes))
+	}
+}
+
+func TestGetIPFamily(t *testing.T) {
+	testCases := []struct {
+		cidrs    []string
+		expected string
+		err      string
+	}{
+		{cidrs: []string{"10.128.0.0/14"}, expected: requirement.IPFamilyIPv4},
+		{cidrs: []string{"fd01::/48"}, expected: requirement.IPFamilyIPv6},
+		{cidrs: []string{"10.128.0.0/14", "fd01::/48"}, expected: requirement.IPFamilyDualStack},
+		{cidrs: nil, err: "no cluster networks"},
+		{cidrs: []string{"10.128.0.0"}, err: "failed to parse cluster network CIDR"},
+	}
+
+	for _, testCase := range testCases {
+		ipFamily, err := getIPFamily(testCase.cidrs)
+
+		if testCase.err != "" {
+			assert.ErrorContains(t, err, testCase.err)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, ipFamily)
+	}
+}
+
+func TestGetConnectivity(t *testing.T) {
+	testCases := []struct {
+		conditions []configv1.ClusterOperatorStatusCondition
+		expected   string
+	}{
+		{
+			conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				{Type: configv1.RetrievedUpdates, Status: configv1.ConditionTrue},
+			},
+			expected: requirement.ConnectivityConnected,
+		},
+		{
+			conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.RetrievedUpdates, Status: configv1.ConditionFalse, Reason: "RemoteFailed"},
+			},
+			expected: requirement.ConnectivityDisconnected,
+		},
+		{
+			conditions: []configv1.ClusterOperatorStatusCondition{
+				{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		connectivity, err := getConnectivity(testCase.conditions)
+
+		if testCase.expected == "" {
+			assert.ErrorContains(t, err, "does not have the RetrievedUpdates condition")
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, connectivity)
+	}
+}
+
+func TestConfigOptions(t *testing.T) {
+	assert.Equal(t, DefaultOptions(), ConfigOptions(nil))
+
+	options := ConfigOptions(&config.GeneralConfig{
+		ControlPlaneLabel:      "node-role.kubernetes.io/master",
+		WorkerLabel:            "node-role.kubernetes.io/worker",
+		SriovOperatorNamespace: "sriov",
+	})
+	assert.Equal(t, "node-role.kubernetes.io/master", options.ControlPlaneLabel)
+	assert.Equal(t, "sriov", options.SriovOperatorNamespace)
+}
+
+func TestCSVNameRegex(t *testing.T) {
+	testCases := map[string]string{
+		"ptp-operator.v4.20.0-202510011200":        "ptp-operator",
+		"topology-aware-lifecycle-manager.v4.19.0": "topology-aware-lifecycle-manager",
+		"sriov-network-operator.4.18.0":            "sriov-network-operator",
+		"openshift-gitops-operator.v1.16.1":        "openshift-gitops-operator",
+	}
+
+	for name, expected := range testCases {
+		match := csvNameRegex.FindStringSubmatch(name)
+		if assert.NotNil(t, match, "CSV name %s should match", name) {
+			assert.Equal(t, expected, match[1])
+		}
+	}
+
+	assert.Nil(t, csvNameRegex.FindStringSubmatch("packageserver"))
+}
+
+func TestLiveSourceUnavailable(t *testing.T) {
+	source := NewLiveSource().WithCluster(requirement.Spoke2, nil)
+
+	_, err := source.ClusterFacts(requirement.Spoke2)
+	assert.ErrorContains(t, err, "cluster spoke2 is not available")
+
+	_, err = source.ClusterFacts(requirement.Hub)
+	assert.ErrorContains(t, err, "cluster hub is not known")
+}
@@ -0,0 +1,67 @@
+package gather
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+)
+
+// LiveSource is a requirement.Source that gathers facts from the clusters it knows about the first time they are
+// needed and caches them for the rest of the run.
+type LiveSource struct {
+	options Options
+	clients map[string]*clients.Settings
+
+	mutex sync.Mutex
+	cache map[string]*requirement.ClusterFacts
+}
+
+// NewLiveSource returns a LiveSource without any clusters, using the default options.
+func NewLiveSource() *LiveSource {
+	return &LiveSource{
+		options: DefaultOptions(),
+		clients: make(map[string]*clients.Settings),
+		cache:   make(map[string]*requirement.ClusterFacts),
+	}
+}
+
+// WithCluster adds a cluster to the source with the provided name, such as requirement.Spoke1. The client may be nil
+// for optional clusters that were not provided, in which case every requirement on the cluster is unmet.
+func (source *LiveSource) WithCluster(name string, client *clients.Settings) *LiveSource {
+	source.clients[name] = client
+
+	return source
+}
+
+// WithOptions sets the options used when gathering facts.
+func (source *LiveSource) WithOptions(options Options) *LiveSource {
+	source.options = options
+
+	return source
+}
+
+// ClusterFacts implements the requirement.Source interface.
+func (source *LiveSource) ClusterFacts(name string) (*requirement.ClusterFacts, error) {
+	client, ok := source.clients[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %s is not known", name)
+	}
+
+	if client == nil {
+		return nil, fmt.Errorf("cluster %s is not available since its client is nil", name)
+	}
+
+	source.mutex.Lock()
+	defer source.mutex.Unlock()
+
+	if facts, ok := source.cache[name]; ok {
+		return facts, nil
+	}
+
+	facts := Gather(client, source.options)
+	source.cache[name] = facts
+
+	return facts, nil
+}
@@ -0,0 +1,394 @@
+// Package requirement describes what a spec needs from the clusters it runs against, such as a minimum OCP version or a
+// SNO topology, and checks those requirements against cached facts about the clusters. It generalizes the assisted
+// meets package so every suite can skip specs the same way.
+//
+// Requirements are stored as Ginkgo labels so they are visible in the spec tree and the JSON report without running
+// the spec. Each label has the form requires:<cluster>:<fact><operator><value>, for example:
+//
+//	requires:spoke1:topology=SNO
+//	requires:hub:ocp>=4.18
+//	requires:spoke1:operator.ptp-operator>=4.20
+//
+// Specs use the skip package to add the labels and skip when they are not met, while tools such as the report can
+// evaluate the labels against a saved facts file to show which specs would skip on a given lab.
+package requirement
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// LabelPrefix is the prefix of every requirement label.
+const LabelPrefix = "requires:"
+
+const (
+	// Hub is the name of the hub cluster in multi-cluster suites.
+	Hub = "hub"
+	// Spoke1 is the name of the first spoke cluster in multi-cluster suites.
+	Spoke1 = "spoke1"
+	// Spoke2 is the name of the second spoke cluster in multi-cluster suites.
+	Spoke2 = "spoke2"
+	// Default is the name of the only cluster in single-cluster suites, which use inittools.APIClient.
+	Default = "default"
+)
+
+// Fact is the name of a fact about a cluster that a requirement checks.
+type Fact string
+
+const (
+	// FactTopology is the topology of the cluster, such as SNO or Standard.
+	FactTopology Fact = "topology"
+	// FactOCPVersion is the OCP version of the cluster.
+	FactOCPVersion Fact = "ocp"
+	// FactNetworkType is the network type of the cluster, such as OVNKubernetes.
+	FactNetworkType Fact = "network"
+	// FactIPFamily is the IP family of the cluster networks, such as IPv4 or DualStack.
+	FactIPFamily Fact = "ipfamily"
+	// FactPlatform is the platform type of the cluster, such as BareMetal or None.
+	FactPlatform Fact = "platform"
+	// FactConnectivity is whether the cluster is connected or disconnected.
+	FactConnectivity Fact = "connectivity"
+	// FactProxy is whether the cluster uses a cluster-wide proxy for egress traffic.
+	FactProxy Fact = "proxy"
+	// FactNICVendor is a vendor of the NICs on the cluster. Requirements on it are met if any NIC has the vendor.
+	FactNICVendor Fact = "nic"
+	// FactOperator is the version of an installed operator. Requirements on it have the name of the operator as their
+	// key, which is written after the fact separated by a dot, such as operator.ptp-operator.
+	FactOperator Fact = "operator"
+)
+
+// Operator is how a requirement compares the fact to its value.
+type Operator string
+
+const (
+	// OperatorEqual requires the fact to be equal to the value, ignoring case. Versions are equal if every segment of
+	// the value matches, so 4.18 is equal to 4.18.3.
+	OperatorEqual Operator = "="
+	// OperatorAtLeast requires a version to be greater than or equal to the value.
+	OperatorAtLeast Operator = ">="
+	// OperatorBelow requires a version to be less than the value.
+	OperatorBelow Operator = "<"
+)
+
+// Requirement is a single requirement on a fact about a cluster.
+type Requirement struct {
+	Cluster  string
+	Fact     Fact
+	Key      string
+	Operator Operator
+	Value    string
+}
+
+// SNO requires cluster to be a single node cluster.
+func SNO(cluster string) Requirement {
+	return Topology(cluster, TopologySNO)
+}
+
+// Topology requires cluster to have the provided topology, such as TopologyCompact.
+func Topology(cluster, topology string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactTopology, Operator: OperatorEqual, Value: topology}
+}
+
+// OCPVersionAtLeast requires the OCP version of cluster to be at least minimum, such as 4.18.
+func OCPVersionAtLeast(cluster, minimum string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactOCPVersion, Operator: OperatorAtLeast, Value: minimum}
+}
+
+// OCPVersionBelow requires the OCP version of cluster to be less than maximum.
+func OCPVersionBelow(cluster, maximum string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactOCPVersion, Operator: OperatorBelow, Value: maximum}
+}
+
+// NetworkType requires cluster to use the provided network type, such as OVNKubernetes.
+func NetworkType(cluster, networkType string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactNetworkType, Operator: OperatorEqual, Value: networkType}
+}
+
+// IPFamily requires the cluster networks of cluster to have the provided IP family, such as IPFamilyIPv6.
+func IPFamily(cluster, ipFamily string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactIPFamily, Operator: OperatorEqual, Value: ipFamily}
+}
+
+// Platform requires cluster to have the provided platform type, such as BareMetal.
+func Platform(cluster, platform string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactPlatform, Operator: OperatorEqual, Value: platform}
+}
+
+// Connected requires cluster to be connected.
+func Connected(cluster string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactConnectivity, Operator: OperatorEqual, Value: ConnectivityConnected}
+}
+
+// Disconnected requires cluster to be disconnected.
+func Disconnected(cluster string) Requirement {
+	return Requirement{
+		Cluster: cluster, Fact: FactConnectivity, Operator: OperatorEqual, Value: ConnectivityDisconnected,
+	}
+}
+
+// ProxyConfigured requires cluster to use a cluster-wide proxy.
+func ProxyConfigured(cluster string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactProxy, Operator: OperatorEqual, Value: ProxyStatusConfigured}
+}
+
+// ProxyNotConfigured requires cluster not to use a cluster-wide proxy.
+func ProxyNotConfigured(cluster string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactProxy, Operator: OperatorEqual, Value: ProxyStatusNone}
+}
+
+// NICVendor requires cluster to have at least one NIC from vendor, such as intel.
+func NICVendor(cluster, vendor string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactNICVendor, Operator: OperatorEqual, Value: vendor}
+}
+
+// OperatorInstalled requires the operator to be installed on cluster. The operator is the name of its CSV without the
+// version, such as the operator names in ranparam.
+func OperatorInstalled(cluster, operator string) Requirement {
+	return OperatorVersionAtLeast(cluster, operator, "0")
+}
+
+// OperatorVersionAtLeast requires the operator to be installed on cluster with a version of at least minimum.
+func OperatorVersionAtLeast(cluster, operator, minimum string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactOperator, Key: operator, Operator: OperatorAtLeast, Value: minimum}
+}
+
+// OperatorVersionBelow requires the operator to be installed on cluster with a version less than maximum.
+func OperatorVersionBelow(cluster, operator, maximum string) Requirement {
+	return Requirement{Cluster: cluster, Fact: FactOperator, Key: operator, Operator: OperatorBelow, Value: maximum}
+}
+
+// Label returns the Ginkgo label for the requirement.
+func (requirement Requirement) Label() string {
+	return fmt.Sprintf("%s%s:%s%s%s", LabelPrefix, requirement.Cluster, requirement.factName(), requirement.Operator,
+		requirement.Value)
+}
+
+// String returns a description of the requirement, which is used in skip messages.
+func (requirement Requirement) String() string {
+	if requirement.Fact == FactOperator && requirement.Operator == OperatorAtLeast && requirement.Value == "0" {
+		return fmt.Sprintf("%s requires operator %s to be installed", requirement.Cluster, requirement.Key)
+	}
+
+	return fmt.Sprintf("%s requires %s %s %s", requirement.Cluster, requirement.factName(), requirement.Operator,
+		requirement.Value)
+}
+
+// IsLabel returns whether label is a requirement label. It does not check that the label is valid.
+func IsLabel(label string) bool {
+	return strings.HasPrefix(label, LabelPrefix)
+}
+
+// Parse returns the requirement described by label, which must have been created by Label or follow the same format.
+func Parse(label string) (Requirement, error) {
+	if !IsLabel(label) {
+		return Requirement{}, fmt.Errorf("label %q does not start with %q", label, LabelPrefix)
+	}
+
+	cluster, condition, found := strings.Cut(strings.TrimPrefix(label, LabelPrefix), ":")
+	if !found || cluster == "" {
+		return Requirement{}, fmt.Errorf("label %q does not have a cluster", label)
+	}
+
+	requirement := Requirement{Cluster: cluster}
+
+	// OperatorAtLeast must be checked before OperatorEqual since it contains it.
+	for _, operator := range []Operator{OperatorAtLeast, OperatorBelow, OperatorEqual} {
+		fact, value, found := strings.Cut(condition, string(operator))
+		if !found {
+			continue
+		}
+
+		requirement.Operator = operator
+		requirement.Value = value
+		factName, key, _ := strings.Cut(fact, ".")
+		requirement.Fact, requirement.Key = Fact(factName), key
+
+		break
+	}
+
+	if requirement.Operator == "" {
+		return Requirement{}, fmt.Errorf("label %q does not have an operator", label)
+	}
+
+	err := requirement.validate()
+	if err != nil {
+		return Requirement{}, fmt.Errorf("label %q is not a valid requirement: %w", label, err)
+	}
+
+	return requirement, nil
+}
+
+// Check returns whether the facts meet the requirement and, if they do not, a message explaining why. Facts that
+// could not be gathered never meet a requirement.
+func (requirement Requirement) Check(facts *ClusterFacts) (bool, string) {
+	if facts == nil {
+		return false, fmt.Sprintf("%s but no facts are available for the cluster", requirement)
+	}
+
+	actual := requirement.actual(facts)
+	if actual == "" {
+		message := fmt.Sprintf("%s but it is unknown", requirement)
+
+		if gatherError, ok := facts.Errors[requirement.factName()]; ok {
+			message += ": " + gatherError
+		}
+
+		return false, message
+	}
+
+	var met bool
+
+	switch {
+	case requirement.Fact == FactNICVendor:
+		met = slices.ContainsFunc(facts.NICVendors, func(vendor string) bool {
+			return strings.EqualFold(vendor, requirement.Value)
+		})
+	case requirement.isVersion():
+		comparison, err := compareVersions(actual, requirement.Value)
+		if err != nil {
+			return false, fmt.Sprintf("%s but failed to compare with %s: %v", requirement, actual, err)
+		}
+
+		met = requirement.Operator == OperatorEqual && comparison == 0 ||
+			requirement.Operator == OperatorAtLeast && comparison >= 0 ||
+			requirement.Operator == OperatorBelow && comparison < 0
+	default:
+		met = strings.EqualFold(actual, requirement.Value)
+	}
+
+	if met {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("%s but it is %s", requirement, actual)
+}
+
+// factName returns the name of the fact as used in labels, including the key if there is one.
+func (requirement Requirement) factName() string {
+	if requirement.Key == "" {
+		return string(requirement.Fact)
+	}
+
+	return fmt.Sprintf("%s.%s", requirement.Fact, requirement.Key)
+}
+
+// isVersion returns whether the fact is a version and should be compared as one.
+func (requirement Requirement) isVersion() bool {
+	return requirement.Fact == FactOCPVersion || requirement.Fact == FactOperator
+}
+
+// actual returns the value of the fact the requirement checks, or an empty string if it is unknown. For NIC vendors,
+// all the vendors are joined with commas.
+func (requirement Requirement) actual(facts *ClusterFacts) string {
+	switch requirement.Fact {
+	case FactTopology:
+		return facts.Topology
+	case FactOCPVersion:
+		return facts.OCPVersion
+	case FactNetworkType:
+		return facts.NetworkType
+	case FactIPFamily:
+		return facts.IPFamily
+	case FactPlatform:
+		return facts.Platform
+	case FactConnectivity:
+		return facts.Connectivity
+	case FactProxy:
+		return facts.Proxy
+	case FactNICVendor:
+		return strings.Join(facts.NICVendors, ",")
+	case FactOperator:
+		return facts.Operators[requirement.Key]
+	default:
+		return ""
+	}
+}
+
+// validate returns an error if the requirement cannot be checked or its label would not be a valid Ginkgo label.
+func (requirement Requirement) validate() error {
+	if requirement.Cluster == "" {
+		return fmt.Errorf("cluster cannot be empty")
+	}
+
+	if requirement.Value == "" {
+		return fmt.Errorf("value cannot be empty")
+	}
+
+	if strings.ContainsAny(requirement.Value, "&|!,()/: ") || strings.ContainsAny(requirement.Key, "&|!,()/: ") {
+		return fmt.Errorf("key and value cannot contain any of the characters &|!,()/: or spaces")
+	}
+
+	if (requirement.Fact == FactOperator) != (requirement.Key != "") {
+		return fmt.Errorf("only the %s fact must have a key", FactOperator)
+	}
+
+	if !slices.Contains([]Fact{FactTopology, FactOCPVersion, FactNetworkType, FactIPFamily, FactPlatform,
+		FactConnectivity, FactProxy, FactNICVendor, FactOperator}, requirement.Fact) {
+		return fmt.Errorf("unknown fact %q", requirement.Fact)
+	}
+
+	if requirement.Operator != OperatorEqual && !requirement.isVersion() {
+		return fmt.Errorf("operator %s can only be used with versions", requirement.Operator)
+	}
+
+	if requirement.isVersion() {
+		_, err := version.NewVersion(requirement.Value)
+		if err != nil {
+			return fmt.Errorf("value %q is not a valid version: %w", requirement.Value, err)
+		}
+	}
+
+	return nil
+}
+
+// compareVersions compares actual to required using only as many segments as required has, so 4.18.3 compared to 4.18
+// is 0. It returns -1, 0, or 1 if actual is less than, equal to, or greater than required. Prerelease and metadata
+// parts of actual are ignored, which matches how OCP and operator versions are checked elsewhere.
+func compareVersions(actual, required string) (int, error) {
+	actualVersion, err := version.NewVersion(actual)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse version %q: %w", actual, err)
+	}
+
+	requiredVersion, err := version.NewVersion(required)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse version %q: %w", required, err)
+	}
+
+	// Segments are padded to at least three, so only versions with more segments than that need to be limited.
+	actualSegments, requiredSegments := actualVersion.Segments(), requiredVersion.Segments()
+	segmentCount := min(len(strings.Split(requiredVersion.Original(), ".")), len(actualSegments), len(requiredSegments))
+
+	return slices.Compare(actualSegments[:segmentCount], requiredSegments[:segmentCount]), nil
+}
+
+// Evaluate checks every requirement label in labels against the facts from source, returning whether they are all met
+// and, if not, a message explaining the first one that is not. Labels that are not requirements are ignored, while
+// invalid requirement labels are never met.
+func Evaluate(labels []string, source Source) (bool, string) {
+	for _, label := range labels {
+		if !IsLabel(label) {
+			continue
+		}
+
+		requirement, err := Parse(label)
+		if err != nil {
+			return false, err.Error()
+		}
+
+		facts, err := source.ClusterFacts(requirement.Cluster)
+		if err != nil {
+			return false, fmt.Sprintf("%s but failed to get facts: %v", requirement, err)
+		}
+
+		met, message := requirement.Check(facts)
+		if !met {
+			return false, message
+		}
+	}
+
+	return true, ""
+}
@@ -0,0 +1,318 @@
+package requirement
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testFacts = &Facts{
+	Version: FactsVersion,
+	Clusters: map[string]*ClusterFacts{
+		Hub: {
+			Topology:     TopologyCompact,
+			OCPVersion:   "4.19.2",
+			NetworkType:  "OVNKubernetes",
+			IPFamily:     IPFamilyDualStack,
+			Platform:     "BareMetal",
+			Connectivity: ConnectivityDisconnected,
+			Proxy:        ProxyStatusConfigured,
+			Operators:    map[string]string{"topology-aware-lifecycle-manager": "4.19.0"},
+		},
+		Spoke1: {
+			Topology:     TopologySNO,
+			OCPVersion:   "4.20.0-rc.3",
+			NetworkType:  "OVNKubernetes",
+			IPFamily:     IPFamilyIPv6,
+			Platform:     "None",
+			Connectivity: ConnectivityDisconnected,
+			Operators:    map[string]string{"ptp-operator": "4.20.0-202510011200"},
+			NICVendors:   []string{"intel", "mellanox"},
+			Errors:       map[string]string{},
+		},
+		Spoke2: {
+			Errors: map[string]string{"ocp": "failed to get clusterversion: connection refused"},
+		},
+	},
+}
+
+func TestLabelRoundTrip(t *testing.T) {
+	requirements := []Requirement{
+		SNO(Spoke1),
+		Topology(Spoke1, TopologySNOPlusOne),
+		OCPVersionAtLeast(Hub, "4.18"),
+		OCPVersionBelow(Hub, "4.20"),
+		NetworkType(Spoke1, "OVNKubernetes"),
+		IPFamily(Spoke1, IPFamilyIPv6),
+		Platform(Spoke1, "BareMetal"),
+		Connected(Hub),
+		Disconnected(Hub),
+		ProxyConfigured(Hub),
+		ProxyNotConfigured(Hub),
+		NICVendor(Spoke1, "intel"),
+		OperatorInstalled(Spoke1, "ptp-operator"),
+		OperatorVersionAtLeast(Spoke1, "ptp-operator", "4.20"),
+		OperatorVersionBelow(Hub, "topology-aware-lifecycle-manager", "4.20"),
+	}
+
+	for _, requirement := range requirements {
+		label := requirement.Label()
+
+		_, err := types.ValidateAndCleanupLabel(label, types.CodeLocation{})
+		assert.NoError(t, err, "label %s should be a valid Ginkgo label", label)
+
+		parsed, err := Parse(label)
+		assert.NoError(t, err)
+		assert.Equal(t, requirement, parsed)
+	}
+
+	assert.Equal(t, "requires:spoke1:operator.ptp-operator>=4.20",
+		OperatorVersionAtLeast(Spoke1, "ptp-operator", "4.20").Label())
+}
+
+func TestParseInvalid(t *testing.T) {
+	testCases := []struct {
+		label    string
+		expected string
+	}{
+		{label: "sno", expected: "does not start with"},
+		{label: "requires:topology=SNO", expected: "does not have a cluster"},
+		{label: "requires::topology=SNO", expected: "does not have a cluster"},
+		{label: "requires:spoke1:topology", expected: "does not have an operator"},
+		{label: "requires:spoke1:topology=", expected: "value cannot be empty"},
+		{label: "requires:spoke1:memory=64Gi", expected: "unknown fact"},
+		{label: "requires:spoke1:topology>=SNO", expected: "can only be used with versions"},
+		{label: "requires:spoke1:ocp>=latest", expected: "is not a valid version"},
+		{label: "requires:spoke1:operator>=4.20", expected: "only the operator fact must have a key"},
+		{label: "requires:spoke1:ocp.ptp>=4.20", expected: "only the operator fact must have a key"},
+	}
+
+	for _, testCase := range testCases {
+		_, err := Parse(testCase.label)
+		if assert.Error(t, err, "label %s should be invalid", testCase.label) {
+			assert.Contains(t, err.Error(), testCase.expected)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name        string
+		requirement Requirement
+		cluster     string
+		met         bool
+		message     string
+	}{
+		{name: "topology", requirement: SNO(Spoke1), cluster: Spoke1, met: true},
+		{
+			name: "wrong topology", requirement: SNO(Hub), cluster: Hub,
+			message: "hub requires topology = SNO but it is Compact",
+		},
+		{name: "ocp at least", requirement: OCPVersionAtLeast(Hub, "4.19"), cluster: Hub, met: true},
+		{name: "ocp prerelease", requirement: OCPVersionAtLeast(Spoke1, "4.20"), cluster: Spoke1, met: true},
+		{
+			name: "ocp too old", requirement: OCPVersionAtLeast(Hub, "4.20"), cluster: Hub,
+			message: "hub requires ocp >= 4.20 but it is 4.19.2",
+		},
+		{name: "ocp below", requirement: OCPVersionBelow(Hub, "4.20"), cluster: Hub, met: true},
+		{name: "ocp below patch", requirement: OCPVersionBelow(Hub, "4.19.3"), cluster: Hub, met: true},
+		{name: "ocp not below", requirement: OCPVersionBelow(Hub, "4.19"), cluster: Hub},
+		{name: "network type ignores case", requirement: NetworkType(Hub, "ovnkubernetes"), cluster: Hub, met: true},
+		{name: "ip family", requirement: IPFamily(Hub, IPFamilyDualStack), cluster: Hub, met: true},
+		{name: "platform", requirement: Platform(Spoke1, "BareMetal"), cluster: Spoke1},
+		{name: "disconnected", requirement: Disconnected(Hub), cluster: Hub, met: true},
+		{name: "proxy", requirement: ProxyConfigured(Hub), cluster: Hub, met: true},
+		{
+			name: "no proxy", requirement: ProxyNotConfigured(Hub), cluster: Hub,
+			message: "hub requires proxy = None but it is Configured",
+		},
+		{name: "nic vendor", requirement: NICVendor(Spoke1, "mellanox"), cluster: Spoke1, met: true},
+		{
+			name: "missing nic vendor", requirement: NICVendor(Spoke1, "broadcom"), cluster: Spoke1,
+			message: "spoke1 requires nic = broadcom but it is intel,mellanox",
+		},
+		{
+			name: "no nics", requirement: NICVendor(Hub, "intel"), cluster: Hub,
+			message: "hub requires nic = intel but it is unknown",
+		},
+		{name: "operator installed", requirement: OperatorInstalled(Spoke1, "ptp-operator"), cluster: Spoke1, met: true},
+		{
+			name: "operator missing", requirement: OperatorInstalled(Hub, "ptp-operator"), cluster: Hub,
+			message: "hub requires operator ptp-operator to be installed but it is unknown",
+		},
+		{
+			name: "operator version", requirement: OperatorVersionAtLeast(Spoke1, "ptp-operator", "4.20"), cluster: Spoke1,
+			met: true,
+		},
+		{
+			name:        "operator version too old",
+			requirement: OperatorVersionAtLeast(Hub, "topology-aware-lifecycle-manager", "4.20"),
+			cluster:     Hub,
+			message:     "hub requires operator.topology-aware-lifecycle-manager >= 4.20 but it is 4.19.0",
+		},
+		{
+			name: "gather error", requirement: OCPVersionAtLeast(Spoke2, "4.18"), cluster: Spoke2,
+			message: "spoke2 requires ocp >= 4.18 but it is unknown: failed to get clusterversion: connection refused",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			met, message := testCase.requirement.Check(testFacts.Clusters[testCase.cluster])
+			assert.Equal(t, testCase.met, met)
+
+			if testCase.met {
+				assert.Empty(t, message)
+			} else if testCase.message != "" {
+				assert.Equal(t, testCase.message, message)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	met, message := Evaluate([]string{"ptp", SNO(Spoke1).Label(), OCPVersionAtLeast(Hub, "4.18").Label()}, testFacts)
+	assert.True(t, met)
+	assert.Empty(t, message)
+
+	met, message = Evaluate([]string{SNO(Spoke1).Label(), Connected(Hub).Label(), SNO(Hub).Label()}, testFacts)
+	assert.False(t, met)
+	assert.Equal(t, "hub requires connectivity = Connected but it is Disconnected", message)
+
+	met, message = Evaluate([]string{SNO("spoke3").Label()}, testFacts)
+	assert.False(t, met)
+	assert.Equal(t, "spoke3 requires topology = SNO but failed to get facts: no facts for cluster spoke3", message)
+
+	met, message = Evaluate([]string{"requires:spoke1:memory=64Gi"}, testFacts)
+	assert.False(t, met)
+	assert.Contains(t, message, "unknown fact")
+}
+
+func TestLoadFacts(t *testing.T) {
+	directory := t.TempDir()
+
+	jsonPath := filepath.Join(directory, "facts.json")
+	err := os.WriteFile(jsonPath, []byte(`{"version": 1, "clusters": {"spoke1": {"topology": "SNO",
+		"operators": {"ptp-operator": "4.20.0"}, "nicVendors": ["intel"]}}}`), 0o600)
+	assert.NoError(t, err)
+
+	yamlPath := filepath.Join(directory, "facts.yaml")
+	err = os.WriteFile(yamlPath, []byte(`version: 1
+clusters:
+  spoke1:
+    topology: SNO
+    ocpVersion: 4.20
+    operators:
+      ptp-operator: 4.20.0
+    nicVendors:
+    - intel
+`), 0o600)
+	assert.NoError(t, err)
+
+	for _, path := range []string{jsonPath, yamlPath} {
+		facts, err := LoadFacts(path)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		expected := &ClusterFacts{
+			Topology:   TopologySNO,
+			Operators:  map[string]string{"ptp-operator": "4.20.0"},
+			NICVendors: []string{"intel"},
+		}
+
+		// Unquoted versions in YAML are floats, so make sure they are still decoded the same as the original text.
+		if path == yamlPath {
+			expected.OCPVersion = "4.20"
+		}
+
+		assert.Equal(t, expected, facts.Clusters[Spoke1])
+	}
+
+	newerPath := filepath.Join(directory, "newer.yaml")
+	err = os.WriteFile(newerPath, []byte("version: 2\nclusters: {}\n"), 0o600)
+	assert.NoError(t, err)
+
+	_, err = LoadFacts(newerPath)
+	assert.ErrorContains(t, err, "only versions up to 1 are supported")
+
+	unknownPath := filepath.Join(directory, "unknown.yaml")
+	err = os.WriteFile(unknownPath, []byte("version: 1\nclusters:\n  hub:\n    memory: 64Gi\n"), 0o600)
+	assert.NoError(t, err)
+
+	_, err = LoadFacts(unknownPath)
+	assert.Error(t, err)
+
+	_, err = LoadFacts(filepath.Join(directory, "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read facts file")
+}
+
+func TestSaveFacts(t *testing.T) {
+	directory := t.TempDir()
+	facts := &Facts{
+		Version:    FactsVersion,
+		GatheredAt: time.Date(2025, time.October, 1, 12, 0, 0, 0, time.UTC),
+		Clusters:   map[string]*ClusterFacts{Hub: testFacts.Clusters[Hub], Spoke2: testFacts.Clusters[Spoke2]},
+	}
+
+	for _, fileName := range []string{"facts.json", "facts.yaml"} {
+		path := filepath.Join(directory, fileName)
+
+		err := SaveFacts(path, facts)
+		if !assert.NoError(t, err) {
+			continue
+		}
+
+		loaded, err := LoadFacts(path)
+		if assert.NoError(t, err) {
+			assert.Equal(t, facts, loaded)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(directory, "facts.json"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "{\n  \"version\": 1,"), "facts.json should be indented JSON")
+
+	err = SaveFacts(filepath.Join(directory, "missing", "facts.yaml"), facts)
+	assert.ErrorContains(t, err, "failed to write facts file")
+}
+
+func TestFindSkipped(t *testing.T) {
+	location := types.CodeLocation{FileName: "tests/ptp.go", LineNumber: 10}
+	specReports := types.SpecReports{
+		{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed},
+		{
+			LeafNodeType: types.NodeTypeIt, LeafNodeText: "runs on SNO", LeafNodeLocation: location,
+			State: types.SpecStatePassed, LeafNodeLabels: []string{SNO(Spoke1).Label()},
+		},
+		{
+			LeafNodeType: types.NodeTypeIt, LeafNodeText: "needs a connected hub", LeafNodeLocation: location,
+			State: types.SpecStatePassed, LeafNodeLabels: []string{Connected(Hub).Label()},
+		},
+		{
+			LeafNodeType: types.NodeTypeIt, LeafNodeText: "filtered out", LeafNodeLocation: location,
+			State: types.SpecStateSkipped, LeafNodeLabels: []string{Connected(Hub).Label()},
+		},
+		{LeafNodeType: types.NodeTypeIt, LeafNodeText: "no requirements", State: types.SpecStatePassed},
+	}
+
+	skipped, evaluated := FindSkipped(specReports, testFacts)
+	assert.Equal(t, 3, evaluated)
+	assert.Equal(t, []SkippedSpec{{
+		Text:     "needs a connected hub",
+		Location: "tests/ptp.go:10",
+		Reason:   "hub requires connectivity = Connected but it is Disconnected",
+	}}, skipped)
+
+	var builder strings.Builder
+
+	WriteSkipped(&builder, "lab", skipped, evaluated)
+	assert.Equal(t, "lab: 1 of 3 specs would be skipped\n"+
+		".  needs a connected hub (tests/ptp.go:10)\n"+
+		".    hub requires connectivity = Connected but it is Disconnected\n", builder.String())
+}
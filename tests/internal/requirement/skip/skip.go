@@ -0,0 +1,114 @@
+// Package skip skips specs whose requirements are not met by the clusters they run against. Importing it registers a
+// top level BeforeEach in the suite that checks the requirement labels of every spec, so specs only need to be
+// decorated with Unless:
+//
+//	It("verifies log reduction", reportxml.ID("12345"),
+//		skip.Unless(requirement.OperatorVersionAtLeast(requirement.Spoke1, "ptp-operator", "4.20")), func() {
+//		...
+//	})
+//
+// Requirements are checked against requirement.DefaultSource, which suites set when creating their clients. Suites
+// that do not set a source use the facts of inittools.APIClient as the requirement.Default cluster.
+//
+// When ECO_DRY_RUN is set along with ECO_CLUSTER_FACTS_FILE, requirements are instead checked against the cluster
+// profile in the file, such as one written by the snapshot command, so no cluster is queried. During a Ginkgo dry run,
+// the specs that would be skipped on the clusters in the profile are also listed at the end of the suite along with
+// the reason:
+//
+//	ECO_DRY_RUN=true ECO_CLUSTER_FACTS_FILE=/path/to/facts.yaml ginkgo -dry-run -v ./tests/cnf/ran/ptp
+package skip
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2" //nolint:depguard // necessary for skipping specs and the dry run listing
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/inittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement/gather"
+	"k8s.io/klog/v2"
+)
+
+// fallbackSource is used when the suite has not set a default source.
+var fallbackSource = sync.OnceValue(func() requirement.Source {
+	return gather.NewLiveSource().
+		WithOptions(gather.ConfigOptions(inittools.GeneralConfig)).
+		WithCluster(requirement.Default, inittools.APIClient)
+})
+
+// profile is the cluster profile in ECO_CLUSTER_FACTS_FILE, which is loaded the first time it is needed.
+var profile = sync.OnceValues(func() (*requirement.Facts, error) {
+	return requirement.LoadFacts(inittools.GeneralConfig.ClusterFactsFile)
+})
+
+var _ = ginkgo.BeforeEach(func() {
+	met, message := requirement.Evaluate(ginkgo.CurrentSpecReport().Labels(), source())
+	if !met {
+		ginkgo.Skip(message)
+	}
+})
+
+var _ = ginkgo.ReportAfterSuite("requirement dry run", func(report ginkgo.Report) {
+	if !report.SuiteConfig.DryRun || !useProfile() {
+		return
+	}
+
+	facts, err := profile()
+	if err != nil {
+		klog.Errorf("Failed to list specs that would be skipped: %v", err)
+
+		return
+	}
+
+	skipped, evaluated := requirement.FindSkipped(report.SpecReports, facts)
+	requirement.WriteSkipped(ginkgo.GinkgoWriter,
+		"Requirements checked against "+inittools.GeneralConfig.ClusterFactsFile, skipped, evaluated)
+})
+
+// Unless returns a decorator that labels a container or spec with the requirements so it is skipped unless they are
+// all met. The labels apply to every spec in a container, so they are also visible in the spec tree and reports.
+// Invalid requirements are never met, so specs using them are skipped with the reason the requirement is invalid.
+func Unless(requirements ...requirement.Requirement) ginkgo.Labels {
+	var labels ginkgo.Labels
+
+	for _, req := range requirements {
+		labels = append(labels, req.Label())
+	}
+
+	return labels
+}
+
+// IfUnmet skips the current spec unless the requirements are all met. It is meant for requirements that can only be
+// decided while the spec runs, such as those depending on a config value, so prefer Unless otherwise.
+func IfUnmet(requirements ...requirement.Requirement) {
+	met, message := requirement.Evaluate(Unless(requirements...), source())
+	if !met {
+		ginkgo.Skip(message)
+	}
+}
+
+// source returns the cluster profile when it should be used instead of the clusters, otherwise the default source, or
+// the fallback source if the suite has not set one.
+func source() requirement.Source {
+	if useProfile() {
+		facts, err := profile()
+		if err != nil {
+			ginkgo.Fail(fmt.Sprintf("Failed to load cluster profile: %v", err))
+		}
+
+		return facts
+	}
+
+	if defaultSource := requirement.DefaultSource(); defaultSource != nil {
+		return defaultSource
+	}
+
+	return fallbackSource()
+}
+
+// useProfile returns whether requirements should be checked against the cluster profile in ECO_CLUSTER_FACTS_FILE
+// rather than querying the clusters, which is only the case when ECO_DRY_RUN is set.
+func useProfile() bool {
+	return inittools.GeneralConfig != nil && inittools.GeneralConfig.DryRun &&
+		inittools.GeneralConfig.ClusterFactsFile != ""
+}
@@ -0,0 +1,58 @@
+package requirement
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// SkippedSpec is a spec that would be skipped because its requirements are not met.
+type SkippedSpec struct {
+	Text     string
+	Location string
+	Reason   string
+}
+
+// FindSkipped evaluates the requirement labels of every spec in specReports that would run against the facts from
+// source. It returns the specs that would be skipped along with the number of specs evaluated. Specs that are pending
+// or not selected by the focus and label filters are not evaluated, so the report from a dry run can be used directly.
+func FindSkipped(specReports types.SpecReports, source Source) ([]SkippedSpec, int) {
+	var (
+		skipped   []SkippedSpec
+		evaluated int
+	)
+
+	for _, specReport := range specReports {
+		if !specReport.LeafNodeType.Is(types.NodeTypeIt) ||
+			specReport.State.Is(types.SpecStatePending|types.SpecStateSkipped) {
+			continue
+		}
+
+		evaluated++
+
+		met, reason := Evaluate(specReport.Labels(), source)
+		if met {
+			continue
+		}
+
+		skipped = append(skipped, SkippedSpec{
+			Text:     specReport.FullText(),
+			Location: specReport.LeafNodeLocation.String(),
+			Reason:   reason,
+		})
+	}
+
+	return skipped, evaluated
+}
+
+// WriteSkipped writes a section to writer with the provided title and number of skipped specs out of the number
+// evaluated, followed by one line per spec and an indented line with the reason it would be skipped.
+func WriteSkipped(writer io.Writer, title string, skipped []SkippedSpec, evaluated int) {
+	fmt.Fprintf(writer, "%s: %d of %d specs would be skipped\n", title, len(skipped), evaluated)
+
+	for _, spec := range skipped {
+		fmt.Fprintf(writer, ".  %s (%s)\n", spec.Text, spec.Location)
+		fmt.Fprintf(writer, ".    %s\n", spec.Reason)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Snapshot gathers the facts that spec requirements are checked against from a hub and its spokes, or from a single
+cluster, and writes them to a cluster profile. The profile is versioned and can be loaded by the suites instead of
+querying the clusters by setting ECO_DRY_RUN along with ECO_CLUSTER_FACTS_FILE, which shows which specs would be
+skipped on the lab the profile was taken from without needing access to it.
+
+The node labels and SR-IOV operator namespace used while gathering are read from the general configuration, so the
+same ECO_* environment variables and config files as the suites apply.
+
+Facts that could not be gathered are recorded in the errors of each cluster in the profile, so only failing to connect
+to a cluster or to write the profile is an error. Upon success the exit code is 0. If any error occurs it will be logged
+to stderr and the exit code will be 1.
+
+Usage:
+
+	snapshot [flags]
+
+The flags are:
+
+	-h, -help
+		Print this help message
+
+	-hub string
+		Path to the kubeconfig of the hub cluster
+
+	-spoke1 string
+		Path to the kubeconfig of the first spoke cluster
+
+	-spoke2 string
+		Path to the kubeconfig of the second spoke cluster
+
+	-default string
+		Path to the kubeconfig of the cluster used by single cluster suites
+
+	-o, -output string
+		File to write the profile to. It is written as YAML if the file ends in .yaml or .yml and JSON otherwise.
+		Required
+
+	-v int
+		Log level verbosity for klog. Use 100 for logging all messages or leave blank for none
+
+At least one of the kubeconfig flags must be provided.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/requirement/gather"
+	"k8s.io/klog/v2"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	help        bool
+	output      string
+	kubeconfigs = make(map[string]*string)
+)
+
+//nolint:gochecknoinits // This is a main package so init is fine.
+func init() {
+	const (
+		helpUsage    = "Print this help message"
+		hubUsage     = "Path to the kubeconfig of the hub cluster"
+		spoke1Usage  = "Path to the kubeconfig of the first spoke cluster"
+		spoke2Usage  = "Path to the kubeconfig of the second spoke cluster"
+		defaultUsage = "Path to the kubeconfig of the cluster used by single cluster suites"
+		outputUsage  = "File to write the profile to, as YAML if it ends in .yaml or .yml and JSON otherwise. Required"
+
+		defaultHelp       = false
+		defaultOutput     = ""
+		defaultKubeconfig = ""
+
+		shorthand = " (shorthand)"
+	)
+
+	klog.InitFlags(nil)
+	klog.EnableContextualLogging(true)
+	logf.SetLogger(logr.Discard())
+
+	_ = flag.Set("logtostderr", "true")
+
+	flag.BoolVar(&help, "help", defaultHelp, helpUsage)
+	flag.BoolVar(&help, "h", defaultHelp, helpUsage+shorthand)
+
+	// The flags are named after the clusters so the kubeconfigs can be looked up by the names suites use.
+	kubeconfigs[requirement.Hub] = flag.String(requirement.Hub, defaultKubeconfig, hubUsage)
+	kubeconfigs[requirement.Spoke1] = flag.String(requirement.Spoke1, defaultKubeconfig, spoke1Usage)
+	kubeconfigs[requirement.Spoke2] = flag.String(requirement.Spoke2, defaultKubeconfig, spoke2Usage)
+	kubeconfigs[requirement.Default] = flag.String(requirement.Default, defaultKubeconfig, defaultUsage)
+
+	flag.StringVar(&output, "output", defaultOutput, outputUsage)
+	flag.StringVar(&output, "o", defaultOutput, outputUsage+shorthand)
+}
+
+func main() {
+	flag.Parse()
+
+	if help {
+		flag.Usage()
+
+		return
+	}
+
+	err := snapshot()
+	if err != nil {
+		klog.Errorf("Failed to snapshot cluster facts: %v", err)
+
+		os.Exit(1)
+	}
+}
+
+// snapshot gathers the facts from every cluster with a kubeconfig and writes them to the output file.
+func snapshot() error {
+	if output == "" {
+		return fmt.Errorf("-output must be provided")
+	}
+
+	generalConfig, err := config.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load general config: %w", err)
+	}
+
+	clusterClients := make(map[string]*clients.Settings)
+
+	for _, name := range slices.Sorted(maps.Keys(kubeconfigs)) {
+		kubeconfig := *kubeconfigs[name]
+		if kubeconfig == "" {
+			continue
+		}
+
+		client := clients.New(kubeconfig)
+		if client == nil {
+			return fmt.Errorf("failed to create client for cluster %s from kubeconfig %q", name, kubeconfig)
+		}
+
+		clusterClients[name] = client
+	}
+
+	if len(clusterClients) == 0 {
+		return fmt.Errorf("at least one of -%s, -%s, -%s, or -%s must be provided",
+			requirement.Hub, requirement.Spoke1, requirement.Spoke2, requirement.Default)
+	}
+
+	facts := gather.GatherAll(clusterClients, gather.ConfigOptions(generalConfig))
+
+	for _, name := range slices.Sorted(maps.Keys(facts.Clusters)) {
+		gatherErrors := facts.Clusters[name].Errors
+
+		for _, fact := range slices.Sorted(maps.Keys(gatherErrors)) {
+			klog.Warningf("Failed to gather %s facts from cluster %s: %s", fact, name, gatherErrors[fact])
+		}
+	}
+
+	err = requirement.SaveFacts(output, facts)
+	if err != nil {
+		return err
+	}
+
+	klog.Infof("Wrote %s", output)
+
+	return nil
+}
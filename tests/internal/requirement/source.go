@@ -0,0 +1,32 @@
+package requirement
+
+import "sync"
+
+// Source provides the facts about clusters that requirements are checked against.
+type Source interface {
+	// ClusterFacts returns the facts about the cluster with the provided name, such as Spoke1. An error is returned
+	// if the cluster is not known to the source.
+	ClusterFacts(cluster string) (*ClusterFacts, error)
+}
+
+var (
+	sourceMutex   sync.RWMutex
+	defaultSource Source
+)
+
+// SetSource sets the source that DefaultSource returns. Suites set it when initializing their clients, usually to a
+// gather.LiveSource, so specs using the skip package check requirements against the clusters they run on.
+func SetSource(source Source) {
+	sourceMutex.Lock()
+	defer sourceMutex.Unlock()
+
+	defaultSource = source
+}
+
+// DefaultSource returns the source set by SetSource, or nil if no source has been set.
+func DefaultSource() Source {
+	sourceMutex.RLock()
+	defer sourceMutex.RUnlock()
+
+	return defaultSource
+}
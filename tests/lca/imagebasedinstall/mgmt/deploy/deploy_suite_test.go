@@ -26,6 +26,10 @@ func TestDeploy(t *testing.T) {
 	RunSpecs(t, "Deploy Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	seedClusterInfo, err := seedimage.GetContent(APIClient, MGMTConfig.SeedImage)
 	Expect(err).NotTo(HaveOccurred(), "error getting seed image info")
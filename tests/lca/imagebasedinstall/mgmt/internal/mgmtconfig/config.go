@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedinstall/internal/ibiconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedinstall/mgmt/internal/mgmtparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/seedimage"
@@ -100,6 +101,8 @@ func NewMGMTConfig() *MGMTConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ibi-mgmt", &mgmtConfig)
+
 	if mgmtConfig.ClusterInfoPath != "" {
 		content, err := os.ReadFile(mgmtConfig.ClusterInfoPath)
 		if err != nil {
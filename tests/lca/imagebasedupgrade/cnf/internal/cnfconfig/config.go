@@ -6,6 +6,7 @@ import (
 	"runtime"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/cnf/internal/cnfparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/ibuconfig"
 	"gopkg.in/yaml.v2"
@@ -67,6 +68,8 @@ func NewCNFConfig() *CNFConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ibu-cnf", &cnfConfig)
+
 	return &cnfConfig
 }
 
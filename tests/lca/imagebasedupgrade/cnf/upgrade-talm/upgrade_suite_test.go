@@ -24,6 +24,10 @@ func TestUpgrade(t *testing.T) {
 	RunSpecs(t, "Upgrade Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	// should have top level check to skip all tests in case test env vars unavailable.
 	By("Checking if target hub cluster has valid apiClient")
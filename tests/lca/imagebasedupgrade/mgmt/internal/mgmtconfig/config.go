@@ -2,6 +2,7 @@ package mgmtconfig
 
 import (
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/internal/ibuconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/imagebasedupgrade/mgmt/internal/mgmtparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/seedimage"
@@ -36,5 +37,7 @@ func NewMGMTConfig() *MGMTConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ibu-mgmt", &mgmtConfig)
+
 	return &mgmtConfig
 }
@@ -29,6 +29,10 @@ func TestNegative(t *testing.T) {
 	RunSpecs(t, "Negative Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	var err error
 
@@ -26,6 +26,10 @@ func TestUpgrade(t *testing.T) {
 	RunSpecs(t, "Upgrade Suite", Label(tsparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	var err error
 
@@ -2,6 +2,7 @@ package ipcconfig
 
 import (
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/internal/lcaconfig"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/lca/ipchange/internal/ipcparams"
 	"k8s.io/klog/v2"
@@ -40,5 +41,7 @@ func NewIPCConfig() *IPCConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ipc", &ipcConfig)
+
 	return &ipcConfig
 }
@@ -30,6 +30,10 @@ func TestIPChange(t *testing.T) {
 	RunSpecs(t, "IPChange Suite", Label(tsparams.LabelSuite), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Checking if API client is valid")
 
@@ -34,6 +34,8 @@ func NewSeedGenerationConfig() *SeedGenerationConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("seedgeneration", &seedConfig)
+
 	return &seedConfig
 }
 
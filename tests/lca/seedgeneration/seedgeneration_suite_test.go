@@ -30,6 +30,10 @@ func TestSeedGeneration(t *testing.T) {
 	RunSpecs(t, "Seed Generation Suite", Label(tsparams.LabelSuite), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By("Checking if target sno cluster has valid apiClient")
 
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/ocp/internal/ocpconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -87,6 +88,8 @@ func NewSriovOcpConfig() *SriovOcpConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ocp-sriov", &sriovOcpConf)
+
 	return &sriovOcpConf
 }
 
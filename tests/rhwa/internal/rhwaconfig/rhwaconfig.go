@@ -47,6 +47,8 @@ func NewRHWAConfig() *RHWAConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("rhwa", &rhwaConf)
+
 	return &rhwaConf
 }
 
@@ -23,6 +23,10 @@ func TestMDR(t *testing.T) {
 	RunSpecs(t, "MDR", Label(mdrparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, mdrparams.ReporterNamespacesToDump, mdrparams.ReporterCRDsToDump)
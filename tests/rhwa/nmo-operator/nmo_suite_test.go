@@ -23,6 +23,10 @@ func TestNMO(t *testing.T) {
 	RunSpecs(t, "NMO", Label(nmoparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, nmoparams.ReporterNamespacesToDump, nmoparams.ReporterCRDsToDump)
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/reporter"
 	_ "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/diskencryption/tests"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/diskencryption/tsparams"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsinittools"
@@ -21,3 +22,7 @@ func TestTPM2(t *testing.T) {
 
 	RunSpecs(t, "RAN tpm2 tests", Label(tsparams.Labels...), reporterConfig)
 }
+
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
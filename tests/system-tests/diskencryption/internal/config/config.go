@@ -9,6 +9,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/diskencryption/tsparams"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
 	"gopkg.in/yaml.v2"
@@ -71,6 +72,8 @@ func NewDiskEncryptionConfig() *DiskEncrptionConfig {
 			WithSSHUser(diskEncryptionConf.BMCUsername, diskEncryptionConf.BMCPassword)
 	}
 
+	config.RegisterSuiteConfig("diskencryption", &diskEncryptionConf)
+
 	return &diskEncryptionConf
 }
 
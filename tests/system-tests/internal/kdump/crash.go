@@ -0,0 +1,199 @@
+package kdump
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CrashCause is the cause of a kernel crash as identified from its vmcore-dmesg.
+type CrashCause string
+
+const (
+	// CrashCauseSysrq is a crash triggered through the sysrq-trigger crash command.
+	CrashCauseSysrq CrashCause = "sysrq"
+	// CrashCauseNMI is a crash caused by a non-maskable interrupt, such as the ones injected through the BMC.
+	CrashCauseNMI CrashCause = "nmi"
+	// CrashCauseUnknown is any other crash, such as a panic caused by a kernel bug.
+	CrashCauseUnknown CrashCause = "unknown"
+)
+
+// maxSummaryFrames is the number of call trace frames included in crash summaries.
+const maxSummaryFrames = 8
+
+var (
+	timestampRegex = regexp.MustCompile(`^\[\s*\d+\.\d+\]\s?`)
+	panicRegex     = regexp.MustCompile(`Kernel panic - not syncing: (.*)$`)
+	taskRegex      = regexp.MustCompile(`CPU: (\d+) (?:UID: \d+ )?PID: (\d+) Comm: (\S+)(?:.*? (\d+\.\d+\.\d+-\S+))?`)
+	ripRegex       = regexp.MustCompile(`^RIP: [0-9a-f]+:(\S+)`)
+	sysrqRegex     = regexp.MustCompile(`(?i)sysrq\s*: trigger a crash|sysrq triggered crash|sysrq_handle_crash`)
+	nmiReasonRegex = regexp.MustCompile(`NMI received for unknown reason|` +
+		`NMI: (IOCK|PCI system) error|NMI: Not continuing`)
+	crashStartRegex = regexp.MustCompile(`Kernel panic - not syncing|^BUG: |^Oops: |` + sysrqRegex.String() + `|` +
+		nmiReasonRegex.String())
+)
+
+// CrashReport is the analysis of the vmcore-dmesg of a kernel crash.
+type CrashReport struct {
+	// Directory is the crash directory the report was retrieved from, when retrieved from a node.
+	Directory string
+	// Files maps the name of the files in the crash directory to their size in bytes.
+	Files map[string]int64
+	// PanicReason is the message of the kernel panic, such as "sysrq triggered crash".
+	PanicReason string
+	// CPU is the CPU the crash occurred on, or -1 if unknown.
+	CPU int
+	// PID is the PID of the task running on the crashing CPU, or -1 if unknown.
+	PID int
+	// Task is the command name of the task running on the crashing CPU.
+	Task string
+	// KernelVersion is the version of the crashed kernel.
+	KernelVersion string
+	// RIP is the function the instruction pointer was in when the crash occurred.
+	RIP string
+	// CallTrace holds the frames of the call trace of the crash, innermost first.
+	CallTrace []string
+	// Cause is the cause of the crash identified from its messages.
+	Cause CrashCause
+}
+
+// ParseVmcoreDmesg analyzes the content of a vmcore-dmesg.txt file. Only the first crash found in dmesg is analyzed,
+// the messages logged before it being ignored. An error is returned if dmesg does not contain a crash.
+func ParseVmcoreDmesg(dmesg string) (*CrashReport, error) {
+	lines := strings.Split(strings.ReplaceAll(dmesg, "\r\n", "\n"), "\n")
+	for index, line := range lines {
+		lines[index] = timestampRegex.ReplaceAllString(strings.TrimRight(line, " "), "")
+	}
+
+	start := -1
+
+	for index, line := range lines {
+		if crashStartRegex.MatchString(line) {
+			start = index
+
+			break
+		}
+	}
+
+	if start < 0 {
+		return nil, fmt.Errorf("no kernel crash found in vmcore-dmesg")
+	}
+
+	report := &CrashReport{CPU: -1, PID: -1, Cause: CrashCauseUnknown}
+	isSysrq, isNMI := false, false
+
+	for index := start; index < len(lines); index++ {
+		line := lines[index]
+
+		isSysrq = isSysrq || sysrqRegex.MatchString(line)
+		isNMI = isNMI || nmiReasonRegex.MatchString(line)
+
+		if matches := panicRegex.FindStringSubmatch(line); matches != nil && report.PanicReason == "" {
+			report.PanicReason = matches[1]
+		}
+
+		if matches := taskRegex.FindStringSubmatch(line); matches != nil && report.Task == "" {
+			report.CPU, _ = strconv.Atoi(matches[1])
+			report.PID, _ = strconv.Atoi(matches[2])
+			report.Task = matches[3]
+			report.KernelVersion = matches[4]
+		}
+
+		if matches := ripRegex.FindStringSubmatch(line); matches != nil && report.RIP == "" {
+			report.RIP = matches[1]
+		}
+
+		if strings.TrimSpace(line) == "Call Trace:" && report.CallTrace == nil {
+			report.CallTrace = parseCallTrace(lines[index+1:])
+		}
+	}
+
+	switch {
+	case isSysrq:
+		report.Cause = CrashCauseSysrq
+	case isNMI || strings.Contains(report.PanicReason, "NMI"):
+		report.Cause = CrashCauseNMI
+	default:
+	}
+
+	return report, nil
+}
+
+// Verify returns an error including the crash summary if the cause of the crash is not expectedCause.
+func (report *CrashReport) Verify(expectedCause CrashCause) error {
+	if report.Cause != expectedCause {
+		return fmt.Errorf("expected a %s crash but found a %s crash:\n%s", expectedCause, report.Cause, report.Summary())
+	}
+
+	return nil
+}
+
+// Summary returns a concise human readable description of the crash, suitable for test reports.
+func (report *CrashReport) Summary() string {
+	var builder strings.Builder
+
+	if report.Directory != "" {
+		fmt.Fprintf(&builder, "Crash directory: %s\n", report.Directory)
+	}
+
+	fmt.Fprintf(&builder, "Cause: %s\n", report.Cause)
+	fmt.Fprintf(&builder, "Panic reason: %s\n", valueOrUnknown(report.PanicReason))
+
+	if report.CPU >= 0 {
+		fmt.Fprintf(&builder, "CPU: %d, PID: %d, Task: %s\n", report.CPU, report.PID, report.Task)
+	}
+
+	if report.KernelVersion != "" {
+		fmt.Fprintf(&builder, "Kernel: %s\n", report.KernelVersion)
+	}
+
+	if report.RIP != "" {
+		fmt.Fprintf(&builder, "RIP: %s\n", report.RIP)
+	}
+
+	if len(report.CallTrace) > 0 {
+		builder.WriteString("Call trace:\n")
+
+		for index, frame := range report.CallTrace {
+			if index == maxSummaryFrames {
+				fmt.Fprintf(&builder, "  ... %d more frames\n", len(report.CallTrace)-maxSummaryFrames)
+
+				break
+			}
+
+			fmt.Fprintf(&builder, "  %s\n", frame)
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// parseCallTrace returns the frames of the call trace starting at the first line of lines. Context markers such as
+// <TASK> and <NMI> are skipped and the trace ends with the first line that is not indented.
+func parseCallTrace(lines []string) []string {
+	frames := []string{}
+
+	for _, line := range lines {
+		if !strings.HasPrefix(line, " ") {
+			break
+		}
+
+		frame := strings.TrimSpace(line)
+		if frame == "" || strings.HasPrefix(frame, "<") {
+			continue
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+
+	return value
+}
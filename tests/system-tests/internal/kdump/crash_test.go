@@ -0,0 +1,183 @@
+package kdump
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sysrqDmesg = `[ 1234.500000] IPv6: ADDRCONF(NETDEV_CHANGE): veth1: link becomes ready
+[ 1240.123456] sysrq: Trigger a crash
+[ 1240.123470] Kernel panic - not syncing: sysrq triggered crash
+[ 1240.123500] CPU: 3 PID: 45678 Comm: tee Kdump: loaded Not tainted 5.14.0-427.13.1.el9_4.x86_64 #1
+[ 1240.123520] Hardware name: Dell Inc. PowerEdge R750/0PJ80M, BIOS 1.8.2 09/14/2022
+[ 1240.123540] Call Trace:
+[ 1240.123550]  <TASK>
+[ 1240.123560]  dump_stack_lvl+0x34/0x48
+[ 1240.123570]  panic+0x102/0x2d4
+[ 1240.123580]  sysrq_handle_crash+0x16/0x20
+[ 1240.123590]  __handle_sysrq.cold+0x7a/0x116
+[ 1240.123600]  write_sysrq_trigger+0x24/0x40
+[ 1240.123610]  </TASK>
+[ 1240.123620] Kernel Offset: 0x2e000000 from 0xffffffff81000000
+`
+
+const legacySysrqDmesg = `[  301.000001] SysRq : Trigger a crash
+[  301.000100] BUG: unable to handle kernel NULL pointer dereference at 0000000000000000
+[  301.000200] CPU: 0 PID: 9012 Comm: sh Kdump: loaded Tainted: G 4.18.0-372.el8.x86_64 #1
+[  301.000300] RIP: 0010:sysrq_handle_crash+0x12/0x20
+[  301.000400] Call Trace:
+[  301.000500]  __handle_sysrq.cold.12+0x7a/0x111
+[  301.000600]  write_sysrq_trigger+0x2b/0x30
+[  301.000700] Kernel panic - not syncing: Fatal exception
+`
+
+const nmiDmesg = `[ 5000.000001] Uhhuh. NMI received for unknown reason 3d on CPU 7.
+[ 5000.000010] Do you have a strange power saving mode enabled?
+[ 5000.000020] Kernel panic - not syncing: NMI: Not continuing
+[ 5000.000030] CPU: 7 PID: 0 Comm: swapper/7 Kdump: loaded Not tainted 5.14.0-427.13.1.el9_4.x86_64 #1
+[ 5000.000040] Call Trace:
+[ 5000.000050]  <NMI>
+[ 5000.000060]  dump_stack_lvl+0x34/0x48
+[ 5000.000070]  panic+0x102/0x2d4
+[ 5000.000080]  nmi_panic.cold+0xc/0xc
+[ 5000.000090]  unknown_nmi_error.cold+0x3d/0x3d
+[ 5000.000100]  default_do_nmi+0x49/0x110
+[ 5000.000110]  exc_nmi+0x102/0x130
+[ 5000.000120]  end_repeat_nmi+0x16/0x67
+[ 5000.000130]  </NMI>
+[ 5000.000140]  <TASK>
+[ 5000.000150]  cpuidle_enter_state+0xd9/0x3f0
+[ 5000.000160]  cpuidle_enter+0x29/0x40
+[ 5000.000170]  </TASK>
+`
+
+const unrelatedDmesg = `[  800.000001] NMI watchdog: Watchdog detected hard LOCKUP on cpu 5
+[  800.000010] Kernel panic - not syncing: Hard LOCKUP
+[  800.000020] CPU: 5 PID: 3456 Comm: irq/155-ice Kdump: loaded Tainted: G OE 5.14.0-427.el9.x86_64 #1
+[  800.000030] Call Trace:
+[  800.000040]  ice_clean_rx_irq+0x1f0/0x600 [ice]
+[  800.000050] Kernel Offset: disabled
+`
+
+func TestParseVmcoreDmesg(t *testing.T) {
+	testCases := []struct {
+		dmesg          string
+		expectedCause  CrashCause
+		expectedReason string
+		expectedCPU    int
+		expectedTask   string
+		expectedRIP    string
+		expectedFrames []string
+	}{
+		{
+			dmesg:          sysrqDmesg,
+			expectedCause:  CrashCauseSysrq,
+			expectedReason: "sysrq triggered crash",
+			expectedCPU:    3,
+			expectedTask:   "tee",
+			expectedFrames: []string{"dump_stack_lvl+0x34/0x48", "panic+0x102/0x2d4", "sysrq_handle_crash+0x16/0x20",
+				"__handle_sysrq.cold+0x7a/0x116", "write_sysrq_trigger+0x24/0x40"},
+		},
+		{
+			dmesg:          legacySysrqDmesg,
+			expectedCause:  CrashCauseSysrq,
+			expectedReason: "Fatal exception",
+			expectedCPU:    0,
+			expectedTask:   "sh",
+			expectedRIP:    "sysrq_handle_crash+0x12/0x20",
+			expectedFrames: []string{"__handle_sysrq.cold.12+0x7a/0x111", "write_sysrq_trigger+0x2b/0x30"},
+		},
+		{
+			dmesg:          nmiDmesg,
+			expectedCause:  CrashCauseNMI,
+			expectedReason: "NMI: Not continuing",
+			expectedCPU:    7,
+			expectedTask:   "swapper/7",
+			expectedFrames: []string{"dump_stack_lvl+0x34/0x48", "panic+0x102/0x2d4", "nmi_panic.cold+0xc/0xc",
+				"unknown_nmi_error.cold+0x3d/0x3d", "default_do_nmi+0x49/0x110", "exc_nmi+0x102/0x130",
+				"end_repeat_nmi+0x16/0x67", "cpuidle_enter_state+0xd9/0x3f0", "cpuidle_enter+0x29/0x40"},
+		},
+		{
+			dmesg:          unrelatedDmesg,
+			expectedCause:  CrashCauseUnknown,
+			expectedReason: "Hard LOCKUP",
+			expectedCPU:    5,
+			expectedTask:   "irq/155-ice",
+			expectedFrames: []string{"ice_clean_rx_irq+0x1f0/0x600 [ice]"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		report, err := ParseVmcoreDmesg(testCase.dmesg)
+		assert.Nil(t, err)
+
+		assert.Equal(t, testCase.expectedCause, report.Cause)
+		assert.Equal(t, testCase.expectedReason, report.PanicReason)
+		assert.Equal(t, testCase.expectedCPU, report.CPU)
+		assert.Equal(t, testCase.expectedTask, report.Task)
+		assert.Equal(t, testCase.expectedRIP, report.RIP)
+		assert.Equal(t, testCase.expectedFrames, report.CallTrace)
+	}
+
+	_, err := ParseVmcoreDmesg("[    0.000000] Linux version 5.14.0-427.el9.x86_64\n")
+	assert.NotNil(t, err)
+}
+
+func TestCrashReportVerify(t *testing.T) {
+	report, err := ParseVmcoreDmesg(unrelatedDmesg)
+	assert.Nil(t, err)
+
+	err = report.Verify(CrashCauseNMI)
+	assert.ErrorContains(t, err, "expected a nmi crash but found a unknown crash")
+	assert.ErrorContains(t, err, "Panic reason: Hard LOCKUP")
+
+	report, err = ParseVmcoreDmesg(nmiDmesg)
+	assert.Nil(t, err)
+	assert.Nil(t, report.Verify(CrashCauseNMI))
+	assert.NotNil(t, report.Verify(CrashCauseSysrq))
+}
+
+func TestCrashReportSummary(t *testing.T) {
+	report, err := ParseVmcoreDmesg(nmiDmesg)
+	assert.Nil(t, err)
+
+	report.Directory = "/var/crash/127.0.0.1-2025-01-01-10:00:00"
+
+	summary := report.Summary()
+	assert.Equal(t, `Crash directory: /var/crash/127.0.0.1-2025-01-01-10:00:00
+Cause: nmi
+Panic reason: NMI: Not continuing
+CPU: 7, PID: 0, Task: swapper/7
+Kernel: 5.14.0-427.13.1.el9_4.x86_64
+Call trace:
+  dump_stack_lvl+0x34/0x48
+  panic+0x102/0x2d4
+  nmi_panic.cold+0xc/0xc
+  unknown_nmi_error.cold+0x3d/0x3d
+  default_do_nmi+0x49/0x110
+  exc_nmi+0x102/0x130
+  end_repeat_nmi+0x16/0x67
+  cpuidle_enter_state+0xd9/0x3f0
+  ... 1 more frames`, summary)
+	assert.False(t, strings.HasSuffix(summary, "\n"))
+}
+
+func TestParseCrashFiles(t *testing.T) {
+	files := parseCrashFiles("vmcore 123456789\nvmcore-dmesg.txt 81920\n\nbroken line here\nkexec-dmesg.log abc\n")
+	assert.Equal(t, map[string]int64{"vmcore": 123456789, "vmcore-dmesg.txt": 81920}, files)
+}
+
+func TestParseLatestCrashDirectory(t *testing.T) {
+	triggeredAt := time.Unix(1760000000, 0)
+	output := "1759990000.5 127.0.0.1-2025-10-09-06:06:40\n" +
+		"1760000100.25 127.0.0.1-2025-10-09-08:55:00\n" +
+		"1760000200.75 127.0.0.1-2025-10-09-08:56:40\n" +
+		"\nbroken line here\nabc 127.0.0.1-2025-10-09-09:00:00\n"
+
+	assert.Equal(t, "127.0.0.1-2025-10-09-08:56:40", parseLatestCrashDirectory(output, triggeredAt))
+	assert.Empty(t, parseLatestCrashDirectory("1759990000.5 127.0.0.1-2025-10-09-06:06:40\n", triggeredAt))
+	assert.Empty(t, parseLatestCrashDirectory("", triggeredAt))
+}
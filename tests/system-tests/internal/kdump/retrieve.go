@@ -0,0 +1,185 @@
+package kdump
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
+)
+
+const (
+	// CrashDirectory is the directory kdump saves the crash dumps to on the nodes.
+	CrashDirectory = "/var/crash"
+	// VmcoreDmesgFile is the name of the file holding the kernel log of the crashed kernel in a crash directory.
+	VmcoreDmesgFile = "vmcore-dmesg.txt"
+	// dmesgTailLines is the number of lines of the vmcore-dmesg retrieved from the nodes, the crash being logged last.
+	dmesgTailLines = 2000
+)
+
+// RetrieveCrashReport waits for kdump to save a crash on nodeName then retrieves and analyzes the vmcore-dmesg.txt
+// of the latest crash directory in /var/crash. Crash directories last modified before triggeredAt are left over from
+// earlier crashes and are ignored.
+func RetrieveCrashReport(
+	ctx context.Context,
+	nodeName string,
+	triggeredAt time.Time,
+	logLevel klog.Level,
+	pollingInterval,
+	timeout time.Duration,
+) (*CrashReport, error) {
+	klog.V(logLevel).Infof("Retrieving the latest kernel crash report from node %q", nodeName)
+
+	var (
+		crashDirectory string
+		files          map[string]int64
+	)
+
+	err := wait.PollUntilContextTimeout(ctx, pollingInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			var err error
+
+			crashDirectory, err = latestCrashDirectory(nodeName, triggeredAt)
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to list crash directories on node %q: %v", nodeName, err)
+
+				return false, nil
+			}
+
+			if crashDirectory == "" {
+				klog.V(logLevel).Infof("No crash directory since %s found yet on node %q",
+					triggeredAt.Format(time.RFC3339), nodeName)
+
+				return false, nil
+			}
+
+			files, err = listCrashFiles(nodeName, crashDirectory)
+			if err != nil {
+				klog.V(logLevel).Infof("Failed to list files of %s on node %q: %v", crashDirectory, nodeName, err)
+
+				return false, nil
+			}
+
+			if _, ok := files[VmcoreDmesgFile]; !ok {
+				klog.V(logLevel).Infof("%s not saved yet in %s on node %q", VmcoreDmesgFile, crashDirectory, nodeName)
+
+				return false, nil
+			}
+
+			return true, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("no crash with a %s found in %s on node %q: %w",
+			VmcoreDmesgFile, CrashDirectory, nodeName, err)
+	}
+
+	dmesgPath := path.Join(crashDirectory, VmcoreDmesgFile)
+
+	dmesg, err := remote.ExecuteOnNodeWithDebugPod(
+		[]string{"chroot", "/rootfs", "tail", "-n", strconv.Itoa(dmesgTailLines), dmesgPath}, nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s on node %q: %w", dmesgPath, nodeName, err)
+	}
+
+	report, err := ParseVmcoreDmesg(dmesg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze %s on node %q: %w", dmesgPath, nodeName, err)
+	}
+
+	report.Directory = crashDirectory
+	report.Files = files
+
+	klog.V(logLevel).Infof("Kernel crash report of node %q:\n%s", nodeName, report.Summary())
+
+	return report, nil
+}
+
+// latestCrashDirectory returns the path of the most recent crash directory on nodeName modified at or after since, or
+// an empty string if there is none.
+func latestCrashDirectory(nodeName string, since time.Time) (string, error) {
+	output, err := remote.ExecuteOnNodeWithDebugPod([]string{"chroot", "/rootfs", "sh", "-c",
+		fmt.Sprintf("find %s -mindepth 1 -maxdepth 1 -type d -printf '%%T@ %%f\\n'", CrashDirectory)}, nodeName)
+	if err != nil {
+		return "", err
+	}
+
+	directory := parseLatestCrashDirectory(output, since)
+	if directory == "" {
+		return "", nil
+	}
+
+	return path.Join(CrashDirectory, directory), nil
+}
+
+// parseLatestCrashDirectory returns the name of the latest crash directory modified at or after since, from the
+// modification times in seconds since the epoch and names listed one per line. The modification time is used to skip
+// older crashes since the date kdump puts in the directory names has no time zone, but kdump still names the
+// directories after the crash time so the latest one sorts last.
+func parseLatestCrashDirectory(output string, since time.Time) string {
+	var directories []string
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		seconds, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+
+		if time.Unix(0, int64(seconds*float64(time.Second))).Before(since) {
+			continue
+		}
+
+		directories = append(directories, fields[1])
+	}
+
+	if len(directories) == 0 {
+		return ""
+	}
+
+	slices.Sort(directories)
+
+	return directories[len(directories)-1]
+}
+
+// listCrashFiles returns the size in bytes of the files in crashDirectory on nodeName, mapped by file name.
+func listCrashFiles(nodeName, crashDirectory string) (map[string]int64, error) {
+	output, err := remote.ExecuteOnNodeWithDebugPod([]string{"chroot", "/rootfs", "sh", "-c",
+		fmt.Sprintf("find %s -mindepth 1 -maxdepth 1 -type f -printf '%%f %%s\\n'", crashDirectory)}, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCrashFiles(output), nil
+}
+
+// parseCrashFiles parses the name and size of files listed one per line.
+func parseCrashFiles(output string) map[string]int64 {
+	files := map[string]int64{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		files[fields[0]] = size
+	}
+
+	return files
+}
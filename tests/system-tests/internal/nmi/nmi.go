@@ -6,15 +6,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/stmcginnis/gofish/redfish"
-
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 
-	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
 )
 
@@ -73,18 +71,33 @@ func TriggerNMIViaRedfish(
 	pollingInterval,
 	timeout time.Duration,
 ) error {
-	klog.V(logLevel).Infof("Triggering NMI via Redfish on %q", nodeName)
 	klog.V(logLevel).Infof("Creating BMC client for node %s", nodeName)
 
-	bmcClient := bmc.New(bmcCredentials.BMCAddress).
-		WithRedfishUser(bmcCredentials.Username, bmcCredentials.Password).
-		WithRedfishTimeout(6 * time.Minute)
+	controller := powercontrol.NewRedfish(powercontrol.Config{
+		Type:     powercontrol.TypeRedfish,
+		Address:  bmcCredentials.BMCAddress,
+		Username: bmcCredentials.Username,
+		Password: bmcCredentials.Password,
+	}).WithTimeout(6 * time.Minute)
+
+	return TriggerNMI(ctx, nodeName, controller, logLevel, pollingInterval, timeout)
+}
 
-	klog.V(logLevel).Infof("Sending NMI reset action to %q", nodeName)
+// TriggerNMI triggers an NMI (Non-Maskable Interrupt) on a node through its power controller, retrying until the BMC
+// accepts the request. This will cause a kernel crash if kdump is configured, generating a vmcore dump.
+func TriggerNMI(
+	ctx context.Context,
+	nodeName string,
+	controller powercontrol.Controller,
+	logLevel klog.Level,
+	pollingInterval,
+	timeout time.Duration,
+) error {
+	klog.V(logLevel).Infof("Sending NMI to %q", nodeName)
 
 	err := wait.PollUntilContextTimeout(ctx, pollingInterval, timeout, true,
 		func(ctx context.Context) (bool, error) {
-			if err := bmcClient.SystemResetAction(redfish.NmiResetType); err != nil {
+			if err := controller.NMI(); err != nil {
 				klog.V(logLevel).Infof("Failed to trigger NMI on %s -> %v", nodeName, err)
 
 				return false, nil
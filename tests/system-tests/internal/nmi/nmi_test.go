@@ -0,0 +1,65 @@
+package nmi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/redfishmock"
+	"github.com/stmcginnis/gofish/redfish"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerNMIViaRedfish(t *testing.T) {
+	testCases := []struct {
+		password      string
+		failures      int
+		powerState    redfish.PowerState
+		expectError   bool
+		expectedNMIs  int
+		expectedCalls int
+	}{
+		{password: "password", powerState: redfish.OnPowerState, expectedNMIs: 1, expectedCalls: 1},
+		{password: "password", failures: 2, powerState: redfish.OnPowerState, expectedNMIs: 1, expectedCalls: 1},
+		{password: "password", failures: 100, powerState: redfish.OnPowerState, expectError: true},
+		{password: "password", powerState: redfish.OffPowerState, expectError: true},
+		{password: "wrong", powerState: redfish.OnPowerState, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		server := redfishmock.NewServer("admin", "password")
+		server.SetPowerState(testCase.powerState)
+		server.FailNext(redfishmock.RouteReset, http.StatusServiceUnavailable, testCase.failures)
+
+		err := TriggerNMIViaRedfish(context.TODO(), "node-0", BMCCredentials{
+			BMCAddress: server.Address(),
+			Username:   "admin",
+			Password:   testCase.password,
+		}, 100, 10*time.Millisecond, 500*time.Millisecond)
+
+		assert.Equal(t, testCase.expectError, err != nil)
+		assert.Equal(t, testCase.expectedNMIs, server.NMICount())
+		assert.Len(t, server.Resets(), testCase.expectedCalls)
+
+		server.Close()
+	}
+}
+
+func TestTriggerNMI(t *testing.T) {
+	fake := powercontrol.NewFake(powercontrol.PowerStateOn).
+		FailNext(powercontrol.OperationNMI, context.DeadlineExceeded, context.DeadlineExceeded)
+
+	err := TriggerNMI(context.TODO(), "node-0", fake, 100, time.Millisecond, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, fake.NMICount())
+	assert.Len(t, fake.Calls(), 3)
+
+	fake = powercontrol.NewFake(powercontrol.PowerStateOn).
+		FailNext(powercontrol.OperationNMI, context.DeadlineExceeded, context.DeadlineExceeded)
+
+	err = TriggerNMI(context.TODO(), "node-0", fake, 100, 10*time.Millisecond, 15*time.Millisecond)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, fake.NMICount())
+}
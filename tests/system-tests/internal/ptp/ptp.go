@@ -3,12 +3,16 @@ package ptp
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -167,3 +171,43 @@ func ValidatePTPStatus(apiClient *clients.Settings, timeInterval time.Duration)
 
 	return ptpSync, nil
 }
+
+// SaveDaemonLogs saves the logs written since startTime by every linuxptp daemon pod to a file in outputDir, named
+// after the node of the pod. It returns the paths of the files keyed by node name.
+func SaveDaemonLogs(apiClient *clients.Settings, startTime time.Time, outputDir string) (map[string]string, error) {
+	podList, err := pod.List(apiClient, ptpNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PTP pod list, %w", err)
+	}
+
+	logFiles := make(map[string]string)
+
+	for _, pod := range podList {
+		if !strings.Contains(pod.Object.Name, ptpLinuxPod) {
+			continue
+		}
+
+		ptpLog, err := pod.GetLogsWithOptions(&corev1.PodLogOptions{
+			Container: ptpLinuxContainer,
+			SinceTime: &metav1.Time{Time: startTime},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logs of PTP pod %s, %w", pod.Object.Name, err)
+		}
+
+		logFile := filepath.Join(outputDir, fmt.Sprintf("ptp_daemon_%s.log", pod.Object.Spec.NodeName))
+
+		err = os.WriteFile(logFile, ptpLog, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save logs of PTP pod %s, %w", pod.Object.Name, err)
+		}
+
+		logFiles[pod.Object.Spec.NodeName] = logFile
+	}
+
+	if len(logFiles) == 0 {
+		return nil, fmt.Errorf("no %s pods found in %s", ptpLinuxPod, ptpNamespace)
+	}
+
+	return logFiles, nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
@@ -24,7 +25,7 @@ import (
 	"k8s.io/klog/v2"
 )
 
-// HardRebootNode executes ipmitool chassis power cycle on a node.
+// HardRebootNode executes ipmitool chassis power cycle on a node from a privileged pod running on it.
 func HardRebootNode(nodeName string, nsName string) error {
 	err := setupHardRebootDeployment(nodeName, nsName)
 	if err != nil {
@@ -36,27 +37,35 @@ func HardRebootNode(nodeName string, nsName string) error {
 		return err
 	}
 
-	err = executeRebootCommand(ipmiPods)
+	err = PowerCycleNode(nodeName, powercontrol.NewIPMI(podIPMIRunner(ipmiPods[0])))
 	if err != nil {
 		return err
 	}
 
-	err = waitForNodeRebootCycle(nodeName)
+	err = cleanupRebootDeployment(nsName)
 	if err != nil {
 		return err
 	}
 
-	err = waitForAPIServerReady()
+	return nil
+}
+
+// PowerCycleNode power cycles a node through controller then waits for the node and the OpenShift API server to come
+// back.
+func PowerCycleNode(nodeName string, controller powercontrol.Controller) error {
+	klog.V(90).Infof("Power cycling node %s", nodeName)
+
+	err := controller.PowerCycle()
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to power cycle node %s: %w", nodeName, err)
 	}
 
-	err = cleanupRebootDeployment(nsName)
+	err = waitForNodeRebootCycle(nodeName)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return waitForAPIServerReady()
 }
 
 // setupHardRebootDeployment sets up the privileged deployment for hard reboot.
@@ -109,14 +118,18 @@ func prepareRebootResources(nodeName, nsName string) ([]*pod.Builder, error) {
 	return ipmiPods, nil
 }
 
-// executeRebootCommand executes the ipmitool power cycle command.
-func executeRebootCommand(ipmiPods []*pod.Builder) error {
-	cmdToExec := []string{"ipmitool", "chassis", "power", "cycle"}
+// podIPMIRunner returns a CommandRunner executing ipmitool in ipmiPod, which talks to the BMC of its node through the
+// in-band interface.
+func podIPMIRunner(ipmiPod *pod.Builder) powercontrol.CommandRunner {
+	return func(args ...string) (string, error) {
+		cmdToExec := append([]string{"ipmitool"}, args...)
 
-	klog.V(90).Infof("Exec cmd %v on pod %s", cmdToExec, ipmiPods[0].Definition.Name)
-	_, err := ipmiPods[0].ExecCommand(cmdToExec)
+		klog.V(90).Infof("Exec cmd %v on pod %s", cmdToExec, ipmiPod.Definition.Name)
 
-	return err
+		output, err := ipmiPod.ExecCommand(cmdToExec)
+
+		return output.String(), err
+	}
 }
 
 // waitForNodeRebootCycle waits for the node to go down and come back up.
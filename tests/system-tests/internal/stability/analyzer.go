@@ -0,0 +1,205 @@
+package stability
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EventKind is the kind of change an Event describes.
+type EventKind string
+
+const (
+	// EventChanged is the kind of event where the value of a metric changed between runs of a sampler.
+	EventChanged EventKind = "Changed"
+	// EventAppeared is the kind of event where a subject or metric was sampled for the first time after the first run
+	// of a sampler, such as when a pod is recreated with a new name.
+	EventAppeared EventKind = "Appeared"
+	// EventDisappeared is the kind of event where a subject or metric was no longer sampled, such as when a pod is
+	// deleted.
+	EventDisappeared EventKind = "Disappeared"
+)
+
+// Event is a change in the samples between consecutive runs of a sampler.
+type Event struct {
+	// Timestamp is the time of the sampler run where the change was first seen.
+	Timestamp time.Time
+	// Kind is the kind of change.
+	Kind EventKind
+	// Source is the name of the sampler that recorded the change.
+	Source string
+	// Subject is the object whose metric changed.
+	Subject string
+	// Metric is the name of the metric that changed.
+	Metric string
+	// Before is the sample from the earlier run. It is nil for EventAppeared.
+	Before *Sample
+	// After is the sample from the later run. It is nil for EventDisappeared.
+	After *Sample
+}
+
+// String returns a single-line description of the event.
+func (event Event) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%s %s %s %s/%s", event.Timestamp.Format(time.RFC3339), event.Kind, event.Source,
+		event.Subject, event.Metric)
+
+	if event.Before != nil {
+		fmt.Fprintf(&builder, " before=%q", event.Before.Value)
+	}
+
+	if event.After != nil {
+		fmt.Fprintf(&builder, " after=%q", event.After.Value)
+
+		if event.After.Detail != "" {
+			fmt.Fprintf(&builder, " detail=%q", event.After.Detail)
+		}
+	}
+
+	return builder.String()
+}
+
+// sampleKey identifies the samples of one metric of one subject from one source.
+type sampleKey struct {
+	subject string
+	metric  string
+}
+
+// sampleRound is the samples recorded by a single run of a sampler.
+type sampleRound struct {
+	timestamp time.Time
+	samples   map[sampleKey]Sample
+}
+
+// newSampleRound returns an empty sampleRound for the run of a sampler at timestamp.
+func newSampleRound(timestamp time.Time) *sampleRound {
+	return &sampleRound{timestamp: timestamp, samples: make(map[sampleKey]Sample)}
+}
+
+// Analyze compares the samples from each run of a sampler with those from the previous run of the same sampler and
+// returns an event for every change, ordered by time and then by source, subject, and metric. Each run of a sampler
+// starts at its round marker, so a run without samples still reports everything from the previous run as
+// disappeared. For samples recorded without round markers, a run is all the samples with the same source and
+// timestamp. Annotations are skipped.
+func Analyze(samples []Sample) []Event {
+	roundsBySource := make(map[string][]*sampleRound)
+
+	for _, sample := range samples {
+		switch sample.Source {
+		case SourceAnnotation:
+			continue
+		case SourceRound:
+			roundsBySource[sample.Subject] = append(roundsBySource[sample.Subject], newSampleRound(sample.Timestamp))
+
+			continue
+		}
+
+		rounds := roundsBySource[sample.Source]
+
+		if len(rounds) == 0 || !rounds[len(rounds)-1].timestamp.Equal(sample.Timestamp) {
+			rounds = append(rounds, newSampleRound(sample.Timestamp))
+			roundsBySource[sample.Source] = rounds
+		}
+
+		rounds[len(rounds)-1].samples[sampleKey{subject: sample.Subject, metric: sample.Metric}] = sample
+	}
+
+	var events []Event
+
+	for source, rounds := range roundsBySource {
+		for index := 1; index < len(rounds); index++ {
+			events = append(events, compareRounds(source, rounds[index-1], rounds[index])...)
+		}
+	}
+
+	slices.SortStableFunc(events, func(first, second Event) int {
+		return cmp.Or(
+			first.Timestamp.Compare(second.Timestamp),
+			cmp.Compare(first.Source, second.Source),
+			cmp.Compare(first.Subject, second.Subject),
+			cmp.Compare(first.Metric, second.Metric))
+	})
+
+	return events
+}
+
+// AnalyzeFile reads the samples from the JSON lines file at filePath and returns the events found by Analyze.
+func AnalyzeFile(filePath string) ([]Event, error) {
+	samples, err := ReadSamples(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return Analyze(samples), nil
+}
+
+// FilterEventsBySource returns the events recorded by any of the samplers in sources.
+func FilterEventsBySource(events []Event, sources ...string) []Event {
+	var filtered []Event
+
+	for _, event := range events {
+		if slices.Contains(sources, event.Source) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered
+}
+
+// FormatEvents returns a multi-line description of events, with one event per line.
+func FormatEvents(events []Event) string {
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		lines = append(lines, event.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// compareRounds returns the events for the changes from the previous to the current run of the sampler for source.
+func compareRounds(source string, previous, current *sampleRound) []Event {
+	var events []Event
+
+	for key, after := range current.samples {
+		event := Event{
+			Timestamp: current.timestamp,
+			Source:    source,
+			Subject:   key.subject,
+			Metric:    key.metric,
+			After:     &after,
+		}
+
+		before, ok := previous.samples[key]
+		switch {
+		case !ok:
+			event.Kind = EventAppeared
+		case before.Value != after.Value:
+			event.Kind = EventChanged
+			event.Before = &before
+		default:
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	for key, before := range previous.samples {
+		if _, ok := current.samples[key]; ok {
+			continue
+		}
+
+		events = append(events, Event{
+			Timestamp: current.timestamp,
+			Kind:      EventDisappeared,
+			Source:    source,
+			Subject:   key.subject,
+			Metric:    key.metric,
+			Before:    &before,
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,153 @@
+package stability
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderRecord(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "stability.jsonl")
+	startTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	restarts := "0"
+
+	recorder := NewRecorder(outputFile,
+		NewSampler("pod-restarts", func() ([]Sample, error) {
+			return []Sample{{Subject: "ns/pod", Metric: "restarts", Value: restarts}}, nil
+		}),
+		NewSampler("broken", func() ([]Sample, error) {
+			return nil, errors.New("sampler error")
+		}))
+	recorder.WithSampler(NewSampler("ptp", func() ([]Sample, error) {
+		return []Sample{{Subject: "cluster", Metric: "sync_state", Value: "Sync", Detail: "in sync"}}, nil
+	}))
+
+	recorder.now = func() time.Time { return startTime }
+	err := recorder.Record()
+	assert.ErrorContains(t, err, "sampler broken failed: sampler error")
+
+	restarts = "1"
+	recorder.now = func() time.Time { return startTime.Add(time.Minute) }
+	_ = recorder.Record()
+
+	samples, err := ReadSamples(outputFile)
+	assert.Nil(t, err)
+	assert.Equal(t, []Sample{
+		{Timestamp: startTime, Source: SourceRound, Subject: "pod-restarts", Metric: "round"},
+		{Timestamp: startTime, Source: "pod-restarts", Subject: "ns/pod", Metric: "restarts", Value: "0"},
+		{Timestamp: startTime, Source: SourceRound, Subject: "ptp", Metric: "round"},
+		{Timestamp: startTime, Source: "ptp", Subject: "cluster", Metric: "sync_state", Value: "Sync", Detail: "in sync"},
+		{Timestamp: startTime.Add(time.Minute), Source: SourceRound, Subject: "pod-restarts", Metric: "round"},
+		{Timestamp: startTime.Add(time.Minute), Source: "pod-restarts", Subject: "ns/pod", Metric: "restarts", Value: "1"},
+		{Timestamp: startTime.Add(time.Minute), Source: SourceRound, Subject: "ptp", Metric: "round"},
+		{
+			Timestamp: startTime.Add(time.Minute), Source: "ptp", Subject: "cluster", Metric: "sync_state", Value: "Sync",
+			Detail: "in sync",
+		},
+	}, samples)
+}
+
+func TestReadSamples(t *testing.T) {
+	_, err := ReadSamples(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.NotNil(t, err)
+
+	invalidFile := filepath.Join(t.TempDir(), "invalid.jsonl")
+	assert.Nil(t, os.WriteFile(invalidFile, []byte("{}\n\nnot json\n"), 0644))
+
+	_, err = ReadSamples(invalidFile)
+	assert.ErrorContains(t, err, "failed to parse sample on line 3")
+}
+
+func TestAnalyze(t *testing.T) {
+	first := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	third := second.Add(time.Minute)
+
+	sample := func(timestamp time.Time, source, subject, metric, value string) Sample {
+		return Sample{Timestamp: timestamp, Source: source, Subject: subject, Metric: metric, Value: value}
+	}
+
+	samples := []Sample{
+		sample(first, "pod-restarts", "ns/pod-a", "restarts", "0"),
+		sample(first, "pod-restarts", "ns/pod-b", "restarts", "0"),
+		sample(first, "ptp", "cluster", "sync_state", "Sync"),
+		sample(second, "pod-restarts", "ns/pod-a", "restarts", "0"),
+		sample(second, "pod-restarts", "ns/pod-b", "restarts", "0"),
+		sample(second, "ptp", "cluster", "sync_state", "Unsync"),
+		sample(third, "pod-restarts", "ns/pod-a", "restarts", "2"),
+		sample(third, "pod-restarts", "ns/pod-c", "restarts", "0"),
+		sample(third, "ptp", "cluster", "sync_state", "Unsync"),
+	}
+
+	events := Analyze(samples)
+	if !assert.Len(t, events, 4) {
+		return
+	}
+
+	assert.Equal(t, Event{
+		Timestamp: second,
+		Kind:      EventChanged,
+		Source:    "ptp",
+		Subject:   "cluster",
+		Metric:    "sync_state",
+		Before:    &samples[2],
+		After:     &samples[5],
+	}, events[0])
+
+	assert.Equal(t, EventChanged, events[1].Kind)
+	assert.Equal(t, "ns/pod-a", events[1].Subject)
+	assert.Equal(t, "0", events[1].Before.Value)
+	assert.Equal(t, "2", events[1].After.Value)
+
+	assert.Equal(t, EventDisappeared, events[2].Kind)
+	assert.Equal(t, "ns/pod-b", events[2].Subject)
+	assert.Nil(t, events[2].After)
+
+	assert.Equal(t, EventAppeared, events[3].Kind)
+	assert.Equal(t, "ns/pod-c", events[3].Subject)
+	assert.Nil(t, events[3].Before)
+
+	assert.Equal(t,
+		`2025-01-01T00:01:00Z Changed ptp cluster/sync_state before="Sync" after="Unsync"`, events[0].String())
+	assert.Empty(t, Analyze(samples[:3]))
+}
+
+func TestAnalyzeEmptyRound(t *testing.T) {
+	first := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+
+	samples := []Sample{
+		{Timestamp: first, Source: SourceRound, Subject: "oom-kills", Metric: "round"},
+		{Timestamp: first, Source: "oom-kills", Subject: "node-0", Metric: "oom_kills", Value: "0"},
+		{Timestamp: second, Source: SourceRound, Subject: "oom-kills", Metric: "round"},
+	}
+
+	events := Analyze(samples)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, EventDisappeared, events[0].Kind)
+		assert.Equal(t, second, events[0].Timestamp)
+		assert.Equal(t, "node-0", events[0].Subject)
+	}
+
+	assert.Empty(t, Analyze(samples[:2]))
+}
+
+func TestFilterEventsBySource(t *testing.T) {
+	events := []Event{{Source: "ptp"}, {Source: "mcp"}, {Source: "policies"}}
+
+	assert.Equal(t, []Event{{Source: "ptp"}, {Source: "policies"}}, FilterEventsBySource(events, "policies", "ptp"))
+	assert.Empty(t, FilterEventsBySource(events))
+}
+
+func TestParseOOMKills(t *testing.T) {
+	oomKills, err := parseOOMKills("pgfault 123\noom_kill 4\nnuma_hit 5\n")
+	assert.Nil(t, err)
+	assert.Equal(t, "4", oomKills)
+
+	_, err = parseOOMKills("pgfault 123\n")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,218 @@
+package stability
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Sample is a single observation of a metric recorded during a stability run. Samples are stored one per line as JSON
+// in the recorder output file.
+type Sample struct {
+	// Timestamp is when the sampler that produced the sample was run. All the samples from a single run of a sampler
+	// share the same timestamp.
+	Timestamp time.Time `json:"timestamp"`
+	// Source is the name of the sampler that produced the sample, such as ptp or pod-restarts.
+	Source string `json:"source"`
+	// Subject is the object the sample is about, such as a node, pod, or policy name.
+	Subject string `json:"subject"`
+	// Metric is the name of the observed property of the subject, such as restarts or compliance.
+	Metric string `json:"metric"`
+	// Value is the observed value of the metric. Changes to it between runs of a sampler are reported as events.
+	Value string `json:"value"`
+	// Detail is optional extra information about the sample. It is not compared by the analyzer.
+	Detail string `json:"detail,omitempty"`
+}
+
+// SourceAnnotation is the source of the samples written by Recorder.Annotate. Annotations mark events during the run,
+// such as workload launches, and are not compared by the analyzer.
+const SourceAnnotation = "annotation"
+
+// SourceRound is the source of the markers written by Recorder.Record for every successful run of a sampler, with the
+// name of the sampler as the subject. They let the analyzer tell a run that returned no samples apart from a run that
+// never happened, and are not compared by the analyzer.
+const SourceRound = "round"
+
+// Sampler collects samples for a single source. Samplers only need to set the Subject, Metric, Value, and optionally
+// Detail of each sample, since the Recorder sets the Timestamp and Source.
+type Sampler interface {
+	// Name returns the name of the sampler, which is used as the source of its samples.
+	Name() string
+	// Sample returns the current samples for the source.
+	Sample() ([]Sample, error)
+}
+
+// funcSampler is a Sampler backed by a function.
+type funcSampler struct {
+	name   string
+	sample func() ([]Sample, error)
+}
+
+// Name returns the name of the funcSampler.
+func (sampler funcSampler) Name() string {
+	return sampler.name
+}
+
+// Sample calls the function of the funcSampler.
+func (sampler funcSampler) Sample() ([]Sample, error) {
+	return sampler.sample()
+}
+
+// NewSampler returns a Sampler with the provided name that calls sample to collect samples.
+func NewSampler(name string, sample func() ([]Sample, error)) Sampler {
+	return funcSampler{name: name, sample: sample}
+}
+
+// Recorder runs a set of samplers and appends their samples to an output file in the JSON lines format.
+type Recorder struct {
+	outputFile string
+	samplers   []Sampler
+	now        func() time.Time
+}
+
+// NewRecorder returns a Recorder that appends the samples from samplers to outputFile.
+func NewRecorder(outputFile string, samplers ...Sampler) *Recorder {
+	return &Recorder{
+		outputFile: outputFile,
+		samplers:   samplers,
+		now:        time.Now,
+	}
+}
+
+// WithSampler adds sampler to the samplers run by the Recorder.
+func (recorder *Recorder) WithSampler(sampler Sampler) *Recorder {
+	recorder.samplers = append(recorder.samplers, sampler)
+
+	return recorder
+}
+
+// OutputFile returns the path of the file the Recorder appends samples to.
+func (recorder *Recorder) OutputFile() string {
+	return recorder.outputFile
+}
+
+// Record runs every sampler once and appends their samples to the output file. A sampler failing does not stop the
+// other samplers from running, but its error is included in the returned error.
+func (recorder *Recorder) Record() error {
+	file, err := os.OpenFile(recorder.outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open stability output file %s: %w", recorder.outputFile, err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+
+	var samplerErrors []error
+
+	for _, sampler := range recorder.samplers {
+		timestamp := recorder.now()
+
+		samples, err := sampler.Sample()
+		if err != nil {
+			samplerErrors = append(samplerErrors, fmt.Errorf("sampler %s failed: %w", sampler.Name(), err))
+
+			continue
+		}
+
+		err = encoder.Encode(Sample{Timestamp: timestamp, Source: SourceRound, Subject: sampler.Name(), Metric: "round"})
+		if err != nil {
+			return fmt.Errorf("failed to write round marker for %s to %s: %w", sampler.Name(), recorder.outputFile, err)
+		}
+
+		for _, sample := range samples {
+			sample.Timestamp = timestamp
+			sample.Source = sampler.Name()
+
+			err = encoder.Encode(sample)
+			if err != nil {
+				return fmt.Errorf("failed to write sample from %s to %s: %w", sampler.Name(), recorder.outputFile, err)
+			}
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return fmt.Errorf("failed to write samples to %s: %w", recorder.outputFile, err)
+	}
+
+	return errors.Join(samplerErrors...)
+}
+
+// Annotate appends an annotation to the output file marking that something of kind happened at timestamp, such as a
+// workload launch. The annotation is shown in the report timeline but is not compared by the analyzer.
+func (recorder *Recorder) Annotate(timestamp time.Time, kind, message string) error {
+	file, err := os.OpenFile(recorder.outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open stability output file %s: %w", recorder.outputFile, err)
+	}
+	defer file.Close()
+
+	err = json.NewEncoder(file).Encode(Sample{
+		Timestamp: timestamp,
+		Source:    SourceAnnotation,
+		Subject:   kind,
+		Metric:    "annotation",
+		Value:     message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write annotation to %s: %w", recorder.outputFile, err)
+	}
+
+	return nil
+}
+
+// Run records samples every interval until duration has passed. Errors from recording are logged rather than
+// returned, so a single failed sample does not end the stability run.
+func (recorder *Recorder) Run(duration, interval time.Duration) {
+	startTime := time.Now()
+
+	for time.Since(startTime) < duration {
+		err := recorder.Record()
+		if err != nil {
+			klog.Errorf("Failed to record stability samples to %s: %v", recorder.outputFile, err)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// ReadSamples reads the samples stored in the JSON lines file at filePath.
+func ReadSamples(filePath string) ([]Sample, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open stability output file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	var samples []Sample
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var sample Sample
+
+		err = json.Unmarshal(scanner.Bytes(), &sample)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse sample on line %d of %s: %w", lineNumber, filePath, err)
+		}
+
+		samples = append(samples, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stability output file %s: %w", filePath, err)
+	}
+
+	return samples, nil
+}
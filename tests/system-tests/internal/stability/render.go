@@ -0,0 +1,87 @@
+package stability
+
+import (
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+var (
+	//go:embed report_template.html
+	htmlReportTemplateFile string
+
+	//go:embed report_template.md
+	markdownReportTemplateFile string
+)
+
+var (
+	reportFuncMap = map[string]any{
+		"formatTime":     formatReportTime,
+		"formatDuration": formatReportDuration,
+		"escapeCell":     escapeMarkdownCell,
+	}
+	htmlReportTemplate = htmltemplate.Must(
+		htmltemplate.New("report_template.html").Funcs(reportFuncMap).Parse(htmlReportTemplateFile))
+	markdownReportTemplate = texttemplate.Must(
+		texttemplate.New("report_template.md").Funcs(reportFuncMap).Parse(markdownReportTemplateFile))
+)
+
+// WriteHTML renders the report as a standalone HTML page to writer.
+func (report *Report) WriteHTML(writer io.Writer) error {
+	return htmlReportTemplate.Execute(writer, report)
+}
+
+// WriteMarkdown renders the report as Markdown to writer.
+func (report *Report) WriteMarkdown(writer io.Writer) error {
+	return markdownReportTemplate.Execute(writer, report)
+}
+
+// Save renders the report to outputFile, using HTML if it has the .html extension and Markdown if it has the .md
+// extension.
+func (report *Report) Save(outputFile string) error {
+	var render func(io.Writer) error
+
+	switch filepath.Ext(outputFile) {
+	case ".html":
+		render = report.WriteHTML
+	case ".md":
+		render = report.WriteMarkdown
+	default:
+		return fmt.Errorf("cannot save stability report to %s: extension must be .html or .md", outputFile)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create stability report %s: %w", outputFile, err)
+	}
+
+	err = render(file)
+	if err != nil {
+		_ = file.Close()
+
+		return fmt.Errorf("failed to render stability report %s: %w", outputFile, err)
+	}
+
+	return file.Close()
+}
+
+// formatReportTime formats timestamp in UTC for the report.
+func formatReportTime(timestamp time.Time) string {
+	return timestamp.UTC().Format(time.RFC3339)
+}
+
+// formatReportDuration rounds duration to the second for the report.
+func formatReportDuration(duration time.Duration) string {
+	return duration.Round(time.Second).String()
+}
+
+// escapeMarkdownCell escapes value so it can be used in a Markdown table cell.
+func escapeMarkdownCell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(value)
+}
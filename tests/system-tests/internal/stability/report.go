@@ -0,0 +1,387 @@
+package stability
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPTPLogTransitions is the maximum number of servo state transitions listed for each PTP daemon log in the report.
+// The total is always reported, but listing every transition of a flapping clock over a long run is not useful.
+const maxPTPLogTransitions = 100
+
+// ptpServoPattern matches the daemon log lines containing the servo state of a synchronization process. For example:
+//
+//	ptp4l[401304.873]: [ptp4l.1.config:6] master offset         -3 s2 freq  -94379 path delay       161
+var ptpServoPattern = regexp.MustCompile(
+	`^(?P<process>ptp4l|phc2sys|ts2phc)\[(?P<timestamp>[\d.]+)\]:.*?\boffset\s+-?\d+\s+(?P<state>s\d+)`)
+
+// PTPLog is a file of linuxptp daemon logs collected from a node during a stability run. Its fields match those of the
+// CollectionResult from the PTP daemonlogs package so the logs it collects can be included in the report.
+type PTPLog struct {
+	// NodeName is the name of the node that the logs were collected from.
+	NodeName string
+	// StartedAt is the time the collection started.
+	StartedAt time.Time
+	// EndedAt is the time the collection ended.
+	EndedAt time.Time
+	// FilePath is the path to the file containing the collected log lines, one per line.
+	FilePath string
+}
+
+// ReportOptions are the inputs for generating a stability report.
+type ReportOptions struct {
+	// Title is the title of the report, such as the name of the stability test.
+	Title string
+	// SamplesFile is the path to the JSON lines file written by the Recorder.
+	SamplesFile string
+	// PTPLogs are the linuxptp daemon logs to summarize in the report. It may be empty.
+	PTPLogs []PTPLog
+	// FailureSources are the samplers whose events fail the run. If it is empty, any event fails the run.
+	FailureSources []string
+}
+
+// Report is the summary of a stability run, which can be rendered as HTML or Markdown.
+type Report struct {
+	Title       string
+	Generated   time.Time
+	StartedAt   time.Time
+	EndedAt     time.Time
+	SampleCount int
+	// Events are all of the changes found by the analyzer.
+	Events []Event
+	// Failures are the events from the failure sources of the run. The run passed if there are none.
+	Failures []Event
+	// Timeline is the annotations, reboots, and changes during the run, ordered by time.
+	Timeline []TimelineEntry
+	// PTPSync is the periods during which the PTP sync state stayed the same.
+	PTPSync []StateSegment
+	// PodRestarts is the change in the restarts of each pod over the run.
+	PodRestarts []CounterSummary
+	// PolicyFlips is the changes to the compliance state of the policies.
+	PolicyFlips []Event
+	// TunedApplies is the change in the number of times tuned applied its profile on each node over the run.
+	TunedApplies []CounterSummary
+	// PTPLogs is the summary of each of the linuxptp daemon logs.
+	PTPLogs []PTPLogSummary
+}
+
+// TimelineEntry is a single entry in the timeline of the report.
+type TimelineEntry struct {
+	Timestamp time.Time
+	// Kind is the kind of the entry, such as reboot, the kind of an annotation, or the source of an event.
+	Kind    string
+	Message string
+}
+
+// StateSegment is a period during which a value stayed the same. The offset and width are the percentages of the run
+// before and during the segment, for drawing the segment on a timeline.
+type StateSegment struct {
+	Value         string
+	Start         time.Time
+	End           time.Time
+	OffsetPercent float64
+	WidthPercent  float64
+}
+
+// Duration returns the duration of the segment.
+func (segment StateSegment) Duration() time.Duration {
+	return segment.End.Sub(segment.Start)
+}
+
+// CounterSummary is the change in a counter of a single subject over the run.
+type CounterSummary struct {
+	Subject string
+	First   int64
+	Last    int64
+	// Changes is the times the counter changed.
+	Changes []time.Time
+}
+
+// Delta returns how much the counter changed over the run.
+func (summary CounterSummary) Delta() int64 {
+	return summary.Last - summary.First
+}
+
+// PTPLogSummary is the summary of a single linuxptp daemon log.
+type PTPLogSummary struct {
+	PTPLog
+	LineCount       int
+	FaultyLineCount int
+	// TransitionCount is the total number of servo state transitions, which may be more than the transitions listed.
+	TransitionCount int
+	Transitions     []PTPLogTransition
+}
+
+// PTPLogTransition is a servo state transition of a process in a linuxptp daemon log. Since the daemon logs use the
+// time since the node booted, the transitions are identified by that rather than by wall clock time.
+type PTPLogTransition struct {
+	Process   string
+	Timestamp string
+	From      string
+	To        string
+	Raw       string
+}
+
+// GenerateReport reads the samples and PTP daemon logs in options and builds the report of the stability run.
+func GenerateReport(options ReportOptions) (*Report, error) {
+	samples, err := ReadSamples(options.SamplesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		Title:       options.Title,
+		Generated:   time.Now(),
+		SampleCount: countMeasuredSamples(samples),
+		Events:      Analyze(samples),
+	}
+
+	report.Failures = report.Events
+	if len(options.FailureSources) > 0 {
+		report.Failures = FilterEventsBySource(report.Events, options.FailureSources...)
+	}
+
+	if len(samples) > 0 {
+		report.StartedAt = slices.MinFunc(samples, compareSampleTimes).Timestamp
+		report.EndedAt = slices.MaxFunc(samples, compareSampleTimes).Timestamp
+	}
+
+	report.Timeline = buildTimeline(samples, report.Events)
+	report.PTPSync = buildStateSegments(samples, SourcePTP, report.StartedAt, report.EndedAt)
+	report.PodRestarts = buildCounterSummaries(samples, SourcePodRestarts)
+	report.TunedApplies = buildCounterSummaries(samples, SourceTunedRestarts)
+
+	for _, event := range report.Events {
+		if event.Source == SourcePolicies {
+			report.PolicyFlips = append(report.PolicyFlips, event)
+		}
+	}
+
+	for _, ptpLog := range options.PTPLogs {
+		summary, err := summarizePTPLog(ptpLog)
+		if err != nil {
+			return nil, err
+		}
+
+		report.PTPLogs = append(report.PTPLogs, summary)
+	}
+
+	return report, nil
+}
+
+// Passed returns true if there were no changes from the failure sources during the run.
+func (report *Report) Passed() bool {
+	return len(report.Failures) == 0
+}
+
+// Duration returns the time between the first and last samples.
+func (report *Report) Duration() time.Duration {
+	return report.EndedAt.Sub(report.StartedAt)
+}
+
+// countMeasuredSamples returns the number of samples, not counting the round markers.
+func countMeasuredSamples(samples []Sample) int {
+	count := 0
+
+	for _, sample := range samples {
+		if sample.Source != SourceRound {
+			count++
+		}
+	}
+
+	return count
+}
+
+// compareSampleTimes compares samples by their timestamps.
+func compareSampleTimes(first, second Sample) int {
+	return first.Timestamp.Compare(second.Timestamp)
+}
+
+// buildTimeline returns the annotations from samples along with the events, with node boot ID changes shown as
+// reboots.
+func buildTimeline(samples []Sample, events []Event) []TimelineEntry {
+	var timeline []TimelineEntry
+
+	for _, sample := range samples {
+		if sample.Source == SourceAnnotation {
+			timeline = append(timeline, TimelineEntry{
+				Timestamp: sample.Timestamp,
+				Kind:      sample.Subject,
+				Message:   sample.Value,
+			})
+		}
+	}
+
+	for _, event := range events {
+		entry := TimelineEntry{Timestamp: event.Timestamp, Kind: event.Source, Message: describeEvent(event)}
+
+		if event.Source == SourceNodeBoot && event.Kind == EventChanged {
+			entry.Kind = "reboot"
+			entry.Message = fmt.Sprintf("node %s rebooted", event.Subject)
+		}
+
+		timeline = append(timeline, entry)
+	}
+
+	slices.SortStableFunc(timeline, func(first, second TimelineEntry) int {
+		return first.Timestamp.Compare(second.Timestamp)
+	})
+
+	return timeline
+}
+
+// describeEvent returns a short description of event without its timestamp and source.
+func describeEvent(event Event) string {
+	switch event.Kind {
+	case EventAppeared:
+		return fmt.Sprintf("%s %s appeared with value %q", event.Subject, event.Metric, event.After.Value)
+	case EventDisappeared:
+		return fmt.Sprintf("%s %s disappeared with value %q", event.Subject, event.Metric, event.Before.Value)
+	default:
+		return fmt.Sprintf("%s %s changed from %q to %q", event.Subject, event.Metric, event.Before.Value,
+			event.After.Value)
+	}
+}
+
+// buildStateSegments returns the periods during which the value of the samples from source stayed the same. Each
+// segment lasts until the next change, or until endedAt for the last segment.
+func buildStateSegments(samples []Sample, source string, startedAt, endedAt time.Time) []StateSegment {
+	var segments []StateSegment
+
+	for _, sample := range samples {
+		if sample.Source != source {
+			continue
+		}
+
+		if len(segments) > 0 {
+			last := &segments[len(segments)-1]
+			last.End = sample.Timestamp
+
+			if last.Value == sample.Value {
+				continue
+			}
+		}
+
+		segments = append(segments, StateSegment{Value: sample.Value, Start: sample.Timestamp, End: sample.Timestamp})
+	}
+
+	if len(segments) > 0 {
+		segments[len(segments)-1].End = endedAt
+	}
+
+	runDuration := endedAt.Sub(startedAt)
+
+	for index := range segments {
+		if runDuration <= 0 {
+			segments[index].WidthPercent = 100
+
+			continue
+		}
+
+		segments[index].OffsetPercent = 100 * float64(segments[index].Start.Sub(startedAt)) / float64(runDuration)
+		segments[index].WidthPercent = 100 * float64(segments[index].Duration()) / float64(runDuration)
+	}
+
+	return segments
+}
+
+// buildCounterSummaries returns the change in the counters from source for each subject, sorted by subject. Samples
+// whose values are not integers are ignored.
+func buildCounterSummaries(samples []Sample, source string) []CounterSummary {
+	summaries := make(map[string]*CounterSummary)
+
+	for _, sample := range samples {
+		if sample.Source != source {
+			continue
+		}
+
+		value, err := strconv.ParseInt(sample.Value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		summary, ok := summaries[sample.Subject]
+		if !ok {
+			summaries[sample.Subject] = &CounterSummary{Subject: sample.Subject, First: value, Last: value}
+
+			continue
+		}
+
+		if value != summary.Last {
+			summary.Changes = append(summary.Changes, sample.Timestamp)
+		}
+
+		summary.Last = value
+	}
+
+	var result []CounterSummary
+
+	for _, subject := range slices.Sorted(maps.Keys(summaries)) {
+		result = append(result, *summaries[subject])
+	}
+
+	return result
+}
+
+// summarizePTPLog reads the linuxptp daemon log of ptpLog and summarizes it.
+func summarizePTPLog(ptpLog PTPLog) (PTPLogSummary, error) {
+	file, err := os.Open(ptpLog.FilePath)
+	if err != nil {
+		return PTPLogSummary{}, fmt.Errorf("failed to open PTP daemon log %s: %w", ptpLog.FilePath, err)
+	}
+	defer file.Close()
+
+	summary := PTPLogSummary{PTPLog: ptpLog}
+	previousStates := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		summary.LineCount++
+
+		if strings.Contains(strings.ToLower(line), "faulty") {
+			summary.FaultyLineCount++
+		}
+
+		match := ptpServoPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		process := match[ptpServoPattern.SubexpIndex("process")]
+		state := match[ptpServoPattern.SubexpIndex("state")]
+		previousState, ok := previousStates[process]
+		previousStates[process] = state
+
+		if !ok || previousState == state {
+			continue
+		}
+
+		summary.TransitionCount++
+
+		if len(summary.Transitions) < maxPTPLogTransitions {
+			summary.Transitions = append(summary.Transitions, PTPLogTransition{
+				Process:   process,
+				Timestamp: match[ptpServoPattern.SubexpIndex("timestamp")],
+				From:      previousState,
+				To:        state,
+				Raw:       line,
+			})
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return PTPLogSummary{}, fmt.Errorf("failed to read PTP daemon log %s: %w", ptpLog.FilePath, err)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,141 @@
+package stability
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateReport(t *testing.T) {
+	testDir := t.TempDir()
+	startTime := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	samplesFile := filepath.Join(testDir, "stability.jsonl")
+	recorder := NewRecorder(samplesFile)
+
+	assert.Nil(t, recorder.Annotate(startTime.Add(-time.Minute), "workload", "test workload launched"))
+
+	rounds := []struct {
+		ptpState   string
+		restarts   string
+		compliance string
+		bootID     string
+	}{
+		{ptpState: "Sync", restarts: "0", compliance: "Compliant", bootID: "boot-1"},
+		{ptpState: "Unsync", restarts: "0", compliance: "Compliant", bootID: "boot-1"},
+		{ptpState: "Sync", restarts: "2", compliance: "NonCompliant", bootID: "boot-2"},
+		{ptpState: "Sync", restarts: "2", compliance: "Compliant", bootID: "boot-2"},
+	}
+
+	for index, round := range rounds {
+		recorder.samplers = []Sampler{
+			NewSampler(SourcePTP, func() ([]Sample, error) {
+				return []Sample{{Subject: "cluster", Metric: "sync_state", Value: round.ptpState}}, nil
+			}),
+			NewSampler(SourcePodRestarts, func() ([]Sample, error) {
+				return []Sample{{Subject: "openshift-etcd/etcd-0", Metric: "restarts", Value: round.restarts}}, nil
+			}),
+			NewSampler(SourcePolicies, func() ([]Sample, error) {
+				return []Sample{{Subject: "du-policy", Metric: "compliance", Value: round.compliance}}, nil
+			}),
+			NewSampler(SourceNodeBoot, func() ([]Sample, error) {
+				return []Sample{{Subject: "node-0", Metric: "boot_id", Value: round.bootID}}, nil
+			}),
+		}
+		recorder.now = func() time.Time { return startTime.Add(time.Duration(index) * time.Minute) }
+
+		assert.Nil(t, recorder.Record())
+	}
+
+	ptpLogFile := filepath.Join(testDir, "ptp_daemon_node-0.log")
+	err := os.WriteFile(ptpLogFile, []byte(strings.Join([]string{
+		"ptp4l[100.000]: [ptp4l.0.config:6] master offset          1 s2 freq  -94379 path delay       161",
+		"ptp4l[101.000]: [ptp4l.0.config:6] port 1: SLAVE to FAULTY on FAULT_DETECTED",
+		"ptp4l[102.000]: [ptp4l.0.config:6] master offset      90000 s0 freq  -94379 path delay       161",
+		"phc2sys[102.500]: [ptp4l.0.config:6] CLOCK_REALTIME phc offset  -5 s2 freq  -19334 delay    470",
+		"ptp4l[103.000]: [ptp4l.0.config:6] master offset          3 s2 freq  -94379 path delay       161",
+	}, "\n")+"\n"), 0644)
+	assert.Nil(t, err)
+
+	report, err := GenerateReport(ReportOptions{
+		Title:       "StabilityWorkload",
+		SamplesFile: samplesFile,
+		PTPLogs:     []PTPLog{{NodeName: "node-0", StartedAt: startTime, EndedAt: startTime, FilePath: ptpLogFile}},
+	})
+	assert.Nil(t, err)
+
+	assert.False(t, report.Passed())
+	assert.Equal(t, 17, report.SampleCount)
+	assert.Equal(t, 4*time.Minute, report.Duration())
+
+	assert.Equal(t, []StateSegment{
+		{Value: "Sync", Start: startTime, End: startTime.Add(time.Minute), OffsetPercent: 25, WidthPercent: 25},
+		{
+			Value: "Unsync", Start: startTime.Add(time.Minute), End: startTime.Add(2 * time.Minute),
+			OffsetPercent: 50, WidthPercent: 25,
+		},
+		{
+			Value: "Sync", Start: startTime.Add(2 * time.Minute), End: startTime.Add(3 * time.Minute),
+			OffsetPercent: 75, WidthPercent: 25,
+		},
+	}, report.PTPSync)
+
+	assert.Equal(t, []CounterSummary{{
+		Subject: "openshift-etcd/etcd-0", First: 0, Last: 2, Changes: []time.Time{startTime.Add(2 * time.Minute)},
+	}}, report.PodRestarts)
+	assert.Equal(t, int64(2), report.PodRestarts[0].Delta())
+	assert.Len(t, report.PolicyFlips, 2)
+	assert.Empty(t, report.TunedApplies)
+
+	var timelineKinds []string
+	for _, entry := range report.Timeline {
+		timelineKinds = append(timelineKinds, entry.Kind)
+	}
+
+	assert.Equal(t, []string{"workload", SourcePTP, "reboot", SourcePodRestarts, SourcePolicies, SourcePTP,
+		SourcePolicies}, timelineKinds)
+	assert.Equal(t, "node node-0 rebooted", report.Timeline[2].Message)
+
+	if assert.Len(t, report.PTPLogs, 1) {
+		assert.Equal(t, 5, report.PTPLogs[0].LineCount)
+		assert.Equal(t, 1, report.PTPLogs[0].FaultyLineCount)
+		assert.Equal(t, 2, report.PTPLogs[0].TransitionCount)
+		assert.Equal(t, "102.000", report.PTPLogs[0].Transitions[0].Timestamp)
+	}
+
+	var markdown bytes.Buffer
+
+	assert.Nil(t, report.WriteMarkdown(&markdown))
+	assert.Contains(t, markdown.String(), "| 2024-12-31T23:59:00Z | 2025-01-01T00:03:00Z | 4m0s | 17 | 6 | FAILED |")
+	assert.Contains(t, markdown.String(), "| openshift-etcd/etcd-0 | 0 | 2 | 2 | 2025-01-01T00:02:00Z |")
+	assert.Contains(t, markdown.String(), "| 2025-01-01T00:02:00Z | reboot | node node-0 rebooted |")
+
+	htmlFile := filepath.Join(testDir, "report.html")
+	assert.Nil(t, report.Save(htmlFile))
+
+	html, err := os.ReadFile(htmlFile)
+	assert.Nil(t, err)
+	assert.Contains(t, string(html), `<div class="segment Unsync" style="left: 50.000%; width: 25.000%;"`)
+
+	assert.NotNil(t, report.Save(filepath.Join(testDir, "report.txt")))
+
+	report, err = GenerateReport(ReportOptions{
+		SamplesFile:    samplesFile,
+		FailureSources: []string{SourceNodeBoot, SourceTunedRestarts},
+	})
+	assert.Nil(t, err)
+	assert.False(t, report.Passed())
+	assert.Len(t, report.Failures, 1)
+
+	report, err = GenerateReport(ReportOptions{SamplesFile: samplesFile, FailureSources: []string{SourceTunedRestarts}})
+	assert.Nil(t, err)
+	assert.True(t, report.Passed())
+	assert.Len(t, report.Events, 6)
+
+	_, err = GenerateReport(ReportOptions{SamplesFile: filepath.Join(testDir, "missing.jsonl")})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,340 @@
+package stability
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clusteroperator"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/mco"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/ocm"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/cluster"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/ptp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SourcePTP is the name of the sampler returned by PTPSampler.
+	SourcePTP = "ptp"
+	// SourcePodRestarts is the name of the sampler returned by PodRestartsSampler.
+	SourcePodRestarts = "pod-restarts"
+	// SourcePolicies is the name of the sampler returned by PolicyComplianceSampler.
+	SourcePolicies = "policies"
+	// SourceTunedRestarts is the name of the sampler returned by TunedRestartsSampler.
+	SourceTunedRestarts = "tuned-restarts"
+	// SourceNodePressure is the name of the sampler returned by NodePressureSampler.
+	SourceNodePressure = "node-pressure"
+	// SourceNodeBoot is the name of the sampler returned by NodeBootSampler.
+	SourceNodeBoot = "node-boot"
+	// SourceMCP is the name of the sampler returned by MCPSampler.
+	SourceMCP = "mcp"
+	// SourceClusterOperators is the name of the sampler returned by ClusterOperatorSampler.
+	SourceClusterOperators = "cluster-operators"
+	// SourceKernelTaints is the name of the sampler returned by KernelTaintSampler.
+	SourceKernelTaints = "kernel-taints"
+	// SourceOOMKills is the name of the sampler returned by OOMKillSampler.
+	SourceOOMKills = "oom-kills"
+)
+
+const (
+	// tunedNamespace is the namespace of the tuned pods.
+	tunedNamespace = "openshift-cluster-node-tuning-operator"
+	// tunedContainer is the name of the container in the tuned pods.
+	tunedContainer = "tuned"
+)
+
+var (
+	// tunedAppliedPattern matches the tuned log lines written each time a profile is applied.
+	tunedAppliedPattern = regexp.MustCompile("static tuning from profile .* applied")
+	// oomKillPattern matches the oom_kill counter in /proc/vmstat.
+	oomKillPattern = regexp.MustCompile(`(?m)^oom_kill\s+(\d+)`)
+	// nodeConditionTypes are the node conditions recorded by the node pressure sampler.
+	nodeConditionTypes = []corev1.NodeConditionType{
+		corev1.NodeReady, corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure,
+	}
+	// clusterOperatorConditionTypes are the ClusterOperator conditions recorded by the cluster operator sampler.
+	clusterOperatorConditionTypes = []string{"Available", "Progressing", "Degraded"}
+	// mcpConditionTypes are the MachineConfigPool conditions recorded by the MCP sampler.
+	mcpConditionTypes = []string{"Updated", "Updating", "Degraded"}
+)
+
+// PTPSampler returns a Sampler that records whether the clocks are in sync. The error from the PTP validation, if any,
+// is stored in the detail of the sample.
+func PTPSampler(apiClient *clients.Settings, timeInterval time.Duration) Sampler {
+	return NewSampler(SourcePTP, func() ([]Sample, error) {
+		ptpOnSync, err := ptp.ValidatePTPStatus(apiClient, timeInterval)
+
+		sample := Sample{Subject: "cluster", Metric: "sync_state", Value: "Unsync"}
+		if ptpOnSync {
+			sample.Value = "Sync"
+		}
+
+		if err != nil {
+			sample.Detail = err.Error()
+		}
+
+		return []Sample{sample}, nil
+	})
+}
+
+// PodRestartsSampler returns a Sampler that records the total container restarts of every pod in namespaces.
+func PodRestartsSampler(apiClient *clients.Settings, namespaces ...string) Sampler {
+	return NewSampler(SourcePodRestarts, func() ([]Sample, error) {
+		var samples []Sample
+
+		for _, namespace := range namespaces {
+			podList, err := pod.List(apiClient, namespace, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+			}
+
+			for _, podBuilder := range podList {
+				totalRestarts := 0
+				for _, containerStatus := range podBuilder.Object.Status.ContainerStatuses {
+					totalRestarts += int(containerStatus.RestartCount)
+				}
+
+				samples = append(samples, Sample{
+					Subject: namespace + "/" + podBuilder.Object.Name,
+					Metric:  "restarts",
+					Value:   strconv.Itoa(totalRestarts),
+				})
+			}
+		}
+
+		return samples, nil
+	})
+}
+
+// PolicyComplianceSampler returns a Sampler that records the compliance state of every policy in the namespace of
+// the cluster.
+func PolicyComplianceSampler(apiClient *clients.Settings, clusterName string) Sampler {
+	return NewSampler(SourcePolicies, func() ([]Sample, error) {
+		allPolicies, err := ocm.ListPoliciesInAllNamespaces(apiClient,
+			runtimeclient.ListOptions{Namespace: clusterName})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get policies in %q NS: %w", clusterName, err)
+		}
+
+		var samples []Sample
+
+		for _, policy := range allPolicies {
+			samples = append(samples, Sample{
+				Subject: policy.Definition.Name,
+				Metric:  "compliance",
+				Value:   string(policy.Object.Status.ComplianceState),
+			})
+		}
+
+		return samples, nil
+	})
+}
+
+// TunedRestartsSampler returns a Sampler that records how many times each tuned pod has applied its profile. Since
+// tuned applies the profile when it starts, this counts tuned restarts.
+func TunedRestartsSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceTunedRestarts, func() ([]Sample, error) {
+		tunedPods, err := pod.ListByNamePattern(apiClient, "tuned", tunedNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tuned pods: %w", err)
+		}
+
+		var samples []Sample
+
+		for _, tunedPod := range tunedPods {
+			tunedLog, err := tunedPod.GetFullLog(tunedContainer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get logs of tuned pod %s: %w", tunedPod.Object.Name, err)
+			}
+
+			samples = append(samples, Sample{
+				Subject: tunedPod.Object.Spec.NodeName,
+				Metric:  "profile_applies",
+				Value:   strconv.Itoa(len(tunedAppliedPattern.FindAllString(tunedLog, -1))),
+			})
+		}
+
+		return samples, nil
+	})
+}
+
+// NodePressureSampler returns a Sampler that records the Ready, MemoryPressure, DiskPressure, and PIDPressure
+// conditions of every node. The kubelet sets these conditions when the node runs low on resources and starts evicting
+// pods.
+func NodePressureSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceNodePressure, func() ([]Sample, error) {
+		nodeList, err := nodes.List(apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+
+		var samples []Sample
+
+		for _, node := range nodeList {
+			conditions := make(map[string]string)
+			for _, condition := range node.Object.Status.Conditions {
+				conditions[string(condition.Type)] = string(condition.Status)
+			}
+
+			for _, conditionType := range nodeConditionTypes {
+				samples = append(samples, conditionSample(node.Object.Name, string(conditionType), conditions))
+			}
+		}
+
+		return samples, nil
+	})
+}
+
+// NodeBootSampler returns a Sampler that records the boot ID of every node. The boot ID changes each time the node
+// boots, so changes to it are reboots.
+func NodeBootSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceNodeBoot, func() ([]Sample, error) {
+		nodeList, err := nodes.List(apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+
+		var samples []Sample
+
+		for _, node := range nodeList {
+			samples = append(samples, Sample{
+				Subject: node.Object.Name,
+				Metric:  "boot_id",
+				Value:   node.Object.Status.NodeInfo.BootID,
+			})
+		}
+
+		return samples, nil
+	})
+}
+
+// MCPSampler returns a Sampler that records the Updated, Updating, and Degraded conditions of every
+// MachineConfigPool.
+func MCPSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceMCP, func() ([]Sample, error) {
+		mcpList, err := mco.ListMCP(apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list MachineConfigPools: %w", err)
+		}
+
+		var samples []Sample
+
+		for _, mcp := range mcpList {
+			conditions := make(map[string]string)
+			for _, condition := range mcp.Object.Status.Conditions {
+				conditions[string(condition.Type)] = string(condition.Status)
+			}
+
+			for _, conditionType := range mcpConditionTypes {
+				samples = append(samples, conditionSample(mcp.Object.Name, conditionType, conditions))
+			}
+		}
+
+		return samples, nil
+	})
+}
+
+// ClusterOperatorSampler returns a Sampler that records the Available, Progressing, and Degraded conditions of every
+// ClusterOperator.
+func ClusterOperatorSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceClusterOperators, func() ([]Sample, error) {
+		operatorList, err := clusteroperator.List(apiClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ClusterOperators: %w", err)
+		}
+
+		var samples []Sample
+
+		for _, operator := range operatorList {
+			conditions := make(map[string]string)
+			for _, condition := range operator.Object.Status.Conditions {
+				conditions[string(condition.Type)] = string(condition.Status)
+			}
+
+			for _, conditionType := range clusterOperatorConditionTypes {
+				samples = append(samples, conditionSample(operator.Object.Name, conditionType, conditions))
+			}
+		}
+
+		return samples, nil
+	})
+}
+
+// KernelTaintSampler returns a Sampler that records the kernel taint flags of every node. The kernel sets taint flags
+// on events such as warnings, oopses, and loading out-of-tree modules.
+func KernelTaintSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceKernelTaints, func() ([]Sample, error) {
+		outputs, err := cluster.ExecCmdWithStdout(apiClient, "cat /proc/sys/kernel/tainted")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kernel taints: %w", err)
+		}
+
+		var samples []Sample
+
+		for nodeName, output := range outputs {
+			samples = append(samples, Sample{
+				Subject: nodeName,
+				Metric:  "tainted",
+				Value:   strings.TrimSpace(output),
+			})
+		}
+
+		return samples, nil
+	})
+}
+
+// OOMKillSampler returns a Sampler that records the number of processes the kernel has killed for running out of
+// memory on every node since it booted.
+func OOMKillSampler(apiClient *clients.Settings) Sampler {
+	return NewSampler(SourceOOMKills, func() ([]Sample, error) {
+		outputs, err := cluster.ExecCmdWithStdout(apiClient, "cat /proc/vmstat")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vmstat: %w", err)
+		}
+
+		var samples []Sample
+
+		for nodeName, output := range outputs {
+			oomKills, err := parseOOMKills(output)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get OOM kills on node %s: %w", nodeName, err)
+			}
+
+			samples = append(samples, Sample{
+				Subject: nodeName,
+				Metric:  "oom_kills",
+				Value:   oomKills,
+			})
+		}
+
+		return samples, nil
+	})
+}
+
+// conditionSample returns the sample for the status of conditionType on subject, using Unknown if the condition is not
+// present in conditions.
+func conditionSample(subject, conditionType string, conditions map[string]string) Sample {
+	status, ok := conditions[conditionType]
+	if !ok {
+		status = string(corev1.ConditionUnknown)
+	}
+
+	return Sample{Subject: subject, Metric: conditionType, Value: status}
+}
+
+// parseOOMKills returns the oom_kill counter from the contents of /proc/vmstat.
+func parseOOMKills(vmstat string) (string, error) {
+	match := oomKillPattern.FindStringSubmatch(vmstat)
+	if match == nil {
+		return "", fmt.Errorf("oom_kill counter not found in vmstat")
+	}
+
+	return match[1], nil
+}
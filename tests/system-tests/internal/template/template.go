@@ -3,6 +3,8 @@ package template
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"text/template"
 
 	"k8s.io/klog/v2"
@@ -62,3 +64,32 @@ func SaveTemplate(
 
 	return nil
 }
+
+// RenderTemplate reads the source template file and returns it with the variables replaced.
+func RenderTemplate(source string, variablesToReplace map[string]interface{}) (string, error) {
+	if source == "" {
+		klog.V(100).Infof("The source is empty")
+
+		return "", fmt.Errorf("the source should be provided")
+	}
+
+	klog.V(100).Infof("Read %s template and replace variables", source)
+
+	tmpl, err := template.New(filepath.Base(source)).Option("missingkey=error").ParseFiles(source)
+	if err != nil {
+		klog.V(100).Infof("Error to read template file %s", source)
+
+		return "", err
+	}
+
+	var rendered strings.Builder
+
+	err = tmpl.Execute(&rendered, variablesToReplace)
+	if err != nil {
+		klog.V(100).Infof("Error to apply the template %s to the vars map", source)
+
+		return "", err
+	}
+
+	return rendered.String(), nil
+}
@@ -0,0 +1,256 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// FieldManager is the field manager used when applying the workload objects.
+	FieldManager = "eco-gotests"
+	// ManagedByLabel is the label set on the namespaces created by Apply, so Teardown only deletes those.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// pollInterval is the interval between two checks of the workload objects.
+	pollInterval = 5 * time.Second
+)
+
+// Deployer applies a set of workload objects to the cluster, waits for them to be ready and tears them down.
+type Deployer struct {
+	apiClient *clients.Settings
+	namespace string
+	objects   []*unstructured.Unstructured
+}
+
+// NewDeployer returns a Deployer for objects. Namespaced objects without a namespace are deployed in namespace,
+// which is created by Apply if it does not exist. Namespaces created this way are deleted by Teardown, even when
+// using another Deployer for the same objects.
+func NewDeployer(
+	apiClient *clients.Settings, namespace string, objects []*unstructured.Unstructured) (*Deployer, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("the apiClient of the workload deployer is nil")
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("the workload deployer has no objects to deploy")
+	}
+
+	deployer := &Deployer{apiClient: apiClient, namespace: namespace}
+	seen := map[string]bool{}
+
+	for _, object := range objects {
+		object = object.DeepCopy()
+
+		namespaced, err := apiClient.Client.IsObjectNamespaced(object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the scope of %s: %w", describeObject(object), err)
+		}
+
+		if !namespaced {
+			object.SetNamespace("")
+		} else if object.GetNamespace() == "" {
+			if namespace == "" {
+				return nil, fmt.Errorf("%s has no namespace and no default namespace was provided",
+					describeObject(object))
+			}
+
+			object.SetNamespace(namespace)
+		}
+
+		key := objectKey(object)
+		if seen[key] {
+			return nil, fmt.Errorf("%s is defined more than once", describeObject(object))
+		}
+
+		seen[key] = true
+
+		deployer.objects = append(deployer.objects, object)
+	}
+
+	return deployer, nil
+}
+
+// Objects returns the objects managed by the deployer, in the order they are applied.
+func (deployer *Deployer) Objects() []*unstructured.Unstructured {
+	return deployer.objects
+}
+
+// Apply creates the default namespace if needed, then applies the objects in order using server-side apply.
+func (deployer *Deployer) Apply(ctx context.Context) error {
+	if deployer.namespace != "" {
+		nsBuilder := namespace.NewBuilder(deployer.apiClient, deployer.namespace)
+
+		if !nsBuilder.Exists() {
+			klog.V(90).Infof("Creating workload namespace %s", deployer.namespace)
+
+			if _, err := nsBuilder.WithLabel(ManagedByLabel, FieldManager).Create(); err != nil {
+				return fmt.Errorf("failed to create workload namespace %s: %w", deployer.namespace, err)
+			}
+		}
+	}
+
+	for _, object := range deployer.objects {
+		klog.V(90).Infof("Applying %s", describeObject(object))
+
+		applied := object.DeepCopy()
+		applied.SetResourceVersion("")
+		applied.SetManagedFields(nil)
+
+		err := deployer.apiClient.Client.Patch(
+			ctx, applied, runtimeclient.Apply, runtimeclient.FieldOwner(FieldManager), runtimeclient.ForceOwnership)
+		if err != nil {
+			return fmt.Errorf("failed to apply %s: %w", describeObject(object), err)
+		}
+	}
+
+	return nil
+}
+
+// WaitReady waits up to timeout for all the objects to be ready, as defined by IsReady. It returns early if one of
+// them failed.
+func (deployer *Deployer) WaitReady(ctx context.Context, timeout time.Duration) error {
+	var (
+		notReady   string
+		failureErr error
+	)
+
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, object := range deployer.objects {
+			current, err := deployer.get(ctx, object)
+			if err != nil {
+				klog.V(90).Infof("Failed to get %s: %v", describeObject(object), err)
+
+				notReady = describeObject(object)
+
+				return false, nil
+			}
+
+			ready, err := IsReady(current)
+			if err != nil {
+				failureErr = err
+
+				return false, err
+			}
+
+			if !ready {
+				klog.V(90).Infof("%s is not ready yet", describeObject(object))
+
+				notReady = describeObject(object)
+
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+
+	if failureErr != nil {
+		return failureErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("workload not ready after %s, waiting for %s: %w", timeout, notReady, err)
+	}
+
+	return nil
+}
+
+// Teardown deletes the objects in the reverse order they were applied, waiting up to timeout for each of them to be
+// gone, then deletes the default namespace if Apply created it. Objects that do not exist are ignored, so Teardown can
+// be used to clean up after a previous run.
+func (deployer *Deployer) Teardown(ctx context.Context, timeout time.Duration) error {
+	var errs []error
+
+	for index := len(deployer.objects) - 1; index >= 0; index-- {
+		if err := deployer.delete(ctx, deployer.objects[index], timeout); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if deployer.namespace == "" {
+		return nil
+	}
+
+	nsBuilder, err := namespace.Pull(deployer.apiClient, deployer.namespace)
+	if err != nil {
+		klog.V(90).Infof("Workload namespace %s not found, skipping its deletion: %v", deployer.namespace, err)
+
+		return nil
+	}
+
+	if nsBuilder.Object.Labels[ManagedByLabel] != FieldManager {
+		klog.V(90).Infof("Workload namespace %s was not created by the deployer, keeping it", deployer.namespace)
+
+		return nil
+	}
+
+	klog.V(90).Infof("Deleting workload namespace %s", deployer.namespace)
+
+	err = nsBuilder.DeleteAndWait(timeout)
+	if err != nil {
+		return fmt.Errorf("failed to delete workload namespace %s: %w", deployer.namespace, err)
+	}
+
+	return nil
+}
+
+// delete deletes object with foreground propagation and waits up to timeout for it to be gone.
+func (deployer *Deployer) delete(ctx context.Context, object *unstructured.Unstructured, timeout time.Duration) error {
+	klog.V(90).Infof("Deleting %s", describeObject(object))
+
+	err := deployer.apiClient.Client.Delete(ctx, object.DeepCopy(), runtimeclient.PropagationPolicy(
+		metav1.DeletePropagationForeground))
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", describeObject(object), err)
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		_, err := deployer.get(ctx, object)
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		if err != nil {
+			klog.V(90).Infof("Failed to get %s: %v", describeObject(object), err)
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("%s was not deleted after %s: %w", describeObject(object), timeout, err)
+	}
+
+	return nil
+}
+
+// get returns the current state of object in the cluster.
+func (deployer *Deployer) get(ctx context.Context, object *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(object.GroupVersionKind())
+
+	err := deployer.apiClient.Client.Get(ctx, runtimeclient.ObjectKeyFromObject(object), current)
+	if err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
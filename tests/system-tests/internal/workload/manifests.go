@@ -0,0 +1,167 @@
+package workload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/template"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// TemplateExtension is the extension of the manifest files rendered as templates before being decoded.
+const TemplateExtension = ".tmpl"
+
+// LoadManifests returns the objects defined in the manifests at path, in the order they should be applied. Path can
+// be:
+//   - a directory containing a kustomization file, which is built with kustomize;
+//   - a directory of .yaml, .yml and .tmpl files, read in lexical order so they can be prefixed to order them;
+//   - a single one of those files.
+//
+// Files with the .tmpl extension are rendered with variables using text/template before being decoded. Each file may
+// contain several documents.
+func LoadManifests(path string, variables map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the manifests path should be provided")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests path %s: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		return loadManifestFile(path, variables)
+	}
+
+	if isKustomization(path) {
+		return buildKustomization(path)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list manifests directory %s: %w", path, err)
+	}
+
+	var objects []*unstructured.Unstructured
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isManifestFile(entry.Name()) {
+			continue
+		}
+
+		fileObjects, err := loadManifestFile(filepath.Join(path, entry.Name()), variables)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, fileObjects...)
+	}
+
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no manifests found in %s", path)
+	}
+
+	return objects, nil
+}
+
+// DecodeManifests decodes the YAML or JSON documents in data into objects, skipping empty documents. Source is only
+// used in error messages.
+func DecodeManifests(data []byte, source string) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	var objects []*unstructured.Unstructured
+
+	for index := 0; ; index++ {
+		var document map[string]interface{}
+
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode document %d of %s: %w", index, source, err)
+		}
+
+		if len(document) == 0 {
+			continue
+		}
+
+		object := &unstructured.Unstructured{Object: document}
+
+		if object.GetAPIVersion() == "" || object.GetKind() == "" || object.GetName() == "" {
+			return nil, fmt.Errorf("document %d of %s must have an apiVersion, kind and metadata.name", index, source)
+		}
+
+		objects = append(objects, object)
+	}
+}
+
+// loadManifestFile decodes the objects in the manifest file at path, rendering it first if it is a template.
+func loadManifestFile(path string, variables map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	klog.V(90).Infof("Loading workload manifests from %s", path)
+
+	var (
+		data []byte
+		err  error
+	)
+
+	if strings.HasSuffix(path, TemplateExtension) {
+		var rendered string
+
+		rendered, err = template.RenderTemplate(path, variables)
+		data = []byte(rendered)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	return DecodeManifests(data, path)
+}
+
+// buildKustomization builds the kustomization in directory and decodes the resulting objects.
+func buildKustomization(directory string) ([]*unstructured.Unstructured, error) {
+	klog.V(90).Infof("Building workload kustomization in %s", directory)
+
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization in %s: %w", directory, err)
+	}
+
+	data, err := resources.AsYaml()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render kustomization in %s: %w", directory, err)
+	}
+
+	return DecodeManifests(data, directory)
+}
+
+// isKustomization returns whether directory contains a kustomization file.
+func isKustomization(directory string) bool {
+	for _, fileName := range konfig.RecognizedKustomizationFileNames() {
+		if _, err := os.Stat(filepath.Join(directory, fileName)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isManifestFile returns whether fileName has one of the extensions of manifest files.
+func isManifestFile(fileName string) bool {
+	return slices.Contains([]string{".yaml", ".yml", TemplateExtension}, filepath.Ext(fileName))
+}
@@ -0,0 +1,142 @@
+package workload
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	deploymentKind  = appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind()
+	statefulSetKind = appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind()
+	daemonSetKind   = appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind()
+	jobKind         = batchv1.SchemeGroupVersion.WithKind("Job").GroupKind()
+	podKind         = corev1.SchemeGroupVersion.WithKind("Pod").GroupKind()
+)
+
+// IsReady returns whether object, as read from the cluster, is ready:
+//   - Deployments have all their replicas updated and available;
+//   - StatefulSets have all their replicas updated and ready;
+//   - DaemonSets have their pods updated and available on all the scheduled nodes;
+//   - Jobs have completed, an error being returned if they failed;
+//   - Pods have the Ready condition, or succeeded.
+//
+// Objects of any other kind are ready as soon as they exist. Objects whose status was not updated for their latest
+// generation yet are not ready.
+func IsReady(object *unstructured.Unstructured) (bool, error) {
+	switch object.GroupVersionKind().GroupKind() {
+	case deploymentKind:
+		return isReadyAs(object, isDeploymentReady)
+	case statefulSetKind:
+		return isReadyAs(object, isStatefulSetReady)
+	case daemonSetKind:
+		return isReadyAs(object, isDaemonSetReady)
+	case jobKind:
+		return isReadyAs(object, isJobReady)
+	case podKind:
+		return isReadyAs(object, isPodReady)
+	default:
+		return true, nil
+	}
+}
+
+// isReadyAs converts object to T then checks its readiness with isReady.
+func isReadyAs[T any](object *unstructured.Unstructured, isReady func(*T) (bool, error)) (bool, error) {
+	typed := new(T)
+
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, typed)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert %s: %w", describeObject(object), err)
+	}
+
+	return isReady(typed)
+}
+
+func isDeploymentReady(deployment *appsv1.Deployment) (bool, error) {
+	replicas := replicasOrDefault(deployment.Spec.Replicas)
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas, nil
+}
+
+func isStatefulSetReady(statefulSet *appsv1.StatefulSet) (bool, error) {
+	replicas := replicasOrDefault(statefulSet.Spec.Replicas)
+
+	return statefulSet.Status.ObservedGeneration >= statefulSet.Generation &&
+		statefulSet.Status.UpdatedReplicas == replicas &&
+		statefulSet.Status.ReadyReplicas == replicas, nil
+}
+
+func isDaemonSetReady(daemonSet *appsv1.DaemonSet) (bool, error) {
+	return daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.DesiredNumberScheduled > 0 &&
+		daemonSet.Status.UpdatedNumberScheduled == daemonSet.Status.DesiredNumberScheduled &&
+		daemonSet.Status.NumberAvailable == daemonSet.Status.DesiredNumberScheduled, nil
+}
+
+func isJobReady(job *batchv1.Job) (bool, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return false, fmt.Errorf("job %s/%s failed: %s", job.Namespace, job.Name, condition.Message)
+		default:
+		}
+	}
+
+	return false, nil
+}
+
+func isPodReady(pod *corev1.Pod) (bool, error) {
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		return false, fmt.Errorf("pod %s/%s failed: %s", pod.Namespace, pod.Name, pod.Status.Message)
+	default:
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue, nil
+		}
+	}
+
+	return false, nil
+}
+
+// replicasOrDefault returns the number of replicas, which defaults to 1 when unset.
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+// describeObject returns the kind, namespace and name of object for logs and errors.
+func describeObject(object *unstructured.Unstructured) string {
+	if object.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", object.GetKind(), object.GetName())
+	}
+
+	return fmt.Sprintf("%s %s/%s", object.GetKind(), object.GetNamespace(), object.GetName())
+}
+
+// objectKey returns the group kind, namespace and name identifying object.
+func objectKey(object *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", schema.GroupKind{Group: object.GroupVersionKind().Group, Kind: object.GetKind()},
+		object.GetNamespace(), object.GetName())
+}
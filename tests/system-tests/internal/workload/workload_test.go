@@ -0,0 +1,200 @@
+package workload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/clients"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/namespace"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func writeTestFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	directory := t.TempDir()
+
+	for name, content := range files {
+		assert.Nil(t, os.WriteFile(filepath.Join(directory, name), []byte(content), 0o600))
+	}
+
+	return directory
+}
+
+func TestLoadManifests(t *testing.T) {
+	directory := writeTestFiles(t, map[string]string{
+		"00-config.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n---\n" +
+			"apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: workload\n",
+		"10-deployment.yaml.tmpl": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ .Name }}\n",
+		"README.md":               "not a manifest",
+	})
+
+	objects, err := LoadManifests(directory, map[string]interface{}{"Name": "du-workload"})
+	assert.Nil(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, []string{"ConfigMap", "ServiceAccount", "Deployment"},
+		[]string{objects[0].GetKind(), objects[1].GetKind(), objects[2].GetKind()})
+	assert.Equal(t, "du-workload", objects[2].GetName())
+
+	_, err = LoadManifests(directory, nil)
+	assert.NotNil(t, err)
+
+	objects, err = LoadManifests(filepath.Join(directory, "00-config.yaml"), nil)
+	assert.Nil(t, err)
+	assert.Len(t, objects, 2)
+
+	_, err = LoadManifests(writeTestFiles(t, map[string]string{"README.md": ""}), nil)
+	assert.NotNil(t, err)
+
+	_, err = LoadManifests(filepath.Join(directory, "missing"), nil)
+	assert.NotNil(t, err)
+}
+
+func TestLoadManifestsKustomization(t *testing.T) {
+	directory := writeTestFiles(t, map[string]string{
+		"kustomization.yaml": "namespace: du-workload\nnamePrefix: test-\nresources:\n- deployment.yaml\n",
+		"deployment.yaml":    "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: workload\n",
+	})
+
+	objects, err := LoadManifests(directory, nil)
+	assert.Nil(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "test-workload", objects[0].GetName())
+	assert.Equal(t, "du-workload", objects[0].GetNamespace())
+}
+
+func TestDecodeManifests(t *testing.T) {
+	objects, err := DecodeManifests([]byte("---\n\n---\napiVersion: v1\nkind: Pod\nmetadata:\n  name: pod\n"), "test")
+	assert.Nil(t, err)
+	assert.Len(t, objects, 1)
+
+	_, err = DecodeManifests([]byte("apiVersion: v1\nkind: Pod\n"), "test")
+	assert.ErrorContains(t, err, "document 0 of test")
+
+	_, err = DecodeManifests([]byte("apiVersion: [v1"), "test")
+	assert.NotNil(t, err)
+}
+
+func toUnstructured(t *testing.T, object runtime.Object) *unstructured.Unstructured {
+	t.Helper()
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	assert.Nil(t, err)
+
+	result := &unstructured.Unstructured{Object: content}
+	result.SetGroupVersionKind(object.GetObjectKind().GroupVersionKind())
+
+	return result
+}
+
+func TestIsReady(t *testing.T) {
+	replicas := int32(2)
+	deploymentType := metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	jobType := metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"}
+
+	testCases := []struct {
+		object        runtime.Object
+		expectedReady bool
+		expectError   bool
+	}{
+		{
+			object: &appsv1.Deployment{TypeMeta: deploymentType, Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}},
+			expectedReady: true,
+		},
+		{
+			object: &appsv1.Deployment{TypeMeta: deploymentType, Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+				Status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1}},
+		},
+		{
+			object: &appsv1.Deployment{TypeMeta: deploymentType, ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1,
+					AvailableReplicas: 1}},
+		},
+		{
+			object: &appsv1.StatefulSet{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+				Status: appsv1.StatefulSetStatus{UpdatedReplicas: 1, ReadyReplicas: 1}},
+			expectedReady: true,
+		},
+		{
+			object: &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"},
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3,
+					NumberAvailable: 2}},
+		},
+		{
+			object: &batchv1.Job{TypeMeta: jobType, Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}}},
+			expectedReady: true,
+		},
+		{
+			object: &batchv1.Job{TypeMeta: jobType, Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}}},
+			expectError: true,
+		},
+		{
+			object: &corev1.Pod{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue}}}},
+			expectedReady: true,
+		},
+		{
+			object:        &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"}},
+			expectedReady: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		ready, err := IsReady(toUnstructured(t, testCase.object))
+
+		assert.Equal(t, testCase.expectError, err != nil)
+		assert.Equal(t, testCase.expectedReady, ready)
+	}
+}
+
+func TestDeployer(t *testing.T) {
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "du-workload"}}
+	workloadNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "du-workload",
+		Labels: map[string]string{ManagedByLabel: FieldManager}}}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+
+	apiClient, clientBuilder := clients.GetModifiableTestClients(clients.TestClientParams{})
+	apiClient.Client = clientBuilder.WithRESTMapper(restMapper).WithRuntimeObjects(existing, workloadNamespace).Build()
+
+	objects, err := DecodeManifests([]byte(
+		"apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: workload\n---\n"+
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n---\n"+
+			"apiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n  namespace: ignored\n"), "test")
+	assert.Nil(t, err)
+
+	_, err = NewDeployer(apiClient, "", objects)
+	assert.ErrorContains(t, err, "no default namespace")
+
+	_, err = NewDeployer(apiClient, "du-workload", append(objects, objects[0]))
+	assert.ErrorContains(t, err, "defined more than once")
+
+	deployer, err := NewDeployer(apiClient, "du-workload", objects)
+	assert.Nil(t, err)
+	assert.Equal(t, "du-workload", deployer.Objects()[0].GetNamespace())
+	assert.Equal(t, "", deployer.Objects()[2].GetNamespace())
+	assert.Equal(t, "", objects[0].GetNamespace())
+
+	err = deployer.Teardown(context.TODO(), time.Second)
+	assert.Nil(t, err)
+
+	_, err = deployer.get(context.TODO(), deployer.Objects()[0])
+	assert.NotNil(t, err)
+	assert.False(t, namespace.NewBuilder(apiClient, "du-workload").Exists())
+}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -65,6 +66,8 @@ func NewIpsecConfig() *IpsecConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ipsec", &ipsecConf)
+
 	return &ipsecConf
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -179,6 +180,8 @@ func NewOCloudConfig() *OCloudConfig {
 		WithRedfishTimeout(ocloudConf.Spoke2BMCTimeout).
 		WithSSHUser(ocloudConf.Spoke2BMCUsername, ocloudConf.Spoke2BMCPassword)
 
+	config.RegisterSuiteConfig("o-cloud", &ocloudConf)
+
 	return &ocloudConf
 }
 
@@ -27,6 +27,10 @@ func TestOCloud(t *testing.T) {
 	RunSpecs(t, "O-Cloud SystemTests Suite", Label(ocloudparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = AfterSuite(func() {
 	err := os.RemoveAll("tmp/")
 	if err != nil {
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -23,6 +25,18 @@ type BMCDetails struct {
 	Username   string `json:"username"`
 	Password   string `json:"password"`
 	BMCAddress string `json:"bmc"`
+	// Type is the type of the BMC, either redfish or ipmi. Defaults to redfish.
+	Type powercontrol.Type `json:"type"`
+}
+
+// PowerControlConfig returns the power control configuration of the BMC.
+func (details BMCDetails) PowerControlConfig() powercontrol.Config {
+	return powercontrol.Config{
+		Type:     details.Type,
+		Address:  details.BMCAddress,
+		Username: details.Username,
+		Password: details.Password,
+	}
 }
 
 // NodesBMCMap holds info about BMC connection for a specific node.
@@ -50,21 +64,27 @@ func (nad *NodesBMCMap) Decode(value string) error {
 		}
 
 		parsedRecord := strings.Split(record, ",")
-		if len(parsedRecord) != 4 {
-			log.Printf("Error parsing BMC record: expected 4 entries, found %d", len(parsedRecord))
-			log.Print("Expected format: nodename,username,password,bmcaddress")
+		if len(parsedRecord) != 4 && len(parsedRecord) != 5 {
+			log.Printf("Error parsing BMC record: expected 4 or 5 entries, found %d", len(parsedRecord))
+			log.Print("Expected format: nodename,username,password,bmcaddress[,bmctype]")
 
-			return fmt.Errorf("error parsing BMC record: expected 4 entries, found %d", len(parsedRecord))
+			return fmt.Errorf("error parsing BMC record: expected 4 or 5 entries, found %d", len(parsedRecord))
 		}
 
 		log.Printf("Processing BMC credentials for node: %s, user: %s, address: %s",
 			parsedRecord[0], parsedRecord[1], parsedRecord[3])
 
-		nodesAuthMap[parsedRecord[0]] = BMCDetails{
+		bmcDetails := BMCDetails{
 			Username:   parsedRecord[1],
 			Password:   parsedRecord[2],
 			BMCAddress: parsedRecord[3],
 		}
+
+		if len(parsedRecord) == 5 {
+			bmcDetails.Type = powercontrol.Type(parsedRecord[4])
+		}
+
+		nodesAuthMap[parsedRecord[0]] = bmcDetails
 	}
 
 	*nad = nodesAuthMap
@@ -80,6 +100,9 @@ type RanDuConfig struct {
 		CreateMethod   string `yaml:"create_method" envconfig:"ECO_RANDU_TESTWORKLOAD_CREATE_METHOD"`
 		CreateShellCmd string `yaml:"create_shell_cmd" envconfig:"ECO_RANDU_TESTWORKLOAD_CREATE_SHELLCMD"`
 		DeleteShellCmd string `yaml:"delete_shell_cmd" envconfig:"ECO_RANDU_TESTWORKLOAD_DELETE_SHELLCMD"`
+		ManifestsPath  string `yaml:"manifests_path" envconfig:"ECO_RANDU_TESTWORKLOAD_MANIFESTS_PATH"`
+		//nolint:lll
+		TemplateVariables map[string]string `yaml:"template_variables" envconfig:"ECO_RANDU_TESTWORKLOAD_TEMPLATE_VARIABLES"`
 	} `yaml:"randu_test_workload"`
 	LaunchWorkloadIterations   int `yaml:"launch_workload_iterations" envconfig:"ECO_RANDU_LAUNCH_WORKLOAD_ITERATIONS"`
 	SoftRebootIterations       int `yaml:"soft_reboot_iterations" envconfig:"ECO_RANDU_SOFT_REBOOT_ITERATIONS"`
@@ -94,6 +117,8 @@ type RanDuConfig struct {
 	PtpEnabled                 bool        `yaml:"ptp_enabled" envconfig:"ECO_RANDU_PTP_ENABLED"`
 	RebootRecoveryTime         int         `yaml:"reboot_recovery_time" envconfig:"ECO_RANDU_RECOVERY_TIME"`
 	NodesCredentialsMap        NodesBMCMap `yaml:"randu_nodes_bmc_map" envconfig:"ECO_RANDU_NODES_CREDENTIALS_MAP"`
+	//nolint:lll
+	StabilityFailureSources []string `yaml:"stability_failure_sources" envconfig:"ECO_RANDU_STABILITY_FAILURE_SOURCES"`
 }
 
 // NewRanDuConfig returns instance of RanDuConfig config type.
@@ -122,6 +147,8 @@ func NewRanDuConfig() *RanDuConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("ran-du", &randuConf)
+
 	return &randuConf
 }
 
@@ -13,6 +13,9 @@ const (
 	DefaultTimeout = 900 * time.Second
 	// TestWorkloadShellLaunchMethod is used when using a shell script for launching the test workload.
 	TestWorkloadShellLaunchMethod = "shell"
+	// TestWorkloadManifestLaunchMethod is used when applying the manifests at manifests_path for launching the test
+	// workload.
+	TestWorkloadManifestLaunchMethod = "manifest"
 	// RanDuLogLevel configures logging level for RAN DU related tests.
 	RanDuLogLevel = 90
 )
@@ -29,6 +29,10 @@ func TestRanDu(t *testing.T) {
 	RunSpecs(t, "RanDU SystemTests Suite", Label(randuparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	if !testNS.Exists() {
 		fmt.Printf("Namespace %s doesn't exist. Creating.", testNS.Definition.Name)
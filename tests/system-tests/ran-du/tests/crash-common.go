@@ -0,0 +1,27 @@
+package ran_du_system_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/kdump"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
+)
+
+// verifyKernelCrash retrieves the report of the latest kernel crash saved by kdump on nodeName since triggeredAt,
+// attaches its summary to the spec report and asserts the crash had the expected cause rather than being an unrelated
+// panic.
+func verifyKernelCrash(ctx context.Context, nodeName string, triggeredAt time.Time, expectedCause kdump.CrashCause) {
+	By("Analyzing the kernel crash report of node " + nodeName)
+
+	report, err := kdump.RetrieveCrashReport(ctx, nodeName, triggeredAt, randuparams.RanDuLogLevel,
+		15*time.Second, 5*time.Minute)
+	Expect(err).ToNot(HaveOccurred(), "Failed to retrieve the kernel crash report of node %s", nodeName)
+
+	AddReportEntry("kernel_crash_"+nodeName, report.Summary())
+
+	err = report.Verify(expectedCause)
+	Expect(err).ToNot(HaveOccurred(), "Unexpected kernel crash on node %s", nodeName)
+}
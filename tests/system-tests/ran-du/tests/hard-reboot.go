@@ -74,7 +74,15 @@ var _ = Describe(
 				for _, node := range nodeList {
 					By("Reboot worker node")
 					fmt.Printf("Reboot worker node %s", node.Definition.Name)
-					err = reboot.HardRebootNode(node.Definition.Name, randuparams.TestNamespaceName)
+					controller, ok, err := hardRebootPowerController(node.Definition.Name)
+					Expect(err).ToNot(HaveOccurred(), "Error selecting the power controller of the node.")
+
+					if ok {
+						err = reboot.PowerCycleNode(node.Definition.Name, controller)
+					} else {
+						err = reboot.HardRebootNode(node.Definition.Name, randuparams.TestNamespaceName)
+					}
+
 					Expect(err).ToNot(HaveOccurred(), "Error rebooting the nodes.")
 
 					By(fmt.Sprintf("Wait for %d minutes for the cluster resources to reconciliate their state",
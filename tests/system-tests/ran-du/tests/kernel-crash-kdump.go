@@ -1,6 +1,7 @@
 package ran_du_system_test
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -12,8 +13,11 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/deployment"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/kdump"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/nmi"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/reboot"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -39,11 +43,25 @@ var _ = Describe(
 			Expect(err).ToNot(HaveOccurred(), "Error listing nodes.")
 
 			for _, node := range nodeList {
+				By(fmt.Sprintf("Cleaning up /var/crash directory on node %q", node.Definition.Name))
+
+				err = nmi.CleanupVarCrashDirectory(context.TODO(), node.Definition.Name,
+					randuparams.RanDuLogLevel, 5*time.Second, 2*time.Minute)
+				Expect(err).ToNot(HaveOccurred(),
+					fmt.Sprintf("Failed to cleanup /var/crash on node %s", node.Definition.Name))
+
 				By("Trigger kernel crash")
 
+				triggeredAt := time.Now()
+
 				err = reboot.KernelCrashKdump(node.Definition.Name)
 				Expect(err).ToNot(HaveOccurred(), "Error triggering a kernel crash on the node.")
 
+				By("Ensure the node is powered on after the kernel crash")
+
+				err = ensureNodePoweredOn(node.Definition.Name)
+				Expect(err).ToNot(HaveOccurred(), "Error powering on the node after the kernel crash.")
+
 				By("Waiting for the openshift apiserver deployment to be available")
 
 				err = openshiftAPIDeploy.WaitUntilCondition("Available", 5*time.Minute)
@@ -62,6 +80,8 @@ var _ = Describe(
 				Expect(err).ToNot(HaveOccurred(), "could not execute command: %s", err)
 
 				Expect(len(strings.Fields(coreDumps))).To(BeNumerically(">=", 1), "error: vmcore dump was not generated")
+
+				verifyKernelCrash(context.TODO(), node.Definition.Name, triggeredAt, kdump.CrashCauseSysrq)
 			}
 		})
 	})
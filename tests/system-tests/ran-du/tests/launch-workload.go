@@ -9,7 +9,6 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/await"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/ptp"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/shell"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
 )
@@ -23,22 +22,20 @@ var _ = Describe(
 			By("Preparing workload")
 
 			if namespace.NewBuilder(APIClient, RanDuTestConfig.TestWorkload.Namespace).Exists() {
-				By("Deleting workload using shell method")
+				By("Deleting workload using " + RanDuTestConfig.TestWorkload.CreateMethod + " method")
 
-				_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.DeleteShellCmd)
+				err := deleteTestWorkload()
 				Expect(err).ToNot(HaveOccurred(), "Failed to delete workload")
 			}
 
-			if RanDuTestConfig.TestWorkload.CreateMethod == "shell" {
-				By("Launching workload using shell method")
+			By("Launching workload using " + RanDuTestConfig.TestWorkload.CreateMethod + " method")
 
-				_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.CreateShellCmd)
-				Expect(err).ToNot(HaveOccurred(), "Failed to launch workload")
-			}
+			_, err := launchTestWorkload()
+			Expect(err).ToNot(HaveOccurred(), "Failed to launch workload")
 
 			By("Waiting for deployment replicas to become ready")
 
-			_, err := await.WaitUntilAllDeploymentsReady(APIClient, RanDuTestConfig.TestWorkload.Namespace,
+			_, err = await.WaitUntilAllDeploymentsReady(APIClient, RanDuTestConfig.TestWorkload.Namespace,
 				randuparams.DefaultTimeout)
 			Expect(err).ToNot(HaveOccurred(), "error while waiting for deployment to become ready")
 
@@ -66,7 +63,7 @@ var _ = Describe(
 		AfterAll(func() {
 			By("Cleaning up test workload resources")
 
-			_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.DeleteShellCmd)
+			err := deleteTestWorkload()
 			Expect(err).ToNot(HaveOccurred(), "Failed to delete workload")
 		})
 	})
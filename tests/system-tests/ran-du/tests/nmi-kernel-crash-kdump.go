@@ -12,6 +12,7 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/kdump"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/nmi"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
@@ -22,7 +23,7 @@ var _ = Describe(
 	Ordered,
 	ContinueOnFailure,
 	Label("NMIKernelCrashKdump"), func() {
-		It("Trigger NMI kernel crash via Redfish to generate kdump vmcore",
+		It("Trigger NMI kernel crash via the BMC to generate kdump vmcore",
 			reportxml.ID("85975"), Label("NMIKernelCrashKdump"), func(ctx SpecContext) {
 				By("Retrieve nodes list")
 
@@ -49,16 +50,12 @@ var _ = Describe(
 				}
 
 				for _, node := range nodeList {
-					auth, ok := RanDuTestConfig.NodesCredentialsMap[node.Definition.Name]
+					controller, ok, err := nodePowerController(node.Definition.Name)
+					Expect(err).ToNot(HaveOccurred(),
+						fmt.Sprintf("Failed to select the power controller of node %s", node.Definition.Name))
 					Expect(ok).To(BeTrue(),
 						fmt.Sprintf("BMC Details for %q not found", node.Definition.Name))
 
-					bmcCredentials := nmi.BMCCredentials{
-						BMCAddress: auth.BMCAddress,
-						Username:   auth.Username,
-						Password:   auth.Password,
-					}
-
 					By(fmt.Sprintf("Cleaning up /var/crash directory on node %q", node.Definition.Name))
 
 					err = nmi.CleanupVarCrashDirectory(ctx, node.Definition.Name,
@@ -66,9 +63,11 @@ var _ = Describe(
 					Expect(err).ToNot(HaveOccurred(),
 						fmt.Sprintf("Failed to cleanup /var/crash on node %s", node.Definition.Name))
 
-					By(fmt.Sprintf("Triggering NMI via Redfish on node %q", node.Definition.Name))
+					By(fmt.Sprintf("Triggering NMI via the BMC of node %q", node.Definition.Name))
 
-					err = nmi.TriggerNMIViaRedfish(ctx, node.Definition.Name, bmcCredentials,
+					triggeredAt := time.Now()
+
+					err = nmi.TriggerNMI(ctx, node.Definition.Name, controller,
 						randuparams.RanDuLogLevel, 15*time.Second, 6*time.Minute)
 					Expect(err).ToNot(HaveOccurred(),
 						fmt.Sprintf("Failed to trigger NMI on node %s", node.Definition.Name))
@@ -96,6 +95,8 @@ var _ = Describe(
 						randuparams.RanDuLogLevel, 15*time.Second, 5*time.Minute)
 					Expect(err).ToNot(HaveOccurred(),
 						fmt.Sprintf("Vmcore dump was not generated on node %s", node.Definition.Name))
+
+					verifyKernelCrash(ctx, node.Definition.Name, triggeredAt, kdump.CrashCauseNMI)
 				}
 			})
 	})
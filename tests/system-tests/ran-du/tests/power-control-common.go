@@ -0,0 +1,77 @@
+package ran_du_system_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/powercontrol"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// nodePowerController returns the power controller of nodeName selected from its entry in the BMC credentials map.
+// The returned bool is false when the map has no entry for the node.
+func nodePowerController(nodeName string) (powercontrol.Controller, bool, error) {
+	bmcDetails, ok := RanDuTestConfig.NodesCredentialsMap[nodeName]
+	if !ok {
+		return nil, false, nil
+	}
+
+	controller, err := powercontrol.New(bmcDetails.PowerControlConfig())
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to create power controller for node %s: %w", nodeName, err)
+	}
+
+	return controller, true, nil
+}
+
+// hardRebootPowerController returns the power controller used to hard reboot nodeName. Only nodes whose BMC type is set
+// explicitly are rebooted through their power controller, so nodes with just BMC credentials keep being rebooted using
+// ipmitool from a pod. The returned bool is false when the node has no BMC type.
+func hardRebootPowerController(nodeName string) (powercontrol.Controller, bool, error) {
+	if RanDuTestConfig.NodesCredentialsMap[nodeName].Type == "" {
+		return nil, false, nil
+	}
+
+	return nodePowerController(nodeName)
+}
+
+// ensureNodePoweredOn powers on nodeName through its power controller if the node is reported off, for example when
+// the BMC does not restart it after a kernel crash. Nodes without BMC details are left untouched.
+func ensureNodePoweredOn(nodeName string) error {
+	controller, ok, err := nodePowerController(nodeName)
+	if err != nil || !ok {
+		return err
+	}
+
+	powerState, err := controller.PowerState()
+	if err != nil {
+		return fmt.Errorf("failed to get power state of node %s: %w", nodeName, err)
+	}
+
+	if powerState != powercontrol.PowerStateOff {
+		return nil
+	}
+
+	klog.V(randuparams.RanDuLogLevel).Infof("Node %s is powered off, powering it on", nodeName)
+
+	err = controller.PowerOn()
+	if err != nil {
+		return fmt.Errorf("failed to power on node %s: %w", nodeName, err)
+	}
+
+	return wait.PollUntilContextTimeout(
+		context.TODO(), 15*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+			powerState, err := controller.PowerState()
+			if err != nil {
+				klog.V(randuparams.RanDuLogLevel).Infof("Failed to get power state of node %s: %v", nodeName, err)
+
+				return false, nil
+			}
+
+			return powerState == powercontrol.PowerStateOn, nil
+		})
+}
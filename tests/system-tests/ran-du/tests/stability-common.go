@@ -0,0 +1,118 @@
+package ran_du_system_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/ptp"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/stability"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
+	"k8s.io/klog/v2"
+)
+
+// stabilityNamespaces are the namespaces whose pod restarts are recorded during the stability tests.
+var stabilityNamespaces = []string{"openshift-etcd", "openshift-apiserver"}
+
+// newStabilityRecorder returns a recorder that writes the stability samples to the <name>.jsonl file of the stability
+// output directory.
+func newStabilityRecorder(name, clusterName string, interval time.Duration) *stability.Recorder {
+	outputFile := filepath.Join(RanDuTestConfig.StabilityOutputPath, name+".jsonl")
+	recorder := stability.NewRecorder(outputFile,
+		stability.PodRestartsSampler(APIClient, stabilityNamespaces...),
+		stability.TunedRestartsSampler(APIClient),
+		stability.NodeBootSampler(APIClient),
+		stability.NodePressureSampler(APIClient),
+		stability.MCPSampler(APIClient),
+		stability.ClusterOperatorSampler(APIClient),
+		stability.KernelTaintSampler(APIClient),
+		stability.OOMKillSampler(APIClient))
+
+	if RanDuTestConfig.PtpEnabled {
+		recorder.WithSampler(stability.PTPSampler(APIClient, interval))
+	}
+
+	if RanDuTestConfig.StabilityPoliciesCheck {
+		recorder.WithSampler(stability.PolicyComplianceSampler(APIClient, clusterName))
+	}
+
+	return recorder
+}
+
+// runStabilityTest records stability samples with recorder every interval for duration, saves the report of the run
+// next to the samples, then fails if the analyzer reports any change from the configured failure sources. Changes from
+// the other samplers are only shown in the report.
+func runStabilityTest(recorder *stability.Recorder, title string, duration, interval time.Duration) {
+	By(fmt.Sprintf("Collecting metrics during %s", duration))
+
+	startTime := time.Now()
+
+	err := recorder.Annotate(startTime, "run", "stability run started")
+	Expect(err).ToNot(HaveOccurred(), "Failed to annotate stability run start")
+
+	recorder.Run(duration, interval)
+
+	err = recorder.Annotate(time.Now(), "run", "stability run ended")
+	Expect(err).ToNot(HaveOccurred(), "Failed to annotate stability run end")
+
+	By("Generating stability report")
+
+	saveStabilityReport(recorder.OutputFile(), title, startTime)
+
+	By("Check all results")
+
+	events, err := stability.AnalyzeFile(recorder.OutputFile())
+	Expect(err).ToNot(HaveOccurred(), "Failed to analyze stability samples")
+
+	if len(RanDuTestConfig.StabilityFailureSources) > 0 {
+		events = stability.FilterEventsBySource(events, RanDuTestConfig.StabilityFailureSources...)
+	}
+
+	Expect(events).To(BeEmpty(), "One or more changes detected in stability tests:\n%s",
+		stability.FormatEvents(events))
+}
+
+// saveStabilityReport saves the HTML and Markdown reports of the samples in samplesFile next to it, including the PTP
+// daemon logs since startTime if PTP is enabled. Failing to generate the report is logged rather than failing the
+// test, since it does not affect the result of the run.
+func saveStabilityReport(samplesFile, title string, startTime time.Time) {
+	reportOptions := stability.ReportOptions{
+		Title:          title,
+		SamplesFile:    samplesFile,
+		FailureSources: RanDuTestConfig.StabilityFailureSources,
+	}
+
+	if RanDuTestConfig.PtpEnabled {
+		logFiles, err := ptp.SaveDaemonLogs(APIClient, startTime, filepath.Dir(samplesFile))
+		if err != nil {
+			klog.Errorf("Failed to save PTP daemon logs for the stability report: %v", err)
+		}
+
+		for nodeName, logFile := range logFiles {
+			reportOptions.PTPLogs = append(reportOptions.PTPLogs, stability.PTPLog{
+				NodeName:  nodeName,
+				StartedAt: startTime,
+				EndedAt:   time.Now(),
+				FilePath:  logFile,
+			})
+		}
+	}
+
+	report, err := stability.GenerateReport(reportOptions)
+	if err != nil {
+		klog.Errorf("Failed to generate stability report for %s: %v", samplesFile, err)
+
+		return
+	}
+
+	basePath := samplesFile[:len(samplesFile)-len(filepath.Ext(samplesFile))] + "_report"
+
+	for _, extension := range []string{".html", ".md"} {
+		err = report.Save(basePath + extension)
+		if err != nil {
+			klog.Errorf("Failed to save stability report: %v", err)
+		}
+	}
+}
@@ -10,8 +10,6 @@ import (
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/await"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/platform"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/shell"
-	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/stability"
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
 )
@@ -22,29 +20,30 @@ var _ = Describe(
 	ContinueOnFailure,
 	Label("StabilityWorkload"), func() {
 		var (
-			clusterName string
+			clusterName        string
+			workloadLaunchedAt time.Time
 		)
 
 		BeforeAll(func() {
 			By("Preparing workload")
 
 			if namespace.NewBuilder(APIClient, RanDuTestConfig.TestWorkload.Namespace).Exists() {
-				By("Deleting workload using shell method")
+				By("Deleting workload using " + RanDuTestConfig.TestWorkload.CreateMethod + " method")
 
-				_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.DeleteShellCmd)
+				err := deleteTestWorkload()
 				Expect(err).ToNot(HaveOccurred(), "Failed to delete workload")
 			}
 
-			if RanDuTestConfig.TestWorkload.CreateMethod == randuparams.TestWorkloadShellLaunchMethod {
-				By("Launching workload using shell method")
+			By("Launching workload using " + RanDuTestConfig.TestWorkload.CreateMethod + " method")
 
-				_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.CreateShellCmd)
-				Expect(err).ToNot(HaveOccurred(), "Failed to launch workload")
-			}
+			var err error
+
+			workloadLaunchedAt, err = launchTestWorkload()
+			Expect(err).ToNot(HaveOccurred(), "Failed to launch workload")
 
 			By("Waiting for deployment replicas to become ready")
 
-			_, err := await.WaitUntilAllDeploymentsReady(APIClient, RanDuTestConfig.TestWorkload.Namespace,
+			_, err = await.WaitUntilAllDeploymentsReady(APIClient, RanDuTestConfig.TestWorkload.Namespace,
 				randuparams.DefaultTimeout)
 			Expect(err).ToNot(HaveOccurred(), "error while waiting for deployment to become ready")
 
@@ -65,102 +64,22 @@ var _ = Describe(
 			Expect(err).ToNot(HaveOccurred(), "Failed to get cluster name")
 		})
 		It("StabilityWorkload", reportxml.ID("42744"), Label("StabilityWorkload"), func() {
-			outputDir := RanDuTestConfig.StabilityOutputPath
-			policiesOutputFile := fmt.Sprintf("%s/stability_workload_policies.log", outputDir)
-			ptpOutputFile := fmt.Sprintf("%s/stability_workload_ptp.log", outputDir)
-			tunedRestartsOutputFile := fmt.Sprintf("%s/stability_workload_tuned_restarts.log", outputDir)
-			namespaces := []string{"openshift-etcd", "openshift-apiserver"}
-
-			totalDuration := time.Duration(RanDuTestConfig.StabilityWorkloadDurMins) * time.Minute
 			interval := time.Duration(RanDuTestConfig.StabilityWorkloadIntMins) * time.Minute
-			startTime := time.Now()
-
-			By(fmt.Sprintf("Collecting metrics during %d minutes", RanDuTestConfig.StabilityWorkloadDurMins))
-
-			for time.Since(startTime) < totalDuration {
-				if RanDuTestConfig.PtpEnabled {
-					err := stability.SavePTPStatus(APIClient, ptpOutputFile, interval)
-					if err != nil {
-						fmt.Printf("Error, could not save PTP status")
-					}
-				}
-
-				if RanDuTestConfig.StabilityPoliciesCheck {
-					err := stability.SavePolicyStatus(APIClient, clusterName, policiesOutputFile)
-					if err != nil {
-						fmt.Printf("Error, could not save policies status")
-					}
-				}
-
-				for _, namespace := range namespaces {
-					err := stability.SavePodsRestartsInNamespace(APIClient,
-						namespace, fmt.Sprintf("%s/stability_workload_%s.log", outputDir, namespace))
-					if err != nil {
-						fmt.Printf("Error, could not save pod restarts")
-					}
-				}
-
-				err := stability.SaveTunedRestarts(APIClient, tunedRestartsOutputFile)
-				if err != nil {
-					fmt.Printf("Error, could not save tuned restarts")
-				}
-
-				time.Sleep(interval)
-			}
-
-			// Final check of all values
-			By("Check all results")
-
-			var stabilityErrors []string
-
-			// Verify policies
-			By("Check Policy changes")
-
-			if RanDuTestConfig.StabilityPoliciesCheck {
-				_, err := stability.VerifyStabilityStatusChange(policiesOutputFile)
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
-			}
+			recorder := newStabilityRecorder("stability_workload", clusterName, interval)
 
-			// Verify podRestarts
-			By("Check Pod restarts")
-
-			for _, namespace := range namespaces {
-				_, err := stability.VerifyStabilityStatusChange(fmt.Sprintf("%s/stability_workload_%s.log", outputDir, namespace))
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
+			if !workloadLaunchedAt.IsZero() {
+				err := recorder.Annotate(workloadLaunchedAt, "workload",
+					fmt.Sprintf("test workload launched in namespace %s", RanDuTestConfig.TestWorkload.Namespace))
+				Expect(err).ToNot(HaveOccurred(), "Failed to annotate workload launch")
 			}
 
-			// Verify PTP output
-			By("Check PTP results")
-
-			if RanDuTestConfig.PtpEnabled {
-				_, err := stability.VerifyStabilityStatusChange(ptpOutputFile)
-				if err != nil {
-					stabilityErrors = append(stabilityErrors, err.Error())
-				}
-			}
-
-			// Verify tuned restarts
-			By("Check tuneds restarts")
-
-			_, err := stability.VerifyStabilityStatusChange(tunedRestartsOutputFile)
-			if err != nil {
-				stabilityErrors = append(stabilityErrors, err.Error())
-			}
-
-			By("Check if there been any error")
-
-			if len(stabilityErrors) > 0 {
-				Expect(stabilityErrors).ToNot(HaveOccurred(), "One or more errors in stability tests: %s", stabilityErrors)
-			}
+			runStabilityTest(recorder, "StabilityWorkload",
+				time.Duration(RanDuTestConfig.StabilityWorkloadDurMins)*time.Minute, interval)
 		})
 		AfterAll(func() {
 			By("Cleaning up test workload resources")
 
-			_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.DeleteShellCmd)
+			err := deleteTestWorkload()
 			Expect(err).ToNot(HaveOccurred(), "Failed to delete workload")
 		})
 	})
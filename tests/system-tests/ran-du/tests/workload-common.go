@@ -0,0 +1,91 @@
+package ran_du_system_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/shell"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/workload"
+	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuinittools"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/ran-du/internal/randuparams"
+	"k8s.io/klog/v2"
+)
+
+// newTestWorkloadDeployer returns a deployer for the test workload manifests at the configured manifests path.
+func newTestWorkloadDeployer() (*workload.Deployer, error) {
+	variables := map[string]interface{}{"Namespace": RanDuTestConfig.TestWorkload.Namespace}
+
+	for key, value := range RanDuTestConfig.TestWorkload.TemplateVariables {
+		variables[key] = value
+	}
+
+	objects, err := workload.LoadManifests(RanDuTestConfig.TestWorkload.ManifestsPath, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load test workload manifests: %w", err)
+	}
+
+	return workload.NewDeployer(APIClient, RanDuTestConfig.TestWorkload.Namespace, objects)
+}
+
+// launchTestWorkload launches the test workload using the configured create method and returns when it was launched.
+// With the manifest method, it also waits for the workload objects to be ready. The returned time is zero when the
+// create method does not launch the workload.
+func launchTestWorkload() (time.Time, error) {
+	switch RanDuTestConfig.TestWorkload.CreateMethod {
+	case randuparams.TestWorkloadShellLaunchMethod:
+		launchedAt := time.Now()
+
+		_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.CreateShellCmd)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to launch workload: %w", err)
+		}
+
+		return launchedAt, nil
+	case randuparams.TestWorkloadManifestLaunchMethod:
+		deployer, err := newTestWorkloadDeployer()
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		launchedAt := time.Now()
+
+		err = deployer.Apply(context.TODO())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to launch workload: %w", err)
+		}
+
+		err = deployer.WaitReady(context.TODO(), randuparams.DefaultTimeout)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return launchedAt, nil
+	default:
+		klog.V(randuparams.RanDuLogLevel).Infof("Test workload create method %q does not launch the workload",
+			RanDuTestConfig.TestWorkload.CreateMethod)
+
+		return time.Time{}, nil
+	}
+}
+
+// deleteTestWorkload deletes the test workload using the configured create method. With the manifest method, the
+// objects are deleted in the reverse order of their manifests and the namespace is deleted last if it was created for
+// the workload.
+func deleteTestWorkload() error {
+	if RanDuTestConfig.TestWorkload.CreateMethod != randuparams.TestWorkloadManifestLaunchMethod {
+		_, err := shell.ExecuteCmd(RanDuTestConfig.TestWorkload.DeleteShellCmd)
+		if err != nil {
+			return fmt.Errorf("failed to delete workload: %w", err)
+		}
+
+		return nil
+	}
+
+	deployer, err := newTestWorkloadDeployer()
+	if err != nil {
+		return err
+	}
+
+	return deployer.Teardown(context.TODO(), randuparams.DefaultTimeout)
+}
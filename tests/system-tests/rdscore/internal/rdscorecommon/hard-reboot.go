@@ -552,3 +552,69 @@ func VerifyGracefulRebootSuite() {
 				VerifyIPVLANConnectivityBetweenDifferentNodes)
 		})
 }
+
+// VerifyAllClusterOperatorsAvailable waits for all the ClusterOperators to be Available after a cluster reboot.
+func VerifyAllClusterOperatorsAvailable() {
+	By("Checking all cluster operators")
+
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Waiting for all ClusterOperators to be Available")
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Sleeping for 3 minutes")
+
+	time.Sleep(3 * time.Minute)
+
+	ok, err := clusteroperator.WaitForAllClusteroperatorsAvailable(
+		APIClient, 15*time.Minute, metav1.ListOptions{})
+	Expect(err).ToNot(HaveOccurred(), "Failed to get cluster operator status")
+	Expect(ok).To(BeTrue(), "Some cluster operators not Available")
+}
+
+// RemoveUnexpectedAdmissionErrorPods deletes the pods in all namespaces that failed with UnexpectedAdmissionError
+// after a cluster reboot.
+func RemoveUnexpectedAdmissionErrorPods(ctx SpecContext) {
+	By("Remove any pods in UnexpectedAdmissionError state")
+
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Remove pods with UnexpectedAdmissionError status")
+
+	klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Sleeping for 3 minutes")
+
+	time.Sleep(3 * time.Minute)
+
+	listOptions := metav1.ListOptions{
+		FieldSelector: "status.phase=Failed",
+	}
+
+	var (
+		podsList []*pod.Builder
+		err      error
+	)
+
+	Eventually(func() bool {
+		podsList, err = pod.ListInAllNamespaces(APIClient, listOptions)
+		if err != nil {
+			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Failed to list pods: %v", err)
+
+			return false
+		}
+
+		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Found %d pods matching search criteria",
+			len(podsList))
+
+		for _, failedPod := range podsList {
+			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Pod %q in %q ns matches search criteria",
+				failedPod.Definition.Name, failedPod.Definition.Namespace)
+		}
+
+		return true
+	}).WithContext(ctx).WithPolling(5*time.Second).WithTimeout(1*time.Minute).Should(BeTrue(),
+		"Failed to search for pods with UnexpectedAdmissionError status")
+
+	for _, failedPod := range podsList {
+		if failedPod.Definition.Status.Reason == "UnexpectedAdmissionError" {
+			klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Deleting pod %q in %q ns",
+				failedPod.Definition.Name, failedPod.Definition.Namespace)
+
+			_, err := failedPod.DeleteAndWait(5 * time.Minute)
+			Expect(err).ToNot(HaveOccurred(), "could not delete pod in UnexpectedAdmissionError state")
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/pod"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/kdump"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/reboot"
 
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreinittools"
@@ -57,6 +58,8 @@ func crashNodeKDump(ctx SpecContext, nodeLabel string) {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Trigerring kernel crash on %q",
 			node.Definition.Name)
 
+		triggeredAt := time.Now()
+
 		err = reboot.KernelCrashKdump(node.Definition.Name)
 		Expect(err).ToNot(HaveOccurred(), "Error triggering a kernel crash on the node.")
 
@@ -74,6 +77,8 @@ func crashNodeKDump(ctx SpecContext, nodeLabel string) {
 
 		verifyVmcoreDumpGenerated(ctx, node.Definition.Name)
 
+		verifyKernelCrash(ctx, node.Definition.Name, triggeredAt, kdump.CrashCauseSysrq)
+
 		cleanupVarCrashDirectory(ctx, node.Definition.Name)
 	}
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/bmc"
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/kdump"
 
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreinittools"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreparams"
@@ -85,6 +86,8 @@ func triggerNMIRedfish(ctx SpecContext, nodeLabel string) {
 
 		By(fmt.Sprintf("Sending NMI reset action to %q", node.Definition.Name))
 
+		triggeredAt := time.Now()
+
 		err = wait.PollUntilContextTimeout(ctx, 15*time.Second, 6*time.Minute, true,
 			func(ctx context.Context) (bool, error) {
 				if err := bmcClient.SystemResetAction(redfish.NmiResetType); err != nil {
@@ -137,6 +140,8 @@ func triggerNMIRedfish(ctx SpecContext, nodeLabel string) {
 		klog.V(rdscoreparams.RDSCoreLogLevel).Infof("Node %q successfully recovered after NMI", node.Definition.Name)
 
 		verifyVmcoreDumpGenerated(ctx, node.Definition.Name)
+
+		verifyKernelCrash(ctx, node.Definition.Name, triggeredAt, kdump.CrashCauseNMI)
 	}
 }
 
@@ -13,6 +13,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/nodes"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/kdump"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/remote"
 
 	. "github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/rdscore/internal/rdscoreinittools"
@@ -76,6 +77,21 @@ func verifyVmcoreDumpGenerated(ctx SpecContext, nodeName string) {
 		"error: vmcore dump was not generated on node %q", nodeName)
 }
 
+// verifyKernelCrash verifies the latest crash saved by kdump on the node since triggeredAt had the expected cause and
+// attaches its summary to the spec report.
+func verifyKernelCrash(ctx SpecContext, nodeName string, triggeredAt time.Time, expectedCause kdump.CrashCause) {
+	By(fmt.Sprintf("Assert the kernel crash on node %q was caused by %s", nodeName, expectedCause))
+
+	report, err := kdump.RetrieveCrashReport(ctx, nodeName, triggeredAt, rdscoreparams.RDSCoreLogLevel,
+		15*time.Second, 5*time.Minute)
+	Expect(err).ToNot(HaveOccurred(), "error: failed to retrieve the kernel crash report of node %q", nodeName)
+
+	AddReportEntry("kernel_crash_"+nodeName, report.Summary())
+
+	err = report.Verify(expectedCause)
+	Expect(err).ToNot(HaveOccurred(), "error: unexpected kernel crash on node %q", nodeName)
+}
+
 // cleanupVarCrashDirectory cleans up the /var/crash directory on the specified node.
 func cleanupVarCrashDirectory(ctx SpecContext, nodeName string) {
 	By(fmt.Sprintf("Cleaning up /var/crash directory on node %q", nodeName))
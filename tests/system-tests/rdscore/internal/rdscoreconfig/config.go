@@ -592,6 +592,8 @@ type CoreConfig struct {
 	// PythonHTTPServerImage is the container image for the monitoring remoteWrite test HTTP server.
 	//nolint:lll,nolintlint
 	PythonHTTPServerImage string `yaml:"rdscore_python_http_server_image" envconfig:"ECO_RDSCORE_PYTHON_HTTP_SERVER_IMAGE"`
+	// WorkflowFile is the path to the YAML workflow of the top level suite. The default workflow is used when empty.
+	WorkflowFile          string `yaml:"rdscore_workflow_file" envconfig:"ECO_RDSCORE_WORKFLOW_FILE"`
 	WorkerLabelListOption metav1.ListOptions
 }
 
@@ -629,6 +631,8 @@ func NewCoreConfig() *CoreConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("rdscore", &rdsCoreConf)
+
 	return &rdsCoreConf
 }
 
@@ -0,0 +1,158 @@
+package rdscoreworkflow
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/rh-ecosystem-edge/eco-goinfra/pkg/reportxml"
+)
+
+// Registry maps the names used in workflows to the functions running them. Functions must either be func() or
+// func(SpecContext), the signatures accepted by It.
+type Registry map[string]interface{}
+
+// NewRegistry returns a Registry of functions, each one registered under its name without the package path.
+func NewRegistry(functions ...interface{}) Registry {
+	registry := Registry{}
+
+	for _, function := range functions {
+		fullName := runtime.FuncForPC(reflect.ValueOf(function).Pointer()).Name()
+		registry[fullName[strings.LastIndex(fullName, ".")+1:]] = function
+	}
+
+	return registry
+}
+
+// Names returns the sorted names of the registered functions.
+func (registry Registry) Names() []string {
+	names := make([]string, 0, len(registry))
+
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate checks all the registered functions have a supported signature.
+func (registry Registry) Validate() error {
+	for _, name := range registry.Names() {
+		switch registry[name].(type) {
+		case func(), func(SpecContext):
+		default:
+			return fmt.Errorf("function %q has unsupported type %T", name, registry[name])
+		}
+	}
+
+	return nil
+}
+
+// Options holds the settings used when expanding a workflow into specs.
+type Options struct {
+	// NameData is used to render the step names, which are templates.
+	NameData any
+	// DumpNodeStatus is called after failed steps of the phases with dumpNodeStatusOnFailure set.
+	DumpNodeStatus func(SpecContext)
+}
+
+// DescribeWorkflow expands workflow into an Ordered, ContinueOnFailure Describe with a Context for each phase. It
+// must be called while the spec tree is built and panics if the workflow cannot be expanded, failing the suite before
+// any spec runs.
+func DescribeWorkflow(text string, workflow *Workflow, registry Registry, options Options, args ...interface{}) bool {
+	err := registry.Validate()
+	if err != nil {
+		panic(fmt.Sprintf("invalid workflow registry: %v", err))
+	}
+
+	err = workflow.Validate(registry.Names())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	args = append(args, Ordered, ContinueOnFailure, func() {
+		for _, phase := range workflow.Phases {
+			describePhase(phase, registry, options)
+		}
+	})
+
+	return Describe(text, args...)
+}
+
+// describePhase adds the Context of phase to the spec tree.
+func describePhase(phase Phase, registry Registry, options Options) {
+	Context(phase.Name, Label(phase.Labels...), func() {
+		if len(phase.Cleanup) > 0 || len(phase.Setup) > 0 {
+			BeforeAll(func(ctx SpecContext) {
+				for _, function := range phase.Cleanup {
+					DeferCleanup(registry[function])
+				}
+
+				for _, step := range phase.Setup {
+					By(step.Name)
+					call(ctx, registry[step.Run])
+				}
+			})
+		}
+
+		for _, step := range phase.Steps {
+			name, err := step.RenderName(options.NameData)
+			if err != nil {
+				panic(err.Error())
+			}
+
+			It(name, stepDecorators(step, registry[step.Run])...)
+		}
+
+		if phase.DumpNodeStatusOnFailure && options.DumpNodeStatus != nil {
+			AfterEach(func(ctx SpecContext) {
+				if CurrentSpecReport().Failed() {
+					By("Dumping node status information due to test failure")
+					options.DumpNodeStatus(ctx)
+				}
+			})
+		}
+	})
+}
+
+// stepDecorators returns the arguments of the It running step with function.
+func stepDecorators(step Step, function interface{}) []interface{} {
+	args := []interface{}{}
+
+	if len(step.Labels) > 0 {
+		args = append(args, Label(slices.Clone(step.Labels)...))
+	}
+
+	if step.ID != "" {
+		args = append(args, reportxml.ID(step.ID))
+	}
+
+	if step.MustPassRepeatedly > 0 {
+		args = append(args, MustPassRepeatedly(step.MustPassRepeatedly))
+	}
+
+	// Validate already checked the timeout can be parsed.
+	if timeout, _ := step.TimeoutDuration(); timeout > 0 {
+		args = append(args, SpecTimeout(timeout))
+	}
+
+	return append(args, function)
+}
+
+// call runs a registered function, passing ctx to the ones accepting it.
+func call(ctx SpecContext, function interface{}) {
+	switch typed := function.(type) {
+	case func():
+		typed()
+	case func(SpecContext):
+		typed(ctx)
+	default:
+		Fail(fmt.Sprintf("unsupported workflow function type %T", function))
+	}
+}
@@ -0,0 +1,216 @@
+package rdscoreworkflow
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Disruption is the disruption a workflow phase validates the cluster against.
+type Disruption string
+
+const (
+	// DisruptionClean is used by phases validating a configured cluster without disrupting it.
+	DisruptionClean Disruption = "clean"
+	// DisruptionGracefulReboot is used by phases validating the cluster after a graceful reboot.
+	DisruptionGracefulReboot Disruption = "graceful-reboot"
+	// DisruptionUngracefulReboot is used by phases validating the cluster after an ungraceful reboot.
+	DisruptionUngracefulReboot Disruption = "ungraceful-reboot"
+	// DisruptionKdump is used by phases validating the cluster after a kernel crash dump.
+	DisruptionKdump Disruption = "kdump"
+)
+
+// DefaultWorkflow is the workflow run when no workflow file is configured.
+//
+//go:embed default-workflow.yaml
+var DefaultWorkflow []byte
+
+// Workflow is the ordered list of phases of the RDS Core validation.
+type Workflow struct {
+	Phases []Phase `yaml:"phases"`
+}
+
+// Phase is a group of steps run against the cluster in the same state, possibly after disrupting it.
+type Phase struct {
+	Name                    string     `yaml:"name"`
+	Disruption              Disruption `yaml:"disruption"`
+	Labels                  []string   `yaml:"labels"`
+	Cleanup                 []string   `yaml:"cleanup"`
+	Setup                   []Step     `yaml:"setup"`
+	Steps                   []Step     `yaml:"steps"`
+	DumpNodeStatusOnFailure bool       `yaml:"dumpNodeStatusOnFailure"`
+}
+
+// Step is a single validation of a phase, run by a registered function.
+type Step struct {
+	Name               string   `yaml:"name"`
+	Run                string   `yaml:"run"`
+	Labels             []string `yaml:"labels"`
+	ID                 string   `yaml:"id"`
+	MustPassRepeatedly int      `yaml:"mustPassRepeatedly"`
+	Timeout            string   `yaml:"timeout"`
+	Disruption         bool     `yaml:"disruption"`
+}
+
+// Load reads the workflow at path, or the default workflow if path is empty.
+func Load(path string) (*Workflow, error) {
+	if path == "" {
+		return Parse(DefaultWorkflow)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow file %s: %w", path, err)
+	}
+
+	workflow, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workflow file %s: %w", path, err)
+	}
+
+	return workflow, nil
+}
+
+// Parse decodes a workflow from its YAML definition. Unknown fields are rejected so typos are not silently ignored.
+func Parse(content []byte) (*Workflow, error) {
+	workflow := &Workflow{}
+
+	err := yaml.UnmarshalStrict(content, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// Validate checks the workflow is consistent and only runs functions in known. All the problems found are returned in
+// a single error.
+func (workflow *Workflow) Validate(known []string) error {
+	var problems []string
+
+	if len(workflow.Phases) == 0 {
+		problems = append(problems, "workflow has no phases")
+	}
+
+	ids := map[string]string{}
+
+	for _, phase := range workflow.Phases {
+		if phase.Name == "" {
+			problems = append(problems, "phase has no name")
+		}
+
+		switch phase.Disruption {
+		case DisruptionClean, DisruptionGracefulReboot, DisruptionUngracefulReboot, DisruptionKdump:
+		default:
+			problems = append(problems, fmt.Sprintf("phase %q has invalid disruption %q", phase.Name, phase.Disruption))
+		}
+
+		for _, function := range phase.Cleanup {
+			if !slices.Contains(known, function) {
+				problems = append(problems,
+					fmt.Sprintf("phase %q cleans up with unknown function %q", phase.Name, function))
+			}
+		}
+
+		disruptions := 0
+
+		for _, step := range slices.Concat(phase.Setup, phase.Steps) {
+			problems = append(problems, step.validate(phase.Name, known)...)
+
+			if step.Disruption {
+				disruptions++
+			}
+		}
+
+		if phase.Disruption == DisruptionClean && disruptions > 0 {
+			problems = append(problems, fmt.Sprintf("clean phase %q has disruption steps", phase.Name))
+		}
+
+		if phase.Disruption != DisruptionClean && disruptions != 1 {
+			problems = append(problems, fmt.Sprintf("phase %q must have exactly one disruption step, found %d",
+				phase.Name, disruptions))
+		}
+
+		for _, step := range phase.Steps {
+			if step.ID == "" {
+				continue
+			}
+
+			if previous, ok := ids[step.ID]; ok {
+				problems = append(problems, fmt.Sprintf("step %q reuses the test case ID %s of step %q",
+					step.Name, step.ID, previous))
+			}
+
+			ids[step.ID] = step.Name
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid workflow:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return nil
+}
+
+// RenderName returns the name of the step rendered as a template with data, such as the suite configuration.
+func (step Step) RenderName(data any) (string, error) {
+	if !strings.Contains(step.Name, "{{") {
+		return step.Name, nil
+	}
+
+	tmpl, err := template.New(step.Run).Option("missingkey=error").Parse(step.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the name of step %q: %w", step.Name, err)
+	}
+
+	var name strings.Builder
+
+	err = tmpl.Execute(&name, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the name of step %q: %w", step.Name, err)
+	}
+
+	return name.String(), nil
+}
+
+// TimeoutDuration returns the parsed timeout of the step, which is zero when unset.
+func (step Step) TimeoutDuration() (time.Duration, error) {
+	if step.Timeout == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(step.Timeout)
+}
+
+// validate returns the problems found in the step of the phase named phaseName.
+func (step Step) validate(phaseName string, known []string) []string {
+	var problems []string
+
+	if step.Name == "" {
+		problems = append(problems, fmt.Sprintf("phase %q has a step with no name", phaseName))
+	}
+
+	if !slices.Contains(known, step.Run) {
+		problems = append(problems, fmt.Sprintf("step %q runs unknown function %q", step.Name, step.Run))
+	}
+
+	if step.MustPassRepeatedly < 0 {
+		problems = append(problems, fmt.Sprintf("step %q has a negative mustPassRepeatedly", step.Name))
+	}
+
+	if _, err := step.TimeoutDuration(); err != nil {
+		problems = append(problems, fmt.Sprintf("step %q has invalid timeout: %v", step.Name, err))
+	}
+
+	if _, err := template.New(step.Run).Parse(step.Name); err != nil {
+		problems = append(problems, fmt.Sprintf("step %q has invalid name template: %v", step.Name, err))
+	}
+
+	return problems
+}
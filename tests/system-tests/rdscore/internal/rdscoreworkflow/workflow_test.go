@@ -0,0 +1,168 @@
+package rdscoreworkflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const testWorkflow = `phases:
+  - name: Configured Cluster
+    disruption: clean
+    labels: [clean-cluster]
+    steps:
+      - name: Verifies {{ .Namespace }} namespace exists
+        run: verifyNamespace
+        labels: [namespace]
+        id: "12345"
+        timeout: 5m
+  - name: Cluster Reboot
+    disruption: graceful-reboot
+    cleanup: [cleanupNodes]
+    setup:
+      - name: Reboots the cluster
+        run: rebootCluster
+        disruption: true
+    steps:
+      - name: Verifies namespace exists after reboot
+        run: verifyNamespace
+        id: "12346"
+        mustPassRepeatedly: 3
+`
+
+func testStep()                   {}
+func testContextStep(SpecContext) {}
+
+func TestParse(t *testing.T) {
+	workflow, err := Parse([]byte(testWorkflow))
+	assert.Nil(t, err)
+	assert.Len(t, workflow.Phases, 2)
+	assert.Equal(t, DisruptionGracefulReboot, workflow.Phases[1].Disruption)
+	assert.Equal(t, []string{"cleanupNodes"}, workflow.Phases[1].Cleanup)
+	assert.True(t, workflow.Phases[1].Setup[0].Disruption)
+	assert.Equal(t, 3, workflow.Phases[1].Steps[0].MustPassRepeatedly)
+	assert.Nil(t, workflow.Validate([]string{"verifyNamespace", "rebootCluster", "cleanupNodes"}))
+
+	_, err = Parse([]byte("phases:\n  - name: Configured Cluster\n    disruptions: clean\n"))
+	assert.NotNil(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	workflow, err := Load("")
+	assert.Nil(t, err)
+	assert.NotEmpty(t, workflow.Phases)
+
+	known := []string{}
+
+	for _, phase := range workflow.Phases {
+		known = append(known, phase.Cleanup...)
+
+		for _, step := range append(phase.Setup, phase.Steps...) {
+			known = append(known, step.Run)
+		}
+	}
+
+	assert.Nil(t, workflow.Validate(known))
+
+	path := filepath.Join(t.TempDir(), "workflow.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(testWorkflow), 0600))
+
+	workflow, err = Load(path)
+	assert.Nil(t, err)
+	assert.Len(t, workflow.Phases, 2)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read workflow file")
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		mutate        func(*Workflow)
+		expectedError string
+	}{
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[0].Disruption = "power-off" },
+			expectedError: `phase "Configured Cluster" has invalid disruption "power-off"`,
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[0].Steps[0].Run = "verifyNothing" },
+			expectedError: `runs unknown function "verifyNothing"`,
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[1].Cleanup = []string{"cleanupNothing"} },
+			expectedError: `phase "Cluster Reboot" cleans up with unknown function "cleanupNothing"`,
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[1].Setup[0].Disruption = false },
+			expectedError: `phase "Cluster Reboot" must have exactly one disruption step, found 0`,
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[0].Steps[0].Disruption = true },
+			expectedError: `clean phase "Configured Cluster" has disruption steps`,
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[1].Steps[0].ID = "12345" },
+			expectedError: "reuses the test case ID 12345",
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[0].Steps[0].Timeout = "five minutes" },
+			expectedError: "has invalid timeout",
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases[0].Steps[0].Name = "Verifies {{ .Namespace" },
+			expectedError: "has invalid name template",
+		},
+		{
+			mutate:        func(workflow *Workflow) { workflow.Phases = nil },
+			expectedError: "workflow has no phases",
+		},
+	}
+
+	for _, testCase := range testCases {
+		workflow, err := Parse([]byte(testWorkflow))
+		assert.Nil(t, err)
+
+		testCase.mutate(workflow)
+
+		err = workflow.Validate([]string{"verifyNamespace", "rebootCluster", "cleanupNodes"})
+		assert.ErrorContains(t, err, testCase.expectedError)
+	}
+}
+
+func TestStepRenderName(t *testing.T) {
+	step := Step{Name: "Verifies {{ .Namespace }} namespace exists", Run: "verifyNamespace"}
+
+	name, err := step.RenderName(struct{ Namespace string }{Namespace: "openshift-nmstate"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Verifies openshift-nmstate namespace exists", name)
+
+	_, err = step.RenderName(map[string]string{})
+	assert.ErrorContains(t, err, "failed to render the name of step")
+
+	name, err = Step{Name: "Verifies CephFS"}.RenderName(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "Verifies CephFS", name)
+}
+
+func TestStepTimeoutDuration(t *testing.T) {
+	timeout, err := Step{}.TimeoutDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), timeout)
+
+	timeout, err = Step{Timeout: "1h30m"}.TimeoutDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, timeout)
+}
+
+func TestNewRegistry(t *testing.T) {
+	registry := NewRegistry(testStep, testContextStep)
+	assert.Equal(t, []string{"testContextStep", "testStep"}, registry.Names())
+	assert.Nil(t, registry.Validate())
+
+	registry["invalidStep"] = func(string) {}
+	assert.ErrorContains(t, registry.Validate(), `function "invalidStep" has unsupported type func(string)`)
+}
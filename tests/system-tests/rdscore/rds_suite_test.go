@@ -24,6 +24,10 @@ func TestRDSCore(t *testing.T) {
 	RunSpecs(t, "RDS Core SystemTests Suite", Label(rdscoreparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, rdscoreparams.ReporterNamespacesToDump, rdscoreparams.ReporterCRDsToDump)
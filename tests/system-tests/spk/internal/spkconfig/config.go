@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -119,6 +120,8 @@ func NewSPKConfig() *SPKConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("spk", &spkConf)
+
 	return &spkConf
 }
 
@@ -23,6 +23,10 @@ func TestRanDu(t *testing.T) {
 	RunSpecs(t, "SPK SystemTests Suite", Label(spkparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = JustAfterEach(func() {
 	reporter.ReportIfFailed(
 		CurrentSpecReport(), currentFile, spkparams.ReporterNamespacesToDump, spkparams.ReporterCRDsToDump)
@@ -10,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/rh-ecosystem-edge/eco-gotests/tests/internal/config"
 	"github.com/rh-ecosystem-edge/eco-gotests/tests/system-tests/internal/systemtestsconfig"
 	"gopkg.in/yaml.v2"
 )
@@ -76,6 +77,8 @@ func NewVCoreConfig() *VCoreConfig {
 		return nil
 	}
 
+	config.RegisterSuiteConfig("vcore", &vcoreConf)
+
 	return &vcoreConf
 }
 
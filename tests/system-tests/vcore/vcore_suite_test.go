@@ -28,6 +28,10 @@ func TestVCore(t *testing.T) {
 	RunSpecs(t, "vCore SystemTests Suite", Label(vcoreparams.Labels...), reporterConfig)
 }
 
+var _ = ReportBeforeSuite(func(report Report) {
+	reporter.DumpSuiteConfigs(report, currentFile)
+})
+
 var _ = BeforeSuite(func() {
 	By(fmt.Sprintf("Create the folder %s for eco-gotests container", vcoreparams.ConfigurationFolderPath))
 